@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	OpenAIService_CreateChatCompletion_FullMethodName       = "/apis.llmgapi.v1.openai.OpenAIService/CreateChatCompletion"
 	OpenAIService_CreateChatCompletionStream_FullMethodName = "/apis.llmgapi.v1.openai.OpenAIService/CreateChatCompletionStream"
+	OpenAIService_ListModels_FullMethodName                 = "/apis.llmgapi.v1.openai.OpenAIService/ListModels"
 )
 
 // OpenAIServiceClient is the client API for OpenAIService service.
@@ -32,6 +33,8 @@ type OpenAIServiceClient interface {
 	// CreateChatCompletionStream generates a streaming model response for the given chat conversation.
 	// It returns a stream of ChatCompletionChunk messages.
 	CreateChatCompletionStream(ctx context.Context, in *CreateChatCompletionStreamRequest, opts ...grpc.CallOption) (OpenAIService_CreateChatCompletionStreamClient, error)
+	// ListModels lists the models served by the upstreams of the current endpoint.
+	ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error)
 }
 
 type openAIServiceClient struct {
@@ -83,6 +86,15 @@ func (x *openAIServiceCreateChatCompletionStreamClient) Recv() (*CreateChatCompl
 	return m, nil
 }
 
+func (c *openAIServiceClient) ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error) {
+	out := new(ListModelsResponse)
+	err := c.cc.Invoke(ctx, OpenAIService_ListModels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OpenAIServiceServer is the server API for OpenAIService service.
 // All implementations must embed UnimplementedOpenAIServiceServer
 // for forward compatibility
@@ -92,6 +104,8 @@ type OpenAIServiceServer interface {
 	// CreateChatCompletionStream generates a streaming model response for the given chat conversation.
 	// It returns a stream of ChatCompletionChunk messages.
 	CreateChatCompletionStream(*CreateChatCompletionStreamRequest, OpenAIService_CreateChatCompletionStreamServer) error
+	// ListModels lists the models served by the upstreams of the current endpoint.
+	ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error)
 	mustEmbedUnimplementedOpenAIServiceServer()
 }
 
@@ -105,6 +119,9 @@ func (UnimplementedOpenAIServiceServer) CreateChatCompletion(context.Context, *C
 func (UnimplementedOpenAIServiceServer) CreateChatCompletionStream(*CreateChatCompletionStreamRequest, OpenAIService_CreateChatCompletionStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method CreateChatCompletionStream not implemented")
 }
+func (UnimplementedOpenAIServiceServer) ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModels not implemented")
+}
 func (UnimplementedOpenAIServiceServer) mustEmbedUnimplementedOpenAIServiceServer() {}
 
 // UnsafeOpenAIServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -157,6 +174,24 @@ func (x *openAIServiceCreateChatCompletionStreamServer) Send(m *CreateChatComple
 	return x.ServerStream.SendMsg(m)
 }
 
+func _OpenAIService_ListModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenAIServiceServer).ListModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OpenAIService_ListModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenAIServiceServer).ListModels(ctx, req.(*ListModelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OpenAIService_ServiceDesc is the grpc.ServiceDesc for OpenAIService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -168,6 +203,10 @@ var OpenAIService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateChatCompletion",
 			Handler:    _OpenAIService_CreateChatCompletion_Handler,
 		},
+		{
+			MethodName: "ListModels",
+			Handler:    _OpenAIService_ListModels_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
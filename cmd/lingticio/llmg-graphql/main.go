@@ -9,8 +9,10 @@ import (
 
 	"github.com/lingticio/llmg/internal/configs"
 	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/internal/endpoints"
 	"github.com/lingticio/llmg/internal/graph/server"
 	"github.com/lingticio/llmg/internal/libs"
+	"github.com/lingticio/llmg/internal/upstreams"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +29,8 @@ func main() {
 				fx.Provide(configs.NewConfig("lingticio", "llmg", configFilePath, envFilePath)),
 				fx.Options(libs.Modules()),
 				fx.Options(datastore.Modules()),
+				fx.Options(endpoints.Modules()),
+				fx.Options(upstreams.Modules()),
 				fx.Options(server.Modules()),
 				fx.Invoke(server.Run()),
 			)
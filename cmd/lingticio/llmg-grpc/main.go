@@ -7,12 +7,18 @@ import (
 
 	"go.uber.org/fx"
 
+	"github.com/lingticio/llmg/internal/batches"
 	"github.com/lingticio/llmg/internal/configs"
 	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/internal/endpoints"
 	grpcservers "github.com/lingticio/llmg/internal/grpc/servers"
+	"github.com/lingticio/llmg/internal/grpc/servers/apiserver"
 	v1 "github.com/lingticio/llmg/internal/grpc/servers/llmg/v1"
 	grpcservices "github.com/lingticio/llmg/internal/grpc/services"
 	"github.com/lingticio/llmg/internal/libs"
+	"github.com/lingticio/llmg/internal/responses"
+	"github.com/lingticio/llmg/internal/rest"
+	"github.com/lingticio/llmg/internal/upstreams"
 	"github.com/spf13/cobra"
 )
 
@@ -29,9 +35,15 @@ func main() {
 				fx.Provide(configs.NewConfig("lingticio", "llmg", configFilePath, envFilePath)),
 				fx.Options(libs.Modules()),
 				fx.Options(datastore.Modules()),
+				fx.Options(endpoints.Modules()),
+				fx.Options(upstreams.Modules()),
+				fx.Options(responses.Modules()),
+				fx.Options(batches.Modules()),
 				fx.Options(grpcservers.Modules()),
 				fx.Options(grpcservices.Modules()),
+				fx.Options(rest.Modules()),
 				fx.Invoke(v1.Run()),
+				fx.Invoke(apiserver.RunGatewayServer()),
 			)
 
 			app.Run()
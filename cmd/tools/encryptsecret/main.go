@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lingticio/llmg/pkg/secrets"
+)
+
+var (
+	generateKey bool
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:   "encryptsecret",
+		Short: "Encrypts the secret read from stdin into an enc: reference, with the master key of " + secrets.MasterKeyEnvName,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if generateKey {
+				key := make([]byte, 32)
+
+				_, err := rand.Read(key)
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(base64.StdEncoding.EncodeToString(key)) //nolint:forbidigo
+
+				return nil
+			}
+
+			masterKey, err := secrets.MasterKeyFromEnv()
+			if err != nil {
+				return err
+			}
+
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return fmt.Errorf("failed to read the secret from stdin: %w", err)
+			}
+
+			plaintext := strings.TrimSpace(line)
+			if plaintext == "" {
+				return errors.New("the secret read from stdin is empty")
+			}
+
+			reference, err := secrets.Encrypt(masterKey, plaintext)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt the secret: %w", err)
+			}
+
+			fmt.Println(reference) //nolint:forbidigo
+
+			return nil
+		},
+	}
+
+	root.Flags().BoolVar(&generateKey, "generate-key", false, "prints a new master key to set "+secrets.MasterKeyEnvName+" to instead")
+
+	if err := root.Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+var (
+	configFilePath string
+	envFilePath    string
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:   "migrateapikeys",
+		Short: "Migrates the API keys stored in plaintext by the redis endpoints provider to be stored by their digest",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := configs.NewConfig("lingticio", "llmg", configFilePath, envFilePath)()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if config.Endpoints.APIKeySecret == "" {
+				return errors.New("endpoints.api_key_secret is not configured, refusing to store API keys by digests keyed by an empty secret")
+			}
+
+			client, err := datastore.NewRueidisClient(config.Redis)
+			if err != nil {
+				return fmt.Errorf("failed to connect to redis: %w", err)
+			}
+
+			defer client.Close()
+
+			provider, _ := authstorage.NewRedisEndpointAuthProvider()(client, config.Endpoints.APIKeySecret).(*authstorage.RedisEndpointProvider)
+
+			migrated, err := provider.MigrateAPIKeys(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to migrate API keys, %d migrated: %w", migrated, err)
+			}
+
+			fmt.Printf("%d API keys migrated\n", migrated) //nolint:forbidigo
+
+			return nil
+		},
+	}
+
+	root.Flags().StringVarP(&configFilePath, "config", "c", "", "config file path")
+	root.Flags().StringVarP(&envFilePath, "env", "e", "", "env file path")
+
+	if err := root.Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
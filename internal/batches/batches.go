@@ -0,0 +1,478 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/upstreams"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+)
+
+const (
+	idLength         = 24
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrBatchTerminal  = errors.New("batch is already finished")
+)
+
+func Modules() fx.Option {
+	return fx.Options(
+		fx.Provide(NewStore()),
+		fx.Provide(NewBatches()),
+	)
+}
+
+func newID(prefix string) string {
+	return prefix + nanoid.NewWithLength(idLength)
+}
+
+type CreateRequest struct {
+	InputFileID      string            `json:"input_file_id"`
+	Endpoint         string            `json:"endpoint"`
+	CompletionWindow string            `json:"completion_window"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+type NewBatchesParams struct {
+	fx.In
+
+	Lifecycle     fx.Lifecycle
+	Logger        *logger.Logger
+	Config        *configs.Config
+	Store         Store
+	Authenticator *endpoints.Authenticator
+	Gateway       *upstreams.Gateway
+}
+
+// Batches implements the OpenAI Batch API, the requests of a batch are
+// processed in the background through the same routing as any other request.
+// Each job is processed by the replica holding its lease, the jobs of the
+// replicas which stopped renewing their leases are taken over by the others.
+type Batches struct {
+	logger        *logger.Logger
+	store         Store
+	authenticator *endpoints.Authenticator
+	gateway       *upstreams.Gateway
+
+	// holder identifies this replica as the holder of the leases of jobs.
+	holder string
+
+	// semaphore bounds the requests in flight across all the batches.
+	semaphore chan struct{}
+
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	wg      sync.WaitGroup
+	mutex   sync.Mutex
+	running map[string]*runningJob
+}
+
+func NewBatches() func(params NewBatchesParams) *Batches {
+	return func(params NewBatchesParams) *Batches {
+		ctx, cancel := context.WithCancelCause(context.Background())
+
+		b := &Batches{
+			logger:        params.Logger,
+			store:         params.Store,
+			authenticator: params.Authenticator,
+			gateway:       params.Gateway,
+			holder:        newID("replica_"),
+			semaphore:     make(chan struct{}, max(params.Config.Batches.Concurrency, 1)),
+			ctx:           ctx,
+			cancel:        cancel,
+			running:       make(map[string]*runningJob),
+		}
+
+		params.Lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				err := b.claim(ctx)
+				if err != nil {
+					return err
+				}
+
+				b.wg.Add(1)
+
+				go func() {
+					defer b.wg.Done()
+
+					b.claimPeriodically()
+				}()
+
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				params.Logger.Info("gracefully shutting down batches...")
+				b.cancel(errShutdown)
+
+				done := make(chan struct{})
+
+				go func() {
+					b.wg.Wait()
+					close(done)
+				}()
+
+				select {
+				case <-done:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		})
+
+		return b
+	}
+}
+
+// claim takes over the jobs which are not finished, nor processed by any
+// replica, i.e. the jobs interrupted by the shutdown, or the failure, of the
+// replica processing them. They are processed again from the start.
+func (b *Batches) claim(ctx context.Context) error {
+	jobs, err := b.store.ListJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.Batch.Terminal() || b.isRunning(job.Batch.ID) {
+			continue
+		}
+
+		acquired, err := b.store.AcquireLease(ctx, job.Batch.ID, b.holder, leaseTTL)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			continue
+		}
+
+		err = b.resume(ctx, job.Batch.ID)
+		if err != nil {
+			_ = b.store.ReleaseLease(context.WithoutCancel(ctx), job.Batch.ID, b.holder)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// claimPeriodically claims the jobs left by the other replicas until the
+// shutdown.
+func (b *Batches) claimPeriodically() {
+	ticker := time.NewTicker(leaseTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := b.claim(b.ctx)
+		if err != nil && b.ctx.Err() == nil {
+			b.logger.Warn("failed to claim batches", zap.Error(err))
+		}
+	}
+}
+
+// resume processes the job, which lease is held, again. The job is read
+// again, as it may have been finished, or cancelled, before the lease was
+// taken.
+func (b *Batches) resume(ctx context.Context, id string) error {
+	job, err := b.store.GetJob(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case job.Batch.Terminal():
+		return b.store.ReleaseLease(ctx, id, b.holder)
+	case job.Batch.Status == StatusCancelling:
+		job.Batch.Status = StatusCancelled
+		job.Batch.CancelledAt = lo.ToPtr(time.Now().Unix())
+
+		// A conflicting write is merged by the next claim.
+		err := b.store.PutJob(ctx, job)
+		if err != nil && !errors.Is(err, ErrJobConflict) {
+			return err
+		}
+
+		return b.store.ReleaseLease(ctx, id, b.holder)
+	}
+
+	b.logger.Info("resuming batch", zap.String("batch_id", job.Batch.ID))
+
+	job.Batch.Status = StatusValidating
+	job.Batch.RequestCounts = RequestCounts{}
+
+	b.start(job)
+
+	return nil
+}
+
+func (b *Batches) isRunning(id string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	_, ok := b.running[id]
+
+	return ok
+}
+
+func (b *Batches) CreateFile(ctx context.Context, endpoint *authstorage.Endpoint, filename string, purpose string, content io.Reader) (*File, error) {
+	if purpose != FilePurposeBatch {
+		return nil, fmt.Errorf("%w: unsupported purpose %q", ErrInvalidRequest, purpose)
+	}
+
+	file := &StoredFile{
+		File: File{
+			ID:        newID("file-"),
+			Object:    "file",
+			CreatedAt: time.Now().Unix(),
+			Filename:  filename,
+			Purpose:   purpose,
+			Status:    "processed",
+		},
+		Owner: endpoints.OwnerOf(endpoint),
+	}
+
+	err := b.store.PutFile(ctx, file, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.File, nil
+}
+
+func (b *Batches) getFile(ctx context.Context, endpoint *authstorage.Endpoint, id string) (*StoredFile, error) {
+	file, err := b.store.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if file.Owner != endpoints.OwnerOf(endpoint) {
+		return nil, ErrFileNotFound
+	}
+
+	return file, nil
+}
+
+func (b *Batches) GetFile(ctx context.Context, endpoint *authstorage.Endpoint, id string) (*File, error) {
+	file, err := b.getFile(ctx, endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.File, nil
+}
+
+func (b *Batches) OpenFileContent(ctx context.Context, endpoint *authstorage.Endpoint, id string) (io.ReadCloser, error) {
+	_, err := b.getFile(ctx, endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.store.OpenFileContent(ctx, id)
+}
+
+func (b *Batches) DeleteFile(ctx context.Context, endpoint *authstorage.Endpoint, id string) error {
+	_, err := b.getFile(ctx, endpoint, id)
+	if err != nil {
+		return err
+	}
+
+	return b.store.DeleteFile(ctx, id)
+}
+
+func (b *Batches) Create(ctx context.Context, endpoint *authstorage.Endpoint, request CreateRequest) (*Batch, error) {
+	switch request.Endpoint {
+	case EndpointChatCompletions, EndpointEmbeddings:
+	default:
+		return nil, fmt.Errorf("%w: unsupported endpoint %q", ErrInvalidRequest, request.Endpoint)
+	}
+
+	ref, ok := endpoints.ReferenceOf(endpoint)
+	if !ok {
+		return nil, fmt.Errorf("%w: batches require an API key issued by the gateway", ErrInvalidRequest)
+	}
+
+	// The requests of the batch are sent with the API key of the batch, which
+	// must be granted their scope as well.
+	err := endpoints.RequireScope(endpoint, lo.Ternary(request.Endpoint == EndpointEmbeddings, authstorage.ScopeEmbeddings, authstorage.ScopeChat))
+	if err != nil {
+		return nil, err
+	}
+
+	if request.CompletionWindow != CompletionWindow24h {
+		return nil, fmt.Errorf("%w: unsupported completion_window %q", ErrInvalidRequest, request.CompletionWindow)
+	}
+
+	file, err := b.getFile(ctx, endpoint, request.InputFileID)
+	if err != nil {
+		if errors.Is(err, ErrFileNotFound) {
+			return nil, fmt.Errorf("%w: input file %s not found", ErrInvalidRequest, request.InputFileID)
+		}
+
+		return nil, err
+	}
+	if file.File.Purpose != FilePurposeBatch {
+		return nil, fmt.Errorf("%w: input file %s is not a batch file", ErrInvalidRequest, request.InputFileID)
+	}
+
+	now := time.Now()
+
+	job := &Job{
+		Batch: Batch{
+			ID:               newID("batch_"),
+			Object:           "batch",
+			Endpoint:         request.Endpoint,
+			InputFileID:      request.InputFileID,
+			CompletionWindow: request.CompletionWindow,
+			Status:           StatusValidating,
+			CreatedAt:        now.Unix(),
+			ExpiresAt:        lo.ToPtr(now.Add(24 * time.Hour).Unix()),
+			Metadata:         lo.Ternary(request.Metadata == nil, make(map[string]string), request.Metadata),
+		},
+		Owner:    endpoints.OwnerOf(endpoint),
+		Endpoint: ref,
+	}
+
+	err = b.store.PutJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := job.Batch
+
+	// Jobs which lease cannot be taken are left to be claimed.
+	acquired, err := b.store.AcquireLease(ctx, job.Batch.ID, b.holder, leaseTTL)
+	if err != nil {
+		b.logger.Warn("failed to acquire batch lease", zap.String("batch_id", job.Batch.ID), zap.Error(err))
+	}
+	if acquired {
+		b.start(job)
+	}
+
+	return &batch, nil
+}
+
+func (b *Batches) getJob(ctx context.Context, endpoint *authstorage.Endpoint, id string) (*Job, error) {
+	job, err := b.store.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Owner != endpoints.OwnerOf(endpoint) {
+		return nil, ErrBatchNotFound
+	}
+
+	return job, nil
+}
+
+func (b *Batches) Get(ctx context.Context, endpoint *authstorage.Endpoint, id string) (*Batch, error) {
+	job, err := b.getJob(ctx, endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job.Batch, nil
+}
+
+// List returns the batches of the endpoint, most recent first, starting after
+// the batch with the ID after when it is not empty.
+func (b *Batches) List(ctx context.Context, endpoint *authstorage.Endpoint, after string, limit int) ([]Batch, bool, error) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	limit = min(limit, maxListLimit)
+
+	jobs, err := b.store.ListJobs(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	owner := endpoints.OwnerOf(endpoint)
+	batches := lo.FilterMap(jobs, func(item *Job, _ int) (Batch, bool) {
+		return item.Batch, item.Owner == owner
+	})
+
+	sort.Slice(batches, func(i, j int) bool {
+		if batches[i].CreatedAt == batches[j].CreatedAt {
+			return batches[i].ID > batches[j].ID
+		}
+
+		return batches[i].CreatedAt > batches[j].CreatedAt
+	})
+
+	if after != "" {
+		_, index, ok := lo.FindIndexOf(batches, func(item Batch) bool {
+			return item.ID == after
+		})
+		if !ok {
+			return nil, false, fmt.Errorf("%w: batch %s not found", ErrInvalidRequest, after)
+		}
+
+		batches = batches[index+1:]
+	}
+	if len(batches) > limit {
+		return batches[:limit], true, nil
+	}
+
+	return batches, false, nil
+}
+
+func (b *Batches) Cancel(ctx context.Context, endpoint *authstorage.Endpoint, id string) (*Batch, error) {
+	for {
+		job, err := b.getJob(ctx, endpoint, id)
+		if err != nil {
+			return nil, err
+		}
+
+		b.mutex.Lock()
+		running, ok := b.running[id]
+		b.mutex.Unlock()
+
+		if ok {
+			return running.requestCancel(ctx)
+		}
+		if job.Batch.Terminal() {
+			return nil, ErrBatchTerminal
+		}
+		if job.Batch.Status == StatusCancelling {
+			return &job.Batch, nil
+		}
+
+		// The batch is not processed by this instance, it is marked so that
+		// the replica processing it, if any, stops, and so that it is
+		// cancelled instead of resumed otherwise.
+		job.Batch.Status = StatusCancelling
+		job.Batch.CancellingAt = lo.ToPtr(time.Now().Unix())
+
+		err = b.store.PutJob(ctx, job)
+		if errors.Is(err, ErrJobConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &job.Batch, nil
+	}
+}
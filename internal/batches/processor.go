@@ -0,0 +1,654 @@
+package batches
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/upstreams"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+	"github.com/lingticio/llmg/pkg/scheduling"
+)
+
+const (
+	maxAttempts      = 3
+	retryBackoff     = time.Second
+	progressInterval = time.Second
+	maxLineSize      = 10 << 20
+
+	// leaseTTL is how long a job stays leased to a replica which stopped
+	// renewing it, before another replica takes the job over.
+	leaseTTL = 30 * time.Second
+	// leaseRenewInterval leaves a replica a couple of attempts to renew its
+	// leases before they expire.
+	leaseRenewInterval = leaseTTL / 3
+)
+
+var (
+	errShutdown  = errors.New("gateway is shutting down")
+	errCancelled = errors.New("batch cancelled")
+	errExpired   = errors.New("batch expired")
+	errLeaseLost = errors.New("batch is processed by another replica")
+)
+
+// runningJob is a job processed by this instance, all the mutations of the
+// job go through its mutex as cancellation races with the processing.
+type runningJob struct {
+	batches *Batches
+	cancel  context.CancelCauseFunc
+
+	mutex         sync.Mutex
+	job           *Job
+	lastSaved     time.Time
+	lastRefreshed time.Time
+	// lost is set once the job is taken over by another replica, the job
+	// is not saved anymore.
+	lost bool
+}
+
+// merge adopts what was changed by the other replicas in stored, which is
+// the cancellation of the job, while keeping the progress of the
+// processing, r.mutex must be held.
+func (r *runningJob) merge(stored *Job) {
+	r.job.Version = stored.Version
+
+	switch {
+	case stored.Batch.Terminal():
+		r.lose()
+	case stored.Batch.Status == StatusCancelling && r.job.Batch.Status != StatusCancelling && !r.job.Batch.Terminal():
+		r.job.Batch.Status = StatusCancelling
+		r.job.Batch.CancellingAt = stored.Batch.CancellingAt
+		r.cancel(errCancelled)
+	}
+}
+
+// lose stops the processing of the job, which is taken over by another
+// replica, r.mutex must be held.
+func (r *runningJob) lose() {
+	r.lost = true
+	r.cancel(errLeaseLost)
+}
+
+// save stores the job, the job is read again, and merged, when it was
+// changed concurrently, r.mutex must be held.
+func (r *runningJob) save(ctx context.Context) error {
+	for !r.lost {
+		err := r.batches.store.PutJob(ctx, r.job)
+		if !errors.Is(err, ErrJobConflict) {
+			return err
+		}
+
+		stored, err := r.batches.store.GetJob(ctx, r.job.Batch.ID)
+		if err != nil {
+			return err
+		}
+
+		r.merge(stored)
+	}
+
+	return nil
+}
+
+// update mutates the job and saves it, progress-only updates are throttled.
+// The job is saved even when ctx is already cancelled, as the cancellation is
+// what most updates record.
+func (r *runningJob) update(ctx context.Context, progress bool, mutate func(batch *Batch)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	mutate(&r.job.Batch)
+
+	if progress && time.Since(r.lastSaved) < progressInterval {
+		return
+	}
+
+	r.lastSaved = time.Now()
+
+	err := r.save(context.WithoutCancel(ctx))
+	if err != nil {
+		r.batches.logger.Error("failed to save batch", zap.String("batch_id", r.job.Batch.ID), zap.Error(err))
+	}
+}
+
+// refresh reads the job again, at most once per progressInterval, so that
+// the cancellations requested through the other replicas are noticed
+// between the requests of the job.
+func (r *runningJob) refresh(ctx context.Context) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if time.Since(r.lastRefreshed) < progressInterval {
+		return
+	}
+
+	r.lastRefreshed = time.Now()
+
+	stored, err := r.batches.store.GetJob(ctx, r.job.Batch.ID)
+	if err != nil {
+		r.batches.logger.Warn("failed to refresh batch", zap.String("batch_id", r.job.Batch.ID), zap.Error(err))
+		return
+	}
+	if stored.Version != r.job.Version {
+		r.merge(stored)
+	}
+}
+
+func (r *runningJob) requestCancel(ctx context.Context) (*Batch, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.job.Batch.Terminal() {
+		return nil, ErrBatchTerminal
+	}
+
+	if r.job.Batch.Status != StatusCancelling {
+		r.job.Batch.Status = StatusCancelling
+		r.job.Batch.CancellingAt = lo.ToPtr(time.Now().Unix())
+
+		err := r.save(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		r.cancel(errCancelled)
+	}
+
+	batch := r.job.Batch
+
+	return &batch, nil
+}
+
+// renewLease keeps the lease of the job until ctx is done, the processing is
+// stopped as soon as the lease is taken over by another replica.
+func (b *Batches) renewLease(ctx context.Context, running *runningJob) {
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		acquired, err := b.store.AcquireLease(ctx, running.job.Batch.ID, b.holder, leaseTTL)
+		if err != nil {
+			b.logger.Warn("failed to renew batch lease", zap.String("batch_id", running.job.Batch.ID), zap.Error(err))
+			continue
+		}
+		if !acquired {
+			b.logger.Warn("batch lease taken over by another replica", zap.String("batch_id", running.job.Batch.ID))
+
+			running.mutex.Lock()
+			running.lose()
+			running.mutex.Unlock()
+
+			return
+		}
+	}
+}
+
+// start processes the job in the background, the lease of the job must be
+// held, it is released once the processing stops.
+func (b *Batches) start(job *Job) {
+	ctx, cancel := context.WithCancelCause(b.ctx)
+
+	running := &runningJob{
+		batches: b,
+		cancel:  cancel,
+		job:     job,
+	}
+
+	b.mutex.Lock()
+	b.running[job.Batch.ID] = running
+	b.mutex.Unlock()
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		renewed := make(chan struct{})
+
+		go func() {
+			defer close(renewed)
+
+			b.renewLease(ctx, running)
+		}()
+
+		b.process(ctx, running)
+
+		// The lease is released once it is not renewed anymore.
+		cancel(nil)
+		<-renewed
+
+		err := b.store.ReleaseLease(context.WithoutCancel(ctx), job.Batch.ID, b.holder)
+		if err != nil {
+			b.logger.Warn("failed to release batch lease", zap.String("batch_id", job.Batch.ID), zap.Error(err))
+		}
+
+		b.mutex.Lock()
+		delete(b.running, job.Batch.ID)
+		b.mutex.Unlock()
+	}()
+}
+
+func readRequestLines(r io.Reader, endpoint string) ([]RequestLine, []BatchError, error) {
+	reader := bufio.NewReaderSize(r, 64<<10) //nolint:mnd
+	lines := make([]RequestLine, 0)
+	errs := make([]BatchError, 0)
+	customIDs := make(map[string]struct{})
+
+	for lineNumber := 1; ; lineNumber++ {
+		raw, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, nil, err
+		}
+		if len(raw) > maxLineSize {
+			errs = append(errs, BatchError{Code: "invalid_request", Message: "line exceeds the maximum size", Line: lo.ToPtr(lineNumber)})
+		} else if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 {
+			line, lineErr := parseRequestLine(trimmed, endpoint, customIDs)
+			if lineErr != nil {
+				lineErr.Line = lo.ToPtr(lineNumber)
+				errs = append(errs, *lineErr)
+			} else {
+				lines = append(lines, line)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	return lines, errs, nil
+}
+
+func parseRequestLine(raw []byte, endpoint string, customIDs map[string]struct{}) (RequestLine, *BatchError) {
+	var line RequestLine
+
+	err := json.Unmarshal(raw, &line)
+	if err != nil {
+		return RequestLine{}, &BatchError{Code: "invalid_json_line", Message: err.Error()}
+	}
+	if line.CustomID == "" {
+		return RequestLine{}, &BatchError{Code: "missing_required_parameter", Message: "custom_id is required", Param: lo.ToPtr("custom_id")}
+	}
+	if _, ok := customIDs[line.CustomID]; ok {
+		return RequestLine{}, &BatchError{Code: "duplicate_custom_id", Message: fmt.Sprintf("custom_id %s is duplicated", line.CustomID), Param: lo.ToPtr("custom_id")}
+	}
+	if line.Method != http.MethodPost {
+		return RequestLine{}, &BatchError{Code: "invalid_method", Message: "method must be POST", Param: lo.ToPtr("method")}
+	}
+	if line.URL != endpoint {
+		return RequestLine{}, &BatchError{Code: "mismatched_endpoint", Message: fmt.Sprintf("url must be %s, the endpoint of the batch", endpoint), Param: lo.ToPtr("url")}
+	}
+	if len(line.Body) == 0 {
+		return RequestLine{}, &BatchError{Code: "missing_required_parameter", Message: "body is required", Param: lo.ToPtr("body")}
+	}
+
+	customIDs[line.CustomID] = struct{}{}
+
+	return line, nil
+}
+
+func (b *Batches) fail(ctx context.Context, running *runningJob, errs ...BatchError) {
+	running.update(ctx, false, func(batch *Batch) {
+		batch.Status = StatusFailed
+		batch.FailedAt = lo.ToPtr(time.Now().Unix())
+		batch.Errors = &BatchErrors{Object: "list", Data: errs}
+	})
+}
+
+func (b *Batches) readInput(ctx context.Context, running *runningJob) ([]RequestLine, bool) {
+	content, err := b.store.OpenFileContent(ctx, running.job.Batch.InputFileID)
+	if err != nil {
+		b.fail(ctx, running, BatchError{Code: "invalid_file", Message: err.Error(), Param: lo.ToPtr("input_file_id")})
+		return nil, false
+	}
+
+	defer content.Close()
+
+	lines, errs, err := readRequestLines(content, running.job.Batch.Endpoint)
+	if err != nil {
+		b.fail(ctx, running, BatchError{Code: "invalid_file", Message: err.Error(), Param: lo.ToPtr("input_file_id")})
+		return nil, false
+	}
+	if len(errs) > 0 {
+		b.fail(ctx, running, errs...)
+		return nil, false
+	}
+	if len(lines) == 0 {
+		b.fail(ctx, running, BatchError{Code: "empty_file", Message: "the input file contains no requests", Param: lo.ToPtr("input_file_id")})
+		return nil, false
+	}
+
+	return lines, true
+}
+
+func (b *Batches) process(ctx context.Context, running *runningJob) {
+	job := running.job
+
+	lines, ok := b.readInput(ctx, running)
+	if !ok {
+		return
+	}
+
+	// The credentials were verified when the batch was created, and are not
+	// stored, the endpoint is looked up by its reference instead.
+	endpoint, err := b.authenticator.Dereference(ctx, job.Endpoint)
+	if err != nil {
+		b.fail(ctx, running, BatchError{Code: "endpoint_unavailable", Message: err.Error()})
+		return
+	}
+
+	running.update(ctx, false, func(batch *Batch) {
+		batch.Status = StatusInProgress
+		batch.InProgressAt = lo.ToPtr(time.Now().Unix())
+		batch.RequestCounts = RequestCounts{Total: len(lines)}
+	})
+
+	ctx, cancel := context.WithDeadlineCause(ctx, time.Unix(lo.FromPtr(job.Batch.ExpiresAt), 0), errExpired)
+	defer cancel()
+
+	// The requests of batches yield the constrained upstreams to the other
+	// requests of their tenant.
+	ctx = scheduling.WithRequestedClass(ctx, string(scheduling.ClassBatch))
+
+	results := b.execute(ctx, running, endpoint, lines)
+
+	cause := context.Cause(ctx)
+	if errors.Is(cause, errShutdown) || errors.Is(cause, errLeaseLost) {
+		// Left as is to be resumed by the replica taking the lease over.
+		return
+	}
+
+	// Whatever interrupted the processing, the results gathered so far are
+	// still written out.
+	ctx = context.WithoutCancel(ctx)
+
+	running.update(ctx, false, func(batch *Batch) {
+		batch.Status = lo.Ternary(batch.Status == StatusCancelling, StatusCancelling, StatusFinalizing)
+		batch.FinalizingAt = lo.ToPtr(time.Now().Unix())
+	})
+
+	outputFileID, errorFileID, err := b.writeResults(ctx, job, results)
+	if err != nil {
+		b.logger.Error("failed to write batch results", zap.String("batch_id", job.Batch.ID), zap.Error(err))
+		b.fail(ctx, running, BatchError{Code: "output_failed", Message: err.Error()})
+
+		return
+	}
+
+	running.update(ctx, false, func(batch *Batch) {
+		now := lo.ToPtr(time.Now().Unix())
+
+		batch.OutputFileID = outputFileID
+		batch.ErrorFileID = errorFileID
+
+		switch {
+		case errors.Is(cause, errCancelled):
+			batch.Status = StatusCancelled
+			batch.CancelledAt = now
+		case errors.Is(cause, errExpired):
+			batch.Status = StatusExpired
+			batch.ExpiredAt = now
+		default:
+			batch.Status = StatusCompleted
+			batch.CompletedAt = now
+		}
+	})
+}
+
+// execute sends the requests with bounded concurrency, requests that are
+// never sent because the batch is interrupted are reported as failed.
+func (b *Batches) execute(ctx context.Context, running *runningJob, endpoint *authstorage.Endpoint, lines []RequestLine) []ResultLine {
+	results := make([]ResultLine, len(lines))
+
+	var wg sync.WaitGroup
+
+	for i, line := range lines {
+		running.refresh(ctx)
+
+		select {
+		case b.semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			for j := i; j < len(lines); j++ {
+				results[j] = interruptedResult(ctx, lines[j])
+			}
+
+			running.update(ctx, true, func(batch *Batch) {
+				batch.RequestCounts.Failed += len(lines) - i
+			})
+
+			break
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-b.semaphore }()
+
+			results[i] = b.executeOne(ctx, endpoint, line)
+
+			running.update(ctx, true, func(batch *Batch) {
+				if results[i].Error != nil {
+					batch.RequestCounts.Failed++
+				} else {
+					batch.RequestCounts.Completed++
+				}
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func interruptedResult(ctx context.Context, line RequestLine) ResultLine {
+	code := "batch_cancelled"
+	if errors.Is(context.Cause(ctx), errExpired) {
+		code = "batch_expired"
+	}
+
+	return ResultLine{
+		ID:       newID("batch_req_"),
+		CustomID: line.CustomID,
+		Error: &ResultError{
+			Code:    code,
+			Message: "the request was not sent before the batch was interrupted",
+		},
+	}
+}
+
+// withRetry sends the request up to maxAttempts times, backing off
+// exponentially between attempts, as long as the failure is retryable.
+// Requests exceeding the rate limits of the endpoint wait at least until
+// they would be allowed.
+func withRetry[T any](ctx context.Context, send func(ctx context.Context) (T, error)) (T, error) {
+	var (
+		response T
+		err      error
+	)
+
+	backoff := retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		response, err = send(ctx)
+		if err == nil || attempt >= maxAttempts || !upstreams.Retryable(err) {
+			return response, err
+		}
+
+		wait := backoff
+
+		var exceededErr *ratelimits.ExceededError
+		if errors.As(err, &exceededErr) {
+			wait = max(wait, exceededErr.Decision.RetryAfter)
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return response, err
+		}
+
+		backoff *= 2
+	}
+}
+
+func (b *Batches) send(ctx context.Context, endpoint *authstorage.Endpoint, line RequestLine) (any, error) {
+	switch line.URL {
+	case EndpointChatCompletions:
+		var request openai.ChatCompletionRequest
+
+		err := json.Unmarshal(line.Body, &request)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed body: %w", ErrInvalidRequest, err)
+		}
+
+		request.Stream = false
+		request.StreamOptions = nil
+
+		return withRetry(ctx, func(ctx context.Context) (any, error) {
+			return b.gateway.CreateChatCompletion(ctx, endpoint, request)
+		})
+	case EndpointEmbeddings:
+		var request openai.EmbeddingRequest
+
+		err := json.Unmarshal(line.Body, &request)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed body: %w", ErrInvalidRequest, err)
+		}
+
+		return withRetry(ctx, func(ctx context.Context) (any, error) {
+			response, err := b.gateway.CreateEmbeddings(ctx, endpoint, request)
+			if err != nil {
+				return nil, err
+			}
+			if request.EncodingFormat == openai.EmbeddingEncodingFormatBase64 {
+				return upstreams.Base64EmbeddingResponseOf(response), nil
+			}
+
+			return response, nil
+		})
+	default:
+		return nil, fmt.Errorf("%w: unsupported url %s", ErrInvalidRequest, line.URL)
+	}
+}
+
+func (b *Batches) executeOne(ctx context.Context, endpoint *authstorage.Endpoint, line RequestLine) ResultLine {
+	requestID := newID("batch_req_")
+
+	body, err := b.send(ctx, endpoint, line)
+	if err == nil {
+		return ResultLine{
+			ID:       requestID,
+			CustomID: line.CustomID,
+			Response: &ResultResponse{
+				StatusCode: http.StatusOK,
+				RequestID:  requestID,
+				Body:       body,
+			},
+		}
+	}
+
+	if errors.Is(err, ErrInvalidRequest) {
+		return ResultLine{
+			ID:       requestID,
+			CustomID: line.CustomID,
+			Error:    &ResultError{Code: "invalid_request", Message: err.Error()},
+		}
+	}
+	if ctx.Err() != nil {
+		return interruptedResult(ctx, line)
+	}
+
+	apiErr := upstreams.AsAPIError(err)
+
+	return ResultLine{
+		ID:       requestID,
+		CustomID: line.CustomID,
+		Error: &ResultError{
+			Code:    apiErr.Code,
+			Message: lo.Ternary(apiErr.Detail == "", apiErr.Title, apiErr.Detail),
+		},
+	}
+}
+
+func encodeResults(results []ResultLine) (*bytes.Buffer, error) {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+
+	for _, result := range results {
+		err := encoder.Encode(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buffer, nil
+}
+
+func (b *Batches) putResults(ctx context.Context, job *Job, name string, results []ResultLine) (*string, error) {
+	content, err := encodeResults(results)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &StoredFile{
+		File: File{
+			ID:        newID("file-"),
+			Object:    "file",
+			CreatedAt: time.Now().Unix(),
+			Filename:  job.Batch.ID + "_" + name + ".jsonl",
+			Purpose:   FilePurposeBatchOutput,
+			Status:    "processed",
+		},
+		Owner: job.Owner,
+	}
+
+	err = b.store.PutFile(ctx, file, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.File.ID, nil
+}
+
+// writeResults writes every result in the order of the input file to the
+// output file, the failed ones are also written to the error file.
+func (b *Batches) writeResults(ctx context.Context, job *Job, results []ResultLine) (*string, *string, error) {
+	outputFileID, err := b.putResults(ctx, job, "output", results)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	failed := lo.Filter(results, func(item ResultLine, _ int) bool {
+		return item.Error != nil
+	})
+	if len(failed) == 0 {
+		return outputFileID, nil, nil
+	}
+
+	errorFileID, err := b.putResults(ctx, job, "error", failed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return outputFileID, errorFileID, nil
+}
@@ -0,0 +1,70 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+)
+
+var (
+	ErrFileNotFound  = errors.New("file not found")
+	ErrBatchNotFound = errors.New("batch not found")
+	// ErrJobConflict is returned by PutJob when the job was changed since it
+	// was read.
+	ErrJobConflict = errors.New("batch was changed concurrently")
+)
+
+// Store keeps the batch jobs and the files they read from and write to.
+type Store interface {
+	PutFile(ctx context.Context, file *StoredFile, content io.Reader) error
+	GetFile(ctx context.Context, id string) (*StoredFile, error)
+	OpenFileContent(ctx context.Context, id string) (io.ReadCloser, error)
+	DeleteFile(ctx context.Context, id string) error
+
+	// PutJob stores the job when the version stored is still job.Version,
+	// 0 for new jobs, and increments job.Version. It returns ErrJobConflict
+	// otherwise, the job must be read again before retrying.
+	PutJob(ctx context.Context, job *Job) error
+	GetJob(ctx context.Context, id string) (*Job, error)
+	ListJobs(ctx context.Context) ([]*Job, error)
+
+	// AcquireLease takes, or renews, the lease of holder on the job for ttl,
+	// so that a job is processed by one replica at a time. It returns false
+	// when another holder has the lease.
+	AcquireLease(ctx context.Context, id string, holder string, ttl time.Duration) (bool, error)
+	// ReleaseLease gives the lease of holder on the job up, if it still has
+	// it.
+	ReleaseLease(ctx context.Context, id string, holder string) error
+}
+
+type NewStoreParams struct {
+	fx.In
+
+	Config  *configs.Config
+	Rueidis *datastore.Rueidis
+}
+
+func NewStore() func(params NewStoreParams) (Store, error) {
+	return func(params NewStoreParams) (Store, error) {
+		switch params.Config.Batches.Storage {
+		case configs.BatchesStorageFilesystem, "":
+			return NewFilesystemStore(params.Config.Batches.Directory)
+		case configs.BatchesStorageRedis:
+			client, err := params.Rueidis.Client()
+			if err != nil {
+				return nil, err
+			}
+
+			return NewRedisStore(client), nil
+		default:
+			return nil, fmt.Errorf("unsupported batches storage %q", params.Config.Batches.Storage)
+		}
+	}
+}
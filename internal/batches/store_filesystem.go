@@ -0,0 +1,248 @@
+package batches
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Store = (*FilesystemStore)(nil)
+
+// FilesystemStore keeps the metadata of files and jobs as JSON documents next
+// to the file contents under a root directory. Jobs are only guarded against
+// the concurrent writes, and leases, of this process, the directory must not
+// be shared by several replicas.
+type FilesystemStore struct {
+	root string
+
+	mutex  sync.Mutex
+	leases map[string]fileLease
+}
+
+type fileLease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+func NewFilesystemStore(root string) (*FilesystemStore, error) {
+	for _, dir := range []string{"files", "jobs"} {
+		err := os.MkdirAll(filepath.Join(root, dir), 0o750)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &FilesystemStore{
+		root:   root,
+		leases: make(map[string]fileLease),
+	}, nil
+}
+
+func (s *FilesystemStore) filePath(id string) string {
+	return filepath.Join(s.root, "files", filepath.Base(id)+".json")
+}
+
+func (s *FilesystemStore) fileContentPath(id string) string {
+	return filepath.Join(s.root, "files", filepath.Base(id)+".jsonl")
+}
+
+func (s *FilesystemStore) jobPath(id string) string {
+	return filepath.Join(s.root, "jobs", filepath.Base(id)+".json")
+}
+
+// writeAtomically writes through a temporary file renamed into place, so
+// that readers never observe a partially written document.
+func writeAtomically(path string, content io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, content)
+	if err != nil {
+		_ = tmp.Close()
+		return 0, err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	return n, os.Rename(tmp.Name(), path)
+}
+
+func writeJSON(path string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = writeAtomically(path, strings.NewReader(string(b)))
+
+	return err
+}
+
+func readJSON(path string, v any, notFound error) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return notFound
+		}
+
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func (s *FilesystemStore) PutFile(ctx context.Context, file *StoredFile, content io.Reader) error {
+	n, err := writeAtomically(s.fileContentPath(file.File.ID), content)
+	if err != nil {
+		return err
+	}
+
+	file.File.Bytes = n
+
+	return writeJSON(s.filePath(file.File.ID), file)
+}
+
+func (s *FilesystemStore) GetFile(ctx context.Context, id string) (*StoredFile, error) {
+	var file StoredFile
+
+	err := readJSON(s.filePath(id), &file, ErrFileNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+func (s *FilesystemStore) OpenFileContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	f, err := os.Open(s.fileContentPath(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrFileNotFound
+		}
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FilesystemStore) DeleteFile(ctx context.Context, id string) error {
+	err := os.Remove(s.filePath(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrFileNotFound
+		}
+
+		return err
+	}
+
+	err = os.Remove(s.fileContentPath(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *FilesystemStore) PutJob(ctx context.Context, job *Job) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var version int64
+
+	stored, err := s.GetJob(ctx, job.Batch.ID)
+	if err != nil && !errors.Is(err, ErrBatchNotFound) {
+		return err
+	}
+	if stored != nil {
+		version = stored.Version
+	}
+	if version != job.Version {
+		return ErrJobConflict
+	}
+
+	updated := *job
+	updated.Version++
+
+	err = writeJSON(s.jobPath(job.Batch.ID), updated)
+	if err != nil {
+		return err
+	}
+
+	job.Version = updated.Version
+
+	return nil
+}
+
+func (s *FilesystemStore) GetJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+
+	err := readJSON(s.jobPath(id), &job, ErrBatchNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (s *FilesystemStore) ListJobs(ctx context.Context) ([]*Job, error) {
+	entries, err := os.ReadDir(filepath.Join(s.root, "jobs"))
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		job, err := s.GetJob(ctx, strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (s *FilesystemStore) AcquireLease(ctx context.Context, id string, holder string, ttl time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lease, ok := s.leases[id]
+	if ok && lease.holder != holder && time.Now().Before(lease.expiresAt) {
+		return false, nil
+	}
+
+	s.leases[id] = fileLease{holder: holder, expiresAt: time.Now().Add(ttl)}
+
+	return true, nil
+}
+
+func (s *FilesystemStore) ReleaseLease(ctx context.Context, id string, holder string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.leases[id].holder == holder {
+		delete(s.leases, id)
+	}
+
+	return nil
+}
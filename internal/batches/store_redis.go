@@ -0,0 +1,247 @@
+package batches
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+)
+
+var _ Store = (*RedisStore)(nil)
+
+// RedisStore keeps files and jobs in Redis, so that they can be shared by
+// every instance of the gateway.
+type RedisStore struct {
+	rueidis rueidis.Client
+}
+
+func NewRedisStore(r rueidis.Client) *RedisStore {
+	return &RedisStore{
+		rueidis: r,
+	}
+}
+
+func (s *RedisStore) getJSON(ctx context.Context, key string, v any, notFound error) error {
+	cmd := s.rueidis.B().
+		Get().
+		Key(key).
+		Build()
+
+	res, err := s.rueidis.Do(ctx, cmd).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return notFound
+		}
+
+		return err
+	}
+
+	return json.Unmarshal([]byte(res), v)
+}
+
+func (s *RedisStore) setJSON(ctx context.Context, key string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	cmd := s.rueidis.B().
+		Set().
+		Key(key).
+		Value(string(b)).
+		Build()
+
+	return s.rueidis.Do(ctx, cmd).Error()
+}
+
+func (s *RedisStore) PutFile(ctx context.Context, file *StoredFile, content io.Reader) error {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	cmd := s.rueidis.B().
+		Set().
+		Key(rediskeys.BatchesFileContentByID1.Format(file.File.ID)).
+		Value(rueidis.BinaryString(b)).
+		Build()
+
+	err = s.rueidis.Do(ctx, cmd).Error()
+	if err != nil {
+		return err
+	}
+
+	file.File.Bytes = int64(len(b))
+
+	return s.setJSON(ctx, rediskeys.BatchesFileByID1.Format(file.File.ID), file)
+}
+
+func (s *RedisStore) GetFile(ctx context.Context, id string) (*StoredFile, error) {
+	var file StoredFile
+
+	err := s.getJSON(ctx, rediskeys.BatchesFileByID1.Format(id), &file, ErrFileNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+func (s *RedisStore) OpenFileContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	cmd := s.rueidis.B().
+		Get().
+		Key(rediskeys.BatchesFileContentByID1.Format(id)).
+		Build()
+
+	res, err := s.rueidis.Do(ctx, cmd).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, ErrFileNotFound
+		}
+
+		return nil, err
+	}
+
+	return io.NopCloser(strings.NewReader(res)), nil
+}
+
+func (s *RedisStore) DeleteFile(ctx context.Context, id string) error {
+	cmd := s.rueidis.B().
+		Del().
+		Key(rediskeys.BatchesFileByID1.Format(id), rediskeys.BatchesFileContentByID1.Format(id)).
+		Build()
+
+	deleted, err := s.rueidis.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrFileNotFound
+	}
+
+	return nil
+}
+
+// putJobScript stores the job of ARGV[2] by KEYS[1], and adds its ID,
+// ARGV[3], to the set of KEYS[2], when the version of the job stored is
+// still ARGV[1]. It returns 1 when the job is stored, 0 otherwise.
+var putJobScript = rueidis.NewLuaScript(`
+local stored = redis.call('GET', KEYS[1])
+local version = 0
+if stored then
+  version = tonumber(cjson.decode(stored).version) or 0
+end
+if version ~= tonumber(ARGV[1]) then
+  return 0
+end
+
+redis.call('SET', KEYS[1], ARGV[2])
+redis.call('SADD', KEYS[2], ARGV[3])
+
+return 1
+`)
+
+// acquireLeaseScript sets KEYS[1] to the holder of ARGV[1] for ARGV[2]
+// milliseconds, unless it is set to another holder. It returns 1 when the
+// lease is taken, or renewed, 0 otherwise.
+var acquireLeaseScript = rueidis.NewLuaScript(`
+local holder = redis.call('GET', KEYS[1])
+if holder and holder ~= ARGV[1] then
+  return 0
+end
+
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+
+return 1
+`)
+
+// releaseLeaseScript deletes KEYS[1] when it is set to the holder of
+// ARGV[1].
+var releaseLeaseScript = rueidis.NewLuaScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  redis.call('DEL', KEYS[1])
+end
+
+return 0
+`)
+
+func (s *RedisStore) PutJob(ctx context.Context, job *Job) error {
+	stored := *job
+	stored.Version++
+
+	b, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	ok, err := putJobScript.Exec(ctx, s.rueidis,
+		[]string{rediskeys.BatchesJobByID1.Format(job.Batch.ID), rediskeys.BatchesJobIDs0.Format()},
+		[]string{strconv.FormatInt(job.Version, 10), string(b), job.Batch.ID},
+	).AsBool()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrJobConflict
+	}
+
+	job.Version = stored.Version
+
+	return nil
+}
+
+func (s *RedisStore) GetJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+
+	err := s.getJSON(ctx, rediskeys.BatchesJobByID1.Format(id), &job, ErrBatchNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (s *RedisStore) ListJobs(ctx context.Context) ([]*Job, error) {
+	cmd := s.rueidis.B().
+		Smembers().
+		Key(rediskeys.BatchesJobIDs0.Format()).
+		Build()
+
+	ids, err := s.rueidis.Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0, len(ids))
+
+	for _, id := range ids {
+		job, err := s.GetJob(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (s *RedisStore) AcquireLease(ctx context.Context, id string, holder string, ttl time.Duration) (bool, error) {
+	return acquireLeaseScript.Exec(ctx, s.rueidis,
+		[]string{rediskeys.BatchesJobLeaseByID1.Format(id)},
+		[]string{holder, strconv.FormatInt(ttl.Milliseconds(), 10)},
+	).AsBool()
+}
+
+func (s *RedisStore) ReleaseLease(ctx context.Context, id string, holder string) error {
+	return releaseLeaseScript.Exec(ctx, s.rueidis,
+		[]string{rediskeys.BatchesJobLeaseByID1.Format(id)},
+		[]string{holder},
+	).Error()
+}
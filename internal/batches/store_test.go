@@ -0,0 +1,110 @@
+package batches
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/internal/endpoints"
+)
+
+func testStoreJobs(t *testing.T, store Store) {
+	t.Helper()
+
+	ctx := context.Background()
+	id := newID("batch_")
+
+	job := &Job{
+		Batch:    Batch{ID: id, Status: StatusValidating},
+		Owner:    "endpoint",
+		Endpoint: endpoints.Reference{EndpointID: "endpoint"},
+	}
+
+	err := store.PutJob(ctx, job)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), job.Version)
+
+	stale, err := store.GetJob(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, *job, *stale)
+
+	job.Batch.Status = StatusInProgress
+
+	err = store.PutJob(ctx, job)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), job.Version)
+
+	// Writes of jobs read before the last write are rejected.
+	stale.Batch.Status = StatusCancelling
+
+	err = store.PutJob(ctx, stale)
+	require.ErrorIs(t, err, ErrJobConflict)
+
+	// Creating a job again is rejected as well.
+	err = store.PutJob(ctx, &Job{Batch: Batch{ID: id}})
+	require.ErrorIs(t, err, ErrJobConflict)
+
+	stored, err := store.GetJob(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusInProgress, stored.Batch.Status)
+	assert.Equal(t, int64(2), stored.Version)
+
+	acquired, err := store.AcquireLease(ctx, id, "replica1", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = store.AcquireLease(ctx, id, "replica2", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Renewing is acquiring again.
+	acquired, err = store.AcquireLease(ctx, id, "replica1", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Only the holder releases the lease.
+	err = store.ReleaseLease(ctx, id, "replica2")
+	require.NoError(t, err)
+
+	acquired, err = store.AcquireLease(ctx, id, "replica2", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	err = store.ReleaseLease(ctx, id, "replica1")
+	require.NoError(t, err)
+
+	acquired, err = store.AcquireLease(ctx, id, "replica2", 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Leases which are not renewed expire.
+	time.Sleep(100 * time.Millisecond)
+
+	acquired, err = store.AcquireLease(ctx, id, "replica1", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestFilesystemStore_Jobs(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	testStoreJobs(t, store)
+}
+
+func TestRedisStore_Jobs(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+
+	defer r.Close()
+
+	testStoreJobs(t, NewRedisStore(r))
+}
@@ -1,9 +1,13 @@
 package configs
 
 import (
+	"reflect"
+
 	"github.com/lingticio/llmg/internal/meta"
+	"github.com/lingticio/llmg/pkg/types/metadata"
 	"github.com/lingticio/llmg/pkg/util/utils"
 	"github.com/mitchellh/mapstructure"
+	"github.com/samber/lo"
 	"github.com/spf13/viper"
 )
 
@@ -32,6 +36,7 @@ func NewConfig(namespace string, app string, configFilePath string, envFilePath
 
 		err = viper.Unmarshal(&config, func(c *mapstructure.DecoderConfig) {
 			c.TagName = "yaml"
+			c.DecodeHook = mapstructure.ComposeDecodeHookFunc(c.DecodeHook, decodeUpstreamSingleOrMultiple(c))
 		})
 		if err != nil {
 			return nil, err
@@ -46,3 +51,51 @@ func NewConfig(namespace string, app string, configFilePath string, envFilePath
 		return &config, nil
 	}
 }
+
+// decodeUpstreamSingleOrMultiple decodes the single upstream of
+// metadata.UpstreamSingleOrMultiple from the fields inlined besides its
+// group, as yaml does, which mapstructure cannot since it is embedded by
+// pointer.
+func decodeUpstreamSingleOrMultiple(config *mapstructure.DecoderConfig) mapstructure.DecodeHookFuncType {
+	decode := func(data any, result any) error {
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			TagName:          config.TagName,
+			WeaklyTypedInput: config.WeaklyTypedInput,
+			DecodeHook:       config.DecodeHook,
+			Result:           result,
+		})
+		if err != nil {
+			return err
+		}
+
+		return decoder.Decode(data)
+	}
+
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		fields, ok := data.(map[string]any)
+		if !ok || to != reflect.TypeOf(metadata.UpstreamSingleOrMultiple{}) {
+			return data, nil
+		}
+
+		var upstream metadata.UpstreamSingleOrMultiple
+
+		err := decode(fields["group"], &upstream.Group)
+		if err != nil {
+			return nil, err
+		}
+
+		inlined := lo.OmitByKeys(fields, []string{"group"})
+		if len(inlined) == 0 {
+			return upstream, nil
+		}
+
+		upstream.Upstream = new(metadata.Upstream)
+
+		err = decode(inlined, upstream.Upstream)
+		if err != nil {
+			return nil, err
+		}
+
+		return upstream, nil
+	}
+}
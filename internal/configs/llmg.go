@@ -1,6 +1,9 @@
 package configs
 
 import (
+	"slices"
+	"time"
+
 	"github.com/lingticio/llmg/internal/meta"
 	"github.com/lingticio/llmg/pkg/types/metadata"
 	"github.com/samber/lo"
@@ -9,6 +12,10 @@ import (
 
 type HTTPServer struct {
 	Addr string `json:"server_addr" yaml:"server_addr"`
+	// AllowedOrigins are the origins, besides the one of the gateway, that
+	// browsers may open WebSocket chat sessions from, e.g.
+	// https://app.example.com.
+	AllowedOrigins []string `json:"allowed_origins" yaml:"allowed_origins"`
 }
 
 type GrpcServer struct {
@@ -17,13 +24,206 @@ type GrpcServer struct {
 
 type GraphQLServer struct {
 	Addr string `json:"server_addr" yaml:"server_addr"`
+	// DisableInputCredentials rejects the apiKey and baseURL fields of the
+	// inputs, so that API keys are only accepted from the headers and the
+	// connection_init payload of WebSocket connections.
+	DisableInputCredentials bool `json:"disable_input_credentials" yaml:"disable_input_credentials"`
+}
+
+type BatchesStorage string
+
+const (
+	BatchesStorageFilesystem BatchesStorage = "filesystem"
+	BatchesStorageRedis      BatchesStorage = "redis"
+)
+
+type Batches struct {
+	// Storage is where batch jobs and files are kept, either filesystem or
+	// redis. The filesystem storage must not be shared by several replicas.
+	Storage BatchesStorage `json:"storage" yaml:"storage"`
+	// Directory is the root directory of the filesystem storage.
+	Directory string `json:"directory" yaml:"directory"`
+	// Concurrency bounds the number of requests of all batches in flight
+	// at the same time.
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+}
+
+type ResponsesStorage string
+
+const (
+	ResponsesStorageMemory ResponsesStorage = "memory"
+	ResponsesStorageRedis  ResponsesStorage = "redis"
+)
+
+type Responses struct {
+	// Storage is where the responses stored for previous_response_id are
+	// kept, either memory or redis. The memory storage is not shared by
+	// several replicas.
+	Storage ResponsesStorage `json:"storage" yaml:"storage"`
+}
+
+type EndpointsProvider string
+
+const (
+	EndpointsProviderConfig EndpointsProvider = "config"
+	EndpointsProviderRedis  EndpointsProvider = "redis"
+	EndpointsProviderRDS    EndpointsProvider = "rds"
+)
+
+type Endpoints struct {
+	// Provider is where the API keys issued by the gateway are looked up,
+	// either config, which reads the routes of this file, redis, or rds.
+	Provider EndpointsProvider `json:"provider" yaml:"provider"`
+	// Database is the database of the rds provider.
+	Database Database `json:"database" yaml:"database"`
+	// APIKeySecret keys the digests API keys are stored by in the redis and
+	// rds providers, which require it. Changing it invalidates every API key
+	// issued.
+	APIKeySecret string `json:"api_key_secret" yaml:"api_key_secret"`
+	// Passthrough forwards the API keys not issued by the gateway, as is, to
+	// the upstream of the X-Base-Url header of the REST API.
+	Passthrough Passthrough `json:"passthrough" yaml:"passthrough"`
+}
+
+type Passthrough struct {
+	// Enabled is off by default, API keys not issued by the gateway are
+	// rejected then.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// AllowedBaseURLs are the only base URLs keys are forwarded to, e.g.
+	// https://api.openai.com/v1, which is also the base URL of requests
+	// without one. Enabling passthrough requires at least one.
+	AllowedBaseURLs []string `json:"allowed_base_urls" yaml:"allowed_base_urls"`
+}
+
+type JWTClaims struct {
+	// Subject is the path of the claim identifying the user, defaults to
+	// sub.
+	Subject string `json:"subject" yaml:"subject"`
+	// Tenant, Team and Group are the paths of the claims selecting the
+	// tenant, team and group of the user, nested claims are separated by
+	// dots, e.g. app_metadata.tenant. Tenant is required, Team and Group
+	// are optional.
+	Tenant string `json:"tenant" yaml:"tenant"`
+	Team   string `json:"team" yaml:"team"`
+	Group  string `json:"group" yaml:"group"`
+}
+
+type JWT struct {
+	// Enabled accepts JWTs as bearer tokens besides API keys.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Secret verifies HS256 tokens.
+	Secret string `json:"secret" yaml:"secret"`
+	// JWKS is the path of a file, or the http(s) URL, of the JSON Web Key
+	// Set verifying RS256 and ES256 tokens.
+	JWKS string `json:"jwks" yaml:"jwks"`
+	// JWKSRefreshInterval is how often the key set is reloaded.
+	JWKSRefreshInterval time.Duration `json:"jwks_refresh_interval" yaml:"jwks_refresh_interval"`
+	// Issuer and Audience are verified when set.
+	Issuer   string `json:"issuer" yaml:"issuer"`
+	Audience string `json:"audience" yaml:"audience"`
+
+	Claims JWTClaims `json:"claims" yaml:"claims"`
+}
+
+// EndpointAPIKey is an API key of an endpoint, along with its lifecycle.
+type EndpointAPIKey struct {
+	Key string `json:"key" yaml:"key"`
+	// ExpiresAt is when the key stops being accepted, empty for never.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	// Disabled keys are revoked.
+	Disabled bool `json:"disabled" yaml:"disabled"`
+	// Scopes are what the key may be used for, any of chat, embeddings,
+	// models, batches, usage and admin. Keys without scopes may be used for
+	// anything but admin.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	// Aliases are the aliases of the other endpoints the key may address,
+	// e.g. at /v1/e/{alias}/chat/completions.
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+}
+
+// RateLimit limits the requests, and the tokens, per minute shared by the
+// requests of a tenant, team, group or endpoint, across the replicas of the
+// gateway. Limits of 0 are not enforced. The limits of every level of the
+// hierarchy an endpoint belongs to are enforced, rather than inherited.
+//
+// Rate limits are declared in the routes of the configuration, and set
+// through the admin API for the endpoints of the redis and rds providers.
+// They are enforced with Redis, which must be configured.
+type RateLimit struct {
+	RequestsPerMinute int64 `json:"requests_per_minute" yaml:"requests_per_minute"`
+	// TokensPerMinute is enforced with the tokens requests are estimated to
+	// cost, corrected with the usage reported by the upstreams.
+	TokensPerMinute int64 `json:"tokens_per_minute" yaml:"tokens_per_minute"`
+}
+
+// BudgetLimit is an amount, in USD, spent on the upstreams, limits of 0 are
+// not enforced.
+type BudgetLimit struct {
+	// Hard rejects the requests once spent.
+	Hard float64 `json:"hard" yaml:"hard"`
+	// Soft is warned about once spent, in the x-llmg-budget-warning header
+	// of the responses, and once to the webhook of the budgets.
+	Soft float64 `json:"soft" yaml:"soft"`
+}
+
+// Budget limits the cost of the requests of a tenant, team, group or
+// endpoint, derived from their usage and the prices of the budgets, per day
+// and per month, in UTC, and over their lifetime. As rate limits, the
+// budgets of every level of the hierarchy an endpoint belongs to are
+// enforced, and they are declared in the same places.
+type Budget struct {
+	Daily    *BudgetLimit `json:"daily,omitempty" yaml:"daily,omitempty"`
+	Monthly  *BudgetLimit `json:"monthly,omitempty" yaml:"monthly,omitempty"`
+	Lifetime *BudgetLimit `json:"lifetime,omitempty" yaml:"lifetime,omitempty"`
+}
+
+// Priority is the priority class of the requests of an endpoint, which
+// weighs how much of the capacity of the constrained upstreams they are
+// shared, see Scheduling. As rate limits, priorities are declared in the
+// routes of the configuration, or set through the admin API.
+type Priority struct {
+	// Class is any of interactive, standard and batch, standard when empty.
+	Class string `json:"class" yaml:"class"`
+	// Permitted are the classes above Class the requests may select with
+	// the x-llmg-priority header, the classes not above Class may always be
+	// selected.
+	Permitted []string `json:"permitted,omitempty" yaml:"permitted,omitempty"`
+}
+
+// EndpointSemanticCache opts the chat completions of an endpoint into the
+// semantic cache.
+type EndpointSemanticCache struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Threshold is the similarity, between 0 and 1, above which cached
+	// answers are served, the one of the semantic cache when 0.
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	// TTL is how long answers are cached, the one of the semantic cache
+	// when 0.
+	TTL time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
+// EndpointExactCache opts the deterministic chat completions of an endpoint
+// into the exact cache.
+type EndpointExactCache struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// TTL is how long answers are cached, the one of the exact cache when 0.
+	TTL time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
 }
 
 type Endpoint struct {
-	ID       string                             `json:"id" yaml:"id"`
-	Alias    string                             `json:"alias" yaml:"alias"`
-	APIKey   string                             `json:"api_key" yaml:"api_key"`
-	Upstream *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	ID     string `json:"id" yaml:"id"`
+	Alias  string `json:"alias" yaml:"alias"`
+	APIKey string `json:"api_key" yaml:"api_key"`
+	// APIKeys are issued besides APIKey, e.g. while rotating keys.
+	APIKeys   []EndpointAPIKey                   `json:"api_keys,omitempty" yaml:"api_keys,omitempty"`
+	Upstream  *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	RateLimit *RateLimit                         `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	Budget    *Budget                            `json:"budget,omitempty" yaml:"budget,omitempty"`
+	Priority  *Priority                          `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// SemanticCache is only honored when the semantic cache is enabled.
+	SemanticCache *EndpointSemanticCache `json:"semantic_cache,omitempty" yaml:"semantic_cache,omitempty"`
+	// ExactCache is only honored when the exact cache is enabled.
+	ExactCache *EndpointExactCache `json:"exact_cache,omitempty" yaml:"exact_cache,omitempty"`
 }
 
 type Group struct {
@@ -32,20 +232,26 @@ type Group struct {
 	Groups    []Group                            `json:"groups" yaml:"groups"`
 	Endpoints []Endpoint                         `json:"endpoints" yaml:"endpoints"`
 	Upstream  *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	RateLimit *RateLimit                         `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	Budget    *Budget                            `json:"budget,omitempty" yaml:"budget,omitempty"`
 }
 
 type Team struct {
 	ID string `json:"id" yaml:"id"`
 
-	Groups   []Group                            `json:"groups" yaml:"groups"`
-	Upstream *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	Groups    []Group                            `json:"groups" yaml:"groups"`
+	Upstream  *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	RateLimit *RateLimit                         `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	Budget    *Budget                            `json:"budget,omitempty" yaml:"budget,omitempty"`
 }
 
 type Tenant struct {
 	ID string `json:"id" yaml:"id"`
 
-	Teams    []Team                             `json:"teams" yaml:"teams"`
-	Upstream *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	Teams     []Team                             `json:"teams" yaml:"teams"`
+	Upstream  *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	RateLimit *RateLimit                         `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	Budget    *Budget                            `json:"budget,omitempty" yaml:"budget,omitempty"`
 }
 
 type Routes struct {
@@ -53,15 +259,272 @@ type Routes struct {
 	Upstream *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty" yaml:"upstream,omitempty"`
 }
 
+// anyLevel reports whether match returns true for the rate limit and the
+// budget of any tenant, team, group or endpoint of the routes.
+func (r Routes) anyLevel(match func(rateLimit *RateLimit, budget *Budget) bool) bool {
+	var anyGroup func(groups []Group) bool
+
+	anyGroup = func(groups []Group) bool {
+		for _, group := range groups {
+			if match(group.RateLimit, group.Budget) || anyGroup(group.Groups) {
+				return true
+			}
+
+			for _, endpoint := range group.Endpoints {
+				if match(endpoint.RateLimit, endpoint.Budget) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, tenant := range r.Tenants {
+		if match(tenant.RateLimit, tenant.Budget) {
+			return true
+		}
+
+		for _, team := range tenant.Teams {
+			if match(team.RateLimit, team.Budget) || anyGroup(team.Groups) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasRateLimits reports whether any tenant, team, group or endpoint of the
+// routes declares a rate limit.
+func (r Routes) HasRateLimits() bool {
+	return r.anyLevel(func(rateLimit *RateLimit, _ *Budget) bool {
+		return rateLimit != nil
+	})
+}
+
+// HasBudgets reports whether any tenant, team, group or endpoint of the
+// routes declares a budget.
+func (r Routes) HasBudgets() bool {
+	return r.anyLevel(func(_ *RateLimit, budget *Budget) bool {
+		return budget != nil
+	})
+}
+
+// AliasedModels returns the models the model_aliases of the upstreams of the
+// routes resolve to, at every level, sorted.
+func (r Routes) AliasedModels() []string {
+	models := make([]string, 0)
+
+	addModels := func(upstream *metadata.UpstreamSingleOrMultiple) {
+		if upstream == nil {
+			return
+		}
+
+		for _, item := range append([]*metadata.Upstream{upstream.Upstream}, upstream.Group...) {
+			if item != nil {
+				models = append(models, lo.Values(item.OpenAI.ModelAliases)...)
+			}
+		}
+	}
+
+	var addGroups func(groups []Group)
+
+	addGroups = func(groups []Group) {
+		for _, group := range groups {
+			addModels(group.Upstream)
+			addGroups(group.Groups)
+
+			for _, endpoint := range group.Endpoints {
+				addModels(endpoint.Upstream)
+			}
+		}
+	}
+
+	addModels(r.Upstream)
+
+	for _, tenant := range r.Tenants {
+		addModels(tenant.Upstream)
+
+		for _, team := range tenant.Teams {
+			addModels(team.Upstream)
+			addGroups(team.Groups)
+		}
+	}
+
+	models = lo.Uniq(lo.Compact(models))
+	slices.Sort(models)
+
+	return models
+}
+
+// ModelPrice is the price, in USD per million tokens, of a model.
+type ModelPrice struct {
+	Prompt     float64 `json:"prompt" yaml:"prompt"`
+	Completion float64 `json:"completion" yaml:"completion"`
+}
+
+type Budgets struct {
+	// Prices are the prices of the models, by their name as sent to the
+	// upstreams, i.e. once aliases are resolved. The requests to models
+	// without price are rejected for the endpoints with hard budgets, and
+	// not charged to the soft ones. They are required for every model the
+	// model_aliases of the routes resolve to when the routes declare
+	// budgets, free models are priced at 0.
+	Prices map[string]ModelPrice `json:"prices" yaml:"prices"`
+	// WebhookURL is posted the soft budgets once they are exceeded, empty
+	// for no webhook.
+	WebhookURL string `json:"webhook_url" yaml:"webhook_url"`
+}
+
+type UsageStorage string
+
+const (
+	UsageStorageJSONL UsageStorage = "jsonl"
+	UsageStorageSQL   UsageStorage = "sql"
+)
+
+type UsageRedis struct {
+	// Enabled records the usage events in Redis streams as well, which serve
+	// the queries of the last Retention.
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	Retention time.Duration `json:"retention" yaml:"retention"`
+}
+
+type Usage struct {
+	// Storage is where the usage events are kept for good, either jsonl or
+	// sql. The usage queries of the jsonl storage, and of the Redis streams,
+	// span at most 31 days, see usage.MaxScanRange.
+	Storage UsageStorage `json:"storage" yaml:"storage"`
+	// Directory is the directory of the files of the jsonl storage.
+	Directory string `json:"directory" yaml:"directory"`
+	// Database is the database of the sql storage.
+	Database Database   `json:"database" yaml:"database"`
+	Redis    UsageRedis `json:"redis" yaml:"redis"`
+}
+
+type SchedulingUpstream struct {
+	// MaxConcurrency bounds the requests in flight to the upstream, 0 for
+	// no bound. The bound is per replica of the gateway, which do not share
+	// their queues: n replicas send up to n times MaxConcurrency requests to
+	// the upstream.
+	MaxConcurrency int `json:"max_concurrency" yaml:"max_concurrency"`
+}
+
+// Scheduling queues the requests of the upstreams of constrained capacity,
+// and dispatches them by weighted fair queuing across the tenants and the
+// priority classes, so that the batches of a tenant cannot starve the
+// interactive requests of the others.
+type Scheduling struct {
+	// Upstreams are the upstreams of constrained capacity, by base URL, the
+	// requests of the other upstreams are never queued.
+	Upstreams map[string]SchedulingUpstream `json:"upstreams" yaml:"upstreams"`
+	// Weights are the weights of the interactive, standard and batch
+	// classes, 8, 4 and 1 when not set. Other classes fail the startup.
+	Weights map[string]int `json:"weights" yaml:"weights"`
+	// MaxWait bounds how long requests are queued before being rejected, 0
+	// for as long as the requests are not canceled.
+	MaxWait time.Duration `json:"max_wait" yaml:"max_wait"`
+}
+
+type SemanticCacheBackend string
+
+const (
+	SemanticCacheBackendRedis  SemanticCacheBackend = "redis"
+	SemanticCacheBackendMemory SemanticCacheBackend = "memory"
+)
+
+type SemanticCacheRedis struct {
+	// Algorithm indexes the embeddings, either FLAT, exact, or HNSW,
+	// approximate but much faster for many answers.
+	Algorithm string `json:"algorithm" yaml:"algorithm"`
+	// M, EfConstruction and EfRuntime tune the HNSW index, the defaults of
+	// RediSearch when 0.
+	M              int `json:"m" yaml:"m"`
+	EfConstruction int `json:"ef_construction" yaml:"ef_construction"`
+	EfRuntime      int `json:"ef_runtime" yaml:"ef_runtime"`
+	// Type is the type of the elements of the embeddings indexed, either
+	// FLOAT32 or FLOAT64.
+	Type string `json:"type" yaml:"type"`
+	// DistanceMetric is either COSINE, IP or L2, the embeddings must be
+	// normalized for IP and L2.
+	DistanceMetric string `json:"distance_metric" yaml:"distance_metric"`
+}
+
+type SemanticCacheMemory struct {
+	// MaxBytes bounds roughly how much memory the answers cached take, the
+	// ones cached first are evicted beyond it, 0 for no bound.
+	MaxBytes int64 `json:"max_bytes" yaml:"max_bytes"`
+	// Snapshot is the file the answers cached are saved to every
+	// SnapshotInterval and on shutdown, and loaded from on startup, empty
+	// for none.
+	Snapshot         string        `json:"snapshot" yaml:"snapshot"`
+	SnapshotInterval time.Duration `json:"snapshot_interval" yaml:"snapshot_interval"`
+}
+
+// SemanticCache serves the chat completions of the endpoints opting in from
+// the answers cached in Redis Stack, or in memory, by the similarity of the
+// embeddings of their questions, which are created with the upstreams of the
+// endpoints.
+type SemanticCache struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Backend is where the answers are cached, either redis, which must
+	// provide RediSearch and RedisJSON, or memory, which is not shared by
+	// the replicas of the gateway.
+	Backend SemanticCacheBackend `json:"backend" yaml:"backend"`
+	// Index is the name of the index, and the prefix of the keys, of the
+	// answers cached in Redis.
+	Index string `json:"index" yaml:"index"`
+	// Redis configures the index of the redis backend, which is created
+	// again from the answers cached when changed.
+	Redis SemanticCacheRedis `json:"redis" yaml:"redis"`
+	// Memory configures the memory backend.
+	Memory SemanticCacheMemory `json:"memory" yaml:"memory"`
+	// EmbeddingModel embeds the questions, it must be served by an upstream
+	// of the endpoints supporting embeddings, possibly as an alias.
+	EmbeddingModel string `json:"embedding_model" yaml:"embedding_model"`
+	// Threshold and TTL apply to the endpoints which do not configure them.
+	Threshold float64       `json:"threshold" yaml:"threshold"`
+	TTL       time.Duration `json:"ttl" yaml:"ttl"`
+}
+
+// ExactCache serves the deterministic chat completions, i.e. of a
+// temperature of 0 or of a seed, of the endpoints opting in from the answers
+// cached in Redis for the exact same requests.
+type ExactCache struct {
+	// Enabled connects Redis.
+	Enabled bool          `json:"enabled" yaml:"enabled"`
+	TTL     time.Duration `json:"ttl" yaml:"ttl"`
+}
+
+type Admin struct {
+	// APIKey authenticates the admin API besides the API keys granted the
+	// admin scope, e.g. to onboard the first tenants. API keys granted the
+	// admin scope administrate the tenant of their endpoint only, creating
+	// and deleting tenants is reserved to APIKey.
+	APIKey string `json:"api_key" yaml:"api_key"`
+}
+
 type Config struct {
 	meta.Meta `json:"-" yaml:"-"`
 
 	Env string `json:"env" yaml:"env"`
 
-	HTTP    HTTPServer    `json:"http" yaml:"http"`
-	Grpc    GrpcServer    `json:"grpc" yaml:"grpc"`
-	GraphQL GraphQLServer `json:"graphql" yaml:"graphql"`
-	Routes  Routes        `json:"configs" yaml:"configs"`
+	HTTP          HTTPServer    `json:"http" yaml:"http"`
+	Redis         Redis         `json:"redis" yaml:"redis"`
+	Grpc          GrpcServer    `json:"grpc" yaml:"grpc"`
+	GraphQL       GraphQLServer `json:"graphql" yaml:"graphql"`
+	Routes        Routes        `json:"configs" yaml:"configs"`
+	Endpoints     Endpoints     `json:"endpoints" yaml:"endpoints"`
+	JWT           JWT           `json:"jwt" yaml:"jwt"`
+	Batches       Batches       `json:"batches" yaml:"batches"`
+	Responses     Responses     `json:"responses" yaml:"responses"`
+	Budgets       Budgets       `json:"budgets" yaml:"budgets"`
+	Usage         Usage         `json:"usage" yaml:"usage"`
+	Scheduling    Scheduling    `json:"scheduling" yaml:"scheduling"`
+	SemanticCache SemanticCache `json:"semantic_cache" yaml:"semantic_cache"`
+	ExactCache    ExactCache    `json:"exact_cache" yaml:"exact_cache"`
+	Admin         Admin         `json:"admin" yaml:"admin"`
 }
 
 func defaultConfig() Config {
@@ -75,6 +538,56 @@ func defaultConfig() Config {
 		GraphQL: GraphQLServer{
 			Addr: ":8082",
 		},
+		// Redis is left unconfigured, so that it is only required by the
+		// features configured to use it.
+		Redis: Redis{
+			Port: "6379",
+		},
+		Endpoints: Endpoints{
+			Provider: EndpointsProviderConfig,
+		},
+		JWT: JWT{
+			JWKSRefreshInterval: time.Hour,
+			Claims: JWTClaims{
+				Subject: "sub",
+			},
+		},
+		Batches: Batches{
+			Storage:     BatchesStorageFilesystem,
+			Directory:   "data/batches",
+			Concurrency: 4, //nolint:mnd
+		},
+		Responses: Responses{
+			Storage: ResponsesStorageMemory,
+		},
+		Usage: Usage{
+			Storage:   UsageStorageJSONL,
+			Directory: "data/usage",
+			Redis: UsageRedis{
+				Retention: 7 * 24 * time.Hour, //nolint:mnd
+			},
+		},
+		Scheduling: Scheduling{
+			MaxWait: time.Minute,
+		},
+		SemanticCache: SemanticCache{
+			Backend: SemanticCacheBackendRedis,
+			Index:   "llmg:semantic_cache",
+			Redis: SemanticCacheRedis{
+				Algorithm:      "HNSW",
+				Type:           "FLOAT32",
+				DistanceMetric: "COSINE",
+			},
+			Memory: SemanticCacheMemory{
+				SnapshotInterval: 5 * time.Minute, //nolint:mnd
+			},
+			EmbeddingModel: "text-embedding-3-small",
+			Threshold:      0.95,           //nolint:mnd
+			TTL:            24 * time.Hour, //nolint:mnd
+		},
+		ExactCache: ExactCache{
+			TTL: 24 * time.Hour, //nolint:mnd
+		},
 	}
 }
 
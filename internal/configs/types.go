@@ -8,14 +8,18 @@ type S3 struct {
 	Endpoint        string `json:"endpoint" yaml:"endpoint"`
 }
 
+// Redis is the Redis shared by the features of the gateway backed by it.
 type Redis struct {
-	Host               string `json:"host" yaml:"host"`
-	Port               string `json:"port" yaml:"port"`
-	TLSEnabled         bool   `json:"tls_enabled" yaml:"tls_enabled"`
-	Username           string `json:"username" yaml:"username"`
-	Password           string `json:"password" yaml:"password"`
-	DB                 int64  `json:"db" yaml:"db"`
-	ClientCacheEnabled bool   `json:"client_cache_enabled" yaml:"client_cache_enabled"`
+	Host       string `json:"host" yaml:"host"`
+	Port       string `json:"port" yaml:"port"`
+	TLSEnabled bool   `json:"tls_enabled" yaml:"tls_enabled"`
+	Username   string `json:"username" yaml:"username"`
+	// Password may be a secret reference, e.g. env:REDIS_PASSWORD.
+	Password string `json:"password" yaml:"password"`
+	DB       int64  `json:"db" yaml:"db"`
+	// ClientCacheEnabled enables the client-side caching of rueidis, which
+	// requires CLIENT TRACKING, unsupported by some managed Redis.
+	ClientCacheEnabled bool `json:"client_cache_enabled" yaml:"client_cache_enabled"`
 }
 
 type Database struct {
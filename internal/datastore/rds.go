@@ -0,0 +1,59 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lingticio/llmg/internal/configs"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the pgx driver
+	_ "modernc.org/sqlite"             // Registers the sqlite driver
+)
+
+// NewRDS opens the database of the connection string, which is either a
+// Postgres URL (postgres:// or postgresql://), or sqlite: followed by the
+// path of the SQLite database file, e.g. sqlite:data/llmg.db or
+// sqlite::memory:.
+func NewRDS() func(config configs.Database) (*sql.DB, error) {
+	return func(config configs.Database) (*sql.DB, error) {
+		var (
+			driverName string
+			dsn        string
+		)
+
+		switch {
+		case strings.HasPrefix(config.ConnectionString, "postgres://"), strings.HasPrefix(config.ConnectionString, "postgresql://"):
+			driverName = "pgx"
+			dsn = config.ConnectionString
+		case strings.HasPrefix(config.ConnectionString, "sqlite:"):
+			driverName = "sqlite"
+			dsn = strings.TrimPrefix(config.ConnectionString, "sqlite:")
+		default:
+			return nil, fmt.Errorf("unsupported database connection string, expected postgres://, postgresql:// or sqlite: prefix")
+		}
+
+		db, err := sql.Open(driverName, dsn)
+		if err != nil {
+			return nil, err
+		}
+		if driverName == "sqlite" {
+			// SQLite serializes writes anyway, and every connection to an
+			// in-memory database would be a database of its own.
+			db.SetMaxOpenConns(1)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err = db.PingContext(ctx)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		return db, nil
+	}
+}
@@ -2,30 +2,113 @@ package datastore
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
 	"time"
 
 	"github.com/redis/rueidis"
+	"go.uber.org/fx"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/pkg/secrets"
 )
 
-func NewRueidis() func() (rueidis.Client, error) {
-	return func() (rueidis.Client, error) {
-		client, err := rueidis.NewClient(rueidis.ClientOption{
-			InitAddress: []string{"localhost:6379"},
-		})
-		if err != nil {
-			return nil, err
-		}
+var ErrRedisNotConfigured = errors.New("redis is not configured, redis.host is empty")
+
+// NewRueidisClient connects the Redis of config, whose password may be a
+// secret reference, see secrets.IsReference.
+func NewRueidisClient(config configs.Redis) (rueidis.Client, error) {
+	if config.Host == "" {
+		return nil, ErrRedisNotConfigured
+	}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	port := config.Port
+	if port == "" {
+		port = "6379"
+	}
 
-		cmd := client.B().Ping().Build()
+	password, err := secrets.NewResolver()().Resolve(config.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve redis.password: %w", err)
+	}
 
-		err = client.Do(ctx, cmd).Error()
-		if err != nil {
-			return nil, err
+	option := rueidis.ClientOption{
+		InitAddress:  []string{net.JoinHostPort(config.Host, port)},
+		Username:     config.Username,
+		Password:     password,
+		SelectDB:     int(config.DB),
+		DisableCache: !config.ClientCacheEnabled,
+	}
+	if config.TLSEnabled {
+		option.TLSConfig = &tls.Config{
+			ServerName: config.Host,
+			MinVersion: tls.VersionTLS12,
 		}
+	}
+
+	client, err := rueidis.NewClient(option)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := client.B().Ping().Build()
+
+	err = client.Do(ctx, cmd).Error()
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
 
-		return client, nil
+	return client, nil
+}
+
+type NewRueidisParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *configs.Config
+}
+
+// Rueidis is the Redis client shared by the features of the gateway. It is
+// only connected once a feature of the configuration asks for it, so that
+// the gateway runs without Redis when none does.
+type Rueidis struct {
+	config configs.Redis
+
+	once   sync.Once
+	client rueidis.Client
+	err    error
+}
+
+func NewRueidis() func(params NewRueidisParams) *Rueidis {
+	return func(params NewRueidisParams) *Rueidis {
+		r := &Rueidis{config: params.Config.Redis}
+
+		params.Lifecycle.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				if r.client != nil {
+					r.client.Close()
+				}
+
+				return nil
+			},
+		})
+
+		return r
 	}
 }
+
+// Client returns the client, connecting it on the first call.
+func (r *Rueidis) Client() (rueidis.Client, error) {
+	r.once.Do(func() {
+		r.client, r.err = NewRueidisClient(r.config)
+	})
+
+	return r.client, r.err
+}
@@ -0,0 +1,250 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+// jwtEndpointIDPrefix prefixes the subject of JWTs to make up the ID of their
+// endpoints.
+const jwtEndpointIDPrefix = "jwt:"
+
+// ErrBaseURLNotAllowed rejects API keys not issued by the gateway forwarded
+// to a base URL which passthrough is not allowed for.
+var ErrBaseURLNotAllowed = errors.New("base url not allowed")
+
+type NewAuthenticatorParams struct {
+	fx.In
+
+	Lifecycle        fx.Lifecycle
+	Logger           *logger.Logger
+	Config           *configs.Config
+	EndpointProvider authstorage.EndpointProvider
+}
+
+// Authenticator resolves the credentials callers present to the frontends,
+// either API keys issued by the gateway, or JWTs when enabled, to endpoints.
+type Authenticator struct {
+	logger      *logger.Logger
+	config      configs.JWT
+	passthrough configs.Passthrough
+	provider    authstorage.EndpointProvider
+	verifier    *jwtVerifier
+}
+
+func NewAuthenticator() func(params NewAuthenticatorParams) (*Authenticator, error) {
+	return func(params NewAuthenticatorParams) (*Authenticator, error) {
+		if params.Config.Endpoints.Passthrough.Enabled && len(params.Config.Endpoints.Passthrough.AllowedBaseURLs) == 0 {
+			return nil, errors.New("passthrough is enabled, but no base url is allowed")
+		}
+
+		authenticator := &Authenticator{
+			logger:      params.Logger,
+			config:      params.Config.JWT,
+			passthrough: params.Config.Endpoints.Passthrough,
+			provider:    params.EndpointProvider,
+		}
+		if !params.Config.JWT.Enabled {
+			return authenticator, nil
+		}
+		if params.Config.JWT.Secret == "" && params.Config.JWT.JWKS == "" {
+			return nil, errors.New("jwt is enabled, but neither secret nor jwks is configured")
+		}
+		if params.Config.JWT.Claims.Tenant == "" {
+			return nil, errors.New("jwt is enabled, but the tenant claim is not configured")
+		}
+		if _, ok := params.EndpointProvider.(authstorage.EndpointProviderHierarchyQueryable); !ok {
+			return nil, errors.New("jwt is enabled, but the endpoint provider cannot look up tenants")
+		}
+
+		authenticator.verifier = newJWTVerifier(params.Config.JWT)
+
+		if authenticator.verifier.keySet != nil {
+			params.Lifecycle.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					// Failing to load the key set is not fatal, it is
+					// loaded again on the first token verified.
+					err := authenticator.verifier.keySet.Load(ctx)
+					if err != nil {
+						params.Logger.Warn("failed to load jwks", zap.String("jwks", params.Config.JWT.JWKS), zap.Error(err))
+					}
+
+					return nil
+				},
+			})
+		}
+
+		return authenticator, nil
+	}
+}
+
+// IsUnauthenticated reports whether err means the credentials presented are
+// not accepted, i.e. they are not valid, expired, were revoked, or are not
+// granted the scope required, as opposed to failing to look them up.
+func IsUnauthenticated(err error) bool {
+	return errors.Is(err, authstorage.ErrAPIKeyNotFound) ||
+		errors.Is(err, ErrInvalidToken) ||
+		errors.Is(err, authstorage.ErrAPIKeyExpired) ||
+		errors.Is(err, authstorage.ErrAPIKeyRevoked) ||
+		errors.Is(err, authstorage.ErrScopeNotGranted) ||
+		errors.Is(err, authstorage.ErrAliasNotGranted) ||
+		errors.Is(err, authstorage.ErrAliasNotFound) ||
+		errors.Is(err, ErrBaseURLNotAllowed)
+}
+
+func (a *Authenticator) acceptsJWT(credential string) bool {
+	return a.verifier != nil && looksLikeJWT(credential)
+}
+
+// endpointOfToken maps the claims of the token onto the tenant, team and
+// group, and therefore the upstream, of the endpoint. The endpoint is
+// identified by the subject of the token, so that the resources created by a
+// user are only visible to them.
+func (a *Authenticator) endpointOfToken(ctx context.Context, token string) (*authstorage.Endpoint, error) {
+	claims, err := a.verifier.verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := claimOf(claims, a.config.Claims.Subject)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrInvalidToken, a.config.Claims.Subject)
+	}
+
+	tenant := claimOf(claims, a.config.Claims.Tenant)
+	if tenant == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrInvalidToken, a.config.Claims.Tenant)
+	}
+
+	provider, _ := a.provider.(authstorage.EndpointProviderHierarchyQueryable)
+
+	endpoint, err := provider.FindOneByHierarchy(ctx, tenant, claimOf(claims, a.config.Claims.Team), claimOf(claims, a.config.Claims.Group))
+	if err != nil {
+		if errors.Is(err, authstorage.ErrHierarchyNotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		}
+
+		return nil, err
+	}
+
+	endpoint.ID = jwtEndpointIDPrefix + subject
+
+	return endpoint, nil
+}
+
+// Authenticate resolves an API key issued by the gateway, or a JWT, to its
+// endpoint. Credentials that are not valid are rejected with an error for
+// which IsUnauthenticated is true.
+func (a *Authenticator) Authenticate(ctx context.Context, credential string) (*authstorage.Endpoint, error) {
+	if a.acceptsJWT(credential) {
+		return a.endpointOfToken(ctx, credential)
+	}
+
+	return Authenticate(ctx, a.provider, credential)
+}
+
+// AuthenticateAlias is Authenticate for requests addressing the endpoint
+// aliased alias rather than the endpoint of the credential, see Address. It
+// is Authenticate when alias is empty.
+func (a *Authenticator) AuthenticateAlias(ctx context.Context, credential string, alias string) (*authstorage.Endpoint, error) {
+	endpoint, err := a.Authenticate(ctx, credential)
+	if err != nil || alias == "" {
+		return endpoint, err
+	}
+
+	return a.address(ctx, endpoint, alias, a.acceptsJWT(credential))
+}
+
+// ResolveAlias is Resolve for requests addressing the endpoint aliased
+// alias. API keys not issued by the gateway cannot address endpoints, and
+// are therefore rejected when alias is not empty.
+func (a *Authenticator) ResolveAlias(ctx context.Context, credential string, baseURL string, alias string) (*authstorage.Endpoint, error) {
+	if alias == "" {
+		return a.Resolve(ctx, credential, baseURL)
+	}
+
+	return a.AuthenticateAlias(ctx, credential, alias)
+}
+
+func (a *Authenticator) address(ctx context.Context, endpoint *authstorage.Endpoint, alias string, token bool) (*authstorage.Endpoint, error) {
+	if token {
+		return addressFromToken(ctx, a.provider, endpoint, alias)
+	}
+
+	return Address(ctx, a.provider, endpoint, alias)
+}
+
+// Resolve is Authenticate, except that, when passthrough is enabled, API
+// keys not issued by the gateway are forwarded to baseURL as is. baseURL
+// must be allowed, otherwise the key is rejected with ErrBaseURLNotAllowed.
+// baseURL is ignored for the keys issued by the gateway, and when
+// passthrough is disabled.
+func (a *Authenticator) Resolve(ctx context.Context, credential string, baseURL string) (*authstorage.Endpoint, error) {
+	if a.acceptsJWT(credential) {
+		return a.endpointOfToken(ctx, credential)
+	}
+
+	endpoint, err := Authenticate(ctx, a.provider, credential)
+	if credential == "" || !a.passthrough.Enabled || !errors.Is(err, authstorage.ErrAPIKeyNotFound) {
+		return endpoint, err
+	}
+
+	baseURL = normalizeBaseURL(baseURL)
+	if !slices.ContainsFunc(a.passthrough.AllowedBaseURLs, func(allowed string) bool {
+		return normalizeBaseURL(allowed) == baseURL
+	}) {
+		return nil, fmt.Errorf("%w: %s", ErrBaseURLNotAllowed, baseURL)
+	}
+
+	return passthroughEndpoint(credential, baseURL), nil
+}
+
+// Dereference looks up the endpoint of ref again, so that the routing in
+// effect at that time applies to the work processed in the background on
+// behalf of the endpoint. The endpoints of JWTs are looked up by the
+// hierarchy of the claims of the token.
+func (a *Authenticator) Dereference(ctx context.Context, ref Reference) (*authstorage.Endpoint, error) {
+	if strings.HasPrefix(ref.EndpointID, jwtEndpointIDPrefix) {
+		provider, ok := a.provider.(authstorage.EndpointProviderHierarchyQueryable)
+		if !ok {
+			return nil, errors.New("the endpoint provider cannot look up tenants")
+		}
+
+		endpoint, err := provider.FindOneByHierarchy(ctx, ref.TenantID, ref.TeamID, ref.GroupID)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoint.ID = ref.EndpointID
+
+		return endpoint, nil
+	}
+
+	provider, ok := a.provider.(authstorage.EndpointProviderEffectiveQueryable)
+	if !ok {
+		return nil, errors.New("the endpoint provider cannot look up endpoints by id")
+	}
+
+	return provider.FindOneEffectiveByID(ctx, ref.EndpointID)
+}
+
+// normalizeBaseURL defaults baseURL to the one of the OpenAI API, and trims
+// its trailing slashes, so that base URLs may be compared.
+func normalizeBaseURL(baseURL string) string {
+	if baseURL == "" {
+		baseURL = openai.DefaultConfig("").BaseURL
+	}
+
+	return strings.TrimRight(baseURL, "/")
+}
@@ -0,0 +1,150 @@
+package endpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/internal/configs"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+func newTestAuthenticator(passthrough configs.Passthrough) *Authenticator {
+	routes := &configs.Routes{
+		Tenants: []configs.Tenant{
+			{
+				ID: "tenant",
+				Teams: []configs.Team{
+					{
+						ID: "team",
+						Groups: []configs.Group{
+							{
+								ID: "group",
+								Endpoints: []configs.Endpoint{
+									{ID: "endpoint", APIKey: "issued"},
+								},
+							},
+						},
+					},
+				},
+				Upstream: &metadata.UpstreamSingleOrMultiple{
+					Upstream: &metadata.Upstream{
+						OpenAI: metadata.UpstreamOpenAI{BaseURL: "https://upstream.example.com/v1"},
+					},
+				},
+			},
+		},
+	}
+
+	return &Authenticator{
+		passthrough: passthrough,
+		provider:    authstorage.NewConfigEndpointProvider()(routes),
+	}
+}
+
+func TestAuthenticatorDereference(t *testing.T) {
+	t.Parallel()
+
+	authenticator := newTestAuthenticator(configs.Passthrough{})
+
+	endpoint, err := authenticator.Authenticate(context.Background(), "issued")
+	require.NoError(t, err)
+
+	ref, ok := ReferenceOf(endpoint)
+	require.True(t, ok)
+
+	dereferenced, err := authenticator.Dereference(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint", dereferenced.ID)
+	assert.Empty(t, dereferenced.APIKey)
+	assert.Equal(t, endpoint.Upstream, dereferenced.Upstream)
+
+	_, err = authenticator.Dereference(context.Background(), Reference{EndpointID: "unknown"})
+	require.ErrorIs(t, err, authstorage.ErrEndpointNotFound)
+
+	dereferenced, err = authenticator.Dereference(context.Background(), Reference{EndpointID: "jwt:user", TenantID: "tenant", TeamID: "team", GroupID: "group"})
+	require.NoError(t, err)
+	assert.Equal(t, "jwt:user", dereferenced.ID)
+	assert.Equal(t, "tenant", dereferenced.Tenant.ID())
+
+	_, ok = ReferenceOf(passthroughEndpoint("sk-foreign", "https://api.openai.com/v1"))
+	assert.False(t, ok)
+	assert.NotContains(t, OwnerOf(passthroughEndpoint("sk-foreign", "https://api.openai.com/v1")), "sk-foreign")
+}
+
+func TestAuthenticatorResolve(t *testing.T) {
+	t.Parallel()
+
+	enabled := configs.Passthrough{
+		Enabled:         true,
+		AllowedBaseURLs: []string{"https://api.openai.com/v1/", "https://allowed.example.com/v1"},
+	}
+
+	tests := []struct {
+		name        string
+		passthrough configs.Passthrough
+		apiKey      string
+		baseURL     string
+		wantErr     error
+		wantBaseURL string
+	}{
+		{
+			name:        "IssuedKey",
+			apiKey:      "issued",
+			baseURL:     "https://attacker.example.com",
+			wantBaseURL: "https://upstream.example.com/v1",
+		},
+		{
+			name:    "PassthroughDisabled",
+			apiKey:  "sk-foreign",
+			baseURL: "https://allowed.example.com/v1",
+			wantErr: authstorage.ErrAPIKeyNotFound,
+		},
+		{
+			name:        "PassthroughAllowed",
+			passthrough: enabled,
+			apiKey:      "sk-foreign",
+			baseURL:     "https://allowed.example.com/v1/",
+			wantBaseURL: "https://allowed.example.com/v1",
+		},
+		{
+			name:        "PassthroughDefaultBaseURL",
+			passthrough: enabled,
+			apiKey:      "sk-foreign",
+			wantBaseURL: "https://api.openai.com/v1",
+		},
+		{
+			name:        "PassthroughNotAllowed",
+			passthrough: enabled,
+			apiKey:      "sk-foreign",
+			baseURL:     "http://169.254.169.254",
+			wantErr:     ErrBaseURLNotAllowed,
+		},
+		{
+			name:        "MissingKey",
+			passthrough: enabled,
+			baseURL:     "https://allowed.example.com/v1",
+			wantErr:     authstorage.ErrAPIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint, err := newTestAuthenticator(tt.passthrough).Resolve(context.Background(), tt.apiKey, tt.baseURL)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, IsUnauthenticated(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBaseURL, endpoint.Upstream.Upstream.OpenAI.BaseURL)
+		})
+	}
+}
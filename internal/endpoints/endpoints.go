@@ -0,0 +1,288 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+func Modules() fx.Option {
+	return fx.Options(
+		fx.Provide(NewEndpointProvider()),
+		fx.Provide(NewAuthenticator()),
+	)
+}
+
+type NewEndpointProviderParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *configs.Config
+	Logger    *logger.Logger
+	Cache     *datastore.Cache
+	Rueidis   *datastore.Rueidis
+}
+
+func NewEndpointProvider() func(params NewEndpointProviderParams) (authstorage.EndpointProvider, error) {
+	return func(params NewEndpointProviderParams) (authstorage.EndpointProvider, error) {
+		// The redis and rds providers store API keys by their digest keyed by
+		// the secret, which an empty secret would make trivial to brute force.
+		switch params.Config.Endpoints.Provider {
+		case configs.EndpointsProviderRedis, configs.EndpointsProviderRDS:
+			if params.Config.Endpoints.APIKeySecret == "" {
+				return nil, fmt.Errorf("endpoints.api_key_secret is required by the %s provider", params.Config.Endpoints.Provider)
+			}
+		}
+
+		switch params.Config.Endpoints.Provider {
+		case configs.EndpointsProviderConfig, "":
+			return authstorage.NewConfigEndpointProvider()(&params.Config.Routes), nil
+		case configs.EndpointsProviderRedis:
+			client, err := params.Rueidis.Client()
+			if err != nil {
+				return nil, err
+			}
+
+			provider := authstorage.NewCachedRedisEndpointAuthProvider()(client, params.Config.Endpoints.APIKeySecret, params.Cache)
+
+			subscribeCtx, cancelSubscribe := context.WithCancel(context.Background())
+
+			params.Lifecycle.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go subscribeInvalidations(subscribeCtx, params.Logger, provider)
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					cancelSubscribe()
+					return nil
+				},
+			})
+
+			return provider, nil
+		case configs.EndpointsProviderRDS:
+			db, err := datastore.NewRDS()(params.Config.Endpoints.Database)
+			if err != nil {
+				return nil, err
+			}
+
+			params.Lifecycle.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return db.Close()
+				},
+			})
+
+			provider := authstorage.NewRDSEndpointAuthProvider()(db, params.Config.Endpoints.APIKeySecret)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			err = provider.Migrate(ctx)
+			if err != nil {
+				_ = db.Close()
+				return nil, err
+			}
+
+			return provider, nil
+		default:
+			return nil, fmt.Errorf("unsupported endpoints provider %q", params.Config.Endpoints.Provider)
+		}
+	}
+}
+
+// subscribeInvalidations keeps the provider subscribed to the invalidations
+// of the other replicas until ctx is done.
+func subscribeInvalidations(ctx context.Context, logger *logger.Logger, provider *authstorage.RedisEndpointProvider) {
+	for {
+		err := provider.SubscribeInvalidations(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Warn("endpoints cache invalidations subscription ended, resubscribing", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+type endpointContextKey struct{}
+
+// WithEndpoint attaches the endpoint the request is authenticated as to ctx.
+func WithEndpoint(ctx context.Context, endpoint *authstorage.Endpoint) context.Context {
+	return context.WithValue(ctx, endpointContextKey{}, endpoint)
+}
+
+// EndpointFromContext returns the endpoint attached by WithEndpoint, or nil.
+func EndpointFromContext(ctx context.Context) *authstorage.Endpoint {
+	endpoint, _ := ctx.Value(endpointContextKey{}).(*authstorage.Endpoint)
+	return endpoint
+}
+
+// Authenticate looks up the endpoint issued by the gateway for apiKey. Keys
+// that are not issued by the gateway are rejected with
+// authstorage.ErrAPIKeyNotFound. Keys that expired, or were revoked, are
+// rejected with authstorage.ErrAPIKeyExpired or authstorage.ErrAPIKeyRevoked.
+func Authenticate(ctx context.Context, provider authstorage.EndpointProvider, apiKey string) (*authstorage.Endpoint, error) {
+	if apiKey == "" {
+		return nil, authstorage.ErrAPIKeyNotFound
+	}
+
+	endpoint, err := provider.FindOneByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil || endpoint.Upstream == nil {
+		return nil, authstorage.ErrAPIKeyNotFound
+	}
+
+	err = endpoint.CheckActive(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+// passthroughEndpoint is an ad-hoc endpoint forwarding the API key, which
+// is not issued by the gateway, to baseURL as is.
+func passthroughEndpoint(apiKey string, baseURL string) *authstorage.Endpoint {
+	return &authstorage.Endpoint{
+		APIKey: apiKey,
+		Upstream: &metadata.UpstreamSingleOrMultiple{
+			Upstream: &metadata.Upstream{
+				OpenAI: metadata.UpstreamOpenAI{
+					BaseURL: baseURL,
+					APIKey:  apiKey,
+					Compatible: metadata.UpstreamOpenAICompatible{
+						Chat: metadata.UpstreamOpenAICompatibleChat{
+							Usage:  true,
+							Stream: true,
+						},
+						Embeddings: true,
+						Models:     true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Address switches the endpoint authenticated by an API key to the endpoint
+// aliased alias, so that one key may be used for several endpoints with
+// their own upstreams. The key must be issued for the aliased endpoint, or
+// be granted alias, otherwise authstorage.ErrAliasNotGranted is returned.
+// The endpoint returned keeps the API key, and the lifecycle and scopes of
+// the key, of endpoint.
+func Address(ctx context.Context, provider authstorage.EndpointProvider, endpoint *authstorage.Endpoint, alias string) (*authstorage.Endpoint, error) {
+	if endpoint.Alias == alias {
+		return endpoint, nil
+	}
+	if !endpoint.HasAlias(alias) {
+		return nil, fmt.Errorf("%w: %s", authstorage.ErrAliasNotGranted, alias)
+	}
+
+	aliased, err := provider.FindOneByAlias(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	if aliased == nil || aliased.Upstream == nil {
+		return nil, authstorage.ErrAliasNotFound
+	}
+
+	addressed := *aliased
+	addressed.APIKey = endpoint.APIKey
+	addressed.APIKeyPrefix = endpoint.APIKeyPrefix
+	addressed.APIKeyOptions = endpoint.APIKeyOptions
+
+	return &addressed, nil
+}
+
+// addressFromToken is Address for the endpoints authenticated by JWTs, which
+// may address the endpoints of the tenant, team and group of their claims.
+// The endpoint returned keeps the ID of the token, so that resources remain
+// owned by its subject. Aliases that do not exist are not granted either, so
+// that the aliases of other tenants cannot be discovered.
+func addressFromToken(ctx context.Context, provider authstorage.EndpointProvider, endpoint *authstorage.Endpoint, alias string) (*authstorage.Endpoint, error) {
+	aliased, err := provider.FindOneByAlias(ctx, alias)
+	if err != nil && !errors.Is(err, authstorage.ErrAliasNotFound) {
+		return nil, err
+	}
+	if aliased == nil || aliased.Upstream == nil ||
+		aliased.Tenant.ID() != endpoint.Tenant.ID() ||
+		(endpoint.Team.ID() != "" && aliased.Team.ID() != endpoint.Team.ID()) ||
+		(endpoint.Group.ID() != "" && aliased.Group.ID() != endpoint.Group.ID()) {
+		return nil, fmt.Errorf("%w: %s", authstorage.ErrAliasNotGranted, alias)
+	}
+
+	addressed := *aliased
+	addressed.ID = endpoint.ID
+	addressed.APIKey = endpoint.APIKey
+
+	return &addressed, nil
+}
+
+// RequireScope rejects endpoints whose API key is not granted scope with
+// authstorage.ErrScopeNotGranted.
+func RequireScope(endpoint *authstorage.Endpoint, scope authstorage.Scope) error {
+	if !endpoint.HasScope(scope) {
+		return fmt.Errorf("%w: %s", authstorage.ErrScopeNotGranted, scope)
+	}
+
+	return nil
+}
+
+// OwnerOf identifies who the resources created through the endpoint belong
+// to, ad-hoc endpoints are identified by the digest of the forwarded API
+// key, so that the key is not stored along with the resources.
+func OwnerOf(endpoint *authstorage.Endpoint) string {
+	if endpoint.ID != "" {
+		return endpoint.ID
+	}
+
+	digest := sha256.Sum256([]byte(endpoint.APIKey))
+
+	return "passthrough:" + hex.EncodeToString(digest[:])
+}
+
+// Reference identifies an endpoint without its credentials, so that the work
+// processed in the background on behalf of the endpoint, e.g. batches, does
+// not store the credentials it was submitted with, see
+// Authenticator.Dereference.
+type Reference struct {
+	EndpointID string `json:"endpoint_id"`
+	// TenantID, TeamID and GroupID are the hierarchy of the endpoint, which
+	// the endpoints of JWTs are looked up by.
+	TenantID string `json:"tenant_id,omitempty"`
+	TeamID   string `json:"team_id,omitempty"`
+	GroupID  string `json:"group_id,omitempty"`
+}
+
+// ReferenceOf returns the reference of the endpoint, false for ad-hoc
+// endpoints, which cannot be looked up without the API key forwarded.
+func ReferenceOf(endpoint *authstorage.Endpoint) (Reference, bool) {
+	if endpoint.ID == "" {
+		return Reference{}, false
+	}
+
+	return Reference{
+		EndpointID: endpoint.ID,
+		TenantID:   endpoint.Tenant.ID(),
+		TeamID:     endpoint.Team.ID(),
+		GroupID:    endpoint.Group.ID(),
+	}, true
+}
@@ -0,0 +1,212 @@
+package endpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/lingticio/llmg/internal/configs"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+func TestNewEndpointProvider_APIKeySecret(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider configs.EndpointsProvider
+		wantErr  bool
+	}{
+		{name: "Config", provider: configs.EndpointsProviderConfig},
+		{name: "Redis", provider: configs.EndpointsProviderRedis, wantErr: true},
+		{name: "RDS", provider: configs.EndpointsProviderRDS, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := NewEndpointProvider()(NewEndpointProviderParams{
+				Lifecycle: fxtest.NewLifecycle(t),
+				Config:    &configs.Config{Endpoints: configs.Endpoints{Provider: tt.provider}},
+			})
+			if tt.wantErr {
+				require.ErrorContains(t, err, "endpoints.api_key_secret is required")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, provider)
+		})
+	}
+}
+
+func upstreamOf(baseURL string) *metadata.UpstreamSingleOrMultiple {
+	return &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{
+			OpenAI: metadata.UpstreamOpenAI{BaseURL: baseURL, APIKey: "sk-upstream"},
+		},
+	}
+}
+
+// newTestAliasProvider provides the endpoints aliased own, shared and
+// other, in the group of the tenant acme, the endpoint aliased sibling in
+// another group of acme, and the endpoint aliased foreign of another tenant.
+func newTestAliasProvider() authstorage.EndpointProvider {
+	return authstorage.NewConfigEndpointProvider()(&configs.Routes{
+		Tenants: []configs.Tenant{
+			{
+				ID: "acme",
+				Teams: []configs.Team{
+					{
+						ID: "team",
+						Groups: []configs.Group{
+							{
+								ID: "group",
+								Endpoints: []configs.Endpoint{
+									{
+										ID:       "own",
+										Alias:    "own",
+										APIKey:   "sk-own",
+										APIKeys:  []configs.EndpointAPIKey{{Key: "sk-granted", Scopes: []string{"chat"}, Aliases: []string{"shared", "missing"}}},
+										Upstream: upstreamOf("https://own.example.com/v1"),
+									},
+									{ID: "shared", Alias: "shared", APIKey: "sk-shared", Upstream: upstreamOf("https://shared.example.com/v1")},
+									{ID: "other", Alias: "other", APIKey: "sk-other", Upstream: upstreamOf("https://other.example.com/v1")},
+								},
+							},
+							{
+								ID:        "sibling-group",
+								Endpoints: []configs.Endpoint{{ID: "sibling", Alias: "sibling", APIKey: "sk-sibling", Upstream: upstreamOf("https://sibling.example.com/v1")}},
+							},
+						},
+					},
+				},
+			},
+			{
+				ID: "other-tenant",
+				Teams: []configs.Team{
+					{
+						ID: "team",
+						Groups: []configs.Group{
+							{
+								ID:        "group",
+								Endpoints: []configs.Endpoint{{ID: "foreign", Alias: "foreign", APIKey: "sk-foreign", Upstream: upstreamOf("https://foreign.example.com/v1")}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAddress(t *testing.T) {
+	t.Parallel()
+
+	provider := newTestAliasProvider()
+
+	tests := []struct {
+		name    string
+		apiKey  string
+		alias   string
+		wantID  string
+		wantErr error
+	}{
+		{name: "OwnAlias", apiKey: "sk-own", alias: "own", wantID: "own"},
+		{name: "GrantedAlias", apiKey: "sk-granted", alias: "shared", wantID: "shared"},
+		{name: "NotGranted", apiKey: "sk-granted", alias: "other", wantErr: authstorage.ErrAliasNotGranted},
+		// Keys without aliases granted may only address their own endpoint.
+		{name: "NoAliasesGranted", apiKey: "sk-own", alias: "shared", wantErr: authstorage.ErrAliasNotGranted},
+		{name: "OtherTenant", apiKey: "sk-granted", alias: "foreign", wantErr: authstorage.ErrAliasNotGranted},
+		// The aliases not granted are not looked up, so that whether they
+		// exist is not disclosed.
+		{name: "UnknownNotGranted", apiKey: "sk-granted", alias: "unknown", wantErr: authstorage.ErrAliasNotGranted},
+		{name: "UnknownGranted", apiKey: "sk-granted", alias: "missing", wantErr: authstorage.ErrAliasNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint, err := Authenticate(context.Background(), provider, tt.apiKey)
+			require.NoError(t, err)
+
+			addressed, err := Address(context.Background(), provider, endpoint, tt.alias)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				// There is no fallback to the endpoint of the key.
+				assert.Nil(t, addressed)
+				assert.True(t, IsUnauthenticated(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, addressed.ID)
+			assert.Equal(t, "https://"+tt.wantID+".example.com/v1", addressed.Upstream.Upstream.OpenAI.BaseURL)
+			// The addressed endpoint keeps the API key, and its lifecycle and
+			// scopes, the request was authenticated with.
+			assert.Equal(t, tt.apiKey, addressed.APIKey)
+			assert.Equal(t, endpoint.APIKeyOptions, addressed.APIKeyOptions)
+		})
+	}
+}
+
+func TestAddressFromToken(t *testing.T) {
+	t.Parallel()
+
+	provider := newTestAliasProvider()
+
+	tests := []struct {
+		name    string
+		team    string
+		group   string
+		alias   string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "Tenant", alias: "sibling", wantID: "sibling"},
+		{name: "Team", team: "team", alias: "shared", wantID: "shared"},
+		{name: "Group", team: "team", group: "group", alias: "shared", wantID: "shared"},
+		{name: "OtherGroup", team: "team", group: "group", alias: "sibling", wantErr: true},
+		{name: "OtherTeam", team: "other-team", alias: "shared", wantErr: true},
+		{name: "OtherTenant", alias: "foreign", wantErr: true},
+		// Unknown aliases are not distinguished from the aliases of other
+		// tenants.
+		{name: "Unknown", alias: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := &authstorage.Endpoint{
+				ID:       "jwt:user",
+				Tenant:   metadata.Tenant{Id: "acme"},
+				Team:     metadata.Team{Id: tt.team},
+				Group:    metadata.Group{Id: tt.group},
+				Upstream: upstreamOf("https://own.example.com/v1"),
+			}
+
+			addressed, err := addressFromToken(context.Background(), provider, endpoint, tt.alias)
+			if tt.wantErr {
+				require.ErrorIs(t, err, authstorage.ErrAliasNotGranted)
+				assert.Nil(t, addressed)
+
+				return
+			}
+
+			require.NoError(t, err)
+			// The addressed endpoint keeps the ID of the token, so that the
+			// resources created remain owned by its subject.
+			assert.Equal(t, "jwt:user", addressed.ID)
+			assert.Empty(t, addressed.APIKey)
+			assert.Equal(t, "https://"+tt.wantID+".example.com/v1", addressed.Upstream.Upstream.OpenAI.BaseURL)
+		})
+	}
+}
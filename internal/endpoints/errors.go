@@ -0,0 +1,33 @@
+package endpoints
+
+import (
+	"errors"
+
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+// AsAPIError maps the errors of authenticating callers to the API errors
+// the frontends respond with.
+func AsAPIError(err error) *apierrors.Error {
+	switch {
+	case errors.Is(err, authstorage.ErrAPIKeyNotFound):
+		return apierrors.NewErrUnauthorized().WithDetail("invalid API key")
+	case errors.Is(err, ErrInvalidToken):
+		return apierrors.NewErrUnauthorized().WithDetail(err.Error())
+	case errors.Is(err, authstorage.ErrAPIKeyExpired):
+		return apierrors.NewErrAPIKeyExpired()
+	case errors.Is(err, authstorage.ErrAPIKeyRevoked):
+		return apierrors.NewErrAPIKeyRevoked()
+	case errors.Is(err, authstorage.ErrScopeNotGranted):
+		return apierrors.NewErrInsufficientScope().WithDetail(err.Error())
+	case errors.Is(err, authstorage.ErrAliasNotGranted):
+		return apierrors.NewPermissionDenied().WithDetail(err.Error())
+	case errors.Is(err, ErrBaseURLNotAllowed):
+		return apierrors.NewPermissionDenied().WithDetail(err.Error())
+	case errors.Is(err, authstorage.ErrAliasNotFound):
+		return apierrors.NewErrNotFound().WithDetail(err.Error())
+	default:
+		return apierrors.NewErrInternal().WithError(err).WithCaller()
+	}
+}
@@ -0,0 +1,109 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/pkg/util/jwks"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// jwtVerifier verifies JWTs with the HS256 secret, or the keys of the JWKS,
+// configured.
+type jwtVerifier struct {
+	config configs.JWT
+	keySet *jwks.KeySet
+}
+
+func newJWTVerifier(config configs.JWT) *jwtVerifier {
+	verifier := &jwtVerifier{
+		config: config,
+	}
+	if config.JWKS != "" {
+		verifier.keySet = jwks.NewKeySet(config.JWKS, config.JWKSRefreshInterval)
+	}
+
+	return verifier
+}
+
+// looksLikeJWT tells JWTs apart from API keys, a JWT is three base64url
+// segments and its header is a JSON object.
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2 && strings.HasPrefix(token, "eyJ") //nolint:mnd
+}
+
+func (v *jwtVerifier) key(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if v.config.Secret == "" {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+
+			return []byte(v.config.Secret), nil
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			if v.keySet == nil {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+
+			kid, _ := token.Header["kid"].(string)
+
+			return v.keySet.Key(ctx, kid)
+		default:
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+	}
+}
+
+// verify verifies the signature and the claims of the token.
+func (v *jwtVerifier) verify(ctx context.Context, token string) (jwt.MapClaims, error) {
+	parser := &jwt.Parser{
+		ValidMethods: []string{"HS256", "RS256", "ES256"},
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := parser.ParseWithClaims(token, claims, v.key(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if v.config.Issuer != "" && !claims.VerifyIssuer(v.config.Issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.config.Audience != "" && !claims.VerifyAudience(v.config.Audience, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// claimOf reads the string claim at path, nested claims are separated by
+// dots.
+func claimOf(claims jwt.MapClaims, path string) string {
+	if path == "" {
+		return ""
+	}
+
+	var current any = map[string]any(claims)
+
+	for _, segment := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return ""
+		}
+
+		current = object[segment]
+	}
+
+	value, _ := current.(string)
+
+	return value
+}
@@ -0,0 +1,87 @@
+package openai
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/graph/openai/model"
+	"github.com/lingticio/llmg/internal/graph/server/middlewares"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+// inputCredentials returns the apiKey and baseURL fields of the input
+// argument of the field being resolved, if any.
+func inputCredentials(ctx context.Context) (*string, *string) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil {
+		return nil, nil
+	}
+
+	switch input := fc.Args["input"].(type) {
+	case model.CreateChatCompletionInput:
+		return input.APIKey, input.BaseURL
+	case model.CreateChatCompletionStreamInput:
+		return input.APIKey, input.BaseURL
+	default:
+		return nil, nil
+	}
+}
+
+// auth implements the @auth directive, it authenticates the API key issued
+// by the gateway, or the JWT, checks that it is granted scope, and attaches
+// the endpoint, or the endpoint addressed by alias in the path, to the
+// context of the resolver.
+func (h *GraphQLHandler) auth(ctx context.Context, _ any, next graphql.Resolver, scope model.AuthScope) (any, error) {
+	apiKey := middlewares.APIKeyFromContext(ctx)
+
+	inputAPIKey, inputBaseURL := inputCredentials(ctx)
+	if inputAPIKey != nil || inputBaseURL != nil {
+		if h.config.GraphQL.DisableInputCredentials {
+			return nil, apierrors.NewBadRequest().WithDetail("apiKey and baseURL in the input are disabled, pass the API key in the headers instead").AsGraphQLError()
+		}
+		if apiKey == "" && inputAPIKey != nil {
+			apiKey = *inputAPIKey
+		}
+	}
+	if apiKey == "" {
+		return nil, apierrors.NewErrUnauthorized().WithDetail("missing API key in Authorization or X-Api-Key").AsGraphQLError()
+	}
+
+	endpoint, err := h.authenticator.AuthenticateAlias(ctx, apiKey, middlewares.EndpointAliasFromContext(ctx))
+	if err == nil {
+		err = endpoints.RequireScope(endpoint, authstorage.Scope(strings.ToLower(string(scope))))
+	}
+	if err != nil {
+		if !endpoints.IsUnauthenticated(err) {
+			h.logger.Error("failed to authenticate endpoint", zap.Error(err))
+		}
+
+		return nil, endpoints.AsAPIError(err).AsGraphQLError()
+	}
+
+	return next(endpoints.WithEndpoint(ctx, endpoint))
+}
+
+// websocketInit reads the API key from the connection_init payload, since
+// browsers cannot set headers for WebSocket connections.
+func websocketInit(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	apiKey := payload.GetString("apiKey")
+	if apiKey == "" {
+		apiKey = payload.GetString("X-Api-Key")
+	}
+	if apiKey == "" {
+		apiKey = strings.TrimPrefix(payload.Authorization(), "Bearer ")
+	}
+	if apiKey != "" {
+		ctx = middlewares.WithAPIKey(ctx, apiKey)
+	}
+
+	// The payload is not acknowledged back, it carries the API key.
+	return ctx, nil, nil
+}
@@ -0,0 +1,205 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/graph/openai/model"
+	"github.com/lingticio/llmg/internal/graph/server/middlewares"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+func newTestGraphQLHandler(t *testing.T, disableInputCredentials bool) *GraphQLHandler {
+	t.Helper()
+
+	log, err := logger.NewLogger(logger.WithLevel(zapcore.FatalLevel))
+	require.NoError(t, err)
+
+	config := &configs.Config{
+		GraphQL: configs.GraphQLServer{DisableInputCredentials: disableInputCredentials},
+	}
+	routes := &configs.Routes{
+		Tenants: []configs.Tenant{
+			{
+				ID: "tenant",
+				Teams: []configs.Team{
+					{
+						ID: "team",
+						Groups: []configs.Group{
+							{
+								ID: "group",
+								Endpoints: []configs.Endpoint{
+									{ID: "header", APIKey: "sk-header"},
+									{ID: "init", APIKey: "sk-init"},
+									{ID: "input", APIKey: "sk-input"},
+									{ID: "models", APIKeys: []configs.EndpointAPIKey{{Key: "sk-models", Scopes: []string{"models"}}}},
+								},
+							},
+						},
+					},
+				},
+				Upstream: &metadata.UpstreamSingleOrMultiple{
+					Upstream: &metadata.Upstream{
+						OpenAI: metadata.UpstreamOpenAI{BaseURL: "https://upstream.example.com/v1"},
+					},
+				},
+			},
+		},
+	}
+
+	authenticator, err := endpoints.NewAuthenticator()(endpoints.NewAuthenticatorParams{
+		Lifecycle:        fxtest.NewLifecycle(t),
+		Logger:           log,
+		Config:           config,
+		EndpointProvider: authstorage.NewConfigEndpointProvider()(routes),
+	})
+	require.NoError(t, err)
+
+	return NewGraphQLHandler()(NewGraphQLHandlerParams{
+		Logger:        log,
+		Config:        config,
+		Authenticator: authenticator,
+	})
+}
+
+// withInput is the context of the resolver of a field whose input argument
+// is input.
+func withInput(ctx context.Context, input model.CreateChatCompletionInput) context.Context {
+	return graphql.WithFieldContext(ctx, &graphql.FieldContext{Args: map[string]any{"input": input}})
+}
+
+// authenticate resolves a field with the @auth directive, and returns the
+// ID of the endpoint attached to the context of the resolver.
+func authenticate(ctx context.Context, h *GraphQLHandler, scope model.AuthScope) (string, error) {
+	resolved, err := h.auth(ctx, nil, func(ctx context.Context) (any, error) {
+		return endpoints.EndpointFromContext(ctx).ID, nil
+	}, scope)
+	if err != nil {
+		return "", err
+	}
+
+	return resolved.(string), nil
+}
+
+func statusOf(t *testing.T, err error) uint64 {
+	t.Helper()
+
+	var gqlErr *gqlerror.Error
+	require.True(t, errors.As(err, &gqlErr), err)
+
+	status, _ := gqlErr.Extensions["status"].(uint64)
+
+	return status
+}
+
+func TestAuth(t *testing.T) {
+	t.Parallel()
+
+	initContext := func(payload transport.InitPayload) context.Context {
+		ctx, _, err := websocketInit(context.Background(), payload)
+		require.NoError(t, err)
+
+		return ctx
+	}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		scope        model.AuthScope
+		wantEndpoint string
+		wantStatus   int
+	}{
+		{name: "Header", ctx: middlewares.WithAPIKey(context.Background(), "sk-header"), wantEndpoint: "header"},
+		{name: "InitPayloadAPIKey", ctx: initContext(transport.InitPayload{"apiKey": "sk-init"}), wantEndpoint: "init"},
+		{name: "InitPayloadXAPIKey", ctx: initContext(transport.InitPayload{"X-Api-Key": "sk-init"}), wantEndpoint: "init"},
+		{name: "InitPayloadAuthorization", ctx: initContext(transport.InitPayload{"Authorization": "Bearer sk-init"}), wantEndpoint: "init"},
+		{name: "InputAPIKey", ctx: withInput(context.Background(), model.CreateChatCompletionInput{APIKey: lo.ToPtr("sk-input")}), wantEndpoint: "input"},
+		// The API key of the headers takes precedence over the one of the
+		// input.
+		{
+			name:         "HeaderOverInputAPIKey",
+			ctx:          withInput(middlewares.WithAPIKey(context.Background(), "sk-header"), model.CreateChatCompletionInput{APIKey: lo.ToPtr("sk-input")}),
+			wantEndpoint: "header",
+		},
+		{name: "Missing", ctx: context.Background(), wantStatus: http.StatusUnauthorized},
+		{name: "MissingInInitPayload", ctx: initContext(transport.InitPayload{}), wantStatus: http.StatusUnauthorized},
+		{name: "Unknown", ctx: middlewares.WithAPIKey(context.Background(), "sk-unknown"), wantStatus: http.StatusUnauthorized},
+		{name: "ScopeNotGranted", ctx: middlewares.WithAPIKey(context.Background(), "sk-models"), wantStatus: http.StatusForbidden},
+		{name: "ScopeGranted", ctx: middlewares.WithAPIKey(context.Background(), "sk-models"), scope: model.AuthScopeModels, wantEndpoint: "models"},
+	}
+
+	h := newTestGraphQLHandler(t, false)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint, err := authenticate(tt.ctx, h, lo.CoalesceOrEmpty(tt.scope, model.AuthScopeChat))
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				assert.Equal(t, uint64(tt.wantStatus), statusOf(t, err)) //nolint:gosec
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEndpoint, endpoint)
+		})
+	}
+}
+
+func TestAuth_DisableInputCredentials(t *testing.T) {
+	t.Parallel()
+
+	h := newTestGraphQLHandler(t, true)
+	headers := middlewares.WithAPIKey(context.Background(), "sk-header")
+
+	tests := []struct {
+		name  string
+		input model.CreateChatCompletionInput
+	}{
+		{name: "APIKey", input: model.CreateChatCompletionInput{APIKey: lo.ToPtr("sk-input")}},
+		{name: "BaseURL", input: model.CreateChatCompletionInput{BaseURL: lo.ToPtr("https://attacker.example.com/v1")}},
+		{name: "Both", input: model.CreateChatCompletionInput{APIKey: lo.ToPtr("sk-input"), BaseURL: lo.ToPtr("https://attacker.example.com/v1")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The input credentials are rejected even along with the API key
+			// of the headers.
+			_, err := authenticate(withInput(headers, tt.input), h, model.AuthScopeChat)
+			require.Error(t, err)
+			assert.Equal(t, uint64(http.StatusBadRequest), statusOf(t, err))
+		})
+	}
+
+	// The API keys of the headers, and of the connection_init payload, are
+	// still accepted.
+	endpoint, err := authenticate(withInput(headers, model.CreateChatCompletionInput{}), h, model.AuthScopeChat)
+	require.NoError(t, err)
+	assert.Equal(t, "header", endpoint)
+
+	ctx, _, err := websocketInit(context.Background(), transport.InitPayload{"apiKey": "sk-init"})
+	require.NoError(t, err)
+
+	endpoint, err = authenticate(ctx, h, model.AuthScopeChat)
+	require.NoError(t, err)
+	assert.Equal(t, "init", endpoint)
+}
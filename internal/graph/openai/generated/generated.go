@@ -45,6 +45,7 @@ type ResolverRoot interface {
 }
 
 type DirectiveRoot struct {
+	Auth func(ctx context.Context, obj any, next graphql.Resolver, scope model.AuthScope) (res any, err error)
 }
 
 type ComplexityRoot struct {
@@ -167,7 +168,9 @@ type ComplexityRoot struct {
 	}
 
 	Model struct {
-		Name func(childComplexity int) int
+		Created func(childComplexity int) int
+		Name    func(childComplexity int) int
+		OwnedBy func(childComplexity int) int
 	}
 
 	ModelConnection struct {
@@ -193,6 +196,7 @@ type ComplexityRoot struct {
 
 	Query struct {
 		Models func(childComplexity int, first *int, after *string, last *int, before *string) int
+		Usage  func(childComplexity int, input model.UsageQueryInput, first *int, after *string, last *int, before *string) int
 	}
 
 	Subscription struct {
@@ -217,6 +221,30 @@ type ComplexityRoot struct {
 		PromptTokens     func(childComplexity int) int
 		TotalTokens      func(childComplexity int) int
 	}
+
+	UsageBucket struct {
+		AverageLatencyMs func(childComplexity int) int
+		CacheHits        func(childComplexity int) int
+		CachedTokens     func(childComplexity int) int
+		CompletionTokens func(childComplexity int) int
+		Cost             func(childComplexity int) int
+		EndTime          func(childComplexity int) int
+		FailedRequests   func(childComplexity int) int
+		Group            func(childComplexity int) int
+		PromptTokens     func(childComplexity int) int
+		Requests         func(childComplexity int) int
+		StartTime        func(childComplexity int) int
+	}
+
+	UsageBucketConnection struct {
+		Edges    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
+	}
+
+	UsageBucketEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
+	}
 }
 
 type MutationResolver interface {
@@ -224,6 +252,7 @@ type MutationResolver interface {
 }
 type QueryResolver interface {
 	Models(ctx context.Context, first *int, after *string, last *int, before *string) (*model.ModelConnection, error)
+	Usage(ctx context.Context, input model.UsageQueryInput, first *int, after *string, last *int, before *string) (*model.UsageBucketConnection, error)
 }
 type SubscriptionResolver interface {
 	CreateChatCompletionStream(ctx context.Context, input model.CreateChatCompletionStreamInput) (<-chan *model.ChatCompletionStreamResult, error)
@@ -243,7 +272,7 @@ func (e *executableSchema) Schema() *ast.Schema {
 	return parsedSchema
 }
 
-func (e *executableSchema) Complexity(typeName, field string, childComplexity int, rawArgs map[string]interface{}) (int, bool) {
+func (e *executableSchema) Complexity(typeName, field string, childComplexity int, rawArgs map[string]any) (int, bool) {
 	ec := executionContext{nil, e, 0, 0, nil}
 	_ = ec
 	switch typeName + "." + field {
@@ -675,6 +704,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.LogProbs.Refusal(childComplexity), true
 
+	case "Model.created":
+		if e.complexity.Model.Created == nil {
+			break
+		}
+
+		return e.complexity.Model.Created(childComplexity), true
+
 	case "Model.name":
 		if e.complexity.Model.Name == nil {
 			break
@@ -682,6 +718,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Model.Name(childComplexity), true
 
+	case "Model.ownedBy":
+		if e.complexity.Model.OwnedBy == nil {
+			break
+		}
+
+		return e.complexity.Model.OwnedBy(childComplexity), true
+
 	case "ModelConnection.edges":
 		if e.complexity.ModelConnection.Edges == nil {
 			break
@@ -762,6 +805,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Models(childComplexity, args["first"].(*int), args["after"].(*string), args["last"].(*int), args["before"].(*string)), true
 
+	case "Query.usage":
+		if e.complexity.Query.Usage == nil {
+			break
+		}
+
+		args, err := ec.field_Query_usage_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Usage(childComplexity, args["input"].(model.UsageQueryInput), args["first"].(*int), args["after"].(*string), args["last"].(*int), args["before"].(*string)), true
+
 	case "Subscription.createChatCompletionStream":
 		if e.complexity.Subscription.CreateChatCompletionStream == nil {
 			break
@@ -844,13 +899,118 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Usage.TotalTokens(childComplexity), true
 
+	case "UsageBucket.averageLatencyMs":
+		if e.complexity.UsageBucket.AverageLatencyMs == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.AverageLatencyMs(childComplexity), true
+
+	case "UsageBucket.cacheHits":
+		if e.complexity.UsageBucket.CacheHits == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.CacheHits(childComplexity), true
+
+	case "UsageBucket.cachedTokens":
+		if e.complexity.UsageBucket.CachedTokens == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.CachedTokens(childComplexity), true
+
+	case "UsageBucket.completionTokens":
+		if e.complexity.UsageBucket.CompletionTokens == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.CompletionTokens(childComplexity), true
+
+	case "UsageBucket.cost":
+		if e.complexity.UsageBucket.Cost == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.Cost(childComplexity), true
+
+	case "UsageBucket.endTime":
+		if e.complexity.UsageBucket.EndTime == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.EndTime(childComplexity), true
+
+	case "UsageBucket.failedRequests":
+		if e.complexity.UsageBucket.FailedRequests == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.FailedRequests(childComplexity), true
+
+	case "UsageBucket.group":
+		if e.complexity.UsageBucket.Group == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.Group(childComplexity), true
+
+	case "UsageBucket.promptTokens":
+		if e.complexity.UsageBucket.PromptTokens == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.PromptTokens(childComplexity), true
+
+	case "UsageBucket.requests":
+		if e.complexity.UsageBucket.Requests == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.Requests(childComplexity), true
+
+	case "UsageBucket.startTime":
+		if e.complexity.UsageBucket.StartTime == nil {
+			break
+		}
+
+		return e.complexity.UsageBucket.StartTime(childComplexity), true
+
+	case "UsageBucketConnection.edges":
+		if e.complexity.UsageBucketConnection.Edges == nil {
+			break
+		}
+
+		return e.complexity.UsageBucketConnection.Edges(childComplexity), true
+
+	case "UsageBucketConnection.pageInfo":
+		if e.complexity.UsageBucketConnection.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.UsageBucketConnection.PageInfo(childComplexity), true
+
+	case "UsageBucketEdge.cursor":
+		if e.complexity.UsageBucketEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.UsageBucketEdge.Cursor(childComplexity), true
+
+	case "UsageBucketEdge.node":
+		if e.complexity.UsageBucketEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.UsageBucketEdge.Node(childComplexity), true
+
 	}
 	return 0, false
 }
 
 func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
-	rc := graphql.GetOperationContext(ctx)
-	ec := executionContext{rc, e, 0, 0, make(chan graphql.DeferredResult)}
+	opCtx := graphql.GetOperationContext(ctx)
+	ec := executionContext{opCtx, e, 0, 0, make(chan graphql.DeferredResult)}
 	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
 		ec.unmarshalInputChatCompletionContentPartImageURLInput,
 		ec.unmarshalInputChatCompletionMessageContentPartInput,
@@ -864,10 +1024,11 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputFunctionCallInput,
 		ec.unmarshalInputJSONSchemaInput,
 		ec.unmarshalInputResponseFormatInput,
+		ec.unmarshalInputUsageQueryInput,
 	)
 	first := true
 
-	switch rc.Operation.Operation {
+	switch opCtx.Operation.Operation {
 	case ast.Query:
 		return func(ctx context.Context) *graphql.Response {
 			var response graphql.Response
@@ -875,7 +1036,7 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			if first {
 				first = false
 				ctx = graphql.WithUnmarshalerMap(ctx, inputUnmarshalMap)
-				data = ec._Query(ctx, rc.Operation.SelectionSet)
+				data = ec._Query(ctx, opCtx.Operation.SelectionSet)
 			} else {
 				if atomic.LoadInt32(&ec.pendingDeferred) > 0 {
 					result := <-ec.deferredResults
@@ -905,7 +1066,7 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			}
 			first = false
 			ctx = graphql.WithUnmarshalerMap(ctx, inputUnmarshalMap)
-			data := ec._Mutation(ctx, rc.Operation.SelectionSet)
+			data := ec._Mutation(ctx, opCtx.Operation.SelectionSet)
 			var buf bytes.Buffer
 			data.MarshalGQL(&buf)
 
@@ -914,7 +1075,7 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			}
 		}
 	case ast.Subscription:
-		next := ec._Subscription(ctx, rc.Operation.SelectionSet)
+		next := ec._Subscription(ctx, opCtx.Operation.SelectionSet)
 
 		var buf bytes.Buffer
 		return func(ctx context.Context) *graphql.Response {
@@ -980,6 +1141,26 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 var sources = []*ast.Source{
 	{Name: "../../../../graph/openai/chat.graphqls", Input: `scalar Map
 
+"""
+Requires the request to be authenticated with an API key issued by the gateway, which is
+looked up in the ` + "`" + `Authorization` + "`" + ` or ` + "`" + `X-Api-Key` + "`" + ` headers, the ` + "`" + `connection_init` + "`" + ` payload of
+WebSocket connections, and then the ` + "`" + `apiKey` + "`" + ` field of the input. Requests are routed to the
+upstream configured for the endpoint of the API key. The API key must be granted ` + "`" + `scope` + "`" + `.
+"""
+directive @auth(scope: AuthScope!) on FIELD_DEFINITION
+
+"""
+What an API key may be used for. API keys without scopes may be used for anything but ` + "`" + `ADMIN` + "`" + `.
+"""
+enum AuthScope {
+  CHAT
+  EMBEDDINGS
+  MODELS
+  BATCHES
+  USAGE
+  ADMIN
+}
+
 type ChatCompletionResult {
   """
   A unique identifier for the chat completion.
@@ -1292,16 +1473,16 @@ enum ServiceTier {
 
 input CreateChatCompletionInput {
   """
-  API key to interact with endpoints.
+  API key issued by the gateway.
 
-  Optional, and can be passed in the headers with 'Authorization' or 'X-Api-Key'.
+  Optional, and can be passed in the headers with 'Authorization' or 'X-Api-Key'. Rejected when
+  input credentials are disabled for the deployment.
   """
   apiKey: String
 
   """
-  Base URL for the upstream API endpoint.
-
-  Optional, only required if the model is hosted on a custom endpoint.
+  Deprecated, requests are always sent to the upstream configured for the endpoint of the API key,
+  and this field is ignored. Rejected when input credentials are disabled for the deployment.
   """
   baseURL: String
 
@@ -1463,18 +1644,17 @@ input CreateChatCompletionInput {
 
 input CreateChatCompletionStreamInput {
   """
-  API key to interact with endpoints.
+  API key issued by the gateway.
 
-  Unlike the ` + "`" + `apiKey` + "`" + ` field in the ` + "`" + `CreateChatCompletionInput` + "`" + ` object, since WebSocket in
-  browsers does not allow setting custom headers, the ` + "`" + `apiKey` + "`" + ` field in this object is required. When
-  using the WebSocket transport in the browser.
+  Since WebSocket in browsers does not allow setting custom headers, the API key can be passed
+  either as ` + "`" + `apiKey` + "`" + ` or ` + "`" + `Authorization` + "`" + ` in the ` + "`" + `connection_init` + "`" + ` payload, or in this field. Rejected
+  when input credentials are disabled for the deployment.
   """
-  apiKey: String!
+  apiKey: String
 
   """
-  Base URL for the upstream API endpoint.
-
-  Optional, only required if the model is hosted on a custom endpoint.
+  Deprecated, requests are always sent to the upstream configured for the endpoint of the API key,
+  and this field is ignored. Rejected when input credentials are disabled for the deployment.
   """
   baseURL: String
 
@@ -1867,7 +2047,18 @@ type FunctionCallChunk {
 }
 
 type Model {
+  """
+  The model identifier, which can be referenced in the API endpoints.
+  """
   name: String!
+  """
+  The Unix timestamp (in seconds) when the model was created.
+  """
+  created: Int!
+  """
+  The organization that owns the model.
+  """
+  ownedBy: String!
 }
 
 type ModelEdge {
@@ -1887,22 +2078,120 @@ type PageInfo {
   hasPreviousPage: Boolean!
 }
 
+"""
+The usage of the requests of a time bucket, and of a group when grouped by.
+"""
+type UsageBucket {
+  """
+  The Unix timestamp (in seconds) of the start of the bucket, null when the range queried is unbounded.
+  """
+  startTime: Int
+  """
+  The Unix timestamp (in seconds) of the end of the bucket, null when the range queried is unbounded.
+  """
+  endTime: Int
+  """
+  The value of each dimension the buckets are grouped by.
+  """
+  group: Map!
+  requests: Int!
+  """
+  The requests responded with an error, including the ones the upstreams failed.
+  """
+  failedRequests: Int!
+  promptTokens: Int!
+  completionTokens: Int!
+  """
+  The tokens of the prompts the upstreams served from their cache, which are included in promptTokens.
+  """
+  cachedTokens: Int!
+  """
+  The cost of the requests in USD, as priced by the budgets.
+  """
+  cost: Float!
+  """
+  The average latency of the requests, in milliseconds.
+  """
+  averageLatencyMs: Int!
+  """
+  The requests answered from the caches of the gateway, which cost nothing.
+  """
+  cacheHits: Int!
+}
+
+type UsageBucketEdge {
+  node: UsageBucket!
+  cursor: String!
+}
+
+type UsageBucketConnection {
+  edges: [UsageBucketEdge!]!
+  pageInfo: PageInfo!
+}
+
+input UsageQueryInput {
+  """
+  The Unix timestamp (in seconds) the range starts at, included. Unbounded when null with the sql
+  storage, 31 days before endTime with the jsonl storage and the Redis streams, which query at most
+  31 days.
+  """
+  startTime: Int
+  """
+  The Unix timestamp (in seconds) the range ends at, excluded. Unbounded when null with the sql
+  storage, now with the jsonl storage and the Redis streams.
+  """
+  endTime: Int
+  """
+  The width of the time buckets in seconds, e.g. 3600 or 86400, which are aligned on UTC. The usage
+  of the whole range is aggregated when null.
+  """
+  bucketWidth: Int
+  """
+  Splits the buckets by any of team, group, endpoint, model, upstream, user, operation, status and cache.
+  """
+  groupBy: [String!]
+  """
+  The tenant of the usage, API keys query the usage of their own tenant only.
+  """
+  tenantId: String
+  """
+  The team of the usage, only API keys also granted the ` + "`" + `ADMIN` + "`" + ` scope may query other teams than
+  their own.
+  """
+  teamId: String
+  groupId: String
+  endpointId: String
+  model: String
+  """
+  The user field of the requests.
+  """
+  user: String
+}
+
 type Query {
-  models(first: Int, after: String, last: Int, before: String): ModelConnection!
+  """
+  Lists the models served by the upstreams of the current endpoint.
+  """
+  models(first: Int, after: String, last: Int, before: String): ModelConnection! @auth(scope: MODELS)
+  """
+  Aggregates the usage of the requests served by the gateway into time buckets, sorted by start
+  time, then by group, e.g. to build chargeback reports.
+  """
+  usage(input: UsageQueryInput!, first: Int, after: String, last: Int, before: String): UsageBucketConnection! @auth(scope: USAGE)
 }
 
 type Mutation {
   """
   Creates a model response for the given chat conversation.
   """
-  createChatCompletion(input: CreateChatCompletionInput!): ChatCompletionResult!
+  createChatCompletion(input: CreateChatCompletionInput!): ChatCompletionResult! @auth(scope: CHAT)
 }
 
 type Subscription {
   """
   Creates a streaming model response for the given chat conversation.
   """
-  createChatCompletionStream(input: CreateChatCompletionStreamInput!): ChatCompletionStreamResult!
+  createChatCompletionStream(input: CreateChatCompletionStreamInput!): ChatCompletionStreamResult! @auth(scope: CHAT)
 }
 `, BuiltIn: false},
 }
@@ -1912,144 +2201,472 @@ var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
 // region    ***************************** args.gotpl *****************************
 
-func (ec *executionContext) field_Mutation_createChatCompletion_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) dir_auth_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
-	args := map[string]interface{}{}
-	var arg0 model.CreateChatCompletionInput
-	if tmp, ok := rawArgs["input"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
-		arg0, err = ec.unmarshalNCreateChatCompletionInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionInput(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	args := map[string]any{}
+	arg0, err := ec.dir_auth_argsScope(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["scope"] = arg0
+	return args, nil
+}
+func (ec *executionContext) dir_auth_argsScope(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (model.AuthScope, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["scope"]
+	if !ok {
+		var zeroVal model.AuthScope
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("scope"))
+	if tmp, ok := rawArgs["scope"]; ok {
+		return ec.unmarshalNAuthScope2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐAuthScope(ctx, tmp)
+	}
+
+	var zeroVal model.AuthScope
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Mutation_createChatCompletion_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Mutation_createChatCompletion_argsInput(ctx, rawArgs)
+	if err != nil {
+		return nil, err
 	}
 	args["input"] = arg0
 	return args, nil
 }
+func (ec *executionContext) field_Mutation_createChatCompletion_argsInput(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (model.CreateChatCompletionInput, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["input"]
+	if !ok {
+		var zeroVal model.CreateChatCompletionInput
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
+	if tmp, ok := rawArgs["input"]; ok {
+		return ec.unmarshalNCreateChatCompletionInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionInput(ctx, tmp)
+	}
+
+	var zeroVal model.CreateChatCompletionInput
+	return zeroVal, nil
+}
 
-func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
-	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	args := map[string]any{}
+	arg0, err := ec.field_Query___type_argsName(ctx, rawArgs)
+	if err != nil {
+		return nil, err
 	}
 	args["name"] = arg0
 	return args, nil
 }
+func (ec *executionContext) field_Query___type_argsName(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["name"]
+	if !ok {
+		var zeroVal string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+	if tmp, ok := rawArgs["name"]; ok {
+		return ec.unmarshalNString2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
 
-func (ec *executionContext) field_Query_models_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_models_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
-	args := map[string]interface{}{}
-	var arg0 *int
-	if tmp, ok := rawArgs["first"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
-		arg0, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	args := map[string]any{}
+	arg0, err := ec.field_Query_models_argsFirst(ctx, rawArgs)
+	if err != nil {
+		return nil, err
 	}
 	args["first"] = arg0
-	var arg1 *string
-	if tmp, ok := rawArgs["after"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
-		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	arg1, err := ec.field_Query_models_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
 	}
 	args["after"] = arg1
-	var arg2 *int
-	if tmp, ok := rawArgs["last"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("last"))
-		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	arg2, err := ec.field_Query_models_argsLast(ctx, rawArgs)
+	if err != nil {
+		return nil, err
 	}
 	args["last"] = arg2
-	var arg3 *string
-	if tmp, ok := rawArgs["before"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("before"))
-		arg3, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	arg3, err := ec.field_Query_models_argsBefore(ctx, rawArgs)
+	if err != nil {
+		return nil, err
 	}
 	args["before"] = arg3
 	return args, nil
 }
+func (ec *executionContext) field_Query_models_argsFirst(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*int, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["first"]
+	if !ok {
+		var zeroVal *int
+		return zeroVal, nil
+	}
 
-func (ec *executionContext) field_Subscription_createChatCompletionStream_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 model.CreateChatCompletionStreamInput
-	if tmp, ok := rawArgs["input"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
-		arg0, err = ec.unmarshalNCreateChatCompletionStreamInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionStreamInput(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+	if tmp, ok := rawArgs["first"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
 	}
-	args["input"] = arg0
-	return args, nil
+
+	var zeroVal *int
+	return zeroVal, nil
 }
 
-func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+func (ec *executionContext) field_Query_models_argsAfter(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["after"]
+	if !ok {
+		var zeroVal *string
+		return zeroVal, nil
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
-}
 
-func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
+
+	var zeroVal *string
+	return zeroVal, nil
 }
 
-// endregion ***************************** args.gotpl *****************************
+func (ec *executionContext) field_Query_models_argsLast(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*int, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["last"]
+	if !ok {
+		var zeroVal *int
+		return zeroVal, nil
+	}
 
-// region    ************************** directives.gotpl **************************
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("last"))
+	if tmp, ok := rawArgs["last"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
+	}
 
-// endregion ************************** directives.gotpl **************************
+	var zeroVal *int
+	return zeroVal, nil
+}
 
-// region    **************************** field.gotpl *****************************
+func (ec *executionContext) field_Query_models_argsBefore(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["before"]
+	if !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
 
-func (ec *executionContext) _ChatCompletionArrayContent_parts(ctx context.Context, field graphql.CollectedField, obj *model.ChatCompletionArrayContent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ChatCompletionArrayContent_parts(ctx, field)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("before"))
+	if tmp, ok := rawArgs["before"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_usage_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Query_usage_argsInput(ctx, rawArgs)
 	if err != nil {
-		return graphql.Null
+		return nil, err
 	}
-	ctx = graphql.WithFieldContext(ctx, fc)
-	defer func() {
-		if r := recover(); r != nil {
+	args["input"] = arg0
+	arg1, err := ec.field_Query_usage_argsFirst(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["first"] = arg1
+	arg2, err := ec.field_Query_usage_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg2
+	arg3, err := ec.field_Query_usage_argsLast(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["last"] = arg3
+	arg4, err := ec.field_Query_usage_argsBefore(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["before"] = arg4
+	return args, nil
+}
+func (ec *executionContext) field_Query_usage_argsInput(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (model.UsageQueryInput, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["input"]
+	if !ok {
+		var zeroVal model.UsageQueryInput
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
+	if tmp, ok := rawArgs["input"]; ok {
+		return ec.unmarshalNUsageQueryInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageQueryInput(ctx, tmp)
+	}
+
+	var zeroVal model.UsageQueryInput
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_usage_argsFirst(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*int, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["first"]
+	if !ok {
+		var zeroVal *int
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+	if tmp, ok := rawArgs["first"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
+	}
+
+	var zeroVal *int
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_usage_argsAfter(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["after"]
+	if !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_usage_argsLast(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*int, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["last"]
+	if !ok {
+		var zeroVal *int
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("last"))
+	if tmp, ok := rawArgs["last"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
+	}
+
+	var zeroVal *int
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_usage_argsBefore(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["before"]
+	if !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("before"))
+	if tmp, ok := rawArgs["before"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_createChatCompletionStream_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Subscription_createChatCompletionStream_argsInput(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field_Subscription_createChatCompletionStream_argsInput(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (model.CreateChatCompletionStreamInput, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["input"]
+	if !ok {
+		var zeroVal model.CreateChatCompletionStreamInput
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
+	if tmp, ok := rawArgs["input"]; ok {
+		return ec.unmarshalNCreateChatCompletionStreamInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionStreamInput(ctx, tmp)
+	}
+
+	var zeroVal model.CreateChatCompletionStreamInput
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field___Type_enumValues_argsIncludeDeprecated(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field___Type_enumValues_argsIncludeDeprecated(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (bool, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["includeDeprecated"]
+	if !ok {
+		var zeroVal bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		return ec.unmarshalOBoolean2bool(ctx, tmp)
+	}
+
+	var zeroVal bool
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field___Type_fields_argsIncludeDeprecated(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field___Type_fields_argsIncludeDeprecated(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (bool, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["includeDeprecated"]
+	if !ok {
+		var zeroVal bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		return ec.unmarshalOBoolean2bool(ctx, tmp)
+	}
+
+	var zeroVal bool
+	return zeroVal, nil
+}
+
+// endregion ***************************** args.gotpl *****************************
+
+// region    ************************** directives.gotpl **************************
+
+// endregion ************************** directives.gotpl **************************
+
+// region    **************************** field.gotpl *****************************
+
+func (ec *executionContext) _ChatCompletionArrayContent_parts(ctx context.Context, field graphql.CollectedField, obj *model.ChatCompletionArrayContent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ChatCompletionArrayContent_parts(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Parts, nil
 	})
@@ -2093,7 +2710,7 @@ func (ec *executionContext) _ChatCompletionAssistantMessage_role(ctx context.Con
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Role, nil
 	})
@@ -2137,7 +2754,7 @@ func (ec *executionContext) _ChatCompletionAssistantMessage_content(ctx context.
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Content, nil
 	})
@@ -2178,7 +2795,7 @@ func (ec *executionContext) _ChatCompletionAssistantMessage_name(ctx context.Con
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -2219,7 +2836,7 @@ func (ec *executionContext) _ChatCompletionAssistantMessage_toolCalls(ctx contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ToolCalls, nil
 	})
@@ -2268,7 +2885,7 @@ func (ec *executionContext) _ChatCompletionAssistantMessage_toolCallId(ctx conte
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ToolCallID, nil
 	})
@@ -2309,7 +2926,7 @@ func (ec *executionContext) _ChatCompletionChoice_index(ctx context.Context, fie
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Index, nil
 	})
@@ -2353,7 +2970,7 @@ func (ec *executionContext) _ChatCompletionChoice_message(ctx context.Context, f
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Message, nil
 	})
@@ -2397,7 +3014,7 @@ func (ec *executionContext) _ChatCompletionChoice_finishReason(ctx context.Conte
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.FinishReason, nil
 	})
@@ -2438,7 +3055,7 @@ func (ec *executionContext) _ChatCompletionChoice_logProbs(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.LogProbs, nil
 	})
@@ -2485,7 +3102,7 @@ func (ec *executionContext) _ChatCompletionContentPartImage_imageUrl(ctx context
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ImageURL, nil
 	})
@@ -2535,7 +3152,7 @@ func (ec *executionContext) _ChatCompletionContentPartImage_type(ctx context.Con
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Type, nil
 	})
@@ -2579,7 +3196,7 @@ func (ec *executionContext) _ChatCompletionContentPartImageURL_url(ctx context.C
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.URL, nil
 	})
@@ -2623,7 +3240,7 @@ func (ec *executionContext) _ChatCompletionContentPartImageURL_detail(ctx contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Detail, nil
 	})
@@ -2664,7 +3281,7 @@ func (ec *executionContext) _ChatCompletionContentPartText_text(ctx context.Cont
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Text, nil
 	})
@@ -2708,7 +3325,7 @@ func (ec *executionContext) _ChatCompletionContentPartText_type(ctx context.Cont
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Type, nil
 	})
@@ -2752,7 +3369,7 @@ func (ec *executionContext) _ChatCompletionMessageToolCall_id(ctx context.Contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ID, nil
 	})
@@ -2796,7 +3413,7 @@ func (ec *executionContext) _ChatCompletionMessageToolCall_function(ctx context.
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Function, nil
 	})
@@ -2846,7 +3463,7 @@ func (ec *executionContext) _ChatCompletionMessageToolCall_type(ctx context.Cont
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Type, nil
 	})
@@ -2890,7 +3507,7 @@ func (ec *executionContext) _ChatCompletionMessageToolCallChunk_index(ctx contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Index, nil
 	})
@@ -2931,7 +3548,7 @@ func (ec *executionContext) _ChatCompletionMessageToolCallChunk_id(ctx context.C
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ID, nil
 	})
@@ -2975,7 +3592,7 @@ func (ec *executionContext) _ChatCompletionMessageToolCallChunk_type(ctx context
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Type, nil
 	})
@@ -3019,7 +3636,7 @@ func (ec *executionContext) _ChatCompletionMessageToolCallChunk_function(ctx con
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Function, nil
 	})
@@ -3066,7 +3683,7 @@ func (ec *executionContext) _ChatCompletionResult_id(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ID, nil
 	})
@@ -3110,7 +3727,7 @@ func (ec *executionContext) _ChatCompletionResult_object(ctx context.Context, fi
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Object, nil
 	})
@@ -3154,7 +3771,7 @@ func (ec *executionContext) _ChatCompletionResult_created(ctx context.Context, f
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Created, nil
 	})
@@ -3198,7 +3815,7 @@ func (ec *executionContext) _ChatCompletionResult_model(ctx context.Context, fie
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Model, nil
 	})
@@ -3242,7 +3859,7 @@ func (ec *executionContext) _ChatCompletionResult_choices(ctx context.Context, f
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Choices, nil
 	})
@@ -3296,7 +3913,7 @@ func (ec *executionContext) _ChatCompletionResult_usage(ctx context.Context, fie
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Usage, nil
 	})
@@ -3348,7 +3965,7 @@ func (ec *executionContext) _ChatCompletionResult_systemFingerprint(ctx context.
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.SystemFingerprint, nil
 	})
@@ -3389,7 +4006,7 @@ func (ec *executionContext) _ChatCompletionStreamChunkChoice_index(ctx context.C
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Index, nil
 	})
@@ -3433,7 +4050,7 @@ func (ec *executionContext) _ChatCompletionStreamChunkChoice_delta(ctx context.C
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Delta, nil
 	})
@@ -3487,7 +4104,7 @@ func (ec *executionContext) _ChatCompletionStreamChunkChoice_finishReason(ctx co
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.FinishReason, nil
 	})
@@ -3528,7 +4145,7 @@ func (ec *executionContext) _ChatCompletionStreamResponseDelta_role(ctx context.
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Role, nil
 	})
@@ -3572,7 +4189,7 @@ func (ec *executionContext) _ChatCompletionStreamResponseDelta_content(ctx conte
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Content, nil
 	})
@@ -3616,7 +4233,7 @@ func (ec *executionContext) _ChatCompletionStreamResponseDelta_toolCalls(ctx con
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ToolCalls, nil
 	})
@@ -3667,7 +4284,7 @@ func (ec *executionContext) _ChatCompletionStreamResponseDelta_functionCall(ctx
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.FunctionCall, nil
 	})
@@ -3714,7 +4331,7 @@ func (ec *executionContext) _ChatCompletionStreamResult_id(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ID, nil
 	})
@@ -3758,7 +4375,7 @@ func (ec *executionContext) _ChatCompletionStreamResult_object(ctx context.Conte
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Object, nil
 	})
@@ -3802,7 +4419,7 @@ func (ec *executionContext) _ChatCompletionStreamResult_created(ctx context.Cont
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Created, nil
 	})
@@ -3846,7 +4463,7 @@ func (ec *executionContext) _ChatCompletionStreamResult_model(ctx context.Contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Model, nil
 	})
@@ -3890,7 +4507,7 @@ func (ec *executionContext) _ChatCompletionStreamResult_choices(ctx context.Cont
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Choices, nil
 	})
@@ -3942,7 +4559,7 @@ func (ec *executionContext) _ChatCompletionStreamResult_systemFingerprint(ctx co
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.SystemFingerprint, nil
 	})
@@ -3983,7 +4600,7 @@ func (ec *executionContext) _ChatCompletionStreamResult_usage(ctx context.Contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Usage, nil
 	})
@@ -4032,7 +4649,7 @@ func (ec *executionContext) _ChatCompletionSystemMessage_role(ctx context.Contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Role, nil
 	})
@@ -4076,7 +4693,7 @@ func (ec *executionContext) _ChatCompletionSystemMessage_content(ctx context.Con
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Content, nil
 	})
@@ -4120,7 +4737,7 @@ func (ec *executionContext) _ChatCompletionSystemMessage_name(ctx context.Contex
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -4161,7 +4778,7 @@ func (ec *executionContext) _ChatCompletionTextContent_text(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Text, nil
 	})
@@ -4205,7 +4822,7 @@ func (ec *executionContext) _ChatCompletionToolMessage_role(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Role, nil
 	})
@@ -4249,7 +4866,7 @@ func (ec *executionContext) _ChatCompletionToolMessage_content(ctx context.Conte
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Content, nil
 	})
@@ -4293,7 +4910,7 @@ func (ec *executionContext) _ChatCompletionToolMessage_name(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -4334,7 +4951,7 @@ func (ec *executionContext) _ChatCompletionToolMessage_toolCallId(ctx context.Co
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.ToolCallID, nil
 	})
@@ -4378,7 +4995,7 @@ func (ec *executionContext) _ChatCompletionUserMessage_role(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Role, nil
 	})
@@ -4422,7 +5039,7 @@ func (ec *executionContext) _ChatCompletionUserMessage_content(ctx context.Conte
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Content, nil
 	})
@@ -4466,7 +5083,7 @@ func (ec *executionContext) _ChatCompletionUserMessage_name(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -4507,7 +5124,7 @@ func (ec *executionContext) _FunctionCall_name(ctx context.Context, field graphq
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -4551,7 +5168,7 @@ func (ec *executionContext) _FunctionCall_arguments(ctx context.Context, field g
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Arguments, nil
 	})
@@ -4595,7 +5212,7 @@ func (ec *executionContext) _FunctionCallChunk_name(ctx context.Context, field g
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -4639,7 +5256,7 @@ func (ec *executionContext) _FunctionCallChunk_arguments(ctx context.Context, fi
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Arguments, nil
 	})
@@ -4683,7 +5300,7 @@ func (ec *executionContext) _LogProbs_content(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Content, nil
 	})
@@ -4737,7 +5354,7 @@ func (ec *executionContext) _LogProbs_refusal(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Refusal, nil
 	})
@@ -4791,7 +5408,7 @@ func (ec *executionContext) _Model_name(ctx context.Context, field graphql.Colle
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -4823,6 +5440,94 @@ func (ec *executionContext) fieldContext_Model_name(_ context.Context, field gra
 	return fc, nil
 }
 
+func (ec *executionContext) _Model_created(ctx context.Context, field graphql.CollectedField, obj *model.Model) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Model_created(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Created, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Model_created(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Model",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Model_ownedBy(ctx context.Context, field graphql.CollectedField, obj *model.Model) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Model_ownedBy(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.OwnedBy, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Model_ownedBy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Model",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _ModelConnection_edges(ctx context.Context, field graphql.CollectedField, obj *model.ModelConnection) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ModelConnection_edges(ctx, field)
 	if err != nil {
@@ -4835,7 +5540,7 @@ func (ec *executionContext) _ModelConnection_edges(ctx context.Context, field gr
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Edges, nil
 	})
@@ -4885,7 +5590,7 @@ func (ec *executionContext) _ModelConnection_pageInfo(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.PageInfo, nil
 	})
@@ -4939,7 +5644,7 @@ func (ec *executionContext) _ModelEdge_node(ctx context.Context, field graphql.C
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Node, nil
 	})
@@ -4968,6 +5673,10 @@ func (ec *executionContext) fieldContext_ModelEdge_node(_ context.Context, field
 			switch field.Name {
 			case "name":
 				return ec.fieldContext_Model_name(ctx, field)
+			case "created":
+				return ec.fieldContext_Model_created(ctx, field)
+			case "ownedBy":
+				return ec.fieldContext_Model_ownedBy(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Model", field.Name)
 		},
@@ -4987,7 +5696,7 @@ func (ec *executionContext) _ModelEdge_cursor(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Cursor, nil
 	})
@@ -5031,9 +5740,36 @@ func (ec *executionContext) _Mutation_createChatCompletion(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().CreateChatCompletion(rctx, fc.Args["input"].(model.CreateChatCompletionInput))
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		directive0 := func(rctx context.Context) (any, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().CreateChatCompletion(rctx, fc.Args["input"].(model.CreateChatCompletionInput))
+		}
+
+		directive1 := func(ctx context.Context) (any, error) {
+			scope, err := ec.unmarshalNAuthScope2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐAuthScope(ctx, "CHAT")
+			if err != nil {
+				var zeroVal *model.ChatCompletionResult
+				return zeroVal, err
+			}
+			if ec.directives.Auth == nil {
+				var zeroVal *model.ChatCompletionResult
+				return zeroVal, errors.New("directive auth is not implemented")
+			}
+			return ec.directives.Auth(ctx, nil, directive0, scope)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.ChatCompletionResult); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/lingticio/llmg/internal/graph/openai/model.ChatCompletionResult`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5102,7 +5838,7 @@ func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field gra
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.StartCursor, nil
 	})
@@ -5143,7 +5879,7 @@ func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.EndCursor, nil
 	})
@@ -5184,7 +5920,7 @@ func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field gra
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.HasNextPage, nil
 	})
@@ -5228,7 +5964,7 @@ func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.HasPreviousPage, nil
 	})
@@ -5272,9 +6008,36 @@ func (ec *executionContext) _Query_models(ctx context.Context, field graphql.Col
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Models(rctx, fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string))
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		directive0 := func(rctx context.Context) (any, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Models(rctx, fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string))
+		}
+
+		directive1 := func(ctx context.Context) (any, error) {
+			scope, err := ec.unmarshalNAuthScope2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐAuthScope(ctx, "MODELS")
+			if err != nil {
+				var zeroVal *model.ModelConnection
+				return zeroVal, err
+			}
+			if ec.directives.Auth == nil {
+				var zeroVal *model.ModelConnection
+				return zeroVal, errors.New("directive auth is not implemented")
+			}
+			return ec.directives.Auth(ctx, nil, directive0, scope)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.ModelConnection); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/lingticio/llmg/internal/graph/openai/model.ModelConnection`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5321,8 +6084,8 @@ func (ec *executionContext) fieldContext_Query_models(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query___type(ctx, field)
+func (ec *executionContext) _Query_usage(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_usage(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5333,52 +6096,66 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(fc.Args["name"].(string))
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		directive0 := func(rctx context.Context) (any, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Usage(rctx, fc.Args["input"].(model.UsageQueryInput), fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string))
+		}
+
+		directive1 := func(ctx context.Context) (any, error) {
+			scope, err := ec.unmarshalNAuthScope2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐAuthScope(ctx, "USAGE")
+			if err != nil {
+				var zeroVal *model.UsageBucketConnection
+				return zeroVal, err
+			}
+			if ec.directives.Auth == nil {
+				var zeroVal *model.UsageBucketConnection
+				return zeroVal, errors.New("directive auth is not implemented")
+			}
+			return ec.directives.Auth(ctx, nil, directive0, scope)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.UsageBucketConnection); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/lingticio/llmg/internal/graph/openai/model.UsageBucketConnection`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*model.UsageBucketConnection)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNUsageBucketConnection2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucketConnection(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_usage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "edges":
+				return ec.fieldContext_UsageBucketConnection_edges(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_UsageBucketConnection_pageInfo(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type UsageBucketConnection", field.Name)
 		},
 	}
 	defer func() {
@@ -5388,15 +6165,15 @@ func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query_usage_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query___schema(ctx, field)
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query___type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5407,7 +6184,81 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectType(fc.Args["name"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	fc.Result = res
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query___schema(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return ec.introspectSchema()
 	})
@@ -5462,9 +6313,36 @@ func (ec *executionContext) _Subscription_createChatCompletionStream(ctx context
 			ret = nil
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().CreateChatCompletionStream(rctx, fc.Args["input"].(model.CreateChatCompletionStreamInput))
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		directive0 := func(rctx context.Context) (any, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Subscription().CreateChatCompletionStream(rctx, fc.Args["input"].(model.CreateChatCompletionStreamInput))
+		}
+
+		directive1 := func(ctx context.Context) (any, error) {
+			scope, err := ec.unmarshalNAuthScope2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐAuthScope(ctx, "CHAT")
+			if err != nil {
+				var zeroVal *model.ChatCompletionStreamResult
+				return zeroVal, err
+			}
+			if ec.directives.Auth == nil {
+				var zeroVal *model.ChatCompletionStreamResult
+				return zeroVal, errors.New("directive auth is not implemented")
+			}
+			return ec.directives.Auth(ctx, nil, directive0, scope)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(<-chan *model.ChatCompletionStreamResult); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be <-chan *github.com/lingticio/llmg/internal/graph/openai/model.ChatCompletionStreamResult`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5523,20 +6401,682 @@ func (ec *executionContext) fieldContext_Subscription_createChatCompletionStream
 	}
 	defer func() {
 		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_createChatCompletionStream_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TokenLogProb_token(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TokenLogProb_token(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Token, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TokenLogProb_token(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TokenLogProb",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TokenLogProb_logProb(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TokenLogProb_logProb(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.LogProb, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TokenLogProb_logProb(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TokenLogProb",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TokenLogProb_bytes(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TokenLogProb_bytes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Bytes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]int)
+	fc.Result = res
+	return ec.marshalNInt2ᚕintᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TokenLogProb_bytes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TokenLogProb",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TokenLogProb_topLogProbs(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TokenLogProb_topLogProbs(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TopLogProbs, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.TopLogProb)
+	fc.Result = res
+	return ec.marshalNTopLogProb2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐTopLogProbᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TokenLogProb_topLogProbs(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TokenLogProb",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "token":
+				return ec.fieldContext_TopLogProb_token(ctx, field)
+			case "logProb":
+				return ec.fieldContext_TopLogProb_logProb(ctx, field)
+			case "bytes":
+				return ec.fieldContext_TopLogProb_bytes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TopLogProb", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TopLogProb_token(ctx context.Context, field graphql.CollectedField, obj *model.TopLogProb) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TopLogProb_token(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Token, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TopLogProb_token(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TopLogProb",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TopLogProb_logProb(ctx context.Context, field graphql.CollectedField, obj *model.TopLogProb) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TopLogProb_logProb(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.LogProb, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TopLogProb_logProb(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TopLogProb",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TopLogProb_bytes(ctx context.Context, field graphql.CollectedField, obj *model.TopLogProb) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TopLogProb_bytes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Bytes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]int)
+	fc.Result = res
+	return ec.marshalNInt2ᚕintᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TopLogProb_bytes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TopLogProb",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Usage_promptTokens(ctx context.Context, field graphql.CollectedField, obj *model.Usage) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Usage_promptTokens(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PromptTokens, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Usage_promptTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Usage",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Usage_completionTokens(ctx context.Context, field graphql.CollectedField, obj *model.Usage) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Usage_completionTokens(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CompletionTokens, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Usage_completionTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Usage",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Usage_totalTokens(ctx context.Context, field graphql.CollectedField, obj *model.Usage) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Usage_totalTokens(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalTokens, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Usage_totalTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Usage",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _UsageBucket_startTime(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_startTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_UsageBucket_startTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "UsageBucket",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _UsageBucket_endTime(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_endTime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EndTime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_UsageBucket_endTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "UsageBucket",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _UsageBucket_group(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_group(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Group, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(map[string]any)
+	fc.Result = res
+	return ec.marshalNMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_UsageBucket_group(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "UsageBucket",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _UsageBucket_requests(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_requests(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Requests, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_UsageBucket_requests(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "UsageBucket",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _UsageBucket_failedRequests(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_failedRequests(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
 	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Subscription_createChatCompletionStream_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.FailedRequests, nil
+	})
+	if err != nil {
 		ec.Error(ctx, err)
-		return fc, err
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_UsageBucket_failedRequests(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "UsageBucket",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TokenLogProb_token(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TokenLogProb_token(ctx, field)
+func (ec *executionContext) _UsageBucket_promptTokens(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_promptTokens(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5547,9 +7087,9 @@ func (ec *executionContext) _TokenLogProb_token(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Token, nil
+		return obj.PromptTokens, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5561,26 +7101,26 @@ func (ec *executionContext) _TokenLogProb_token(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TokenLogProb_token(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucket_promptTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TokenLogProb",
+		Object:     "UsageBucket",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TokenLogProb_logProb(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TokenLogProb_logProb(ctx, field)
+func (ec *executionContext) _UsageBucket_completionTokens(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_completionTokens(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5591,9 +7131,9 @@ func (ec *executionContext) _TokenLogProb_logProb(ctx context.Context, field gra
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LogProb, nil
+		return obj.CompletionTokens, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5605,26 +7145,26 @@ func (ec *executionContext) _TokenLogProb_logProb(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TokenLogProb_logProb(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucket_completionTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TokenLogProb",
+		Object:     "UsageBucket",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TokenLogProb_bytes(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TokenLogProb_bytes(ctx, field)
+func (ec *executionContext) _UsageBucket_cachedTokens(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_cachedTokens(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5635,9 +7175,9 @@ func (ec *executionContext) _TokenLogProb_bytes(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Bytes, nil
+		return obj.CachedTokens, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5649,14 +7189,14 @@ func (ec *executionContext) _TokenLogProb_bytes(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]int)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNInt2ᚕintᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TokenLogProb_bytes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucket_cachedTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TokenLogProb",
+		Object:     "UsageBucket",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5667,8 +7207,8 @@ func (ec *executionContext) fieldContext_TokenLogProb_bytes(_ context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _TokenLogProb_topLogProbs(ctx context.Context, field graphql.CollectedField, obj *model.TokenLogProb) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TokenLogProb_topLogProbs(ctx, field)
+func (ec *executionContext) _UsageBucket_cost(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_cost(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5679,9 +7219,9 @@ func (ec *executionContext) _TokenLogProb_topLogProbs(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TopLogProbs, nil
+		return obj.Cost, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5693,34 +7233,26 @@ func (ec *executionContext) _TokenLogProb_topLogProbs(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.TopLogProb)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNTopLogProb2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐTopLogProbᚄ(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TokenLogProb_topLogProbs(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucket_cost(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TokenLogProb",
+		Object:     "UsageBucket",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "token":
-				return ec.fieldContext_TopLogProb_token(ctx, field)
-			case "logProb":
-				return ec.fieldContext_TopLogProb_logProb(ctx, field)
-			case "bytes":
-				return ec.fieldContext_TopLogProb_bytes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TopLogProb", field.Name)
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TopLogProb_token(ctx context.Context, field graphql.CollectedField, obj *model.TopLogProb) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TopLogProb_token(ctx, field)
+func (ec *executionContext) _UsageBucket_averageLatencyMs(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_averageLatencyMs(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5731,9 +7263,9 @@ func (ec *executionContext) _TopLogProb_token(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Token, nil
+		return obj.AverageLatencyMs, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5745,26 +7277,26 @@ func (ec *executionContext) _TopLogProb_token(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TopLogProb_token(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucket_averageLatencyMs(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TopLogProb",
+		Object:     "UsageBucket",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TopLogProb_logProb(ctx context.Context, field graphql.CollectedField, obj *model.TopLogProb) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TopLogProb_logProb(ctx, field)
+func (ec *executionContext) _UsageBucket_cacheHits(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucket) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucket_cacheHits(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5775,9 +7307,9 @@ func (ec *executionContext) _TopLogProb_logProb(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LogProb, nil
+		return obj.CacheHits, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5789,26 +7321,26 @@ func (ec *executionContext) _TopLogProb_logProb(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TopLogProb_logProb(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucket_cacheHits(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TopLogProb",
+		Object:     "UsageBucket",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TopLogProb_bytes(ctx context.Context, field graphql.CollectedField, obj *model.TopLogProb) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_TopLogProb_bytes(ctx, field)
+func (ec *executionContext) _UsageBucketConnection_edges(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucketConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucketConnection_edges(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5819,9 +7351,9 @@ func (ec *executionContext) _TopLogProb_bytes(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Bytes, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5833,26 +7365,32 @@ func (ec *executionContext) _TopLogProb_bytes(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]int)
+	res := resTmp.([]*model.UsageBucketEdge)
 	fc.Result = res
-	return ec.marshalNInt2ᚕintᚄ(ctx, field.Selections, res)
+	return ec.marshalNUsageBucketEdge2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucketEdgeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_TopLogProb_bytes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucketConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TopLogProb",
+		Object:     "UsageBucketConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_UsageBucketEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_UsageBucketEdge_cursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UsageBucketEdge", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Usage_promptTokens(ctx context.Context, field graphql.CollectedField, obj *model.Usage) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Usage_promptTokens(ctx, field)
+func (ec *executionContext) _UsageBucketConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucketConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucketConnection_pageInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5863,9 +7401,9 @@ func (ec *executionContext) _Usage_promptTokens(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PromptTokens, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5877,26 +7415,36 @@ func (ec *executionContext) _Usage_promptTokens(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.PageInfo)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Usage_promptTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucketConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Usage",
+		Object:     "UsageBucketConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Usage_completionTokens(ctx context.Context, field graphql.CollectedField, obj *model.Usage) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Usage_completionTokens(ctx, field)
+func (ec *executionContext) _UsageBucketEdge_node(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucketEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucketEdge_node(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5907,9 +7455,9 @@ func (ec *executionContext) _Usage_completionTokens(ctx context.Context, field g
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CompletionTokens, nil
+		return obj.Node, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5921,26 +7469,50 @@ func (ec *executionContext) _Usage_completionTokens(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.UsageBucket)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNUsageBucket2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucket(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Usage_completionTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucketEdge_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Usage",
+		Object:     "UsageBucketEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "startTime":
+				return ec.fieldContext_UsageBucket_startTime(ctx, field)
+			case "endTime":
+				return ec.fieldContext_UsageBucket_endTime(ctx, field)
+			case "group":
+				return ec.fieldContext_UsageBucket_group(ctx, field)
+			case "requests":
+				return ec.fieldContext_UsageBucket_requests(ctx, field)
+			case "failedRequests":
+				return ec.fieldContext_UsageBucket_failedRequests(ctx, field)
+			case "promptTokens":
+				return ec.fieldContext_UsageBucket_promptTokens(ctx, field)
+			case "completionTokens":
+				return ec.fieldContext_UsageBucket_completionTokens(ctx, field)
+			case "cachedTokens":
+				return ec.fieldContext_UsageBucket_cachedTokens(ctx, field)
+			case "cost":
+				return ec.fieldContext_UsageBucket_cost(ctx, field)
+			case "averageLatencyMs":
+				return ec.fieldContext_UsageBucket_averageLatencyMs(ctx, field)
+			case "cacheHits":
+				return ec.fieldContext_UsageBucket_cacheHits(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UsageBucket", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Usage_totalTokens(ctx context.Context, field graphql.CollectedField, obj *model.Usage) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Usage_totalTokens(ctx, field)
+func (ec *executionContext) _UsageBucketEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *model.UsageBucketEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_UsageBucketEdge_cursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5951,9 +7523,9 @@ func (ec *executionContext) _Usage_totalTokens(ctx context.Context, field graphq
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalTokens, nil
+		return obj.Cursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5965,19 +7537,19 @@ func (ec *executionContext) _Usage_totalTokens(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Usage_totalTokens(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_UsageBucketEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Usage",
+		Object:     "UsageBucketEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
@@ -5995,7 +7567,7 @@ func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -6039,7 +7611,7 @@ func (ec *executionContext) ___Directive_description(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Description(), nil
 	})
@@ -6080,7 +7652,7 @@ func (ec *executionContext) ___Directive_locations(ctx context.Context, field gr
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Locations, nil
 	})
@@ -6124,7 +7696,7 @@ func (ec *executionContext) ___Directive_args(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Args, nil
 	})
@@ -6178,7 +7750,7 @@ func (ec *executionContext) ___Directive_isRepeatable(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.IsRepeatable, nil
 	})
@@ -6222,7 +7794,7 @@ func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -6266,7 +7838,7 @@ func (ec *executionContext) ___EnumValue_description(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Description(), nil
 	})
@@ -6307,7 +7879,7 @@ func (ec *executionContext) ___EnumValue_isDeprecated(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.IsDeprecated(), nil
 	})
@@ -6351,7 +7923,7 @@ func (ec *executionContext) ___EnumValue_deprecationReason(ctx context.Context,
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.DeprecationReason(), nil
 	})
@@ -6392,7 +7964,7 @@ func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.Col
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -6436,7 +8008,7 @@ func (ec *executionContext) ___Field_description(ctx context.Context, field grap
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Description(), nil
 	})
@@ -6477,7 +8049,7 @@ func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.Col
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Args, nil
 	})
@@ -6531,7 +8103,7 @@ func (ec *executionContext) ___Field_type(ctx context.Context, field graphql.Col
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Type, nil
 	})
@@ -6597,7 +8169,7 @@ func (ec *executionContext) ___Field_isDeprecated(ctx context.Context, field gra
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.IsDeprecated(), nil
 	})
@@ -6641,7 +8213,7 @@ func (ec *executionContext) ___Field_deprecationReason(ctx context.Context, fiel
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.DeprecationReason(), nil
 	})
@@ -6682,7 +8254,7 @@ func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphq
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name, nil
 	})
@@ -6726,7 +8298,7 @@ func (ec *executionContext) ___InputValue_description(ctx context.Context, field
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Description(), nil
 	})
@@ -6767,7 +8339,7 @@ func (ec *executionContext) ___InputValue_type(ctx context.Context, field graphq
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Type, nil
 	})
@@ -6833,7 +8405,7 @@ func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, fiel
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.DefaultValue, nil
 	})
@@ -6874,7 +8446,7 @@ func (ec *executionContext) ___Schema_description(ctx context.Context, field gra
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Description(), nil
 	})
@@ -6915,7 +8487,7 @@ func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.C
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Types(), nil
 	})
@@ -6981,7 +8553,7 @@ func (ec *executionContext) ___Schema_queryType(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.QueryType(), nil
 	})
@@ -7047,7 +8619,7 @@ func (ec *executionContext) ___Schema_mutationType(ctx context.Context, field gr
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.MutationType(), nil
 	})
@@ -7110,7 +8682,7 @@ func (ec *executionContext) ___Schema_subscriptionType(ctx context.Context, fiel
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.SubscriptionType(), nil
 	})
@@ -7173,7 +8745,7 @@ func (ec *executionContext) ___Schema_directives(ctx context.Context, field grap
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Directives(), nil
 	})
@@ -7229,7 +8801,7 @@ func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.Coll
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Kind(), nil
 	})
@@ -7273,7 +8845,7 @@ func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.Coll
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Name(), nil
 	})
@@ -7314,7 +8886,7 @@ func (ec *executionContext) ___Type_description(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Description(), nil
 	})
@@ -7355,7 +8927,7 @@ func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.Co
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Fields(fc.Args["includeDeprecated"].(bool)), nil
 	})
@@ -7421,7 +8993,7 @@ func (ec *executionContext) ___Type_interfaces(ctx context.Context, field graphq
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Interfaces(), nil
 	})
@@ -7484,7 +9056,7 @@ func (ec *executionContext) ___Type_possibleTypes(ctx context.Context, field gra
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.PossibleTypes(), nil
 	})
@@ -7547,7 +9119,7 @@ func (ec *executionContext) ___Type_enumValues(ctx context.Context, field graphq
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.EnumValues(fc.Args["includeDeprecated"].(bool)), nil
 	})
@@ -7609,7 +9181,7 @@ func (ec *executionContext) ___Type_inputFields(ctx context.Context, field graph
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.InputFields(), nil
 	})
@@ -7660,7 +9232,7 @@ func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.Co
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.OfType(), nil
 	})
@@ -7723,7 +9295,7 @@ func (ec *executionContext) ___Type_specifiedByURL(ctx context.Context, field gr
 			ret = graphql.Null
 		}
 	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.SpecifiedByURL(), nil
 	})
@@ -7756,10 +9328,10 @@ func (ec *executionContext) fieldContext___Type_specifiedByURL(_ context.Context
 
 // region    **************************** input.gotpl *****************************
 
-func (ec *executionContext) unmarshalInputChatCompletionContentPartImageURLInput(ctx context.Context, obj interface{}) (model.ChatCompletionContentPartImageURLInput, error) {
+func (ec *executionContext) unmarshalInputChatCompletionContentPartImageURLInput(ctx context.Context, obj any) (model.ChatCompletionContentPartImageURLInput, error) {
 	var it model.ChatCompletionContentPartImageURLInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -7790,10 +9362,10 @@ func (ec *executionContext) unmarshalInputChatCompletionContentPartImageURLInput
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputChatCompletionMessageContentPartInput(ctx context.Context, obj interface{}) (model.ChatCompletionMessageContentPartInput, error) {
+func (ec *executionContext) unmarshalInputChatCompletionMessageContentPartInput(ctx context.Context, obj any) (model.ChatCompletionMessageContentPartInput, error) {
 	var it model.ChatCompletionMessageContentPartInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -7831,10 +9403,10 @@ func (ec *executionContext) unmarshalInputChatCompletionMessageContentPartInput(
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputChatCompletionMessageInput(ctx context.Context, obj interface{}) (model.ChatCompletionMessageInput, error) {
+func (ec *executionContext) unmarshalInputChatCompletionMessageInput(ctx context.Context, obj any) (model.ChatCompletionMessageInput, error) {
 	var it model.ChatCompletionMessageInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -7893,10 +9465,10 @@ func (ec *executionContext) unmarshalInputChatCompletionMessageInput(ctx context
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputChatCompletionMessageToolCallInput(ctx context.Context, obj interface{}) (model.ChatCompletionMessageToolCallInput, error) {
+func (ec *executionContext) unmarshalInputChatCompletionMessageToolCallInput(ctx context.Context, obj any) (model.ChatCompletionMessageToolCallInput, error) {
 	var it model.ChatCompletionMessageToolCallInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -7934,10 +9506,10 @@ func (ec *executionContext) unmarshalInputChatCompletionMessageToolCallInput(ctx
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputChatCompletionTool(ctx context.Context, obj interface{}) (model.ChatCompletionTool, error) {
+func (ec *executionContext) unmarshalInputChatCompletionTool(ctx context.Context, obj any) (model.ChatCompletionTool, error) {
 	var it model.ChatCompletionTool
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -7968,10 +9540,10 @@ func (ec *executionContext) unmarshalInputChatCompletionTool(ctx context.Context
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputChatCompletionToolFunction(ctx context.Context, obj interface{}) (model.ChatCompletionToolFunction, error) {
+func (ec *executionContext) unmarshalInputChatCompletionToolFunction(ctx context.Context, obj any) (model.ChatCompletionToolFunction, error) {
 	var it model.ChatCompletionToolFunction
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -8009,10 +9581,10 @@ func (ec *executionContext) unmarshalInputChatCompletionToolFunction(ctx context
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputCreateChatCompletionInput(ctx context.Context, obj interface{}) (model.CreateChatCompletionInput, error) {
+func (ec *executionContext) unmarshalInputCreateChatCompletionInput(ctx context.Context, obj any) (model.CreateChatCompletionInput, error) {
 	var it model.CreateChatCompletionInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -8176,10 +9748,10 @@ func (ec *executionContext) unmarshalInputCreateChatCompletionInput(ctx context.
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputCreateChatCompletionStreamInput(ctx context.Context, obj interface{}) (model.CreateChatCompletionStreamInput, error) {
+func (ec *executionContext) unmarshalInputCreateChatCompletionStreamInput(ctx context.Context, obj any) (model.CreateChatCompletionStreamInput, error) {
 	var it model.CreateChatCompletionStreamInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -8192,7 +9764,7 @@ func (ec *executionContext) unmarshalInputCreateChatCompletionStreamInput(ctx co
 		switch k {
 		case "apiKey":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("apiKey"))
-			data, err := ec.unmarshalNString2string(ctx, v)
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -8357,10 +9929,10 @@ func (ec *executionContext) unmarshalInputCreateChatCompletionStreamInput(ctx co
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputCreateChatCompletionStreamOptions(ctx context.Context, obj interface{}) (model.CreateChatCompletionStreamOptions, error) {
+func (ec *executionContext) unmarshalInputCreateChatCompletionStreamOptions(ctx context.Context, obj any) (model.CreateChatCompletionStreamOptions, error) {
 	var it model.CreateChatCompletionStreamOptions
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -8384,10 +9956,10 @@ func (ec *executionContext) unmarshalInputCreateChatCompletionStreamOptions(ctx
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputFunctionCallInput(ctx context.Context, obj interface{}) (model.FunctionCallInput, error) {
+func (ec *executionContext) unmarshalInputFunctionCallInput(ctx context.Context, obj any) (model.FunctionCallInput, error) {
 	var it model.FunctionCallInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -8418,10 +9990,10 @@ func (ec *executionContext) unmarshalInputFunctionCallInput(ctx context.Context,
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputJSONSchemaInput(ctx context.Context, obj interface{}) (model.JSONSchemaInput, error) {
+func (ec *executionContext) unmarshalInputJSONSchemaInput(ctx context.Context, obj any) (model.JSONSchemaInput, error) {
 	var it model.JSONSchemaInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -8466,10 +10038,10 @@ func (ec *executionContext) unmarshalInputJSONSchemaInput(ctx context.Context, o
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputResponseFormatInput(ctx context.Context, obj interface{}) (model.ResponseFormatInput, error) {
+func (ec *executionContext) unmarshalInputResponseFormatInput(ctx context.Context, obj any) (model.ResponseFormatInput, error) {
 	var it model.ResponseFormatInput
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
 		asMap[k] = v
 	}
 
@@ -8500,6 +10072,96 @@ func (ec *executionContext) unmarshalInputResponseFormatInput(ctx context.Contex
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputUsageQueryInput(ctx context.Context, obj any) (model.UsageQueryInput, error) {
+	var it model.UsageQueryInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"startTime", "endTime", "bucketWidth", "groupBy", "tenantId", "teamId", "groupId", "endpointId", "model", "user"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "startTime":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("startTime"))
+			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.StartTime = data
+		case "endTime":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("endTime"))
+			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.EndTime = data
+		case "bucketWidth":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("bucketWidth"))
+			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.BucketWidth = data
+		case "groupBy":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("groupBy"))
+			data, err := ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.GroupBy = data
+		case "tenantId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("tenantId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.TenantID = data
+		case "teamId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("teamId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.TeamID = data
+		case "groupId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("groupId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.GroupID = data
+		case "endpointId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("endpointId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.EndpointID = data
+		case "model":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("model"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Model = data
+		case "user":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("user"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.User = data
+		}
+	}
+
+	return it, nil
+}
+
 // endregion **************************** input.gotpl *****************************
 
 // region    ************************** interface.gotpl ***************************
@@ -9504,6 +11166,16 @@ func (ec *executionContext) _Model(ctx context.Context, sel ast.SelectionSet, ob
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "created":
+			out.Values[i] = ec._Model_created(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "ownedBy":
+			out.Values[i] = ec._Model_ownedBy(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9728,10 +11400,32 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			Field:  field,
 		})
 
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Query")
-		case "models":
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Query")
+		case "models":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_models(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "usage":
 			field := field
 
 			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
@@ -9740,7 +11434,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_models(ctx, field)
+				res = ec._Query_usage(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -9956,6 +11650,177 @@ func (ec *executionContext) _Usage(ctx context.Context, sel ast.SelectionSet, ob
 	return out
 }
 
+var usageBucketImplementors = []string{"UsageBucket"}
+
+func (ec *executionContext) _UsageBucket(ctx context.Context, sel ast.SelectionSet, obj *model.UsageBucket) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, usageBucketImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("UsageBucket")
+		case "startTime":
+			out.Values[i] = ec._UsageBucket_startTime(ctx, field, obj)
+		case "endTime":
+			out.Values[i] = ec._UsageBucket_endTime(ctx, field, obj)
+		case "group":
+			out.Values[i] = ec._UsageBucket_group(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "requests":
+			out.Values[i] = ec._UsageBucket_requests(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "failedRequests":
+			out.Values[i] = ec._UsageBucket_failedRequests(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "promptTokens":
+			out.Values[i] = ec._UsageBucket_promptTokens(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "completionTokens":
+			out.Values[i] = ec._UsageBucket_completionTokens(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "cachedTokens":
+			out.Values[i] = ec._UsageBucket_cachedTokens(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "cost":
+			out.Values[i] = ec._UsageBucket_cost(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "averageLatencyMs":
+			out.Values[i] = ec._UsageBucket_averageLatencyMs(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "cacheHits":
+			out.Values[i] = ec._UsageBucket_cacheHits(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var usageBucketConnectionImplementors = []string{"UsageBucketConnection"}
+
+func (ec *executionContext) _UsageBucketConnection(ctx context.Context, sel ast.SelectionSet, obj *model.UsageBucketConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, usageBucketConnectionImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("UsageBucketConnection")
+		case "edges":
+			out.Values[i] = ec._UsageBucketConnection_edges(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "pageInfo":
+			out.Values[i] = ec._UsageBucketConnection_pageInfo(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var usageBucketEdgeImplementors = []string{"UsageBucketEdge"}
+
+func (ec *executionContext) _UsageBucketEdge(ctx context.Context, sel ast.SelectionSet, obj *model.UsageBucketEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, usageBucketEdgeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("UsageBucketEdge")
+		case "node":
+			out.Values[i] = ec._UsageBucketEdge_node(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "cursor":
+			out.Values[i] = ec._UsageBucketEdge_cursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var __DirectiveImplementors = []string{"__Directive"}
 
 func (ec *executionContext) ___Directive(ctx context.Context, sel ast.SelectionSet, obj *introspection.Directive) graphql.Marshaler {
@@ -10282,7 +12147,17 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 
 // region    ***************************** type.gotpl *****************************
 
-func (ec *executionContext) unmarshalNBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
+func (ec *executionContext) unmarshalNAuthScope2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐAuthScope(ctx context.Context, v any) (model.AuthScope, error) {
+	var res model.AuthScope
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNAuthScope2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐAuthScope(ctx context.Context, sel ast.SelectionSet, v model.AuthScope) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) unmarshalNBoolean2bool(ctx context.Context, v any) (bool, error) {
 	res, err := graphql.UnmarshalBoolean(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10435,13 +12310,13 @@ func (ec *executionContext) marshalNChatCompletionMessageContentPart2ᚕgithub
 	return ret
 }
 
-func (ec *executionContext) unmarshalNChatCompletionMessageContentPartInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageContentPartInput(ctx context.Context, v interface{}) (*model.ChatCompletionMessageContentPartInput, error) {
+func (ec *executionContext) unmarshalNChatCompletionMessageContentPartInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageContentPartInput(ctx context.Context, v any) (*model.ChatCompletionMessageContentPartInput, error) {
 	res, err := ec.unmarshalInputChatCompletionMessageContentPartInput(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalNChatCompletionMessageInput2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageInputᚄ(ctx context.Context, v interface{}) ([]*model.ChatCompletionMessageInput, error) {
-	var vSlice []interface{}
+func (ec *executionContext) unmarshalNChatCompletionMessageInput2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageInputᚄ(ctx context.Context, v any) ([]*model.ChatCompletionMessageInput, error) {
+	var vSlice []any
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
@@ -10457,7 +12332,7 @@ func (ec *executionContext) unmarshalNChatCompletionMessageInput2ᚕᚖgithubᚗ
 	return res, nil
 }
 
-func (ec *executionContext) unmarshalNChatCompletionMessageInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageInput(ctx context.Context, v interface{}) (*model.ChatCompletionMessageInput, error) {
+func (ec *executionContext) unmarshalNChatCompletionMessageInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageInput(ctx context.Context, v any) (*model.ChatCompletionMessageInput, error) {
 	res, err := ec.unmarshalInputChatCompletionMessageInput(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10482,7 +12357,7 @@ func (ec *executionContext) marshalNChatCompletionMessageToolCallChunk2ᚖgithub
 	return ec._ChatCompletionMessageToolCallChunk(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNChatCompletionMessageToolCallInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageToolCallInput(ctx context.Context, v interface{}) (*model.ChatCompletionMessageToolCallInput, error) {
+func (ec *executionContext) unmarshalNChatCompletionMessageToolCallInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageToolCallInput(ctx context.Context, v any) (*model.ChatCompletionMessageToolCallInput, error) {
 	res, err := ec.unmarshalInputChatCompletionMessageToolCallInput(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10579,17 +12454,17 @@ func (ec *executionContext) marshalNChatCompletionStreamResult2ᚖgithubᚗcom
 	return ec._ChatCompletionStreamResult(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNCreateChatCompletionInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionInput(ctx context.Context, v interface{}) (model.CreateChatCompletionInput, error) {
+func (ec *executionContext) unmarshalNCreateChatCompletionInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionInput(ctx context.Context, v any) (model.CreateChatCompletionInput, error) {
 	res, err := ec.unmarshalInputCreateChatCompletionInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalNCreateChatCompletionStreamInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionStreamInput(ctx context.Context, v interface{}) (model.CreateChatCompletionStreamInput, error) {
+func (ec *executionContext) unmarshalNCreateChatCompletionStreamInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionStreamInput(ctx context.Context, v any) (model.CreateChatCompletionStreamInput, error) {
 	res, err := ec.unmarshalInputCreateChatCompletionStreamInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalNFloat2float64(ctx context.Context, v interface{}) (float64, error) {
+func (ec *executionContext) unmarshalNFloat2float64(ctx context.Context, v any) (float64, error) {
 	res, err := graphql.UnmarshalFloatContext(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10614,12 +12489,12 @@ func (ec *executionContext) marshalNFunctionCall2ᚖgithubᚗcomᚋlingticioᚋl
 	return ec._FunctionCall(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNFunctionCallInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐFunctionCallInput(ctx context.Context, v interface{}) (*model.FunctionCallInput, error) {
+func (ec *executionContext) unmarshalNFunctionCallInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐFunctionCallInput(ctx context.Context, v any) (*model.FunctionCallInput, error) {
 	res, err := ec.unmarshalInputFunctionCallInput(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalNID2string(ctx context.Context, v interface{}) (string, error) {
+func (ec *executionContext) unmarshalNID2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalID(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10634,7 +12509,7 @@ func (ec *executionContext) marshalNID2string(ctx context.Context, sel ast.Selec
 	return res
 }
 
-func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}) (int, error) {
+func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v any) (int, error) {
 	res, err := graphql.UnmarshalInt(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10649,8 +12524,8 @@ func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.Selecti
 	return res
 }
 
-func (ec *executionContext) unmarshalNInt2ᚕintᚄ(ctx context.Context, v interface{}) ([]int, error) {
-	var vSlice []interface{}
+func (ec *executionContext) unmarshalNInt2ᚕintᚄ(ctx context.Context, v any) ([]int, error) {
+	var vSlice []any
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
@@ -10681,12 +12556,12 @@ func (ec *executionContext) marshalNInt2ᚕintᚄ(ctx context.Context, sel ast.S
 	return ret
 }
 
-func (ec *executionContext) unmarshalNMap2map(ctx context.Context, v interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) unmarshalNMap2map(ctx context.Context, v any) (map[string]any, error) {
 	res, err := graphql.UnmarshalMap(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNMap2map(ctx context.Context, sel ast.SelectionSet, v map[string]interface{}) graphql.Marshaler {
+func (ec *executionContext) marshalNMap2map(ctx context.Context, sel ast.SelectionSet, v map[string]any) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -10790,7 +12665,7 @@ func (ec *executionContext) marshalNPageInfo2ᚖgithubᚗcomᚋlingticioᚋllmg
 	return ec._PageInfo(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNString2string(ctx context.Context, v interface{}) (string, error) {
+func (ec *executionContext) unmarshalNString2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10923,6 +12798,89 @@ func (ec *executionContext) marshalNUsage2ᚖgithubᚗcomᚋlingticioᚋllmgᚋi
 	return ec._Usage(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNUsageBucket2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucket(ctx context.Context, sel ast.SelectionSet, v *model.UsageBucket) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._UsageBucket(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNUsageBucketConnection2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucketConnection(ctx context.Context, sel ast.SelectionSet, v model.UsageBucketConnection) graphql.Marshaler {
+	return ec._UsageBucketConnection(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNUsageBucketConnection2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucketConnection(ctx context.Context, sel ast.SelectionSet, v *model.UsageBucketConnection) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._UsageBucketConnection(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNUsageBucketEdge2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucketEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.UsageBucketEdge) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNUsageBucketEdge2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucketEdge(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNUsageBucketEdge2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageBucketEdge(ctx context.Context, sel ast.SelectionSet, v *model.UsageBucketEdge) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._UsageBucketEdge(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNUsageQueryInput2githubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐUsageQueryInput(ctx context.Context, v any) (model.UsageQueryInput, error) {
+	res, err := ec.unmarshalInputUsageQueryInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalN__Directive2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx context.Context, sel ast.SelectionSet, v introspection.Directive) graphql.Marshaler {
 	return ec.___Directive(ctx, sel, &v)
 }
@@ -10971,7 +12929,7 @@ func (ec *executionContext) marshalN__Directive2ᚕgithubᚗcomᚋ99designsᚋgq
 	return ret
 }
 
-func (ec *executionContext) unmarshalN__DirectiveLocation2string(ctx context.Context, v interface{}) (string, error) {
+func (ec *executionContext) unmarshalN__DirectiveLocation2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -10986,8 +12944,8 @@ func (ec *executionContext) marshalN__DirectiveLocation2string(ctx context.Conte
 	return res
 }
 
-func (ec *executionContext) unmarshalN__DirectiveLocation2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
-	var vSlice []interface{}
+func (ec *executionContext) unmarshalN__DirectiveLocation2ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
+	var vSlice []any
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
@@ -11161,7 +13119,7 @@ func (ec *executionContext) marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgen
 	return ec.___Type(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalN__TypeKind2string(ctx context.Context, v interface{}) (string, error) {
+func (ec *executionContext) unmarshalN__TypeKind2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -11176,7 +13134,7 @@ func (ec *executionContext) marshalN__TypeKind2string(ctx context.Context, sel a
 	return res
 }
 
-func (ec *executionContext) unmarshalOBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
+func (ec *executionContext) unmarshalOBoolean2bool(ctx context.Context, v any) (bool, error) {
 	res, err := graphql.UnmarshalBoolean(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -11186,7 +13144,7 @@ func (ec *executionContext) marshalOBoolean2bool(ctx context.Context, sel ast.Se
 	return res
 }
 
-func (ec *executionContext) unmarshalOBoolean2ᚖbool(ctx context.Context, v interface{}) (*bool, error) {
+func (ec *executionContext) unmarshalOBoolean2ᚖbool(ctx context.Context, v any) (*bool, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11202,7 +13160,7 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
-func (ec *executionContext) unmarshalOChatCompletionContentPartImageURLInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionContentPartImageURLInput(ctx context.Context, v interface{}) (*model.ChatCompletionContentPartImageURLInput, error) {
+func (ec *executionContext) unmarshalOChatCompletionContentPartImageURLInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionContentPartImageURLInput(ctx context.Context, v any) (*model.ChatCompletionContentPartImageURLInput, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11217,11 +13175,11 @@ func (ec *executionContext) marshalOChatCompletionMessageContent2githubᚗcomᚋ
 	return ec._ChatCompletionMessageContent(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalOChatCompletionMessageContentPartInput2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageContentPartInputᚄ(ctx context.Context, v interface{}) ([]*model.ChatCompletionMessageContentPartInput, error) {
+func (ec *executionContext) unmarshalOChatCompletionMessageContentPartInput2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageContentPartInputᚄ(ctx context.Context, v any) ([]*model.ChatCompletionMessageContentPartInput, error) {
 	if v == nil {
 		return nil, nil
 	}
-	var vSlice []interface{}
+	var vSlice []any
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
@@ -11331,11 +13289,11 @@ func (ec *executionContext) marshalOChatCompletionMessageToolCallChunk2ᚕᚖgit
 	return ret
 }
 
-func (ec *executionContext) unmarshalOChatCompletionMessageToolCallInput2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageToolCallInputᚄ(ctx context.Context, v interface{}) ([]*model.ChatCompletionMessageToolCallInput, error) {
+func (ec *executionContext) unmarshalOChatCompletionMessageToolCallInput2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionMessageToolCallInputᚄ(ctx context.Context, v any) ([]*model.ChatCompletionMessageToolCallInput, error) {
 	if v == nil {
 		return nil, nil
 	}
-	var vSlice []interface{}
+	var vSlice []any
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
@@ -11351,11 +13309,11 @@ func (ec *executionContext) unmarshalOChatCompletionMessageToolCallInput2ᚕᚖg
 	return res, nil
 }
 
-func (ec *executionContext) unmarshalOChatCompletionTool2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionTool(ctx context.Context, v interface{}) ([]*model.ChatCompletionTool, error) {
+func (ec *executionContext) unmarshalOChatCompletionTool2ᚕᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionTool(ctx context.Context, v any) ([]*model.ChatCompletionTool, error) {
 	if v == nil {
 		return nil, nil
 	}
-	var vSlice []interface{}
+	var vSlice []any
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
@@ -11371,7 +13329,7 @@ func (ec *executionContext) unmarshalOChatCompletionTool2ᚕᚖgithubᚗcomᚋli
 	return res, nil
 }
 
-func (ec *executionContext) unmarshalOChatCompletionTool2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionTool(ctx context.Context, v interface{}) (*model.ChatCompletionTool, error) {
+func (ec *executionContext) unmarshalOChatCompletionTool2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionTool(ctx context.Context, v any) (*model.ChatCompletionTool, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11379,7 +13337,7 @@ func (ec *executionContext) unmarshalOChatCompletionTool2ᚖgithubᚗcomᚋlingt
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalOChatCompletionToolChoiceOption2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionToolChoiceOption(ctx context.Context, v interface{}) (*model.ChatCompletionToolChoiceOption, error) {
+func (ec *executionContext) unmarshalOChatCompletionToolChoiceOption2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionToolChoiceOption(ctx context.Context, v any) (*model.ChatCompletionToolChoiceOption, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11395,7 +13353,7 @@ func (ec *executionContext) marshalOChatCompletionToolChoiceOption2ᚖgithubᚗc
 	return v
 }
 
-func (ec *executionContext) unmarshalOChatCompletionToolFunction2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionToolFunction(ctx context.Context, v interface{}) (*model.ChatCompletionToolFunction, error) {
+func (ec *executionContext) unmarshalOChatCompletionToolFunction2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐChatCompletionToolFunction(ctx context.Context, v any) (*model.ChatCompletionToolFunction, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11403,7 +13361,7 @@ func (ec *executionContext) unmarshalOChatCompletionToolFunction2ᚖgithubᚗcom
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalOCreateChatCompletionStreamOptions2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionStreamOptions(ctx context.Context, v interface{}) (*model.CreateChatCompletionStreamOptions, error) {
+func (ec *executionContext) unmarshalOCreateChatCompletionStreamOptions2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐCreateChatCompletionStreamOptions(ctx context.Context, v any) (*model.CreateChatCompletionStreamOptions, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11411,7 +13369,7 @@ func (ec *executionContext) unmarshalOCreateChatCompletionStreamOptions2ᚖgithu
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalOFinishReason2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐFinishReason(ctx context.Context, v interface{}) (*model.FinishReason, error) {
+func (ec *executionContext) unmarshalOFinishReason2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐFinishReason(ctx context.Context, v any) (*model.FinishReason, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11427,7 +13385,7 @@ func (ec *executionContext) marshalOFinishReason2ᚖgithubᚗcomᚋlingticioᚋl
 	return v
 }
 
-func (ec *executionContext) unmarshalOFloat2ᚖfloat64(ctx context.Context, v interface{}) (*float64, error) {
+func (ec *executionContext) unmarshalOFloat2ᚖfloat64(ctx context.Context, v any) (*float64, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11457,7 +13415,7 @@ func (ec *executionContext) marshalOFunctionCallChunk2ᚖgithubᚗcomᚋlingtici
 	return ec._FunctionCallChunk(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalOImageDetail2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐImageDetail(ctx context.Context, v interface{}) (*model.ImageDetail, error) {
+func (ec *executionContext) unmarshalOImageDetail2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐImageDetail(ctx context.Context, v any) (*model.ImageDetail, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11473,7 +13431,7 @@ func (ec *executionContext) marshalOImageDetail2ᚖgithubᚗcomᚋlingticioᚋll
 	return v
 }
 
-func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v interface{}) (*int, error) {
+func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v any) (*int, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11489,7 +13447,7 @@ func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.Sele
 	return res
 }
 
-func (ec *executionContext) unmarshalOJSONSchemaInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐJSONSchemaInput(ctx context.Context, v interface{}) (*model.JSONSchemaInput, error) {
+func (ec *executionContext) unmarshalOJSONSchemaInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐJSONSchemaInput(ctx context.Context, v any) (*model.JSONSchemaInput, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11504,7 +13462,7 @@ func (ec *executionContext) marshalOLogProbs2ᚖgithubᚗcomᚋlingticioᚋllmg
 	return ec._LogProbs(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalOMap2map(ctx context.Context, v interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) unmarshalOMap2map(ctx context.Context, v any) (map[string]any, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11512,7 +13470,7 @@ func (ec *executionContext) unmarshalOMap2map(ctx context.Context, v interface{}
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalOMap2map(ctx context.Context, sel ast.SelectionSet, v map[string]interface{}) graphql.Marshaler {
+func (ec *executionContext) marshalOMap2map(ctx context.Context, sel ast.SelectionSet, v map[string]any) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -11520,7 +13478,7 @@ func (ec *executionContext) marshalOMap2map(ctx context.Context, sel ast.Selecti
 	return res
 }
 
-func (ec *executionContext) unmarshalOResponseFormatInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐResponseFormatInput(ctx context.Context, v interface{}) (*model.ResponseFormatInput, error) {
+func (ec *executionContext) unmarshalOResponseFormatInput2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐResponseFormatInput(ctx context.Context, v any) (*model.ResponseFormatInput, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11528,7 +13486,7 @@ func (ec *executionContext) unmarshalOResponseFormatInput2ᚖgithubᚗcomᚋling
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalOServiceTier2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐServiceTier(ctx context.Context, v interface{}) (*model.ServiceTier, error) {
+func (ec *executionContext) unmarshalOServiceTier2ᚖgithubᚗcomᚋlingticioᚋllmgᚋinternalᚋgraphᚋopenaiᚋmodelᚐServiceTier(ctx context.Context, v any) (*model.ServiceTier, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -11544,11 +13502,49 @@ func (ec *executionContext) marshalOServiceTier2ᚖgithubᚗcomᚋlingticioᚋll
 	return v
 }
 
-func (ec *executionContext) unmarshalOString2ᚕᚖstring(ctx context.Context, v interface{}) ([]*string, error) {
+func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []any
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOString2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) unmarshalOString2ᚕᚖstring(ctx context.Context, v any) ([]*string, error) {
 	if v == nil {
 		return nil, nil
 	}
-	var vSlice []interface{}
+	var vSlice []any
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
@@ -11576,7 +13572,7 @@ func (ec *executionContext) marshalOString2ᚕᚖstring(ctx context.Context, sel
 	return ret
 }
 
-func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
+func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v any) (*string, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -243,7 +243,7 @@ type ChatCompletionToolFunction struct {
 	// documentation about the format.
 	//
 	// Omitting `parameters` defines a function with an empty parameter list.
-	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Parameters map[string]any `json:"parameters,omitempty"`
 }
 
 type ChatCompletionToolMessage struct {
@@ -279,13 +279,13 @@ func (this ChatCompletionUserMessage) GetContent() ChatCompletionMessageContent
 func (this ChatCompletionUserMessage) GetName() *string                         { return this.Name }
 
 type CreateChatCompletionInput struct {
-	// API key to interact with endpoints.
+	// API key issued by the gateway.
 	//
-	// Optional, and can be passed in the headers with 'Authorization' or 'X-Api-Key'.
+	// Optional, and can be passed in the headers with 'Authorization' or 'X-Api-Key'. Rejected when
+	// input credentials are disabled for the deployment.
 	APIKey *string `json:"apiKey,omitempty"`
-	// Base URL for the upstream API endpoint.
-	//
-	// Optional, only required if the model is hosted on a custom endpoint.
+	// Deprecated, requests are always sent to the upstream configured for the endpoint of the API key,
+	// and this field is ignored. Rejected when input credentials are disabled for the deployment.
 	BaseURL *string `json:"baseURL,omitempty"`
 	// A list of messages comprising the conversation so far.
 	// [Example Python code](https://cookbook.openai.com/examples/how_to_format_inputs_to_chatgpt_models).
@@ -308,7 +308,7 @@ type CreateChatCompletionInput struct {
 	// effect will vary per model, but values between -1 and 1 should decrease or
 	// increase likelihood of selection; values like -100 or 100 should result in a ban
 	// or exclusive selection of the relevant token.
-	LogitBias map[string]interface{} `json:"logitBias,omitempty"`
+	LogitBias map[string]any `json:"logitBias,omitempty"`
 	// Whether to return log probabilities of the output tokens or not. If true,
 	// returns the log probabilities of each output token returned in the `content` of
 	// `message`.
@@ -406,15 +406,14 @@ type CreateChatCompletionInput struct {
 }
 
 type CreateChatCompletionStreamInput struct {
-	// API key to interact with endpoints.
-	//
-	// Unlike the `apiKey` field in the `CreateChatCompletionInput` object, since WebSocket in
-	// browsers does not allow setting custom headers, the `apiKey` field in this object is required. When
-	// using the WebSocket transport in the browser.
-	APIKey string `json:"apiKey"`
-	// Base URL for the upstream API endpoint.
+	// API key issued by the gateway.
 	//
-	// Optional, only required if the model is hosted on a custom endpoint.
+	// Since WebSocket in browsers does not allow setting custom headers, the API key can be passed
+	// either as `apiKey` or `Authorization` in the `connection_init` payload, or in this field. Rejected
+	// when input credentials are disabled for the deployment.
+	APIKey *string `json:"apiKey,omitempty"`
+	// Deprecated, requests are always sent to the upstream configured for the endpoint of the API key,
+	// and this field is ignored. Rejected when input credentials are disabled for the deployment.
 	BaseURL *string `json:"baseURL,omitempty"`
 	// A list of messages comprising the conversation so far.
 	// [Example Python code](https://cookbook.openai.com/examples/how_to_format_inputs_to_chatgpt_models).
@@ -437,7 +436,7 @@ type CreateChatCompletionStreamInput struct {
 	// effect will vary per model, but values between -1 and 1 should decrease or
 	// increase likelihood of selection; values like -100 or 100 should result in a ban
 	// or exclusive selection of the relevant token.
-	LogitBias map[string]interface{} `json:"logitBias,omitempty"`
+	LogitBias map[string]any `json:"logitBias,omitempty"`
 	// Whether to return log probabilities of the output tokens or not. If true,
 	// returns the log probabilities of each output token returned in the `content` of
 	// `message`.
@@ -575,7 +574,7 @@ type JSONSchemaInput struct {
 	// The name of the response format. Must be a-z, A-Z, 0-9, or contain underscores and dashes, with a maximum length of 64.
 	Name string `json:"name"`
 	// The schema for the response format, described as a JSON Schema object.
-	Schema map[string]interface{} `json:"schema"`
+	Schema map[string]any `json:"schema"`
 	// Whether to enable strict schema adherence when generating the output. If set to true, the model will always follow the exact schema defined in the `schema` field.
 	Strict *bool `json:"strict,omitempty"`
 }
@@ -588,7 +587,12 @@ type LogProbs struct {
 }
 
 type Model struct {
+	// The model identifier, which can be referenced in the API endpoints.
 	Name string `json:"name"`
+	// The Unix timestamp (in seconds) when the model was created.
+	Created int `json:"created"`
+	// The organization that owns the model.
+	OwnedBy string `json:"ownedBy"`
 }
 
 type ModelConnection struct {
@@ -653,6 +657,114 @@ type Usage struct {
 	TotalTokens int `json:"totalTokens"`
 }
 
+// The usage of the requests of a time bucket, and of a group when grouped by.
+type UsageBucket struct {
+	// The Unix timestamp (in seconds) of the start of the bucket, null when the range queried is unbounded.
+	StartTime *int `json:"startTime,omitempty"`
+	// The Unix timestamp (in seconds) of the end of the bucket, null when the range queried is unbounded.
+	EndTime *int `json:"endTime,omitempty"`
+	// The value of each dimension the buckets are grouped by.
+	Group    map[string]any `json:"group"`
+	Requests int            `json:"requests"`
+	// The requests responded with an error, including the ones the upstreams failed.
+	FailedRequests   int `json:"failedRequests"`
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	// The tokens of the prompts the upstreams served from their cache, which are included in promptTokens.
+	CachedTokens int `json:"cachedTokens"`
+	// The cost of the requests in USD, as priced by the budgets.
+	Cost float64 `json:"cost"`
+	// The average latency of the requests, in milliseconds.
+	AverageLatencyMs int `json:"averageLatencyMs"`
+	// The requests answered from the caches of the gateway, which cost nothing.
+	CacheHits int `json:"cacheHits"`
+}
+
+type UsageBucketConnection struct {
+	Edges    []*UsageBucketEdge `json:"edges"`
+	PageInfo *PageInfo          `json:"pageInfo"`
+}
+
+type UsageBucketEdge struct {
+	Node   *UsageBucket `json:"node"`
+	Cursor string       `json:"cursor"`
+}
+
+type UsageQueryInput struct {
+	// The Unix timestamp (in seconds) the range starts at, included. Unbounded when null with the sql
+	// storage, 31 days before endTime with the jsonl storage and the Redis streams, which query at most
+	// 31 days.
+	StartTime *int `json:"startTime,omitempty"`
+	// The Unix timestamp (in seconds) the range ends at, excluded. Unbounded when null with the sql
+	// storage, now with the jsonl storage and the Redis streams.
+	EndTime *int `json:"endTime,omitempty"`
+	// The width of the time buckets in seconds, e.g. 3600 or 86400, which are aligned on UTC. The usage
+	// of the whole range is aggregated when null.
+	BucketWidth *int `json:"bucketWidth,omitempty"`
+	// Splits the buckets by any of team, group, endpoint, model, upstream, user, operation, status and cache.
+	GroupBy []string `json:"groupBy,omitempty"`
+	// The tenant of the usage, API keys query the usage of their own tenant only.
+	TenantID *string `json:"tenantId,omitempty"`
+	// The team of the usage, only API keys also granted the `ADMIN` scope may query other teams than
+	// their own.
+	TeamID     *string `json:"teamId,omitempty"`
+	GroupID    *string `json:"groupId,omitempty"`
+	EndpointID *string `json:"endpointId,omitempty"`
+	Model      *string `json:"model,omitempty"`
+	// The user field of the requests.
+	User *string `json:"user,omitempty"`
+}
+
+// What an API key may be used for. API keys without scopes may be used for anything but `ADMIN`.
+type AuthScope string
+
+const (
+	AuthScopeChat       AuthScope = "CHAT"
+	AuthScopeEmbeddings AuthScope = "EMBEDDINGS"
+	AuthScopeModels     AuthScope = "MODELS"
+	AuthScopeBatches    AuthScope = "BATCHES"
+	AuthScopeUsage      AuthScope = "USAGE"
+	AuthScopeAdmin      AuthScope = "ADMIN"
+)
+
+var AllAuthScope = []AuthScope{
+	AuthScopeChat,
+	AuthScopeEmbeddings,
+	AuthScopeModels,
+	AuthScopeBatches,
+	AuthScopeUsage,
+	AuthScopeAdmin,
+}
+
+func (e AuthScope) IsValid() bool {
+	switch e {
+	case AuthScopeChat, AuthScopeEmbeddings, AuthScopeModels, AuthScopeBatches, AuthScopeUsage, AuthScopeAdmin:
+		return true
+	}
+	return false
+}
+
+func (e AuthScope) String() string {
+	return string(e)
+}
+
+func (e *AuthScope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuthScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuthScope", str)
+	}
+	return nil
+}
+
+func (e AuthScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ChatCompletionToolChoiceOption string
 
 const (
@@ -679,7 +791,7 @@ func (e ChatCompletionToolChoiceOption) String() string {
 	return string(e)
 }
 
-func (e *ChatCompletionToolChoiceOption) UnmarshalGQL(v interface{}) error {
+func (e *ChatCompletionToolChoiceOption) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
@@ -726,7 +838,7 @@ func (e FinishReason) String() string {
 	return string(e)
 }
 
-func (e *FinishReason) UnmarshalGQL(v interface{}) error {
+func (e *FinishReason) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
@@ -769,7 +881,7 @@ func (e ImageDetail) String() string {
 	return string(e)
 }
 
-func (e *ImageDetail) UnmarshalGQL(v interface{}) error {
+func (e *ImageDetail) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
@@ -812,7 +924,7 @@ func (e ServiceTier) String() string {
 	return string(e)
 }
 
-func (e *ServiceTier) UnmarshalGQL(v interface{}) error {
+func (e *ServiceTier) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
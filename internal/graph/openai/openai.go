@@ -2,15 +2,22 @@ package openai
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/endpoints"
 	"github.com/lingticio/llmg/internal/graph/openai/generated"
 	"github.com/lingticio/llmg/internal/graph/openai/resolvers"
+	"github.com/lingticio/llmg/internal/upstreams"
 	"github.com/nekomeowww/xo/logger"
+	"github.com/vektah/gqlparser/v2/ast"
 	"go.uber.org/fx"
 )
 
@@ -19,29 +26,58 @@ import (
 type NewGraphQLHandlerParams struct {
 	fx.In
 
-	Logger *logger.Logger
+	Logger         *logger.Logger
+	Config         *configs.Config
+	Authenticator  *endpoints.Authenticator
+	UpstreamModels *upstreams.Models
+	Gateway        *upstreams.Gateway
+	UsageLedger    *upstreams.UsageLedger
 }
 
 type GraphQLHandler struct {
-	logger *logger.Logger
+	logger         *logger.Logger
+	config         *configs.Config
+	authenticator  *endpoints.Authenticator
+	upstreamModels *upstreams.Models
+	gateway        *upstreams.Gateway
+	usageLedger    *upstreams.UsageLedger
 }
 
 func NewGraphQLHandler() func(params NewGraphQLHandlerParams) *GraphQLHandler {
 	return func(params NewGraphQLHandlerParams) *GraphQLHandler {
 		return &GraphQLHandler{
-			logger: params.Logger,
+			logger:         params.Logger,
+			config:         params.Config,
+			authenticator:  params.Authenticator,
+			upstreamModels: params.UpstreamModels,
+			gateway:        params.Gateway,
+			usageLedger:    params.UsageLedger,
 		}
 	}
 }
 
-func (h *GraphQLHandler) InstallForEcho(endpoint string, e *echo.Echo) {
-	graphqlHandler := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: &resolvers.Resolver{Logger: h.logger}}))
+// InstallForEcho serves the GraphQL API at each of paths.
+func (h *GraphQLHandler) InstallForEcho(e *echo.Echo, paths ...string) {
+	graphqlHandler := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: &resolvers.Resolver{
+			Logger:         h.logger,
+			UpstreamModels: h.upstreamModels,
+			Gateway:        h.gateway,
+			UsageLedger:    h.usageLedger,
+		},
+		Directives: generated.DirectiveRoot{
+			Auth: h.auth,
+		},
+	}))
 
 	// As documentation of Subscriptions — gqlgen https://gqlgen.com/recipes/subscriptions/
 	// has stated, websocket transport is needed for subscriptions.
 	//
 	// But it is possible for future implementation to use other transport types. (e.g. SSE)
-	graphqlHandler.AddTransport(&transport.Websocket{
+	//
+	// The transports are matched in order, the websocket transport is added
+	// first so that it is the one handling the upgrade requests.
+	graphqlHandler.AddTransport(transport.Websocket{
 		Upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true
@@ -49,15 +85,30 @@ func (h *GraphQLHandler) InstallForEcho(endpoint string, e *echo.Echo) {
 			ReadBufferSize:  1024, //nolint:mnd
 			WriteBufferSize: 1024, //nolint:mnd
 		},
+		InitFunc:              websocketInit,
+		KeepAlivePingInterval: 10 * time.Second, //nolint:mnd
+	})
+	graphqlHandler.AddTransport(transport.Options{})
+	graphqlHandler.AddTransport(transport.GET{})
+	graphqlHandler.AddTransport(transport.POST{})
+	graphqlHandler.AddTransport(transport.MultipartForm{})
+
+	graphqlHandler.SetQueryCache(lru.New[*ast.QueryDocument](1000)) //nolint:mnd
+
+	graphqlHandler.Use(extension.Introspection{})
+	graphqlHandler.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](100), //nolint:mnd
 	})
 
 	// As documentation of Subscriptions — gqlgen https://gqlgen.com/recipes/subscriptions/
 	// has stated, POST only handler will not gonna work for subscriptions.
 	// Therefore e.Any is used to handle all request methods.
-	e.Any(endpoint, func(c echo.Context) error {
-		graphqlHandler.ServeHTTP(c.Response(), c.Request())
-		return nil
-	})
+	for _, path := range paths {
+		e.Any(path, func(c echo.Context) error {
+			graphqlHandler.ServeHTTP(c.Response(), c.Request())
+			return nil
+		})
+	}
 }
 
 func (h *GraphQLHandler) InstallPlaygroundForEcho(endpoint string, playgroundEndpoint string, e *echo.Echo) {
@@ -2,18 +2,19 @@ package resolvers
 
 // This file will be automatically regenerated based on the schema, any resolver implementations
 // will be copied through when generating and any unknown code will be moved to the end.
-// Code generated by github.com/99designs/gqlgen version v0.17.49
+// Code generated by github.com/99designs/gqlgen version v0.17.61
 
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
 
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/lingticio/llmg/internal/graph/openai/generated"
 	"github.com/lingticio/llmg/internal/graph/openai/model"
-	"github.com/lingticio/llmg/internal/graph/server/middlewares"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/usage"
+	"github.com/lingticio/llmg/pkg/util/pagination"
 	"github.com/samber/lo"
 	"github.com/sashabaranov/go-openai"
 	"github.com/vektah/gqlparser/v2/gqlerror"
@@ -22,27 +23,14 @@ import (
 
 // CreateChatCompletion is the resolver for the createChatCompletion field.
 func (r *mutationResolver) CreateChatCompletion(ctx context.Context, input model.CreateChatCompletionInput) (*model.ChatCompletionResult, error) {
-	apiKey := middlewares.APIKeyFromContext(ctx)
-	if apiKey == "" {
-		if input.APIKey != nil {
-			apiKey = *input.APIKey
-		}
-	}
-
-	config := openai.DefaultConfig(apiKey)
-	baseURL := middlewares.XBaseURLFromContext(ctx)
-	if baseURL != "" {
-		config.BaseURL = baseURL
-	}
-	if input.BaseURL != nil {
-		config.BaseURL = *input.BaseURL
+	endpoint, err := endpointFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	client := openai.NewClientWithConfig(config)
-
-	openaiResponse, err := client.CreateChatCompletion(ctx, inputToRequest(input, false))
+	openaiResponse, err := r.Gateway.CreateChatCompletion(ctx, endpoint, inputToRequest(input, false))
 	if err != nil {
-		return nil, err
+		return nil, upstreams.AsAPIError(err).AsGraphQLError()
 	}
 
 	response := &model.ChatCompletionResult{
@@ -78,27 +66,74 @@ func (r *mutationResolver) CreateChatCompletion(ctx context.Context, input model
 
 // Models is the resolver for the models field.
 func (r *queryResolver) Models(ctx context.Context, first *int, after *string, last *int, before *string) (*model.ModelConnection, error) {
-	panic(fmt.Errorf("not implemented: Models - models"))
+	endpoint, err := endpointFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := r.UpstreamModels.List(ctx, endpoint)
+	if err != nil {
+		return nil, upstreams.AsAPIError(err).AsGraphQLError()
+	}
+
+	edges, pageInfo, err := pagination.Paginate(models, func(item upstreams.Model) string {
+		return item.ID
+	}, pagination.Params{
+		First:  first,
+		After:  after,
+		Last:   last,
+		Before: before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapModelConnection(edges, pageInfo), nil
 }
 
-// CreateChatCompletionStream is the resolver for the createChatCompletionStream field.
-func (r *subscriptionResolver) CreateChatCompletionStream(ctx context.Context, input model.CreateChatCompletionStreamInput) (<-chan *model.ChatCompletionStreamResult, error) {
-	config := openai.DefaultConfig(input.APIKey)
-	if input.BaseURL != nil {
-		config.BaseURL = *input.BaseURL
+// Usage is the resolver for the usage field.
+func (r *queryResolver) Usage(ctx context.Context, input model.UsageQueryInput, first *int, after *string, last *int, before *string) (*model.UsageBucketConnection, error) {
+	endpoint, err := endpointFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	client := openai.NewClientWithConfig(config)
+	buckets, err := r.UsageLedger.QueryOf(ctx, endpoint, usageInputToQuery(input))
+	if err != nil {
+		return nil, upstreams.AsAPIError(err).AsGraphQLError()
+	}
 
-	ch := make(chan *model.ChatCompletionStreamResult)
+	edges, pageInfo, err := pagination.Paginate(buckets, usage.Bucket.ID, pagination.Params{
+		First:  first,
+		After:  after,
+		Last:   last,
+		Before: before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapUsageBucketConnection(edges, pageInfo), nil
+}
 
-	stream, err := client.CreateChatCompletionStream(ctx, streamInputToRequest(input, true))
+// CreateChatCompletionStream is the resolver for the createChatCompletionStream field.
+func (r *subscriptionResolver) CreateChatCompletionStream(ctx context.Context, input model.CreateChatCompletionStreamInput) (<-chan *model.ChatCompletionStreamResult, error) {
+	endpoint, err := endpointFromContext(ctx)
 	if err != nil {
-		r.Logger.Error("failed to create chat completion stream", zap.Error(err))
 		return nil, err
 	}
 
+	stream, err := r.Gateway.CreateChatCompletionStream(ctx, endpoint, streamInputToRequest(input, true))
+	if err != nil {
+		r.Logger.Error("failed to create chat completion stream", zap.Error(err))
+		return nil, upstreams.AsAPIError(err).AsGraphQLError()
+	}
+
+	ch := make(chan *model.ChatCompletionStreamResult)
+
 	go func() {
+		defer stream.Close()
+
 		for {
 			response, err := stream.Recv()
 			if errors.Is(err, io.EOF) {
@@ -1,14 +1,34 @@
 package resolvers
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
+	"github.com/lingticio/llmg/internal/endpoints"
 	"github.com/lingticio/llmg/internal/graph/openai/model"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/usage"
+	"github.com/lingticio/llmg/pkg/util/pagination"
 	"github.com/nekomeowww/fo"
 	"github.com/samber/lo"
 	"github.com/sashabaranov/go-openai"
 )
 
+// endpointFromContext returns the endpoint the request is authenticated as by
+// the @auth directive.
+func endpointFromContext(ctx context.Context) (*authstorage.Endpoint, error) {
+	endpoint := endpoints.EndpointFromContext(ctx)
+	if endpoint == nil {
+		return nil, apierrors.NewErrUnauthorized().WithDetail("the request is not authenticated").AsGraphQLError()
+	}
+
+	return endpoint, nil
+}
+
 func inputToRequest(input model.CreateChatCompletionInput, stream bool) openai.ChatCompletionRequest {
 	request := openai.ChatCompletionRequest{
 		Model:  input.Model,
@@ -77,7 +97,7 @@ func inputToRequest(input model.CreateChatCompletionInput, stream bool) openai.C
 		request.MaxTokens = *input.MaxTokens
 	}
 	if input.Temperature != nil {
-		request.Temperature = float32(*input.Temperature)
+		request.Temperature = exactcache.Temperature(float32(*input.Temperature))
 	}
 	if input.TopP != nil {
 		request.TopP = float32(*input.TopP)
@@ -241,7 +261,7 @@ func streamInputToRequest(input model.CreateChatCompletionStreamInput, stream bo
 		request.MaxTokens = *input.MaxTokens
 	}
 	if input.Temperature != nil {
-		request.Temperature = float32(*input.Temperature)
+		request.Temperature = exactcache.Temperature(float32(*input.Temperature))
 	}
 	if input.TopP != nil {
 		request.TopP = float32(*input.TopP)
@@ -483,3 +503,91 @@ func mapDelta(message openai.ChatCompletionStreamChoiceDelta) *model.ChatComplet
 
 	return delta
 }
+
+func mapModelConnection(edges []pagination.Edge[upstreams.Model], pageInfo pagination.PageInfo) *model.ModelConnection {
+	return &model.ModelConnection{
+		Edges: lo.Map(edges, func(item pagination.Edge[upstreams.Model], index int) *model.ModelEdge {
+			return &model.ModelEdge{
+				Node: &model.Model{
+					Name:    item.Node.ID,
+					Created: int(item.Node.Created),
+					OwnedBy: item.Node.OwnedBy,
+				},
+				Cursor: item.Cursor,
+			}
+		}),
+		PageInfo: mapPageInfo(pageInfo),
+	}
+}
+
+func mapPageInfo(pageInfo pagination.PageInfo) *model.PageInfo {
+	return &model.PageInfo{
+		StartCursor:     pageInfo.StartCursor,
+		EndCursor:       pageInfo.EndCursor,
+		HasNextPage:     pageInfo.HasNextPage,
+		HasPreviousPage: pageInfo.HasPreviousPage,
+	}
+}
+
+func usageInputToQuery(input model.UsageQueryInput) usage.Query {
+	query := usage.Query{
+		Filter: usage.Filter{
+			TenantID:   lo.FromPtr(input.TenantID),
+			TeamID:     lo.FromPtr(input.TeamID),
+			GroupID:    lo.FromPtr(input.GroupID),
+			EndpointID: lo.FromPtr(input.EndpointID),
+			Model:      lo.FromPtr(input.Model),
+			User:       lo.FromPtr(input.User),
+		},
+		GroupBy: lo.Map(input.GroupBy, func(item string, _ int) usage.Dimension {
+			return usage.Dimension(item)
+		}),
+	}
+	if input.StartTime != nil {
+		query.Since = time.Unix(int64(*input.StartTime), 0)
+	}
+	if input.EndTime != nil {
+		query.Until = time.Unix(int64(*input.EndTime), 0)
+	}
+	if input.BucketWidth != nil {
+		query.Width = time.Duration(*input.BucketWidth) * time.Second
+	}
+
+	return query
+}
+
+func mapUsageBucket(bucket usage.Bucket) *model.UsageBucket {
+	mapped := &model.UsageBucket{
+		Group: lo.MapEntries(bucket.Group, func(key usage.Dimension, value string) (string, any) {
+			return string(key), value
+		}),
+		Requests:         int(bucket.Requests),
+		FailedRequests:   int(bucket.FailedRequests),
+		PromptTokens:     int(bucket.PromptTokens),
+		CompletionTokens: int(bucket.CompletionTokens),
+		CachedTokens:     int(bucket.CachedTokens),
+		Cost:             bucket.Cost,
+		AverageLatencyMs: int(bucket.AverageLatency().Milliseconds()),
+		CacheHits:        int(bucket.CacheHits),
+	}
+	if !bucket.Start.IsZero() {
+		mapped.StartTime = lo.ToPtr(int(bucket.Start.Unix()))
+	}
+	if !bucket.End.IsZero() {
+		mapped.EndTime = lo.ToPtr(int(bucket.End.Unix()))
+	}
+
+	return mapped
+}
+
+func mapUsageBucketConnection(edges []pagination.Edge[usage.Bucket], pageInfo pagination.PageInfo) *model.UsageBucketConnection {
+	return &model.UsageBucketConnection{
+		Edges: lo.Map(edges, func(item pagination.Edge[usage.Bucket], index int) *model.UsageBucketEdge {
+			return &model.UsageBucketEdge{
+				Node:   mapUsageBucket(item.Node),
+				Cursor: item.Cursor,
+			}
+		}),
+		PageInfo: mapPageInfo(pageInfo),
+	}
+}
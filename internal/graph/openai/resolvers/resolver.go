@@ -1,11 +1,17 @@
 package resolvers
 
-import "github.com/nekomeowww/xo/logger"
+import (
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/nekomeowww/xo/logger"
+)
 
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	Logger *logger.Logger
+	Logger         *logger.Logger
+	UpstreamModels *upstreams.Models
+	Gateway        *upstreams.Gateway
+	UsageLedger    *upstreams.UsageLedger
 }
@@ -2,16 +2,22 @@ package middlewares
 
 import (
 	"context"
+	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/util/headers"
 )
 
 type ContextKey string
 
 const (
 	ContextKeyHeaderAuthorizationAPIKey ContextKey = "header-authorization-api-key"
-	ContextKeyHeaderXBaseURL            ContextKey = "header-x-base-url"
+	ContextKeyPathEndpointAlias         ContextKey = "path-endpoint-alias"
 )
 
 func HeaderAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
@@ -22,28 +28,91 @@ func HeaderAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
 			apiKey = strings.TrimPrefix(auth, "Bearer ")
 		}
 
-		c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), ContextKeyHeaderAuthorizationAPIKey, apiKey)))
+		c.SetRequest(c.Request().WithContext(WithAPIKey(c.Request().Context(), apiKey)))
 
 		return next(c)
 	}
 }
 
+// WithAPIKey attaches apiKey to ctx, as if it was passed in the headers.
+func WithAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, ContextKeyHeaderAuthorizationAPIKey, apiKey)
+}
+
 func APIKeyFromContext(ctx context.Context) string {
 	apiKey, _ := ctx.Value(ContextKeyHeaderAuthorizationAPIKey).(string)
 	return apiKey
 }
 
-func HeaderXBaseURL(next echo.HandlerFunc) echo.HandlerFunc {
+// PathEndpointAlias reads the alias of the endpoint addressed in the path of
+// the request, e.g. /v1/e/{alias}/graphql, if any.
+func PathEndpointAlias(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		baseURL := c.Request().Header.Get("X-Base-Url")
+		alias := c.Param("alias")
+		if alias != "" {
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), ContextKeyPathEndpointAlias, alias)))
+		}
+
+		return next(c)
+	}
+}
 
-		c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), ContextKeyHeaderXBaseURL, baseURL)))
+// EndpointAliasFromContext returns the alias read by PathEndpointAlias, it
+// is empty when the request addresses the endpoint of its API key.
+func EndpointAliasFromContext(ctx context.Context) string {
+	alias, _ := ctx.Value(ContextKeyPathEndpointAlias).(string)
+	return alias
+}
+
+// HeaderPriority attaches the priority class requested by the
+// x-llmg-priority header, if any, to the context of the request.
+func HeaderPriority(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		priority := c.Request().Header.Get(scheduling.HeaderPriority)
+		if priority != "" {
+			c.SetRequest(c.Request().WithContext(scheduling.WithRequestedClass(c.Request().Context(), priority)))
+		}
 
 		return next(c)
 	}
 }
 
-func XBaseURLFromContext(ctx context.Context) string {
-	baseURL, _ := ctx.Value(ContextKeyHeaderXBaseURL).(string)
-	return baseURL
+// HeaderCacheControl attaches the Cache-Control header, if any, to the
+// context of the request.
+func HeaderCacheControl(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values := c.Request().Header.Values(exactcache.HeaderCacheControl)
+		if len(values) > 0 {
+			c.SetRequest(c.Request().WithContext(exactcache.WithControl(c.Request().Context(), exactcache.ParseControl(values...))))
+		}
+
+		return next(c)
+	}
+}
+
+// ReportedHeaders responds with the headers reported while resolving the
+// mutations, e.g. the x-ratelimit-* and Retry-After headers of the rate
+// limits, as long as the response is not committed yet, which is never the
+// case of subscriptions served over WebSocket.
+func ReportedHeaders(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var mutex sync.Mutex
+
+		ctx := headers.WithReporter(c.Request().Context(), func(header http.Header) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if c.Response().Committed {
+				return
+			}
+
+			for key, values := range header {
+				c.Response().Header()[key] = values
+			}
+		})
+
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		return next(c)
+	}
 }
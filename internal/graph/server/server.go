@@ -13,6 +13,7 @@ import (
 	"github.com/lingticio/llmg/internal/configs"
 	"github.com/lingticio/llmg/internal/graph/openai"
 	"github.com/lingticio/llmg/internal/graph/server/middlewares"
+	"github.com/lingticio/llmg/pkg/util/headers"
 	"github.com/nekomeowww/xo/logger"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -43,18 +44,23 @@ func NewServer() func(params NewServerParams) *Server {
 	return func(params NewServerParams) *Server {
 		e := echo.New()
 
-		e.Use(middlewares.HeaderXBaseURL)
 		e.Use(middlewares.HeaderAPIKey)
+		e.Use(middlewares.PathEndpointAlias)
+		e.Use(middlewares.HeaderPriority)
+		e.Use(middlewares.HeaderCacheControl)
+		e.Use(middlewares.ReportedHeaders)
 		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 			AllowOriginFunc: func(origin string) (bool, error) {
 				return true, nil
 			},
-			AllowHeaders: []string{"Origin", "Content-Length", "Content-Type"},
-			AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "HEAD"},
-			MaxAge:       60 * 60 * 24 * 7, //nolint:mnd
+			AllowHeaders:  []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Api-Key", "X-Llmg-Priority", "Cache-Control"},
+			AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "HEAD"},
+			ExposeHeaders: headers.Registered(),
+			MaxAge:        60 * 60 * 24 * 7, //nolint:mnd
 		}))
 
-		params.OpenAIHandler.InstallForEcho("/v1/openai/query", e)
+		// The endpoint of the API key may be addressed by alias in the path.
+		params.OpenAIHandler.InstallForEcho(e, "/v1/openai/query", "/v1/e/:alias/graphql")
 		params.OpenAIHandler.InstallPlaygroundForEcho("/v1/openai/query", "/v1/openai/", e)
 
 		for _, v := range e.Routes() {
@@ -21,6 +21,7 @@ import (
 	"github.com/lingticio/llmg/internal/grpc/servers/interceptors"
 	"github.com/lingticio/llmg/internal/grpc/servers/middlewares"
 	grpcpkg "github.com/lingticio/llmg/pkg/util/grpc"
+	"github.com/lingticio/llmg/pkg/util/headers"
 )
 
 type NewGatewayServerParams struct {
@@ -47,6 +48,9 @@ func NewGatewayServer() func(params NewGatewayServerParams) (*GatewayServer, err
 		e := echo.New()
 
 		e.Use(middlewares.ResponseLog(params.Logger))
+		e.Use(middlewares.ReportedHeaders)
+		e.Use(middlewares.RequestedPriority)
+		e.Use(middlewares.RequestedCacheControl)
 		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 			AllowOrigins: []string{
 				"http://localhost:3000",
@@ -57,6 +61,7 @@ func NewGatewayServer() func(params NewGatewayServerParams) (*GatewayServer, err
 				echo.HeaderAccept,
 				echo.HeaderAuthorization,
 			},
+			ExposeHeaders: headers.Registered(),
 		}))
 		e.RouteNotFound("/*", middlewares.NotFound)
 
@@ -87,9 +92,12 @@ func NewGatewayServer() func(params NewGatewayServerParams) (*GatewayServer, err
 				gateway, err := grpcpkg.NewGateway(ctx, conn, params.Logger,
 					grpcpkg.WithServerMuxOptions(
 						runtime.WithErrorHandler(interceptors.HTTPErrorHandler(params.Logger)),
+						runtime.WithOutgoingHeaderMatcher(interceptors.OutgoingHeaderMatcher),
 						runtime.WithMetadata(interceptors.MetadataCookie()),
 						runtime.WithMetadata(interceptors.MetadataAuthorization()),
 						runtime.WithMetadata(interceptors.MetadataRequestPath()),
+						runtime.WithMetadata(interceptors.MetadataPriority()),
+						runtime.WithMetadata(interceptors.MetadataCacheControl()),
 					),
 					grpcpkg.WithHandlers(params.Register.HTTPHandlers...),
 				)
@@ -36,6 +36,9 @@ func NewGRPCServer() func(params NewGRPCServerParams) *GRPCServer {
 			grpc.ChainUnaryInterceptor(
 				interceptors.PanicInterceptor(params.Logger),
 			),
+			grpc.ChainStreamInterceptor(
+				interceptors.PanicStreamInterceptor(params.Logger),
+			),
 		)
 
 		params.Lifecycle.Append(fx.Hook{
@@ -0,0 +1,31 @@
+package interceptors
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/lingticio/llmg/pkg/exactcache"
+)
+
+// MetadataCacheControl forwards the Cache-Control header of the requests of
+// the HTTP gateway as metadata.
+func MetadataCacheControl() func(context.Context, *http.Request) metadata.MD {
+	return func(ctx context.Context, r *http.Request) metadata.MD {
+		md := metadata.MD{}
+
+		values := r.Header.Values(exactcache.HeaderCacheControl)
+		if len(values) > 0 {
+			md.Append(exactcache.HeaderCacheControl, values...)
+		}
+
+		return md
+	}
+}
+
+// CacheControlFromMetadata parses the cache-control metadata, which allows
+// everything when missing.
+func CacheControlFromMetadata(md metadata.MD) exactcache.Control {
+	return exactcache.ParseControl(md.Get(exactcache.HeaderCacheControl)...)
+}
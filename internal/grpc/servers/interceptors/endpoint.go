@@ -0,0 +1,171 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/nekomeowww/xo/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+	"github.com/lingticio/llmg/apis/llmgapi/v1/openai"
+	"github.com/lingticio/llmg/apis/llmgapi/v1/usage"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/scheduling"
+)
+
+// APIKeyFromMetadata reads the API key issued by the gateway, either from
+// x-api-key, or as a Bearer token from the Authorization header of gRPC
+// clients or of the requests forwarded by the HTTP gateway.
+func APIKeyFromMetadata(md metadata.MD) (string, error) {
+	values := md.Get("x-api-key")
+	if len(values) > 0 && values[0] != "" {
+		return values[0], nil
+	}
+
+	values = md.Get("authorization")
+	if len(values) > 0 && values[0] != "" {
+		return BearerFromAuthorization(values[0])
+	}
+
+	authorization, err := AuthorizationFromMetadata(md)
+	if err != nil {
+		return "", err
+	}
+
+	return BearerFromAuthorization(authorization)
+}
+
+// EndpointAliasFromMetadata reads the alias of the endpoint the request
+// addresses from x-llmg-endpoint, it is empty when the request addresses the
+// endpoint of its API key.
+func EndpointAliasFromMetadata(md metadata.MD) string {
+	values := md.Get("x-llmg-endpoint")
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// methodScopes are the scopes API keys must be granted to call the methods,
+// methods not listed require authstorage.ScopeAdmin.
+var methodScopes = map[string]authstorage.Scope{
+	openai.OpenAIService_CreateChatCompletion_FullMethodName:       authstorage.ScopeChat,
+	openai.OpenAIService_CreateChatCompletionStream_FullMethodName: authstorage.ScopeChat,
+	openai.OpenAIService_ListModels_FullMethodName:                 authstorage.ScopeModels,
+	usage.UsageService_QueryUsage_FullMethodName:                   authstorage.ScopeUsage,
+}
+
+func scopeOf(fullMethod string) authstorage.Scope {
+	scope, ok := methodScopes[fullMethod]
+	if !ok {
+		return authstorage.ScopeAdmin
+	}
+
+	return scope
+}
+
+// isAdminMethod reports whether the method belongs to the admin API, which
+// the admin API key of the configuration may call without an endpoint.
+func isAdminMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+admin.AdminService_ServiceDesc.ServiceName+"/")
+}
+
+func authenticateEndpoint(ctx context.Context, logger *logger.Logger, authenticator *endpoints.Authenticator, adminAPIKey string, fullMethod string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, apierrors.NewErrUnauthorized().WithDetail("missing API key").AsStatus()
+	}
+
+	apiKey, err := APIKeyFromMetadata(md)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == "" {
+		return nil, apierrors.NewErrUnauthorized().WithDetail("missing API key in x-api-key or Authorization").AsStatus()
+	}
+	if adminAPIKey != "" && isAdminMethod(fullMethod) && subtle.ConstantTimeCompare([]byte(apiKey), []byte(adminAPIKey)) == 1 {
+		return ctx, nil
+	}
+
+	endpoint, err := authenticator.AuthenticateAlias(ctx, apiKey, EndpointAliasFromMetadata(md))
+	if err == nil {
+		err = endpoints.RequireScope(endpoint, scopeOf(fullMethod))
+	}
+	if err != nil {
+		if !endpoints.IsUnauthenticated(err) {
+			logger.Error("failed to authenticate endpoint", zap.Error(err))
+		}
+
+		return nil, endpoints.AsAPIError(err).AsStatus()
+	}
+
+	ctx = endpoints.WithEndpoint(ctx, endpoint)
+	ctx = scheduling.WithRequestedClass(ctx, PriorityFromMetadata(md))
+	ctx = exactcache.WithControl(ctx, CacheControlFromMetadata(md))
+
+	return ctx, nil
+}
+
+// skipsEndpointAuthentication reports whether the method is served without
+// an API key, which is the case of the reflection and health services.
+func skipsEndpointAuthentication(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.reflection.") || strings.HasPrefix(fullMethod, "/grpc.health.")
+}
+
+// EndpointUnaryInterceptor authenticates the API key, or JWT, of the request,
+// and attaches the resolved endpoint, or the endpoint addressed by the
+// x-llmg-endpoint metadata, to the context, where it is read with
+// endpoints.EndpointFromContext, along with the priority class requested by
+// the x-llmg-priority metadata, and the cache-control metadata. The admin API is also served to
+// adminAPIKey when it is not empty, in which case no endpoint is attached.
+// The headers reported while serving the request, e.g. the rate limits it
+// is checked against, are sent as the header metadata of the response.
+func EndpointUnaryInterceptor(logger *logger.Logger, authenticator *endpoints.Authenticator, adminAPIKey string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if skipsEndpointAuthentication(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticateEndpoint(ctx, logger, authenticator, adminAPIKey, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(withUnaryHeaderReporter(ctx), req)
+	}
+}
+
+type endpointServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *endpointServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// EndpointStreamInterceptor is the streaming counterpart of
+// EndpointUnaryInterceptor.
+func EndpointStreamInterceptor(logger *logger.Logger, authenticator *endpoints.Authenticator, adminAPIKey string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skipsEndpointAuthentication(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticateEndpoint(ss.Context(), logger, authenticator, adminAPIKey, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &endpointServerStream{ServerStream: ss, ctx: withStreamHeaderReporter(ctx, ss)})
+	}
+}
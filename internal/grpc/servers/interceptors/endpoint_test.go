@@ -0,0 +1,228 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+	"github.com/lingticio/llmg/apis/llmgapi/v1/openai"
+	"github.com/lingticio/llmg/apis/llmgapi/v1/usage"
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/endpoints"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	typesmetadata "github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+const testAdminAPIKey = "sk-configured-admin"
+
+// testAPIKeys are the keys issued by newTestAuthenticator, by the scopes
+// they are granted.
+var testAPIKeys = map[string][]authstorage.Scope{
+	"sk-default": nil,
+	"sk-chat":    {authstorage.ScopeChat},
+	"sk-models":  {authstorage.ScopeModels},
+	"sk-usage":   {authstorage.ScopeUsage},
+	"sk-admin":   {authstorage.ScopeAdmin},
+}
+
+func newTestAuthenticator(t *testing.T) (*logger.Logger, *endpoints.Authenticator) {
+	t.Helper()
+
+	log, err := logger.NewLogger(logger.WithLevel(zapcore.FatalLevel))
+	require.NoError(t, err)
+
+	endpointConfigs := make([]configs.Endpoint, 0, len(testAPIKeys))
+
+	for key, scopes := range testAPIKeys {
+		apiKey := configs.EndpointAPIKey{Key: key}
+		for _, scope := range scopes {
+			apiKey.Scopes = append(apiKey.Scopes, string(scope))
+		}
+
+		endpointConfigs = append(endpointConfigs, configs.Endpoint{ID: key, APIKeys: []configs.EndpointAPIKey{apiKey}})
+	}
+
+	routes := &configs.Routes{
+		Tenants: []configs.Tenant{
+			{
+				ID: "tenant",
+				Teams: []configs.Team{
+					{
+						ID:     "team",
+						Groups: []configs.Group{{ID: "group", Endpoints: endpointConfigs}},
+					},
+				},
+				Upstream: &typesmetadata.UpstreamSingleOrMultiple{
+					Upstream: &typesmetadata.Upstream{
+						OpenAI: typesmetadata.UpstreamOpenAI{BaseURL: "https://upstream.example.com/v1"},
+					},
+				},
+			},
+		},
+	}
+
+	authenticator, err := endpoints.NewAuthenticator()(endpoints.NewAuthenticatorParams{
+		Lifecycle:        fxtest.NewLifecycle(t),
+		Logger:           log,
+		Config:           &configs.Config{},
+		EndpointProvider: authstorage.NewConfigEndpointProvider()(routes),
+	})
+	require.NoError(t, err)
+
+	return log, authenticator
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+// testMethod is a method of the services served by the v1 gRPC server.
+type testMethod struct {
+	fullMethod string
+	stream     bool
+}
+
+func testMethodsOf(descs ...grpc.ServiceDesc) []testMethod {
+	var methods []testMethod
+
+	for _, desc := range descs {
+		for _, method := range desc.Methods {
+			methods = append(methods, testMethod{fullMethod: "/" + desc.ServiceName + "/" + method.MethodName})
+		}
+		for _, stream := range desc.Streams {
+			methods = append(methods, testMethod{fullMethod: "/" + desc.ServiceName + "/" + stream.StreamName, stream: true})
+		}
+	}
+
+	return methods
+}
+
+func TestScopeOf(t *testing.T) {
+	t.Parallel()
+
+	// The scopes of the methods not listed here are admin.
+	scopes := map[string]authstorage.Scope{
+		openai.OpenAIService_CreateChatCompletion_FullMethodName:       authstorage.ScopeChat,
+		openai.OpenAIService_CreateChatCompletionStream_FullMethodName: authstorage.ScopeChat,
+		openai.OpenAIService_ListModels_FullMethodName:                 authstorage.ScopeModels,
+		usage.UsageService_QueryUsage_FullMethodName:                   authstorage.ScopeUsage,
+	}
+
+	methods := testMethodsOf(openai.OpenAIService_ServiceDesc, usage.UsageService_ServiceDesc, admin.AdminService_ServiceDesc)
+	// Methods added to the services later, and not listed yet, require admin.
+	methods = append(methods, testMethod{fullMethod: "/" + openai.OpenAIService_ServiceDesc.ServiceName + "/CreateEmbedding"})
+
+	served := map[string]bool{}
+
+	for _, method := range methods {
+		served[method.fullMethod] = true
+
+		want, ok := scopes[method.fullMethod]
+		if !ok {
+			want = authstorage.ScopeAdmin
+		}
+
+		assert.Equal(t, want, scopeOf(method.fullMethod), method.fullMethod)
+	}
+
+	for fullMethod := range methodScopes {
+		assert.True(t, served[fullMethod], "%s is not served", fullMethod)
+	}
+}
+
+func TestEndpointInterceptor_Scopes(t *testing.T) {
+	t.Parallel()
+
+	log, authenticator := newTestAuthenticator(t)
+	unary := EndpointUnaryInterceptor(log, authenticator, testAdminAPIKey)
+	stream := EndpointStreamInterceptor(log, authenticator, testAdminAPIKey)
+
+	call := func(method testMethod, apiKey string) (*authstorage.Endpoint, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+apiKey))
+
+		var endpoint *authstorage.Endpoint
+
+		if method.stream {
+			err := stream(nil, &testServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: method.fullMethod}, func(_ any, ss grpc.ServerStream) error {
+				endpoint = endpoints.EndpointFromContext(ss.Context())
+				return nil
+			})
+
+			return endpoint, err
+		}
+
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method.fullMethod}, func(ctx context.Context, _ any) (any, error) {
+			endpoint = endpoints.EndpointFromContext(ctx)
+			return nil, nil
+		})
+
+		return endpoint, err
+	}
+
+	for _, method := range testMethodsOf(openai.OpenAIService_ServiceDesc, usage.UsageService_ServiceDesc, admin.AdminService_ServiceDesc) {
+		t.Run(method.fullMethod, func(t *testing.T) {
+			t.Parallel()
+
+			scope := scopeOf(method.fullMethod)
+
+			for apiKey, scopes := range testAPIKeys {
+				endpoint, err := call(method, apiKey)
+				if (authstorage.APIKeyOptions{Scopes: scopes}).HasScope(scope) {
+					require.NoError(t, err, apiKey)
+					require.NotNil(t, endpoint, apiKey)
+					assert.Equal(t, apiKey, endpoint.ID)
+				} else {
+					assert.Equal(t, codes.PermissionDenied, status.Code(err), apiKey)
+				}
+			}
+
+			// The admin API key of the configuration is only an API key of
+			// the admin API, where no endpoint is attached.
+			endpoint, err := call(method, testAdminAPIKey)
+			if isAdminMethod(method.fullMethod) {
+				require.NoError(t, err)
+				assert.Nil(t, endpoint)
+			} else {
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			}
+
+			_, err = call(method, "sk-unknown")
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+}
+
+func TestEndpointInterceptor_WithoutAdminAPIKey(t *testing.T) {
+	t.Parallel()
+
+	log, authenticator := newTestAuthenticator(t)
+	unary := EndpointUnaryInterceptor(log, authenticator, "")
+
+	// Without the admin API key configured, an empty API key is not the
+	// admin API key.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", ""))
+	_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: admin.AdminService_ListTenants_FullMethodName}, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -94,6 +94,7 @@ func HTTPErrorHandler(logger *logger.Logger) func(ctx context.Context, _ *runtim
 
 			b, _ := json.Marshal(errResp)
 
+			forwardReportedHeaders(ctx, writer)
 			writer.Header().Set("Content-Type", "application/json")
 			writer.WriteHeader(errResp.HTTPStatus())
 
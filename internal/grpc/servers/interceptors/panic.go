@@ -24,3 +24,18 @@ func PanicInterceptor(logger *logger.Logger) grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// PanicStreamInterceptor is the streaming counterpart of PanicInterceptor.
+func PanicStreamInterceptor(logger *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) { //nolint:nonamedreturns
+		defer func() {
+			r := recover()
+			if r != nil {
+				logger.Error("panicked", zap.Any("err", r), zap.Stack(string(debug.Stack())))
+				err = apierrors.NewErrInternal().AsStatus()
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,32 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPanicInterceptor(t *testing.T) {
+	t.Parallel()
+
+	log, err := logger.NewLogger(logger.WithLevel(zapcore.FatalLevel))
+	require.NoError(t, err)
+
+	resp, err := PanicInterceptor(log)(context.Background(), nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+		panic("unary")
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	err = PanicStreamInterceptor(log)(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(any, grpc.ServerStream) error {
+		panic("stream")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,36 @@
+package interceptors
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/lingticio/llmg/pkg/scheduling"
+)
+
+// MetadataPriority forwards the x-llmg-priority header of the requests of
+// the HTTP gateway as metadata.
+func MetadataPriority() func(context.Context, *http.Request) metadata.MD {
+	return func(ctx context.Context, r *http.Request) metadata.MD {
+		md := metadata.MD{}
+
+		priority := r.Header.Get(scheduling.HeaderPriority)
+		if priority != "" {
+			md.Append(scheduling.HeaderPriority, priority)
+		}
+
+		return md
+	}
+}
+
+// PriorityFromMetadata reads the priority class requested by
+// x-llmg-priority, it is empty when the request does not request one.
+func PriorityFromMetadata(md metadata.MD) string {
+	values := md.Get(scheduling.HeaderPriority)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
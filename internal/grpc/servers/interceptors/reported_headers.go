@@ -0,0 +1,79 @@
+package interceptors
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+// reportedMetadata converts the headers reported while serving the request
+// into gRPC metadata.
+func reportedMetadata(header http.Header) metadata.MD {
+	md := metadata.MD{}
+
+	for key, values := range header {
+		md.Append(strings.ToLower(key), values...)
+	}
+
+	return md
+}
+
+// withUnaryHeaderReporter reports the headers reported while serving the
+// request, e.g. the x-ratelimit-* headers, in the header metadata of the
+// response.
+func withUnaryHeaderReporter(ctx context.Context) context.Context {
+	return headers.WithReporter(ctx, func(header http.Header) {
+		_ = grpc.SetHeader(ctx, reportedMetadata(header))
+	})
+}
+
+// withStreamHeaderReporter is withUnaryHeaderReporter for streams, whose
+// headers are only sent along with the first message.
+func withStreamHeaderReporter(ctx context.Context, ss grpc.ServerStream) context.Context {
+	return headers.WithReporter(ctx, func(header http.Header) {
+		_ = ss.SetHeader(reportedMetadata(header))
+	})
+}
+
+// isReportedHeader reports whether the metadata of key is one of the
+// headers reported while serving requests.
+func isReportedHeader(key string) bool {
+	return headers.IsRegistered(key)
+}
+
+// OutgoingHeaderMatcher forwards the reported metadata, e.g. x-ratelimit-*
+// and Retry-After, as they are to the responses of the HTTP gateway, the
+// other metadata are prefixed with Grpc-Metadata- as by default.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if isReportedHeader(key) {
+		return key, true
+	}
+
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// forwardReportedHeaders writes the reported metadata of the failed call to
+// the response of the HTTP gateway, which the error handler otherwise
+// drops.
+func forwardReportedHeaders(ctx context.Context, writer http.ResponseWriter) {
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	for key, values := range md.HeaderMD {
+		if !isReportedHeader(key) {
+			continue
+		}
+
+		for _, value := range values {
+			writer.Header().Add(key, value)
+		}
+	}
+}
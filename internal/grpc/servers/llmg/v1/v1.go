@@ -6,9 +6,15 @@ import (
 	"net"
 	"net/http"
 
+	adminapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/admin"
 	openaiapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/openai"
+	usageapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/usage"
 	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/grpc/servers/interceptors"
+	"github.com/lingticio/llmg/internal/grpc/services/llmgapi/v1/admin"
 	"github.com/lingticio/llmg/internal/grpc/services/llmgapi/v1/openai"
+	"github.com/lingticio/llmg/internal/grpc/services/llmgapi/v1/usage"
 	"github.com/nekomeowww/xo/logger"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -22,7 +28,10 @@ type NewV1GRPCServerParam struct {
 	Lifecycle     fx.Lifecycle
 	Config        *configs.Config
 	Logger        *logger.Logger
+	Authenticator *endpoints.Authenticator
 	OpenAIService *openai.OpenAIService
+	AdminService  *admin.AdminService
+	UsageService  *usage.UsageService
 }
 
 type V1GRPCServer struct {
@@ -32,8 +41,19 @@ type V1GRPCServer struct {
 
 func NewV1GRPCServer() func(params NewV1GRPCServerParam) *V1GRPCServer {
 	return func(params NewV1GRPCServerParam) *V1GRPCServer {
-		grpcServer := grpc.NewServer()
+		grpcServer := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(
+				interceptors.PanicInterceptor(params.Logger),
+				interceptors.EndpointUnaryInterceptor(params.Logger, params.Authenticator, params.Config.Admin.APIKey),
+			),
+			grpc.ChainStreamInterceptor(
+				interceptors.PanicStreamInterceptor(params.Logger),
+				interceptors.EndpointStreamInterceptor(params.Logger, params.Authenticator, params.Config.Admin.APIKey),
+			),
+		)
 		openaiapiv1.RegisterOpenAIServiceServer(grpcServer, params.OpenAIService)
+		adminapiv1.RegisterAdminServiceServer(grpcServer, params.AdminService)
+		usageapiv1.RegisterUsageServiceServer(grpcServer, params.UsageService)
 		reflection.Register(grpcServer)
 
 		params.Lifecycle.Append(fx.Hook{
@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/lingticio/llmg/pkg/exactcache"
+)
+
+// RequestedCacheControl attaches the Cache-Control header, if any, to the
+// context of the request, where the gateway reads whether the answer may be
+// served from, or stored into, its caches.
+func RequestedCacheControl(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		values := c.Request().Header.Values(exactcache.HeaderCacheControl)
+		if len(values) > 0 {
+			c.SetRequest(c.Request().WithContext(exactcache.WithControl(c.Request().Context(), exactcache.ParseControl(values...))))
+		}
+
+		return next(c)
+	}
+}
@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/lingticio/llmg/pkg/scheduling"
+)
+
+// RequestedPriority attaches the priority class requested by the
+// x-llmg-priority header, if any, to the context of the request, where the
+// gateway resolves it with the priority of the endpoint.
+func RequestedPriority(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		priority := c.Request().Header.Get(scheduling.HeaderPriority)
+		if priority != "" {
+			c.SetRequest(c.Request().WithContext(scheduling.WithRequestedClass(c.Request().Context(), priority)))
+		}
+
+		return next(c)
+	}
+}
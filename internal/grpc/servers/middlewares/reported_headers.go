@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+// ReportedHeaders responds with the headers reported while serving the
+// request, e.g. the x-ratelimit-* and Retry-After headers of the rate
+// limits, as long as the response is not committed yet.
+func ReportedHeaders(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var mutex sync.Mutex
+
+		ctx := headers.WithReporter(c.Request().Context(), func(header http.Header) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if c.Response().Committed {
+				return
+			}
+
+			for key, values := range header {
+				c.Response().Header()[key] = values
+			}
+		})
+
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		return next(c)
+	}
+}
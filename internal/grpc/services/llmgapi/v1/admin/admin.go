@@ -0,0 +1,986 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/lingticio/llmg/apis/jsonapi"
+	adminapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+	"github.com/lingticio/llmg/pkg/util/pagination"
+)
+
+const endpointIDLength = 24
+
+type NewAdminServiceParams struct {
+	fx.In
+
+	Logger           *logger.Logger
+	EndpointProvider authstorage.EndpointProvider
+	Scheduler        *scheduling.Scheduler
+}
+
+// AdminService manages tenants, teams, groups, endpoints and API keys
+// through the endpoint provider, which must be administrable, i.e. the redis
+// or rds provider. Callers are authenticated as admins by the endpoint
+// interceptor, and are restricted to the tenant of their endpoint unless
+// they are the admin API key of the configuration, see scope.go.
+type AdminService struct {
+	adminapiv1.UnimplementedAdminServiceServer
+
+	logger           *logger.Logger
+	endpointProvider authstorage.EndpointProvider
+	scheduler        *scheduling.Scheduler
+}
+
+func NewAdminService() func(params NewAdminServiceParams) *AdminService {
+	return func(params NewAdminServiceParams) *AdminService {
+		return &AdminService{
+			logger:           params.Logger,
+			endpointProvider: params.EndpointProvider,
+			scheduler:        params.Scheduler,
+		}
+	}
+}
+
+func (s *AdminService) provider() (authstorage.EndpointProviderAdministrable, error) {
+	provider, ok := s.endpointProvider.(authstorage.EndpointProviderAdministrable)
+	if !ok {
+		return nil, apierrors.NewErrNotImplemented().WithDetail("the endpoints provider is read-only, configure the redis or rds provider to manage endpoints").AsStatus()
+	}
+
+	return provider, nil
+}
+
+func (s *AdminService) apiError(err error) error {
+	switch {
+	case errors.Is(err, authstorage.ErrTenantNotFound),
+		errors.Is(err, authstorage.ErrTeamNotFound),
+		errors.Is(err, authstorage.ErrGroupNotFound),
+		errors.Is(err, authstorage.ErrEndpointNotFound),
+		errors.Is(err, authstorage.ErrAPIKeyNotFound):
+		return apierrors.NewErrNotFound().WithDetail(err.Error()).AsStatus()
+	case errors.Is(err, authstorage.ErrAPIKeyExists):
+		return apierrors.NewErrAlreadyExists().WithDetail(err.Error()).AsStatus()
+	case errors.Is(err, authstorage.ErrHierarchyNotEmpty),
+		errors.Is(err, authstorage.ErrAPIKeyExpired),
+		errors.Is(err, authstorage.ErrAPIKeyRevoked):
+		return apierrors.NewErrConflict().WithDetail(err.Error()).AsStatus()
+	default:
+		s.logger.Error("failed to administrate endpoints", zap.Error(err))
+
+		return apierrors.NewErrInternal().WithError(err).WithCaller().AsStatus()
+	}
+}
+
+func requireID(id string, parameter string) error {
+	if id == "" {
+		return apierrors.NewErrInvalidArgument().WithDetailf("%s is required", parameter).WithSourceParameter(parameter).AsStatus()
+	}
+
+	return nil
+}
+
+func scopesFromRequest(scopes []string) ([]authstorage.Scope, error) {
+	result := make([]authstorage.Scope, 0, len(scopes))
+
+	for _, scope := range scopes {
+		if !lo.Contains(authstorage.Scopes, authstorage.Scope(scope)) {
+			return nil, apierrors.NewErrInvalidArgument().WithDetailf("unknown scope %q", scope).WithSourceParameter("scopes").AsStatus()
+		}
+
+		result = append(result, authstorage.Scope(scope))
+	}
+
+	return result, nil
+}
+
+// paginate returns the page of items requested by req.
+func paginate[T any, R any](items []T, idOf func(T) string, req interface {
+	GetPagination() *jsonapi.PaginationRequest
+}, toResponse func(T) R) ([]R, *jsonapi.PageInfo, error) {
+	edges, pageInfo, err := pagination.Paginate(items, idOf, pagination.ParamsFromJSONAPI(req.GetPagination()))
+	if err != nil {
+		return nil, nil, apierrors.NewErrInvalidArgument().WithError(err).WithSourceParameter("pagination").AsStatus()
+	}
+
+	return lo.Map(edges, func(item pagination.Edge[T], _ int) R { return toResponse(item.Node) }), pageInfo.JSONAPI(), nil
+}
+
+func (s *AdminService) CreateTenant(ctx context.Context, req *adminapiv1.CreateTenantRequest) (*adminapiv1.Tenant, error) {
+	err := requireConfiguredAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = requireID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = provider.FindOneTenant(ctx, req.GetId())
+	if err == nil {
+		return nil, apierrors.NewErrAlreadyExists().WithDetailf("tenant %s already exists", req.GetId()).AsStatus()
+	}
+	if !errors.Is(err, authstorage.ErrTenantNotFound) {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.ConfigureOneTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return s.setTenantUpstream(ctx, provider, req.GetId(), req.GetUpstream())
+}
+
+func (s *AdminService) GetTenant(ctx context.Context, req *adminapiv1.GetTenantRequest) (*adminapiv1.Tenant, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	tenant, err := provider.FindOneTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return tenantToResponse(*tenant), nil
+}
+
+func (s *AdminService) ListTenants(ctx context.Context, req *adminapiv1.ListTenantsRequest) (*adminapiv1.ListTenantsResponse, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, err := provider.ListTenants(ctx)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	tenants = lo.Filter(tenants, func(item authstorage.TenantRecord, _ int) bool { return visibleTo(ctx, item.ID) })
+
+	data, pageInfo, err := paginate(tenants, func(item authstorage.TenantRecord) string { return item.ID }, req, tenantToResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminapiv1.ListTenantsResponse{Data: data, PageInfo: pageInfo}, nil
+}
+
+func (s *AdminService) DeleteTenant(ctx context.Context, req *adminapiv1.DeleteTenantRequest) (*emptypb.Empty, error) {
+	err := requireConfiguredAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = provider.DeleteOneTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminService) SetTenantUpstream(ctx context.Context, req *adminapiv1.SetTenantUpstreamRequest) (*adminapiv1.Tenant, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	_, err = provider.FindOneTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return s.setTenantUpstream(ctx, provider, req.GetId(), req.GetUpstream())
+}
+
+func (s *AdminService) setTenantUpstream(ctx context.Context, provider authstorage.EndpointProviderAdministrable, id string, upstream *adminapiv1.UpstreamSingleOrMultiple) (*adminapiv1.Tenant, error) {
+	err := provider.ConfigureOneUpstreamForTenant(ctx, id, upstreamSingleOrMultipleFromRequest(upstream))
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	tenant, err := provider.FindOneTenant(ctx, id)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return tenantToResponse(*tenant), nil
+}
+
+func (s *AdminService) SetTenantPolicy(ctx context.Context, req *adminapiv1.SetTenantPolicyRequest) (*adminapiv1.Tenant, error) {
+	err := requireConfiguredAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePolicy(req.GetPolicy(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = provider.FindOneTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.ConfigureOnePolicyForTenant(ctx, req.GetId(), policyFromRequest(req.GetPolicy()))
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	tenant, err := provider.FindOneTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return tenantToResponse(*tenant), nil
+}
+
+func (s *AdminService) CreateTeam(ctx context.Context, req *adminapiv1.CreateTeamRequest) (*adminapiv1.Team, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = requireID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeTenant(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	_, err = provider.FindOneTenant(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	_, err = provider.FindOneTeam(ctx, req.GetId())
+	if err == nil {
+		return nil, apierrors.NewErrAlreadyExists().WithDetailf("team %s already exists", req.GetId()).AsStatus()
+	}
+	if !errors.Is(err, authstorage.ErrTeamNotFound) {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.ConfigureOneTeam(ctx, req.GetTenantId(), req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return s.setTeamUpstream(ctx, provider, req.GetId(), req.GetUpstream())
+}
+
+func (s *AdminService) GetTeam(ctx context.Context, req *adminapiv1.GetTeamRequest) (*adminapiv1.Team, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := authorizeTeam(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return teamToResponse(*team), nil
+}
+
+func (s *AdminService) ListTeams(ctx context.Context, req *adminapiv1.ListTeamsRequest) (*adminapiv1.ListTeamsResponse, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeTenant(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	_, err = provider.FindOneTenant(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	teams, err := provider.ListTeams(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	data, pageInfo, err := paginate(teams, func(item authstorage.TeamRecord) string { return item.ID }, req, teamToResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminapiv1.ListTeamsResponse{Data: data, PageInfo: pageInfo}, nil
+}
+
+func (s *AdminService) DeleteTeam(ctx context.Context, req *adminapiv1.DeleteTeamRequest) (*emptypb.Empty, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeTeam(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.DeleteOneTeam(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminService) SetTeamUpstream(ctx context.Context, req *adminapiv1.SetTeamUpstreamRequest) (*adminapiv1.Team, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeTeam(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return s.setTeamUpstream(ctx, provider, req.GetId(), req.GetUpstream())
+}
+
+func (s *AdminService) setTeamUpstream(ctx context.Context, provider authstorage.EndpointProviderAdministrable, id string, upstream *adminapiv1.UpstreamSingleOrMultiple) (*adminapiv1.Team, error) {
+	err := provider.ConfigureOneUpstreamForTeam(ctx, id, upstreamSingleOrMultipleFromRequest(upstream))
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	team, err := provider.FindOneTeam(ctx, id)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return teamToResponse(*team), nil
+}
+
+func (s *AdminService) SetTeamPolicy(ctx context.Context, req *adminapiv1.SetTeamPolicyRequest) (*adminapiv1.Team, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePolicy(req.GetPolicy(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeTeam(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.ConfigureOnePolicyForTeam(ctx, req.GetId(), policyFromRequest(req.GetPolicy()))
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	team, err := provider.FindOneTeam(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return teamToResponse(*team), nil
+}
+
+func (s *AdminService) CreateGroup(ctx context.Context, req *adminapiv1.CreateGroupRequest) (*adminapiv1.Group, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = requireID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeTeam(ctx, provider, req.GetTeamId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	if req.GetParentGroupId() != "" {
+		parent, err := provider.FindOneGroup(ctx, req.GetParentGroupId())
+		if err != nil {
+			return nil, s.apiError(err)
+		}
+		if parent.TeamID != req.GetTeamId() {
+			return nil, apierrors.NewErrInvalidArgument().WithDetailf("group %s is not a group of team %s", parent.ID, req.GetTeamId()).WithSourceParameter("parent_group_id").AsStatus()
+		}
+	}
+
+	_, err = provider.FindOneGroup(ctx, req.GetId())
+	if err == nil {
+		return nil, apierrors.NewErrAlreadyExists().WithDetailf("group %s already exists", req.GetId()).AsStatus()
+	}
+	if !errors.Is(err, authstorage.ErrGroupNotFound) {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.ConfigureOneGroup(ctx, req.GetTeamId(), req.GetParentGroupId(), req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return s.setGroupUpstream(ctx, provider, req.GetId(), req.GetUpstream())
+}
+
+func (s *AdminService) GetGroup(ctx context.Context, req *adminapiv1.GetGroupRequest) (*adminapiv1.Group, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	group, _, err := authorizeGroup(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return groupToResponse(*group), nil
+}
+
+func (s *AdminService) ListGroups(ctx context.Context, req *adminapiv1.ListGroupsRequest) (*adminapiv1.ListGroupsResponse, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeTeam(ctx, provider, req.GetTeamId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	groups, err := provider.ListGroups(ctx, req.GetTeamId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	data, pageInfo, err := paginate(groups, func(item authstorage.GroupRecord) string { return item.ID }, req, groupToResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminapiv1.ListGroupsResponse{Data: data, PageInfo: pageInfo}, nil
+}
+
+func (s *AdminService) DeleteGroup(ctx context.Context, req *adminapiv1.DeleteGroupRequest) (*emptypb.Empty, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = authorizeGroup(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.DeleteOneGroup(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminService) SetGroupUpstream(ctx context.Context, req *adminapiv1.SetGroupUpstreamRequest) (*adminapiv1.Group, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = authorizeGroup(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return s.setGroupUpstream(ctx, provider, req.GetId(), req.GetUpstream())
+}
+
+func (s *AdminService) setGroupUpstream(ctx context.Context, provider authstorage.EndpointProviderAdministrable, id string, upstream *adminapiv1.UpstreamSingleOrMultiple) (*adminapiv1.Group, error) {
+	err := provider.ConfigureOneUpstreamForGroup(ctx, id, upstreamSingleOrMultipleFromRequest(upstream))
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	group, err := provider.FindOneGroup(ctx, id)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return groupToResponse(*group), nil
+}
+
+func (s *AdminService) SetGroupPolicy(ctx context.Context, req *adminapiv1.SetGroupPolicyRequest) (*adminapiv1.Group, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePolicy(req.GetPolicy(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = authorizeGroup(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.ConfigureOnePolicyForGroup(ctx, req.GetId(), policyFromRequest(req.GetPolicy()))
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	group, err := provider.FindOneGroup(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return groupToResponse(*group), nil
+}
+
+func (s *AdminService) CreateEndpoint(ctx context.Context, req *adminapiv1.CreateEndpointRequest) (*adminapiv1.CreateEndpointResponse, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := scopesFromRequest(req.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+
+	group, team, err := authorizeGroup(ctx, provider, req.GetGroupId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	id := lo.Ternary(req.GetId() == "", "endpoint_"+nanoid.NewWithLength(endpointIDLength), req.GetId())
+
+	_, err = provider.FindOneByID(ctx, id)
+	if err == nil {
+		return nil, apierrors.NewErrAlreadyExists().WithDetailf("endpoint %s already exists", id).AsStatus()
+	}
+	if !errors.Is(err, authstorage.ErrEndpointNotFound) {
+		return nil, s.apiError(err)
+	}
+
+	if req.GetAlias() != "" {
+		aliased, err := provider.FindOneByAlias(ctx, req.GetAlias())
+		if err != nil && !errors.Is(err, authstorage.ErrAliasNotFound) {
+			return nil, s.apiError(err)
+		}
+		if err == nil && aliased != nil {
+			return nil, apierrors.NewErrAlreadyExists().WithDetailf("alias %s is already taken", req.GetAlias()).WithSourceParameter("alias").AsStatus()
+		}
+	}
+
+	apiKey := authstorage.NewAPIKey()
+	options := authstorage.APIKeyOptions{
+		ExpiresAt: expiresAtFromRequest(req.GetExpiresAt()),
+		Scopes:    scopes,
+		Aliases:   req.GetAliases(),
+	}
+
+	err = provider.ConfigureOne(ctx, apiKey, req.GetAlias(), &authstorage.Endpoint{
+		Tenant:        metadata.Tenant{Id: team.TenantID},
+		Team:          metadata.Team{Id: team.ID},
+		Group:         metadata.Group{Id: group.ID},
+		ID:            id,
+		APIKeyOptions: options,
+	})
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	endpoint, err := s.setEndpointUpstream(ctx, provider, id, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminapiv1.CreateEndpointResponse{
+		Endpoint: endpoint,
+		ApiKey:   apiKeyToResponse(apiKey, id, options),
+	}, nil
+}
+
+func (s *AdminService) GetEndpoint(ctx context.Context, req *adminapiv1.GetEndpointRequest) (*adminapiv1.Endpoint, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := authorizeEndpoint(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return endpointToResponse(*endpoint), nil
+}
+
+func (s *AdminService) ListEndpoints(ctx context.Context, req *adminapiv1.ListEndpointsRequest) (*adminapiv1.ListEndpointsResponse, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = authorizeGroup(ctx, provider, req.GetGroupId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	endpoints, err := provider.ListEndpoints(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	data, pageInfo, err := paginate(endpoints, func(item authstorage.Endpoint) string { return item.ID }, req, endpointToResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminapiv1.ListEndpointsResponse{Data: data, PageInfo: pageInfo}, nil
+}
+
+func (s *AdminService) DeleteEndpoint(ctx context.Context, req *adminapiv1.DeleteEndpointRequest) (*emptypb.Empty, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeEndpoint(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.DeleteOneEndpoint(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminService) SetEndpointUpstream(ctx context.Context, req *adminapiv1.SetEndpointUpstreamRequest) (*adminapiv1.Endpoint, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateUpstream(req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeUpstream(ctx, req.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeEndpoint(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return s.setEndpointUpstream(ctx, provider, req.GetId(), req.GetUpstream())
+}
+
+func (s *AdminService) setEndpointUpstream(ctx context.Context, provider authstorage.EndpointProviderAdministrable, id string, upstream *adminapiv1.UpstreamSingleOrMultiple) (*adminapiv1.Endpoint, error) {
+	err := provider.ConfigureOneUpstreamForEndpoint(ctx, id, upstreamSingleOrMultipleFromRequest(upstream))
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	endpoint, err := provider.FindOneByID(ctx, id)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return endpointToResponse(*endpoint), nil
+}
+
+func (s *AdminService) SetEndpointPolicy(ctx context.Context, req *adminapiv1.SetEndpointPolicyRequest) (*adminapiv1.Endpoint, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePolicy(req.GetPolicy(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := authorizeEndpoint(ctx, provider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	policy := policyFromRequest(req.GetPolicy())
+
+	err = authorizePriority(ctx, endpoint.Policy, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = provider.ConfigureOnePolicyForEndpoint(ctx, req.GetId(), policy)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	endpoint, err = provider.FindOneByID(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return endpointToResponse(*endpoint), nil
+}
+
+func (s *AdminService) ExplainEndpointUpstream(ctx context.Context, req *adminapiv1.ExplainEndpointUpstreamRequest) (*adminapiv1.ExplainEndpointUpstreamResponse, error) {
+	provider, ok := s.endpointProvider.(authstorage.EndpointProviderUpstreamExplainable)
+	if !ok {
+		return nil, apierrors.NewErrNotImplemented().WithDetail("the endpoints provider cannot explain upstreams").AsStatus()
+	}
+
+	err := requireID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorizeEffectiveEndpoint(ctx, s.endpointProvider, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	effective, err := provider.ExplainUpstream(ctx, req.GetId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return &adminapiv1.ExplainEndpointUpstreamResponse{
+		Upstream: upstreamSingleOrMultipleToResponse(effective.Upstream),
+		Sources:  effective.Sources,
+	}, nil
+}
+
+func (s *AdminService) CreateAPIKey(ctx context.Context, req *adminapiv1.CreateAPIKeyRequest) (*adminapiv1.APIKey, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := scopesFromRequest(req.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeEndpoint(ctx, provider, req.GetEndpointId())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	apiKey := authstorage.NewAPIKey()
+	options := authstorage.APIKeyOptions{
+		ExpiresAt: expiresAtFromRequest(req.GetExpiresAt()),
+		Scopes:    scopes,
+		Aliases:   req.GetAliases(),
+	}
+
+	err = provider.AddOneAPIKey(ctx, req.GetEndpointId(), apiKey, options)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return apiKeyToResponse(apiKey, req.GetEndpointId(), options), nil
+}
+
+func (s *AdminService) RotateAPIKey(ctx context.Context, req *adminapiv1.RotateAPIKeyRequest) (*adminapiv1.APIKey, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = requireID(req.GetApiKey(), "api_key")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeAPIKey(ctx, provider, req.GetApiKey())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	apiKey := authstorage.NewAPIKey()
+
+	err = authstorage.RotateOneAPIKey(ctx, provider, req.GetApiKey(), apiKey, req.GetGracePeriod().AsDuration())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	endpoint, err := provider.FindOneByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+	if endpoint == nil {
+		return nil, s.apiError(authstorage.ErrAPIKeyNotFound)
+	}
+
+	return apiKeyToResponse(apiKey, endpoint.ID, endpoint.APIKeyOptions), nil
+}
+
+func (s *AdminService) RevokeAPIKey(ctx context.Context, req *adminapiv1.RevokeAPIKeyRequest) (*emptypb.Empty, error) {
+	provider, err := s.provider()
+	if err != nil {
+		return nil, err
+	}
+
+	err = requireID(req.GetApiKey(), "api_key")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = authorizeAPIKey(ctx, provider, req.GetApiKey())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	err = provider.RevokeOneAPIKey(ctx, req.GetApiKey())
+	if err != nil {
+		return nil, s.apiError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListSchedulerQueues lists the queues of the replica, it lists none when no
+// upstream is of constrained capacity. The queues are shared by every tenant,
+// hence are only listed to the admin API key of the configuration.
+func (s *AdminService) ListSchedulerQueues(ctx context.Context, req *adminapiv1.ListSchedulerQueuesRequest) (*adminapiv1.ListSchedulerQueuesResponse, error) {
+	err := requireConfiguredAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminapiv1.ListSchedulerQueuesResponse{
+		Queues: lo.Map(s.scheduler.Stats(), func(item scheduling.QueueStats, _ int) *adminapiv1.SchedulerQueue {
+			return schedulerQueueToResponse(item)
+		}),
+	}, nil
+}
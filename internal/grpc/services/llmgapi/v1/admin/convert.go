@@ -0,0 +1,372 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adminapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/secrets"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+func upstreamFromRequest(upstream *adminapiv1.Upstream) *metadata.Upstream {
+	if upstream == nil {
+		return nil
+	}
+
+	openai := upstream.GetOpenai()
+
+	var extraHeaders http.Header
+	if len(openai.GetExtraHeaders()) > 0 {
+		extraHeaders = make(http.Header, len(openai.GetExtraHeaders()))
+
+		for name, values := range openai.GetExtraHeaders() {
+			for _, value := range values.GetValues() {
+				extraHeaders.Add(name, value)
+			}
+		}
+	}
+
+	var weight *uint
+	if openai.Weight != nil {
+		weight = lo.ToPtr(uint(openai.GetWeight()))
+	}
+
+	return &metadata.Upstream{
+		OpenAI: metadata.UpstreamOpenAI{
+			Weight:       weight,
+			BaseURL:      openai.GetBaseUrl(),
+			APIKey:       openai.GetApiKey(),
+			ExtraHeaders: extraHeaders,
+			Compatible: metadata.UpstreamOpenAICompatible{
+				Chat: metadata.UpstreamOpenAICompatibleChat{
+					Usage:  openai.GetCompatible().GetChat().GetUsage(),
+					Stream: openai.GetCompatible().GetChat().GetStream(),
+				},
+				Models:     openai.GetCompatible().GetModels(),
+				Embeddings: openai.GetCompatible().GetEmbeddings(),
+				Images:     openai.GetCompatible().GetImages(),
+				Audio:      openai.GetCompatible().GetAudio(),
+			},
+			ModelAliases: openai.GetModelAliases(),
+		},
+	}
+}
+
+// validateUpstream rejects the API keys redacted in the responses, which are
+// sent back when upstreams read from the admin API are written back as is,
+// and the env: and file: references, which would resolve the environment
+// variables and files of the gateway, e.g. its master key, as the API keys
+// sent to upstreams chosen by admins. Only enc: references are accepted.
+func validateUpstream(upstream *adminapiv1.UpstreamSingleOrMultiple) error {
+	upstreams := append([]*adminapiv1.Upstream{upstream.GetUpstream()}, upstream.GetGroup()...)
+
+	for _, item := range upstreams {
+		apiKey := item.GetOpenai().GetApiKey()
+
+		if secrets.IsRedacted(apiKey) {
+			return apierrors.NewErrInvalidArgument().
+				WithDetail("api_key is redacted, set the API key, or its enc:ciphertext reference").
+				WithSourceParameter("upstream").
+				AsStatus()
+		}
+		if secrets.IsReference(apiKey) && !secrets.IsEncrypted(apiKey) {
+			return apierrors.NewErrInvalidArgument().
+				WithDetail("api_key only accepts enc:ciphertext references, env: and file: references are only accepted in the configuration file").
+				WithSourceParameter("upstream").
+				AsStatus()
+		}
+	}
+
+	return nil
+}
+
+// upstreamSingleOrMultipleFromRequest returns nil when upstream is not set,
+// which removes the override.
+func upstreamSingleOrMultipleFromRequest(upstream *adminapiv1.UpstreamSingleOrMultiple) *metadata.UpstreamSingleOrMultiple {
+	if upstream == nil || (upstream.GetUpstream() == nil && len(upstream.GetGroup()) == 0) {
+		return nil
+	}
+
+	return &metadata.UpstreamSingleOrMultiple{
+		Upstream: upstreamFromRequest(upstream.GetUpstream()),
+		Group:    lo.Map(upstream.GetGroup(), func(item *adminapiv1.Upstream, _ int) *metadata.Upstream { return upstreamFromRequest(item) }),
+	}
+}
+
+func upstreamToResponse(upstream *metadata.Upstream) *adminapiv1.Upstream {
+	if upstream == nil {
+		return nil
+	}
+
+	var weight *uint32
+	if upstream.OpenAI.Weight != nil {
+		weight = lo.ToPtr(uint32(*upstream.OpenAI.Weight)) //nolint:gosec
+	}
+
+	return &adminapiv1.Upstream{
+		Openai: &adminapiv1.UpstreamOpenAI{
+			Weight:  weight,
+			BaseUrl: upstream.OpenAI.BaseURL,
+			ApiKey:  secrets.Redact(upstream.OpenAI.APIKey),
+			ExtraHeaders: lo.MapValues(upstream.OpenAI.ExtraHeaders, func(values []string, _ string) *adminapiv1.HeaderValues {
+				return &adminapiv1.HeaderValues{Values: values}
+			}),
+			Compatible: &adminapiv1.UpstreamOpenAICompatible{
+				Chat: &adminapiv1.UpstreamOpenAICompatibleChat{
+					Usage:  upstream.OpenAI.Compatible.Chat.Usage,
+					Stream: upstream.OpenAI.Compatible.Chat.Stream,
+				},
+				Models:     upstream.OpenAI.Compatible.Models,
+				Embeddings: upstream.OpenAI.Compatible.Embeddings,
+				Images:     upstream.OpenAI.Compatible.Images,
+				Audio:      upstream.OpenAI.Compatible.Audio,
+			},
+			ModelAliases: upstream.OpenAI.ModelAliases,
+		},
+	}
+}
+
+func upstreamSingleOrMultipleToResponse(upstream *metadata.UpstreamSingleOrMultiple) *adminapiv1.UpstreamSingleOrMultiple {
+	if upstream == nil {
+		return nil
+	}
+
+	return &adminapiv1.UpstreamSingleOrMultiple{
+		Upstream: upstreamToResponse(upstream.Upstream),
+		Group:    lo.Map(upstream.Group, func(item *metadata.Upstream, _ int) *adminapiv1.Upstream { return upstreamToResponse(item) }),
+	}
+}
+
+// validatePolicy rejects negative limits, unknown priority classes and
+// similarity thresholds outside of [0, 1]. The priority and the caching are
+// only accepted for endpoints.
+func validatePolicy(policy *adminapiv1.Policy, endpoint bool) error {
+	invalid := func(detail string) error {
+		return apierrors.NewErrInvalidArgument().WithDetail(detail).WithSourceParameter("policy").AsStatus()
+	}
+
+	if !endpoint && (policy.GetPriority() != nil || policy.GetSemanticCache() != nil || policy.GetExactCache() != nil) {
+		return invalid("priority, semantic_cache and exact_cache only apply to endpoints")
+	}
+	if policy.GetRateLimit().GetRequestsPerMinute() < 0 || policy.GetRateLimit().GetTokensPerMinute() < 0 {
+		return invalid("rate_limit must not be negative")
+	}
+
+	for _, limit := range []*adminapiv1.BudgetLimit{policy.GetBudget().GetDaily(), policy.GetBudget().GetMonthly(), policy.GetBudget().GetLifetime()} {
+		if limit.GetHard() < 0 || limit.GetSoft() < 0 {
+			return invalid("budget must not be negative")
+		}
+	}
+
+	if policy.GetPriority().GetClass() != "" {
+		_, err := scheduling.ParseClass(policy.GetPriority().GetClass())
+		if err != nil {
+			return invalid(err.Error())
+		}
+	}
+
+	for _, class := range policy.GetPriority().GetPermitted() {
+		_, err := scheduling.ParseClass(class)
+		if err != nil {
+			return invalid(err.Error())
+		}
+	}
+
+	if threshold := policy.GetSemanticCache().GetThreshold(); threshold < 0 || threshold > 1 {
+		return invalid("semantic_cache.threshold must be between 0 and 1")
+	}
+	if policy.GetSemanticCache().GetTtl().AsDuration() < 0 || policy.GetExactCache().GetTtl().AsDuration() < 0 {
+		return invalid("ttl must not be negative")
+	}
+
+	return nil
+}
+
+func budgetLimitFromRequest(limit *adminapiv1.BudgetLimit) *configs.BudgetLimit {
+	if limit == nil {
+		return nil
+	}
+
+	return &configs.BudgetLimit{Hard: limit.GetHard(), Soft: limit.GetSoft()}
+}
+
+// policyFromRequest returns nil when policy is not set, which removes the
+// policy.
+func policyFromRequest(policy *adminapiv1.Policy) *authstorage.Policy {
+	if policy == nil {
+		return nil
+	}
+
+	result := &authstorage.Policy{}
+
+	if rateLimit := policy.GetRateLimit(); rateLimit != nil {
+		result.RateLimit = &configs.RateLimit{
+			RequestsPerMinute: rateLimit.GetRequestsPerMinute(),
+			TokensPerMinute:   rateLimit.GetTokensPerMinute(),
+		}
+	}
+	if budget := policy.GetBudget(); budget != nil {
+		result.Budget = &configs.Budget{
+			Daily:    budgetLimitFromRequest(budget.GetDaily()),
+			Monthly:  budgetLimitFromRequest(budget.GetMonthly()),
+			Lifetime: budgetLimitFromRequest(budget.GetLifetime()),
+		}
+	}
+	if priority := policy.GetPriority(); priority != nil {
+		result.Priority = &configs.Priority{
+			Class:     priority.GetClass(),
+			Permitted: priority.GetPermitted(),
+		}
+	}
+	if semanticCache := policy.GetSemanticCache(); semanticCache != nil {
+		result.SemanticCache = &configs.EndpointSemanticCache{
+			Enabled:   semanticCache.GetEnabled(),
+			Threshold: semanticCache.GetThreshold(),
+			TTL:       semanticCache.GetTtl().AsDuration(),
+		}
+	}
+	if exactCache := policy.GetExactCache(); exactCache != nil {
+		result.ExactCache = &configs.EndpointExactCache{
+			Enabled: exactCache.GetEnabled(),
+			TTL:     exactCache.GetTtl().AsDuration(),
+		}
+	}
+
+	return result
+}
+
+func budgetLimitToResponse(limit *configs.BudgetLimit) *adminapiv1.BudgetLimit {
+	if limit == nil {
+		return nil
+	}
+
+	return &adminapiv1.BudgetLimit{Hard: limit.Hard, Soft: limit.Soft}
+}
+
+func policyToResponse(policy *authstorage.Policy) *adminapiv1.Policy {
+	if policy == nil {
+		return nil
+	}
+
+	result := &adminapiv1.Policy{}
+
+	if policy.RateLimit != nil {
+		result.RateLimit = &adminapiv1.RateLimit{
+			RequestsPerMinute: policy.RateLimit.RequestsPerMinute,
+			TokensPerMinute:   policy.RateLimit.TokensPerMinute,
+		}
+	}
+	if policy.Budget != nil {
+		result.Budget = &adminapiv1.Budget{
+			Daily:    budgetLimitToResponse(policy.Budget.Daily),
+			Monthly:  budgetLimitToResponse(policy.Budget.Monthly),
+			Lifetime: budgetLimitToResponse(policy.Budget.Lifetime),
+		}
+	}
+	if policy.Priority != nil {
+		result.Priority = &adminapiv1.Priority{
+			Class:     policy.Priority.Class,
+			Permitted: policy.Priority.Permitted,
+		}
+	}
+	if policy.SemanticCache != nil {
+		result.SemanticCache = &adminapiv1.SemanticCache{
+			Enabled:   policy.SemanticCache.Enabled,
+			Threshold: policy.SemanticCache.Threshold,
+			Ttl:       durationpb.New(policy.SemanticCache.TTL),
+		}
+	}
+	if policy.ExactCache != nil {
+		result.ExactCache = &adminapiv1.ExactCache{
+			Enabled: policy.ExactCache.Enabled,
+			Ttl:     durationpb.New(policy.ExactCache.TTL),
+		}
+	}
+
+	return result
+}
+
+func tenantToResponse(tenant authstorage.TenantRecord) *adminapiv1.Tenant {
+	return &adminapiv1.Tenant{
+		Id:       tenant.ID,
+		Upstream: upstreamSingleOrMultipleToResponse(tenant.Upstream),
+		Policy:   policyToResponse(tenant.Policy),
+	}
+}
+
+func teamToResponse(team authstorage.TeamRecord) *adminapiv1.Team {
+	return &adminapiv1.Team{
+		Id:       team.ID,
+		TenantId: team.TenantID,
+		Upstream: upstreamSingleOrMultipleToResponse(team.Upstream),
+		Policy:   policyToResponse(team.Policy),
+	}
+}
+
+func groupToResponse(group authstorage.GroupRecord) *adminapiv1.Group {
+	return &adminapiv1.Group{
+		Id:            group.ID,
+		TeamId:        group.TeamID,
+		ParentGroupId: group.ParentGroupID,
+		Upstream:      upstreamSingleOrMultipleToResponse(group.Upstream),
+		Policy:        policyToResponse(group.Policy),
+	}
+}
+
+func endpointToResponse(endpoint authstorage.Endpoint) *adminapiv1.Endpoint {
+	return &adminapiv1.Endpoint{
+		Id:       endpoint.ID,
+		TenantId: endpoint.Tenant.ID(),
+		TeamId:   endpoint.Team.ID(),
+		GroupId:  endpoint.Group.ID(),
+		Alias:    endpoint.Alias,
+		Upstream: upstreamSingleOrMultipleToResponse(endpoint.Upstream),
+		Policy:   policyToResponse(endpoint.Policy),
+	}
+}
+
+func apiKeyToResponse(apiKey string, endpointID string, options authstorage.APIKeyOptions) *adminapiv1.APIKey {
+	return &adminapiv1.APIKey{
+		ApiKey:     apiKey,
+		EndpointId: endpointID,
+		ExpiresAt: lo.TernaryF(options.ExpiresAt == nil, func() *timestamppb.Timestamp { return nil }, func() *timestamppb.Timestamp {
+			return timestamppb.New(*options.ExpiresAt)
+		}),
+		Scopes:  lo.Map(options.Scopes, func(item authstorage.Scope, _ int) string { return string(item) }),
+		Aliases: options.Aliases,
+	}
+}
+
+func expiresAtFromRequest(expiresAt *timestamppb.Timestamp) *time.Time {
+	if expiresAt == nil {
+		return nil
+	}
+
+	return lo.ToPtr(expiresAt.AsTime())
+}
+
+func schedulerQueueToResponse(queue scheduling.QueueStats) *adminapiv1.SchedulerQueue {
+	return &adminapiv1.SchedulerQueue{
+		Upstream: queue.Upstream,
+		Capacity: int64(queue.Capacity),
+		InFlight: int64(queue.InFlight),
+		Classes: lo.Map(queue.Classes, func(item scheduling.ClassStats, _ int) *adminapiv1.SchedulerClassStats {
+			return &adminapiv1.SchedulerClassStats{
+				Class:       string(item.Class),
+				Waiting:     int64(item.Waiting),
+				Dispatched:  item.Dispatched,
+				AverageWait: durationpb.New(item.AverageWait()),
+				MaxWait:     durationpb.New(item.MaxWait),
+			}
+		}),
+	}
+}
@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adminapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+)
+
+func TestValidateUpstream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		apiKey  string
+		wantErr bool
+	}{
+		{name: "Plaintext", apiKey: "sk-plaintext"},
+		{name: "Encrypted", apiKey: "enc:c2VhbGVk"},
+		{name: "Empty"},
+		{name: "Redacted", apiKey: "redacted:abcd", wantErr: true},
+		{name: "Environment", apiKey: "env:LLMG_SECRETS_MASTER_KEY", wantErr: true},
+		{name: "File", apiKey: "file:/etc/passwd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			upstream := &adminapiv1.Upstream{Openai: &adminapiv1.UpstreamOpenAI{ApiKey: tt.apiKey}}
+
+			for _, item := range []*adminapiv1.UpstreamSingleOrMultiple{
+				{Upstream: upstream},
+				{Group: []*adminapiv1.Upstream{{Openai: &adminapiv1.UpstreamOpenAI{}}, upstream}},
+			} {
+				err := validateUpstream(item)
+				if !tt.wantErr {
+					require.NoError(t, err)
+
+					continue
+				}
+
+				require.Error(t, err)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		policy   *adminapiv1.Policy
+		endpoint bool
+		wantErr  bool
+	}{
+		{name: "Unset"},
+		{
+			name: "Limits",
+			policy: &adminapiv1.Policy{
+				RateLimit: &adminapiv1.RateLimit{RequestsPerMinute: 60},
+				Budget:    &adminapiv1.Budget{Monthly: &adminapiv1.BudgetLimit{Hard: 100, Soft: 80}},
+			},
+		},
+		{
+			name: "Endpoint",
+			policy: &adminapiv1.Policy{
+				Priority:      &adminapiv1.Priority{Class: "batch", Permitted: []string{"Standard"}},
+				SemanticCache: &adminapiv1.SemanticCache{Enabled: true, Threshold: 0.9},
+			},
+			endpoint: true,
+		},
+		{name: "NegativeRateLimit", policy: &adminapiv1.Policy{RateLimit: &adminapiv1.RateLimit{TokensPerMinute: -1}}, wantErr: true},
+		{name: "NegativeBudget", policy: &adminapiv1.Policy{Budget: &adminapiv1.Budget{Daily: &adminapiv1.BudgetLimit{Soft: -1}}}, wantErr: true},
+		{name: "UnknownClass", policy: &adminapiv1.Policy{Priority: &adminapiv1.Priority{Class: "urgent"}}, endpoint: true, wantErr: true},
+		{name: "UnknownPermitted", policy: &adminapiv1.Policy{Priority: &adminapiv1.Priority{Permitted: []string{"urgent"}}}, endpoint: true, wantErr: true},
+		{name: "Threshold", policy: &adminapiv1.Policy{SemanticCache: &adminapiv1.SemanticCache{Threshold: 1.5}}, endpoint: true, wantErr: true},
+		{name: "PriorityOfGroup", policy: &adminapiv1.Policy{Priority: &adminapiv1.Priority{Class: "batch"}}, wantErr: true},
+		{name: "CacheOfTeam", policy: &adminapiv1.Policy{ExactCache: &adminapiv1.ExactCache{Enabled: true}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validatePolicy(tt.policy, tt.endpoint)
+			if !tt.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
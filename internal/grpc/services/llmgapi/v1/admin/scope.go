@@ -0,0 +1,191 @@
+package admin
+
+import (
+	"context"
+	"slices"
+
+	adminapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+// Callers are either the admin API key of the configuration, in which case
+// no endpoint is attached to the context and every tenant is administrated,
+// or endpoints whose API key is granted the admin scope, which administrate
+// the tenant of the endpoint only. The resources of other tenants are
+// reported as not found to the latter, so that their IDs are not disclosed,
+// and the endpoints without a tenant administrate none.
+
+// scopedTenantOf returns the tenant the caller is restricted to, and whether
+// it is restricted, which it is not for the admin API key of the
+// configuration only.
+func scopedTenantOf(ctx context.Context) (string, bool) {
+	endpoint := endpoints.EndpointFromContext(ctx)
+	if endpoint == nil {
+		return "", false
+	}
+
+	return endpoint.Tenant.ID(), true
+}
+
+// requireConfiguredAdmin rejects the callers restricted to a tenant, for the
+// methods that concern every tenant, or the replica itself.
+func requireConfiguredAdmin(ctx context.Context) error {
+	_, scoped := scopedTenantOf(ctx)
+	if !scoped {
+		return nil
+	}
+
+	return apierrors.NewPermissionDenied().WithDetail("the method is reserved to the admin API key of the configuration").AsStatus()
+}
+
+func visibleTo(ctx context.Context, tenantID string) bool {
+	scopedTenantID, scoped := scopedTenantOf(ctx)
+	if !scoped {
+		return true
+	}
+
+	return scopedTenantID != "" && scopedTenantID == tenantID
+}
+
+func authorizeTenant(ctx context.Context, tenantID string) error {
+	if !visibleTo(ctx, tenantID) {
+		return authstorage.ErrTenantNotFound
+	}
+
+	return nil
+}
+
+func authorizeTeam(ctx context.Context, provider authstorage.EndpointProviderAdministrable, teamID string) (*authstorage.TeamRecord, error) {
+	team, err := provider.FindOneTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if !visibleTo(ctx, team.TenantID) {
+		return nil, authstorage.ErrTeamNotFound
+	}
+
+	return team, nil
+}
+
+func authorizeGroup(ctx context.Context, provider authstorage.EndpointProviderAdministrable, groupID string) (*authstorage.GroupRecord, *authstorage.TeamRecord, error) {
+	group, err := provider.FindOneGroup(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	team, err := provider.FindOneTeam(ctx, group.TeamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !visibleTo(ctx, team.TenantID) {
+		return nil, nil, authstorage.ErrGroupNotFound
+	}
+
+	return group, team, nil
+}
+
+func authorizeEndpoint(ctx context.Context, provider authstorage.EndpointProviderAdministrable, endpointID string) (*authstorage.Endpoint, error) {
+	endpoint, err := provider.FindOneByID(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !visibleTo(ctx, endpoint.Tenant.ID()) {
+		return nil, authstorage.ErrEndpointNotFound
+	}
+
+	return endpoint, nil
+}
+
+func authorizeAPIKey(ctx context.Context, provider authstorage.EndpointProviderAdministrable, apiKey string) (*authstorage.Endpoint, error) {
+	endpoint, err := provider.FindOneByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil || !visibleTo(ctx, endpoint.Tenant.ID()) {
+		return nil, authstorage.ErrAPIKeyNotFound
+	}
+
+	return endpoint, nil
+}
+
+// authorizeEffectiveEndpoint is authorizeEndpoint for the providers which are
+// not administrable, e.g. when explaining the upstreams of configured
+// endpoints.
+func authorizeEffectiveEndpoint(ctx context.Context, provider authstorage.EndpointProvider, endpointID string) error {
+	_, scoped := scopedTenantOf(ctx)
+	if !scoped {
+		return nil
+	}
+
+	queryable, ok := provider.(authstorage.EndpointProviderEffectiveQueryable)
+	if !ok {
+		return authstorage.ErrEndpointNotFound
+	}
+
+	endpoint, err := queryable.FindOneEffectiveByID(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+	if !visibleTo(ctx, endpoint.Tenant.ID()) {
+		return authstorage.ErrEndpointNotFound
+	}
+
+	return nil
+}
+
+// authorizePriority rejects the callers restricted to a tenant changing the
+// priority of an endpoint from current, which would let them take the
+// capacity of the upstreams they share with other tenants. The priority read
+// from the admin API may be written back as is.
+func authorizePriority(ctx context.Context, current *authstorage.Policy, policy *authstorage.Policy) error {
+	_, scoped := scopedTenantOf(ctx)
+	if !scoped {
+		return nil
+	}
+
+	priorityOf := func(policy *authstorage.Policy) configs.Priority {
+		if policy == nil || policy.Priority == nil {
+			return configs.Priority{}
+		}
+
+		return *policy.Priority
+	}
+
+	currentPriority, priority := priorityOf(current), priorityOf(policy)
+	if currentPriority.Class == priority.Class && slices.Equal(currentPriority.Permitted, priority.Permitted) {
+		return nil
+	}
+
+	return apierrors.NewPermissionDenied().WithDetail("the priority of endpoints is reserved to the admin API key of the configuration").AsStatus()
+}
+
+// authorizeUpstream rejects the callers restricted to a tenant setting a
+// base_url without an api_key, either on the upstream itself, or on the
+// single upstream of a group, so that the upstream does not depend on the
+// credentials of the levels above to authenticate against a host chosen by
+// the caller.
+func authorizeUpstream(ctx context.Context, upstream *adminapiv1.UpstreamSingleOrMultiple) error {
+	_, scoped := scopedTenantOf(ctx)
+	if !scoped {
+		return nil
+	}
+
+	shared := upstream.GetUpstream().GetOpenai()
+
+	for _, item := range append([]*adminapiv1.Upstream{upstream.GetUpstream()}, upstream.GetGroup()...) {
+		openai := item.GetOpenai()
+		if openai.GetBaseUrl() == "" || openai.GetApiKey() != "" || shared.GetApiKey() != "" {
+			continue
+		}
+
+		return apierrors.NewPermissionDenied().
+			WithDetail("base_url requires an api_key, only the admin API key of the configuration may set it alone").
+			WithSourceParameter("upstream").
+			AsStatus()
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adminapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+	"github.com/lingticio/llmg/internal/endpoints"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+func scopedContext(tenantID string) context.Context {
+	return endpoints.WithEndpoint(context.Background(), &authstorage.Endpoint{Tenant: metadata.Tenant{Id: tenantID}})
+}
+
+func TestScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		configuredAdmin bool
+		visible         []string
+	}{
+		{name: "ConfiguredAdmin", ctx: context.Background(), configuredAdmin: true, visible: []string{"acme", "other", ""}},
+		{name: "Scoped", ctx: scopedContext("acme"), visible: []string{"acme"}},
+		// The endpoints without a tenant are restricted, to no tenant at all.
+		{name: "ScopedWithoutTenant", ctx: scopedContext("")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := requireConfiguredAdmin(tt.ctx)
+			if tt.configuredAdmin {
+				require.NoError(t, err)
+			} else {
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			}
+
+			for _, tenantID := range []string{"acme", "other", ""} {
+				assert.Equal(t, slices.Contains(tt.visible, tenantID), visibleTo(tt.ctx, tenantID), tenantID)
+			}
+		})
+	}
+}
+
+func TestAuthorizeUpstream(t *testing.T) {
+	t.Parallel()
+
+	upstream := func(baseURL string, apiKey string) *adminapiv1.Upstream {
+		return &adminapiv1.Upstream{Openai: &adminapiv1.UpstreamOpenAI{BaseUrl: baseURL, ApiKey: apiKey}}
+	}
+
+	tests := []struct {
+		name     string
+		upstream *adminapiv1.UpstreamSingleOrMultiple
+		wantErr  bool
+	}{
+		{name: "None"},
+		{name: "APIKey", upstream: &adminapiv1.UpstreamSingleOrMultiple{Upstream: upstream("", "sk-acme")}},
+		{name: "BaseURLWithAPIKey", upstream: &adminapiv1.UpstreamSingleOrMultiple{Upstream: upstream("https://acme", "sk-acme")}},
+		{name: "BaseURL", upstream: &adminapiv1.UpstreamSingleOrMultiple{Upstream: upstream("https://acme", "")}, wantErr: true},
+		{name: "GroupWithAPIKeys", upstream: &adminapiv1.UpstreamSingleOrMultiple{Group: []*adminapiv1.Upstream{upstream("https://first", "sk-first"), upstream("", "")}}},
+		{name: "GroupWithSharedAPIKey", upstream: &adminapiv1.UpstreamSingleOrMultiple{Upstream: upstream("", "sk-acme"), Group: []*adminapiv1.Upstream{upstream("https://first", ""), upstream("https://second", "")}}},
+		{name: "GroupBaseURL", upstream: &adminapiv1.UpstreamSingleOrMultiple{Group: []*adminapiv1.Upstream{upstream("https://first", "sk-first"), upstream("https://second", "")}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The admin API key of the configuration may set any upstream.
+			require.NoError(t, authorizeUpstream(context.Background(), tt.upstream))
+
+			err := authorizeUpstream(scopedContext("acme"), tt.upstream)
+			if tt.wantErr {
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
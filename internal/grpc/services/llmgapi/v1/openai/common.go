@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	openaiapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/openai"
+	"github.com/lingticio/llmg/pkg/exactcache"
 	"github.com/samber/lo"
 	"github.com/sashabaranov/go-openai"
 )
@@ -102,7 +103,7 @@ func gRPCRequestToOpenAIRequest(req *openaiapiv1.CreateChatCompletionRequest) op
 		request.MaxTokens = int(req.GetMaxTokens())
 	}
 	if req.Temperature != nil {
-		request.Temperature = req.GetTemperature()
+		request.Temperature = exactcache.Temperature(req.GetTemperature())
 	}
 	if req.TopP != nil {
 		request.TopP = req.GetTopP()
@@ -283,7 +284,7 @@ func gRPCStreamRequestToOpenAIRequest(req *openaiapiv1.CreateChatCompletionStrea
 		request.MaxTokens = int(req.GetMaxTokens())
 	}
 	if req.Temperature != nil {
-		request.Temperature = req.GetTemperature()
+		request.Temperature = exactcache.Temperature(req.GetTemperature())
 	}
 	if req.TopP != nil {
 		request.TopP = req.GetTopP()
@@ -12,65 +12,63 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	openaiapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/openai"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/util/pagination"
 )
 
 type NewOpenAIServiceParams struct {
 	fx.In
 
-	Logger *logger.Logger
+	Logger         *logger.Logger
+	UpstreamModels *upstreams.Models
+	Gateway        *upstreams.Gateway
 }
 
 type OpenAIService struct {
 	openaiapiv1.UnimplementedOpenAIServiceServer
 
-	logger *logger.Logger
+	logger         *logger.Logger
+	upstreamModels *upstreams.Models
+	gateway        *upstreams.Gateway
 }
 
 func NewOpenAIService() func(params NewOpenAIServiceParams) *OpenAIService {
 	return func(params NewOpenAIServiceParams) *OpenAIService {
 		return &OpenAIService{
-			logger: params.Logger,
+			logger:         params.Logger,
+			upstreamModels: params.UpstreamModels,
+			gateway:        params.Gateway,
 		}
 	}
 }
 
-func clientConfigFromContext(ctx context.Context) (openai.ClientConfig, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return openai.ClientConfig{}, status.Errorf(codes.Internal, "failed to get metadata")
+// endpointFromContext returns the endpoint the request is authenticated as by
+// the endpoint interceptor, its upstream is where the request is sent to.
+func (s *OpenAIService) endpointFromContext(ctx context.Context) (*authstorage.Endpoint, error) {
+	endpoint := endpoints.EndpointFromContext(ctx)
+	if endpoint == nil {
+		return nil, apierrors.NewErrUnauthorized().WithDetail("the request is not authenticated").AsStatus()
 	}
 
-	apiKeys := md.Get("x-api-key")
-	if len(apiKeys) == 0 {
-		return openai.ClientConfig{}, status.Errorf(codes.InvalidArgument, "missing API key in x-api-key")
-	}
-
-	config := openai.DefaultConfig(apiKeys[0])
-
-	baseURLs := md.Get("x-base-url")
-	if len(baseURLs) > 0 {
-		config.BaseURL = baseURLs[0]
-	}
-
-	return config, nil
+	return endpoint, nil
 }
 
 func (s *OpenAIService) CreateChatCompletion(ctx context.Context, req *openaiapiv1.CreateChatCompletionRequest) (*openaiapiv1.CreateChatCompletionResponse, error) {
-	config, err := clientConfigFromContext(ctx)
+	endpoint, err := s.endpointFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	client := openai.NewClientWithConfig(config)
-
-	openaiResponse, err := client.CreateChatCompletion(ctx, gRPCRequestToOpenAIRequest(req))
+	openaiResponse, err := s.gateway.CreateChatCompletion(ctx, endpoint, gRPCRequestToOpenAIRequest(req))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create chat completion: %v", err)
+		return nil, upstreams.AsAPIError(err).AsStatus()
 	}
 
 	response := &openaiapiv1.CreateChatCompletionResponse{
@@ -106,18 +104,18 @@ func (s *OpenAIService) CreateChatCompletion(ctx context.Context, req *openaiapi
 }
 
 func (s *OpenAIService) CreateChatCompletionStream(req *openaiapiv1.CreateChatCompletionStreamRequest, server openaiapiv1.OpenAIService_CreateChatCompletionStreamServer) error {
-	config, err := clientConfigFromContext(server.Context())
+	endpoint, err := s.endpointFromContext(server.Context())
 	if err != nil {
 		return err
 	}
 
-	client := openai.NewClientWithConfig(config)
-
-	stream, err := client.CreateChatCompletionStream(server.Context(), gRPCStreamRequestToOpenAIRequest(req))
+	stream, err := s.gateway.CreateChatCompletionStream(server.Context(), endpoint, gRPCStreamRequestToOpenAIRequest(req))
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to create chat completion stream: %v", err)
+		return upstreams.AsAPIError(err).AsStatus()
 	}
 
+	defer stream.Close()
+
 	for {
 		response, err := stream.Recv()
 		if errors.Is(err, io.EOF) {
@@ -160,3 +158,35 @@ func (s *OpenAIService) CreateChatCompletionStream(req *openaiapiv1.CreateChatCo
 
 	return nil
 }
+
+func (s *OpenAIService) ListModels(ctx context.Context, req *openaiapiv1.ListModelsRequest) (*openaiapiv1.ListModelsResponse, error) {
+	endpoint, err := s.endpointFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := s.upstreamModels.List(ctx, endpoint)
+	if err != nil {
+		return nil, apierrors.NewErrUnavailable().WithError(err).AsStatus()
+	}
+
+	edges, pageInfo, err := pagination.Paginate(models, func(item upstreams.Model) string {
+		return item.ID
+	}, pagination.ParamsFromJSONAPI(req.GetPagination()))
+	if err != nil {
+		return nil, apierrors.NewErrInvalidArgument().WithError(err).WithSourceParameter("pagination").AsStatus()
+	}
+
+	return &openaiapiv1.ListModelsResponse{
+		Object: "list",
+		Data: lo.Map(edges, func(item pagination.Edge[upstreams.Model], index int) *openaiapiv1.Model {
+			return &openaiapiv1.Model{
+				Id:      item.Node.ID,
+				Object:  item.Node.Object,
+				Created: timestamppb.New(time.Unix(item.Node.Created, 0)),
+				OwnedBy: item.Node.OwnedBy,
+			}
+		}),
+		PageInfo: pageInfo.JSONAPI(),
+	}, nil
+}
@@ -0,0 +1,118 @@
+package usage
+
+import (
+	"context"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	usageapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/usage"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	"github.com/lingticio/llmg/pkg/usage"
+	"github.com/lingticio/llmg/pkg/util/pagination"
+)
+
+type NewUsageServiceParams struct {
+	fx.In
+
+	Logger      *logger.Logger
+	UsageLedger *upstreams.UsageLedger
+}
+
+// UsageService serves the usage recorded by the usage ledger to the
+// endpoints granted the usage scope.
+type UsageService struct {
+	usageapiv1.UnimplementedUsageServiceServer
+
+	logger      *logger.Logger
+	usageLedger *upstreams.UsageLedger
+}
+
+func NewUsageService() func(params NewUsageServiceParams) *UsageService {
+	return func(params NewUsageServiceParams) *UsageService {
+		return &UsageService{
+			logger:      params.Logger,
+			usageLedger: params.UsageLedger,
+		}
+	}
+}
+
+func queryFromRequest(req *usageapiv1.QueryUsageRequest) usage.Query {
+	query := usage.Query{
+		Filter: usage.Filter{
+			TenantID:   req.GetTenantId(),
+			TeamID:     req.GetTeamId(),
+			GroupID:    req.GetGroupId(),
+			EndpointID: req.GetEndpointId(),
+			Model:      req.GetModel(),
+			User:       req.GetUser(),
+		},
+		GroupBy: lo.Map(req.GetGroupBy(), func(item string, _ int) usage.Dimension {
+			return usage.Dimension(item)
+		}),
+	}
+	if req.StartTime != nil {
+		query.Since = req.GetStartTime().AsTime()
+	}
+	if req.EndTime != nil {
+		query.Until = req.GetEndTime().AsTime()
+	}
+	if req.BucketWidth != nil {
+		query.Width = req.GetBucketWidth().AsDuration()
+	}
+
+	return query
+}
+
+func bucketToResponse(bucket usage.Bucket) *usageapiv1.UsageBucket {
+	response := &usageapiv1.UsageBucket{
+		Group: lo.MapKeys(bucket.Group, func(_ string, key usage.Dimension) string {
+			return string(key)
+		}),
+		Requests:         bucket.Requests,
+		FailedRequests:   bucket.FailedRequests,
+		PromptTokens:     bucket.PromptTokens,
+		CompletionTokens: bucket.CompletionTokens,
+		CachedTokens:     bucket.CachedTokens,
+		Cost:             bucket.Cost,
+		AverageLatency:   durationpb.New(bucket.AverageLatency()),
+		CacheHits:        bucket.CacheHits,
+	}
+	if !bucket.Start.IsZero() {
+		response.StartTime = timestamppb.New(bucket.Start)
+	}
+	if !bucket.End.IsZero() {
+		response.EndTime = timestamppb.New(bucket.End)
+	}
+
+	return response
+}
+
+func (s *UsageService) QueryUsage(ctx context.Context, req *usageapiv1.QueryUsageRequest) (*usageapiv1.QueryUsageResponse, error) {
+	endpoint := endpoints.EndpointFromContext(ctx)
+	if endpoint == nil {
+		return nil, apierrors.NewErrUnauthorized().WithDetail("the request is not authenticated").AsStatus()
+	}
+
+	buckets, err := s.usageLedger.QueryOf(ctx, endpoint, queryFromRequest(req))
+	if err != nil {
+		return nil, upstreams.AsAPIError(err).AsStatus()
+	}
+
+	edges, pageInfo, err := pagination.Paginate(buckets, usage.Bucket.ID, pagination.ParamsFromJSONAPI(req.GetPagination()))
+	if err != nil {
+		return nil, apierrors.NewErrInvalidArgument().WithError(err).WithSourceParameter("pagination").AsStatus()
+	}
+
+	return &usageapiv1.QueryUsageResponse{
+		Data: lo.Map(edges, func(item pagination.Edge[usage.Bucket], _ int) *usageapiv1.UsageBucket {
+			return bucketToResponse(item.Node)
+		}),
+		PageInfo: pageInfo.JSONAPI(),
+	}, nil
+}
@@ -1,12 +1,16 @@
 package services
 
 import (
+	"github.com/lingticio/llmg/internal/grpc/services/llmgapi/v1/admin"
 	"github.com/lingticio/llmg/internal/grpc/services/llmgapi/v1/openai"
+	"github.com/lingticio/llmg/internal/grpc/services/llmgapi/v1/usage"
 	"go.uber.org/fx"
 )
 
 func Modules() fx.Option {
 	return fx.Options(
 		fx.Provide(openai.NewOpenAIService()),
+		fx.Provide(admin.NewAdminService()),
+		fx.Provide(usage.NewUsageService()),
 	)
 }
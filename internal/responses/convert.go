@@ -0,0 +1,436 @@
+package responses
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+)
+
+const idLength = 24
+
+func newID(prefix string) string {
+	return prefix + "_" + nanoid.NewWithLength(idLength)
+}
+
+func textOfParts(parts MessageContent) (string, bool) {
+	var text string
+
+	for _, part := range parts {
+		switch part.Type {
+		case ContentTypeInputText, ContentTypeOutputText:
+			text += part.Text
+		case ContentTypeRefusal:
+			text += part.Refusal
+		default:
+			return "", false
+		}
+	}
+
+	return text, true
+}
+
+func messageOfItem(item InputItem) (openai.ChatCompletionMessage, error) {
+	role := item.Role
+	// The developer role is only understood by OpenAI, it is equivalent to
+	// the system role for every other upstream.
+	if role == "developer" {
+		role = openai.ChatMessageRoleSystem
+	}
+
+	switch role {
+	case openai.ChatMessageRoleUser, openai.ChatMessageRoleAssistant, openai.ChatMessageRoleSystem:
+	default:
+		return openai.ChatCompletionMessage{}, fmt.Errorf("%w: unsupported message role %q", ErrInvalidRequest, item.Role)
+	}
+
+	text, ok := textOfParts(item.Content)
+	if ok {
+		return openai.ChatCompletionMessage{Role: role, Content: text}, nil
+	}
+
+	parts := make([]openai.ChatMessagePart, 0, len(item.Content))
+
+	for _, part := range item.Content {
+		switch part.Type {
+		case ContentTypeInputText, ContentTypeOutputText:
+			parts = append(parts, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: part.Text})
+		case ContentTypeInputImage:
+			parts = append(parts, openai.ChatMessagePart{
+				Type: openai.ChatMessagePartTypeImageURL,
+				ImageURL: &openai.ChatMessageImageURL{
+					URL:    part.ImageURL,
+					Detail: openai.ImageURLDetail(part.Detail),
+				},
+			})
+		default:
+			return openai.ChatCompletionMessage{}, fmt.Errorf("%w: unsupported content type %q", ErrInvalidRequest, part.Type)
+		}
+	}
+
+	return openai.ChatCompletionMessage{Role: role, MultiContent: parts}, nil
+}
+
+// appendToolCall attaches the tool call to the preceding assistant message,
+// as chat completions expect all the tool calls of a turn in one message.
+func appendToolCall(messages []openai.ChatCompletionMessage, call openai.ToolCall) []openai.ChatCompletionMessage {
+	if len(messages) > 0 && messages[len(messages)-1].Role == openai.ChatMessageRoleAssistant {
+		messages[len(messages)-1].ToolCalls = append(messages[len(messages)-1].ToolCalls, call)
+
+		return messages
+	}
+
+	return append(messages, openai.ChatCompletionMessage{
+		Role:      openai.ChatMessageRoleAssistant,
+		ToolCalls: []openai.ToolCall{call},
+	})
+}
+
+func messagesOfInput(input Input) ([]openai.ChatCompletionMessage, error) {
+	messages := make([]openai.ChatCompletionMessage, 0, len(input))
+
+	for _, item := range input {
+		switch item.Type {
+		case ItemTypeMessage, "":
+			message, err := messageOfItem(item)
+			if err != nil {
+				return nil, err
+			}
+
+			messages = append(messages, message)
+		case ItemTypeFunctionCall:
+			messages = appendToolCall(messages, openai.ToolCall{
+				ID:   item.CallID,
+				Type: openai.ToolTypeFunction,
+				Function: openai.FunctionCall{
+					Name:      item.Name,
+					Arguments: item.Arguments,
+				},
+			})
+		case ItemTypeFunctionCallOutput:
+			messages = append(messages, openai.ChatCompletionMessage{
+				Role:       openai.ChatMessageRoleTool,
+				Content:    item.Output,
+				ToolCallID: item.CallID,
+			})
+		default:
+			return nil, fmt.Errorf("%w: unsupported input item type %q", ErrInvalidRequest, item.Type)
+		}
+	}
+
+	return messages, nil
+}
+
+func messagesOfOutput(output []OutputItem) []openai.ChatCompletionMessage {
+	messages := make([]openai.ChatCompletionMessage, 0, len(output))
+
+	for _, item := range output {
+		switch item := item.(type) {
+		case *OutputMessage:
+			messages = append(messages, openai.ChatCompletionMessage{
+				Role: openai.ChatMessageRoleAssistant,
+				Content: lo.Reduce(item.Content, func(agg string, part OutputText, _ int) string {
+					return agg + part.Text
+				}, ""),
+			})
+		case *FunctionCall:
+			messages = appendToolCall(messages, openai.ToolCall{
+				ID:   item.CallID,
+				Type: openai.ToolTypeFunction,
+				Function: openai.FunctionCall{
+					Name:      item.Name,
+					Arguments: item.Arguments,
+				},
+			})
+		}
+	}
+
+	return messages
+}
+
+func toolsOf(tools []Tool) ([]openai.Tool, error) {
+	chatTools := make([]openai.Tool, 0, len(tools))
+
+	for _, tool := range tools {
+		if tool.Type != string(openai.ToolTypeFunction) {
+			return nil, fmt.Errorf("%w: unsupported tool type %q", ErrInvalidRequest, tool.Type)
+		}
+
+		definition := &openai.FunctionDefinition{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Strict:      lo.FromPtr(tool.Strict),
+		}
+		if len(tool.Parameters) > 0 {
+			definition.Parameters = tool.Parameters
+		}
+
+		chatTools = append(chatTools, openai.Tool{
+			Type:     openai.ToolTypeFunction,
+			Function: definition,
+		})
+	}
+
+	return chatTools, nil
+}
+
+// toolChoiceOf decodes tool_choice, which is either one of none, auto and
+// required, or an object naming the function to call.
+func toolChoiceOf(raw json.RawMessage) (any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte(`"`)) {
+		var choice string
+
+		err := json.Unmarshal(raw, &choice)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed tool_choice: %w", ErrInvalidRequest, err)
+		}
+
+		return choice, nil
+	}
+
+	var choice struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}
+
+	err := json.Unmarshal(raw, &choice)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed tool_choice: %w", ErrInvalidRequest, err)
+	}
+	if choice.Type != string(openai.ToolTypeFunction) || choice.Name == "" {
+		return nil, fmt.Errorf("%w: unsupported tool_choice type %q", ErrInvalidRequest, choice.Type)
+	}
+
+	return openai.ToolChoice{
+		Type:     openai.ToolTypeFunction,
+		Function: openai.ToolFunction{Name: choice.Name},
+	}, nil
+}
+
+func responseFormatOf(text *TextConfig) (*openai.ChatCompletionResponseFormat, error) {
+	if text == nil {
+		return nil, nil
+	}
+
+	switch text.Format.Type {
+	case "", "text":
+		return nil, nil
+	case string(openai.ChatCompletionResponseFormatTypeJSONObject):
+		return &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}, nil
+	case string(openai.ChatCompletionResponseFormatTypeJSONSchema):
+		return &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:        text.Format.Name,
+				Description: text.Format.Description,
+				Schema:      text.Format.Schema,
+				Strict:      lo.FromPtr(text.Format.Strict),
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported text format %q", ErrInvalidRequest, text.Format.Type)
+	}
+}
+
+// chatRequestOf translates the request onto a chat completion request, with
+// history being the conversation of the previous response. Instructions are
+// never carried over from previous responses.
+func chatRequestOf(request Request, history []openai.ChatCompletionMessage) (openai.ChatCompletionRequest, []openai.ChatCompletionMessage, error) {
+	input, err := messagesOfInput(request.Input)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, nil, err
+	}
+
+	tools, err := toolsOf(request.Tools)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, nil, err
+	}
+
+	toolChoice, err := toolChoiceOf(request.ToolChoice)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, nil, err
+	}
+
+	responseFormat, err := responseFormatOf(request.Text)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, nil, err
+	}
+
+	messages := make([]openai.ChatCompletionMessage, 0, len(history)+len(input)+1)
+	if request.Instructions != nil && *request.Instructions != "" {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: *request.Instructions,
+		})
+	}
+
+	messages = append(messages, history...)
+	messages = append(messages, input...)
+
+	chatRequest := openai.ChatCompletionRequest{
+		Model:          request.Model,
+		Messages:       messages,
+		MaxTokens:      lo.FromPtr(request.MaxOutputTokens),
+		Temperature:    temperatureOf(request.Temperature),
+		TopP:           lo.FromPtr(request.TopP),
+		ResponseFormat: responseFormat,
+		User:           request.User,
+		ToolChoice:     toolChoice,
+	}
+	if len(tools) > 0 {
+		chatRequest.Tools = tools
+	}
+	if request.ParallelToolCalls != nil && len(tools) > 0 {
+		chatRequest.ParallelToolCalls = *request.ParallelToolCalls
+	}
+
+	return chatRequest, input, nil
+}
+
+// newResponse creates the in progress response of the request.
+func newResponse(request Request) *Response {
+	var toolChoice any = "auto"
+	if len(request.ToolChoice) > 0 {
+		_ = json.Unmarshal(request.ToolChoice, &toolChoice)
+	}
+
+	text := TextConfig{Format: TextFormat{Type: "text"}}
+	if request.Text != nil && request.Text.Format.Type != "" {
+		text = *request.Text
+	}
+
+	return &Response{
+		ID:                 newID("resp"),
+		Object:             "response",
+		CreatedAt:          time.Now().Unix(),
+		Status:             StatusInProgress,
+		Instructions:       request.Instructions,
+		MaxOutputTokens:    request.MaxOutputTokens,
+		Model:              request.Model,
+		Output:             make([]OutputItem, 0),
+		ParallelToolCalls:  lo.FromPtrOr(request.ParallelToolCalls, true),
+		PreviousResponseID: request.PreviousResponseID,
+		Store:              lo.FromPtrOr(request.Store, true),
+		Temperature:        request.Temperature,
+		TopP:               request.TopP,
+		Text:               text,
+		ToolChoice:         toolChoice,
+		Tools:              lo.Ternary(request.Tools == nil, make([]Tool, 0), request.Tools),
+		User:               lo.Ternary(request.User == "", nil, &request.User),
+		Metadata:           lo.Ternary(request.Metadata == nil, make(map[string]string), request.Metadata),
+	}
+}
+
+func usageOf(usage openai.Usage) *Usage {
+	result := &Usage{
+		InputTokens:  usage.PromptTokens,
+		OutputTokens: usage.CompletionTokens,
+		TotalTokens:  usage.TotalTokens,
+	}
+	if usage.PromptTokensDetails != nil {
+		result.InputTokensDetails.CachedTokens = usage.PromptTokensDetails.CachedTokens
+	}
+	if usage.CompletionTokensDetails != nil {
+		result.OutputTokensDetails.ReasoningTokens = usage.CompletionTokensDetails.ReasoningTokens
+	}
+
+	return result
+}
+
+// finish settles the status of the response from the finish reason of the
+// chat completion.
+func finish(response *Response, reason openai.FinishReason) {
+	switch reason {
+	case openai.FinishReasonLength:
+		response.Status = StatusIncomplete
+		response.IncompleteDetails = &IncompleteDetails{Reason: "max_output_tokens"}
+	case openai.FinishReasonContentFilter:
+		response.Status = StatusIncomplete
+		response.IncompleteDetails = &IncompleteDetails{Reason: "content_filter"}
+	default:
+		response.Status = StatusCompleted
+	}
+}
+
+func newOutputMessage() *OutputMessage {
+	return &OutputMessage{
+		Type:    ItemTypeMessage,
+		ID:      newID("msg"),
+		Status:  StatusInProgress,
+		Role:    openai.ChatMessageRoleAssistant,
+		Content: make([]OutputText, 0),
+	}
+}
+
+func newOutputText(text string) OutputText {
+	return OutputText{
+		Type:        ContentTypeOutputText,
+		Text:        text,
+		Annotations: make([]any, 0),
+	}
+}
+
+func newFunctionCall(call openai.ToolCall) *FunctionCall {
+	return &FunctionCall{
+		Type:      ItemTypeFunctionCall,
+		ID:        newID("fc"),
+		Status:    StatusInProgress,
+		CallID:    call.ID,
+		Name:      call.Function.Name,
+		Arguments: call.Function.Arguments,
+	}
+}
+
+// complete fills the response with the first choice of the chat completion.
+func complete(response *Response, chatResponse openai.ChatCompletionResponse) {
+	if chatResponse.Model != "" {
+		response.Model = chatResponse.Model
+	}
+
+	response.Usage = usageOf(chatResponse.Usage)
+	if len(chatResponse.Choices) == 0 {
+		response.Status = StatusCompleted
+		return
+	}
+
+	choice := chatResponse.Choices[0]
+	if choice.Message.Content != "" {
+		message := newOutputMessage()
+		message.Status = StatusCompleted
+		message.Content = append(message.Content, newOutputText(choice.Message.Content))
+		response.Output = append(response.Output, message)
+	}
+
+	for _, call := range choice.Message.ToolCalls {
+		item := newFunctionCall(call)
+		item.Status = StatusCompleted
+		response.Output = append(response.Output, item)
+	}
+
+	finish(response, choice.FinishReason)
+}
+
+// temperatureOf returns the temperature of the chat completion of a response
+// requesting temperature, if any.
+func temperatureOf(temperature *float32) float32 {
+	if temperature == nil {
+		return 0
+	}
+
+	return exactcache.Temperature(*temperature)
+}
@@ -0,0 +1,313 @@
+package responses
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeRequest(t *testing.T, body string) Request {
+	t.Helper()
+
+	var request Request
+
+	err := json.Unmarshal([]byte(body), &request)
+	require.NoError(t, err)
+
+	return request
+}
+
+func TestChatRequestOf(t *testing.T) {
+	history := []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleUser, Content: "Hi"},
+		{Role: openai.ChatMessageRoleAssistant, Content: "Hello!"},
+	}
+
+	testCases := []struct {
+		name     string
+		body     string
+		history  []openai.ChatCompletionMessage
+		messages []openai.ChatCompletionMessage
+		check    func(t *testing.T, request openai.ChatCompletionRequest)
+		err      string
+	}{
+		{
+			name: "PlainInput",
+			body: `{"model":"gpt-4o","input":"Hi","max_output_tokens":16,"user":"alice"}`,
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hi"},
+			},
+			check: func(t *testing.T, request openai.ChatCompletionRequest) {
+				t.Helper()
+
+				assert.Equal(t, "gpt-4o", request.Model)
+				assert.Equal(t, 16, request.MaxTokens)
+				assert.Equal(t, "alice", request.User)
+				assert.Nil(t, request.Tools)
+				assert.Nil(t, request.ResponseFormat)
+			},
+		},
+		{
+			name:    "InstructionsBeforeHistory",
+			body:    `{"model":"gpt-4o","instructions":"Be brief.","input":[{"role":"developer","content":"No emojis."},{"role":"user","content":[{"type":"input_text","text":"How are "},{"type":"input_text","text":"you?"}]}]}`,
+			history: history,
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleSystem, Content: "Be brief."},
+				history[0],
+				history[1],
+				{Role: openai.ChatMessageRoleSystem, Content: "No emojis."},
+				{Role: openai.ChatMessageRoleUser, Content: "How are you?"},
+			},
+		},
+		{
+			name: "Image",
+			body: `{"model":"gpt-4o","input":[{"role":"user","content":[{"type":"input_text","text":"What is it?"},{"type":"input_image","image_url":"https://example.com/a.png","detail":"low"}]}]}`,
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, MultiContent: []openai.ChatMessagePart{
+					{Type: openai.ChatMessagePartTypeText, Text: "What is it?"},
+					{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{URL: "https://example.com/a.png", Detail: openai.ImageURLDetailLow}},
+				}},
+			},
+		},
+		{
+			name: "FunctionCalls",
+			body: `{"model":"gpt-4o","input":[
+				{"role":"user","content":"Weather in Paris and Rome?"},
+				{"type":"function_call","call_id":"call_1","name":"weather","arguments":"{\"city\":\"Paris\"}"},
+				{"type":"function_call","call_id":"call_2","name":"weather","arguments":"{\"city\":\"Rome\"}"},
+				{"type":"function_call_output","call_id":"call_1","output":"sunny"},
+				{"type":"function_call_output","call_id":"call_2","output":"rainy"}
+			],"tools":[{"type":"function","name":"weather","parameters":{"type":"object"}}],"tool_choice":"required","parallel_tool_calls":false}`,
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Weather in Paris and Rome?"},
+				{Role: openai.ChatMessageRoleAssistant, ToolCalls: []openai.ToolCall{
+					{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+					{ID: "call_2", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Rome"}`}},
+				}},
+				{Role: openai.ChatMessageRoleTool, Content: "sunny", ToolCallID: "call_1"},
+				{Role: openai.ChatMessageRoleTool, Content: "rainy", ToolCallID: "call_2"},
+			},
+			check: func(t *testing.T, request openai.ChatCompletionRequest) {
+				t.Helper()
+
+				require.Len(t, request.Tools, 1)
+				assert.Equal(t, "weather", request.Tools[0].Function.Name)
+				assert.Equal(t, "required", request.ToolChoice)
+				assert.False(t, request.ParallelToolCalls.(bool))
+			},
+		},
+		{
+			name: "NamedToolChoice",
+			body: `{"model":"gpt-4o","input":"Hi","tools":[{"type":"function","name":"weather"}],"tool_choice":{"type":"function","name":"weather"}}`,
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hi"},
+			},
+			check: func(t *testing.T, request openai.ChatCompletionRequest) {
+				t.Helper()
+
+				assert.Equal(t, openai.ToolChoice{Type: openai.ToolTypeFunction, Function: openai.ToolFunction{Name: "weather"}}, request.ToolChoice)
+				assert.Nil(t, request.ParallelToolCalls)
+			},
+		},
+		{
+			name: "JSONSchema",
+			body: `{"model":"gpt-4o","input":"Hi","text":{"format":{"type":"json_schema","name":"answer","schema":{"type":"object"},"strict":true}}}`,
+			messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: "Hi"},
+			},
+			check: func(t *testing.T, request openai.ChatCompletionRequest) {
+				t.Helper()
+
+				require.NotNil(t, request.ResponseFormat)
+				assert.Equal(t, openai.ChatCompletionResponseFormatTypeJSONSchema, request.ResponseFormat.Type)
+				assert.Equal(t, "answer", request.ResponseFormat.JSONSchema.Name)
+				assert.True(t, request.ResponseFormat.JSONSchema.Strict)
+			},
+		},
+		{
+			name: "UnsupportedRole",
+			body: `{"model":"gpt-4o","input":[{"role":"tool","content":"Hi"}]}`,
+			err:  `unsupported message role "tool"`,
+		},
+		{
+			name: "UnsupportedContent",
+			body: `{"model":"gpt-4o","input":[{"role":"user","content":[{"type":"input_file"}]}]}`,
+			err:  `unsupported content type "input_file"`,
+		},
+		{
+			name: "UnsupportedItem",
+			body: `{"model":"gpt-4o","input":[{"type":"reasoning"}]}`,
+			err:  `unsupported input item type "reasoning"`,
+		},
+		{
+			name: "UnsupportedTool",
+			body: `{"model":"gpt-4o","input":"Hi","tools":[{"type":"web_search"}]}`,
+			err:  `unsupported tool type "web_search"`,
+		},
+		{
+			name: "UnsupportedToolChoice",
+			body: `{"model":"gpt-4o","input":"Hi","tool_choice":{"type":"file_search"}}`,
+			err:  `unsupported tool_choice type "file_search"`,
+		},
+		{
+			name: "UnsupportedTextFormat",
+			body: `{"model":"gpt-4o","input":"Hi","text":{"format":{"type":"xml"}}}`,
+			err:  `unsupported text format "xml"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request, _, err := chatRequestOf(decodeRequest(t, tc.body), tc.history)
+			if tc.err != "" {
+				require.ErrorIs(t, err, ErrInvalidRequest)
+				assert.ErrorContains(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.messages, request.Messages)
+
+			if tc.check != nil {
+				tc.check(t, request)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	testCases := []struct {
+		name         string
+		choice       openai.ChatCompletionChoice
+		status       string
+		incomplete   string
+		itemTypes    []string
+		conversation []openai.ChatCompletionMessage
+	}{
+		{
+			name: "Text",
+			choice: openai.ChatCompletionChoice{
+				Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "Hello!"},
+				FinishReason: openai.FinishReasonStop,
+			},
+			status:    StatusCompleted,
+			itemTypes: []string{ItemTypeMessage},
+			conversation: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleAssistant, Content: "Hello!"},
+			},
+		},
+		{
+			name: "ToolCalls",
+			choice: openai.ChatCompletionChoice{
+				Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, ToolCalls: []openai.ToolCall{
+					{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+					{ID: "call_2", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Rome"}`}},
+				}},
+				FinishReason: openai.FinishReasonToolCalls,
+			},
+			status:    StatusCompleted,
+			itemTypes: []string{ItemTypeFunctionCall, ItemTypeFunctionCall},
+			conversation: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleAssistant, ToolCalls: []openai.ToolCall{
+					{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+					{ID: "call_2", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Rome"}`}},
+				}},
+			},
+		},
+		{
+			name: "Length",
+			choice: openai.ChatCompletionChoice{
+				Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "Once upon"},
+				FinishReason: openai.FinishReasonLength,
+			},
+			status:     StatusIncomplete,
+			incomplete: "max_output_tokens",
+			itemTypes:  []string{ItemTypeMessage},
+			conversation: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleAssistant, Content: "Once upon"},
+			},
+		},
+		{
+			name: "ContentFilter",
+			choice: openai.ChatCompletionChoice{
+				Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant},
+				FinishReason: openai.FinishReasonContentFilter,
+			},
+			status:       StatusIncomplete,
+			incomplete:   "content_filter",
+			itemTypes:    []string{},
+			conversation: []openai.ChatCompletionMessage{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := newResponse(decodeRequest(t, `{"model":"gpt-4o","input":"Hi"}`))
+			complete(response, openai.ChatCompletionResponse{
+				Model:   "gpt-4o-2024-08-06",
+				Choices: []openai.ChatCompletionChoice{tc.choice},
+				Usage:   openai.Usage{PromptTokens: 3, CompletionTokens: 5, TotalTokens: 8},
+			})
+
+			assert.Equal(t, "gpt-4o-2024-08-06", response.Model)
+			assert.Equal(t, tc.status, response.Status)
+			assert.Equal(t, &Usage{InputTokens: 3, OutputTokens: 5, TotalTokens: 8}, response.Usage)
+
+			if tc.incomplete == "" {
+				assert.Nil(t, response.IncompleteDetails)
+			} else {
+				require.NotNil(t, response.IncompleteDetails)
+				assert.Equal(t, tc.incomplete, response.IncompleteDetails.Reason)
+			}
+
+			assert.Equal(t, tc.itemTypes, lo.Map(response.Output, func(item OutputItem, _ int) string {
+				switch item := item.(type) {
+				case *OutputMessage:
+					assert.Equal(t, StatusCompleted, item.Status)
+					return item.Type
+				case *FunctionCall:
+					assert.Equal(t, StatusCompleted, item.Status)
+					return item.Type
+				default:
+					return ""
+				}
+			}))
+
+			// The output is carried over to the responses continuing this
+			// one.
+			assert.Equal(t, tc.conversation, messagesOfOutput(response.Output))
+		})
+	}
+}
+
+func TestResponse_UnmarshalJSON(t *testing.T) {
+	response := newResponse(decodeRequest(t, `{"model":"gpt-4o","input":"Hi"}`))
+	complete(response, openai.ChatCompletionResponse{
+		Choices: []openai.ChatCompletionChoice{{
+			Message: openai.ChatCompletionMessage{
+				Content:   "Let me check.",
+				ToolCalls: []openai.ToolCall{{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: "{}"}}},
+			},
+			FinishReason: openai.FinishReasonToolCalls,
+		}},
+	})
+
+	b, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var decoded Response
+
+	err = json.Unmarshal(b, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, response.Output, decoded.Output)
+	assert.Equal(t, response.ID, decoded.ID)
+	assert.Equal(t, response.Usage, decoded.Usage)
+
+	err = json.Unmarshal([]byte(`{"output":[{"type":"reasoning"}]}`), &decoded)
+	require.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package responses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/upstreams"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+const (
+	storeTTL = 24 * time.Hour
+)
+
+var (
+	ErrResponseNotFound = errors.New("response not found")
+)
+
+func Modules() fx.Option {
+	return fx.Options(
+		fx.Provide(NewStore()),
+		fx.Provide(NewResponses()),
+	)
+}
+
+type NewResponsesParams struct {
+	fx.In
+
+	Logger  *logger.Logger
+	Store   Store
+	Gateway *upstreams.Gateway
+}
+
+// Responses implements the OpenAI Responses API on top of the chat path of
+// the gateway, so that it can be served by any upstream.
+type Responses struct {
+	logger  *logger.Logger
+	store   Store
+	gateway *upstreams.Gateway
+}
+
+func NewResponses() func(params NewResponsesParams) *Responses {
+	return func(params NewResponsesParams) *Responses {
+		return &Responses{
+			logger:  params.Logger,
+			store:   params.Store,
+			gateway: params.Gateway,
+		}
+	}
+}
+
+func (r *Responses) load(ctx context.Context, endpoint *authstorage.Endpoint, id string) (*Record, error) {
+	stored, err := r.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Owner != endpoints.OwnerOf(endpoint) {
+		return nil, ErrResponseNotFound
+	}
+
+	return stored, nil
+}
+
+// save stores the response, unless it opted out. The responses which fail to
+// be stored are answered anyway, the failure is logged.
+func (r *Responses) save(ctx context.Context, endpoint *authstorage.Endpoint, response *Response, messages []openai.ChatCompletionMessage) {
+	if !response.Store {
+		return
+	}
+
+	err := r.store.Put(context.WithoutCancel(ctx), &Record{
+		Owner:    endpoints.OwnerOf(endpoint),
+		Response: response,
+		Messages: messages,
+	}, storeTTL)
+	if err != nil {
+		r.logger.Error("failed to store response",
+			zap.String("response_id", response.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// prepare resolves the conversation the request continues and translates
+// the request onto the chat path. The returned messages are the
+// conversation to store along with the response, the output excluded.
+func (r *Responses) prepare(ctx context.Context, endpoint *authstorage.Endpoint, request Request) (openai.ChatCompletionRequest, []openai.ChatCompletionMessage, error) {
+	if request.Model == "" {
+		return openai.ChatCompletionRequest{}, nil, fmt.Errorf("%w: model is required", ErrInvalidRequest)
+	}
+	if len(request.Input) == 0 {
+		return openai.ChatCompletionRequest{}, nil, fmt.Errorf("%w: input is required", ErrInvalidRequest)
+	}
+
+	var history []openai.ChatCompletionMessage
+
+	if request.PreviousResponseID != nil && *request.PreviousResponseID != "" {
+		previous, err := r.load(ctx, endpoint, *request.PreviousResponseID)
+		if err != nil {
+			return openai.ChatCompletionRequest{}, nil, err
+		}
+
+		history = previous.Messages
+	}
+
+	chatRequest, input, err := chatRequestOf(request, history)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, nil, err
+	}
+
+	messages := make([]openai.ChatCompletionMessage, 0, len(history)+len(input))
+	messages = append(messages, history...)
+	messages = append(messages, input...)
+
+	return chatRequest, messages, nil
+}
+
+func (r *Responses) Create(ctx context.Context, endpoint *authstorage.Endpoint, request Request) (*Response, error) {
+	chatRequest, messages, err := r.prepare(ctx, endpoint, request)
+	if err != nil {
+		return nil, err
+	}
+
+	chatResponse, err := r.gateway.CreateChatCompletion(ctx, endpoint, chatRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newResponse(request)
+	complete(response, chatResponse)
+
+	r.save(ctx, endpoint, response, append(messages, messagesOfOutput(response.Output)...))
+
+	return response, nil
+}
+
+func (r *Responses) CreateStream(ctx context.Context, endpoint *authstorage.Endpoint, request Request) (*Stream, error) {
+	chatRequest, messages, err := r.prepare(ctx, endpoint, request)
+	if err != nil {
+		return nil, err
+	}
+
+	chatRequest.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	chatStream, err := r.gateway.CreateChatCompletionStream(ctx, endpoint, chatRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStream(chatStream, newResponse(request), func(response *Response) {
+		r.save(ctx, endpoint, response, append(messages, messagesOfOutput(response.Output)...))
+	}), nil
+}
+
+func (r *Responses) Get(ctx context.Context, endpoint *authstorage.Endpoint, id string) (*Response, error) {
+	stored, err := r.load(ctx, endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored.Response, nil
+}
+
+func (r *Responses) Delete(ctx context.Context, endpoint *authstorage.Endpoint, id string) error {
+	_, err := r.load(ctx, endpoint, id)
+	if err != nil {
+		return err
+	}
+
+	return r.store.Delete(ctx, id)
+}
@@ -0,0 +1,61 @@
+package responses
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+)
+
+// Record is a stored response together with the conversation that led to
+// it, which is replayed when the response is referenced by
+// previous_response_id. Responses are only visible to the endpoint that
+// created them, their owner.
+type Record struct {
+	Owner    string                         `json:"owner"`
+	Response *Response                      `json:"response"`
+	Messages []openai.ChatCompletionMessage `json:"messages"`
+}
+
+// Store keeps the responses stored.
+type Store interface {
+	// Put stores record for ttl.
+	Put(ctx context.Context, record *Record, ttl time.Duration) error
+	// Get returns the record of the response id, ErrResponseNotFound when
+	// it is not stored, or expired.
+	Get(ctx context.Context, id string) (*Record, error)
+	// Delete deletes the record of the response id, ErrResponseNotFound when
+	// it is not stored.
+	Delete(ctx context.Context, id string) error
+}
+
+type NewStoreParams struct {
+	fx.In
+
+	Config  *configs.Config
+	Cache   *datastore.Cache
+	Rueidis *datastore.Rueidis
+}
+
+func NewStore() func(params NewStoreParams) (Store, error) {
+	return func(params NewStoreParams) (Store, error) {
+		switch params.Config.Responses.Storage {
+		case configs.ResponsesStorageMemory, "":
+			return NewMemoryStore(params.Cache), nil
+		case configs.ResponsesStorageRedis:
+			client, err := params.Rueidis.Client()
+			if err != nil {
+				return nil, err
+			}
+
+			return NewRedisStore(client), nil
+		default:
+			return nil, fmt.Errorf("unsupported responses storage %q", params.Config.Responses.Storage)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package responses
+
+import (
+	"context"
+	"time"
+
+	"github.com/lingticio/llmg/internal/datastore"
+)
+
+const memoryKeyPrefix = "responses:"
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore keeps the responses in the memory of the replica.
+type MemoryStore struct {
+	cache *datastore.Cache
+}
+
+func NewMemoryStore(cache *datastore.Cache) *MemoryStore {
+	return &MemoryStore{
+		cache: cache,
+	}
+}
+
+func (s *MemoryStore) Put(_ context.Context, record *Record, ttl time.Duration) error {
+	s.cache.Set(memoryKeyPrefix+record.Response.ID, record, ttl)
+
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*Record, error) {
+	cached, ok := s.cache.Get(memoryKeyPrefix + id)
+	if !ok {
+		return nil, ErrResponseNotFound
+	}
+
+	record, ok := cached.(*Record)
+	if !ok {
+		return nil, ErrResponseNotFound
+	}
+
+	return record, nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	_, ok := s.cache.Get(memoryKeyPrefix + id)
+	if !ok {
+		return ErrResponseNotFound
+	}
+
+	s.cache.Delete(memoryKeyPrefix + id)
+
+	return nil
+}
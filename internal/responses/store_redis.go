@@ -0,0 +1,83 @@
+package responses
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+)
+
+var _ Store = (*RedisStore)(nil)
+
+// RedisStore keeps the responses in Redis, so that they can be referenced
+// through every instance of the gateway.
+type RedisStore struct {
+	rueidis rueidis.Client
+}
+
+func NewRedisStore(r rueidis.Client) *RedisStore {
+	return &RedisStore{
+		rueidis: r,
+	}
+}
+
+func (s *RedisStore) Put(ctx context.Context, record *Record, ttl time.Duration) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	cmd := s.rueidis.B().
+		Set().
+		Key(rediskeys.ResponseByID1.Format(record.Response.ID)).
+		Value(string(b)).
+		Px(ttl).
+		Build()
+
+	return s.rueidis.Do(ctx, cmd).Error()
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*Record, error) {
+	cmd := s.rueidis.B().
+		Get().
+		Key(rediskeys.ResponseByID1.Format(id)).
+		Build()
+
+	res, err := s.rueidis.Do(ctx, cmd).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, ErrResponseNotFound
+		}
+
+		return nil, err
+	}
+
+	var record Record
+
+	err = json.Unmarshal([]byte(res), &record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	cmd := s.rueidis.B().
+		Del().
+		Key(rediskeys.ResponseByID1.Format(id)).
+		Build()
+
+	deleted, err := s.rueidis.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrResponseNotFound
+	}
+
+	return nil
+}
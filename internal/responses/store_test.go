@@ -0,0 +1,90 @@
+package responses
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/internal/datastore"
+)
+
+func testStore(t *testing.T, store Store) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	response := newResponse(Request{Model: "gpt-4o"})
+	complete(response, openai.ChatCompletionResponse{
+		Choices: []openai.ChatCompletionChoice{{
+			Message: openai.ChatCompletionMessage{
+				Content:   "Let me check.",
+				ToolCalls: []openai.ToolCall{{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: "{}"}}},
+			},
+			FinishReason: openai.FinishReasonToolCalls,
+		}},
+	})
+
+	record := &Record{
+		Owner:    "endpoint",
+		Response: response,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, Content: "Weather?"},
+			{Role: openai.ChatMessageRoleAssistant, Content: "Let me check."},
+		},
+	}
+
+	_, err := store.Get(ctx, response.ID)
+	require.ErrorIs(t, err, ErrResponseNotFound)
+
+	err = store.Put(ctx, record, time.Minute)
+	require.NoError(t, err)
+
+	stored, err := store.Get(ctx, response.ID)
+	require.NoError(t, err)
+	assert.Equal(t, record.Owner, stored.Owner)
+	assert.Equal(t, record.Messages, stored.Messages)
+	assert.Equal(t, record.Response.ID, stored.Response.ID)
+	assert.Equal(t, record.Response.Status, stored.Response.Status)
+	assert.Equal(t, record.Response.Output, stored.Response.Output)
+
+	err = store.Delete(ctx, response.ID)
+	require.NoError(t, err)
+
+	_, err = store.Get(ctx, response.ID)
+	require.ErrorIs(t, err, ErrResponseNotFound)
+
+	err = store.Delete(ctx, response.ID)
+	require.ErrorIs(t, err, ErrResponseNotFound)
+
+	// The records expire.
+	err = store.Put(ctx, record, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := store.Get(ctx, response.ID)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMemoryStore(t *testing.T) {
+	cache, err := datastore.NewCache()(datastore.NewCacheParams{})
+	require.NoError(t, err)
+
+	testStore(t, NewMemoryStore(cache))
+}
+
+func TestRedisStore(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(r.Close)
+
+	testStore(t, NewRedisStore(r))
+}
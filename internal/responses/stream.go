@@ -0,0 +1,288 @@
+package responses
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/lingticio/llmg/internal/upstreams"
+)
+
+// chatStream is a streamed chat completion, of the gateway.
+type chatStream interface {
+	Recv() (openai.ChatCompletionStreamResponse, error)
+	Close() error
+}
+
+var _ chatStream = (*upstreams.ChatCompletionStream)(nil)
+
+type streamedCall struct {
+	item        *FunctionCall
+	outputIndex int
+	arguments   strings.Builder
+}
+
+// Stream translates a streamed chat completion into the typed events of the
+// Responses API.
+type Stream struct {
+	chatStream chatStream
+	response   *Response
+	onComplete func(response *Response)
+
+	sequenceNumber int
+	pending        []StreamEvent
+	done           bool
+
+	message            *OutputMessage
+	messageOutputIndex int
+	text               strings.Builder
+
+	calls        map[int]*streamedCall
+	callsInOrder []*streamedCall
+
+	finishReason openai.FinishReason
+}
+
+func newStream(chatStream chatStream, response *Response, onComplete func(response *Response)) *Stream {
+	s := &Stream{
+		chatStream: chatStream,
+		response:   response,
+		onComplete: onComplete,
+		calls:      make(map[int]*streamedCall),
+	}
+
+	s.emit(StreamEvent{Type: "response.created", Response: s.snapshotResponse()})
+	s.emit(StreamEvent{Type: "response.in_progress", Response: s.snapshotResponse()})
+
+	return s
+}
+
+func (s *Stream) emit(event StreamEvent) {
+	event.SequenceNumber = s.sequenceNumber
+	s.sequenceNumber++
+	s.pending = append(s.pending, event)
+}
+
+// Events are queued before being sent, snapshots keep them from observing
+// the mutations made to the response while the rest of the chunk is
+// handled.
+func (s *Stream) snapshotResponse() *Response {
+	response := *s.response
+	response.Output = append(make([]OutputItem, 0, len(s.response.Output)), s.response.Output...)
+
+	return &response
+}
+
+func snapshotMessage(message *OutputMessage) *OutputMessage {
+	snapshot := *message
+	snapshot.Content = append(make([]OutputText, 0, len(message.Content)), message.Content...)
+
+	return &snapshot
+}
+
+func snapshotCall(call *FunctionCall) *FunctionCall {
+	snapshot := *call
+
+	return &snapshot
+}
+
+func (s *Stream) handleContent(delta string) {
+	if s.message == nil {
+		s.message = newOutputMessage()
+		s.messageOutputIndex = len(s.response.Output)
+		s.response.Output = append(s.response.Output, s.message)
+
+		s.emit(StreamEvent{
+			Type:        "response.output_item.added",
+			OutputIndex: lo.ToPtr(s.messageOutputIndex),
+			Item:        snapshotMessage(s.message),
+		})
+
+		part := newOutputText("")
+		s.message.Content = append(s.message.Content, part)
+
+		s.emit(StreamEvent{
+			Type:         "response.content_part.added",
+			ItemID:       s.message.ID,
+			OutputIndex:  lo.ToPtr(s.messageOutputIndex),
+			ContentIndex: lo.ToPtr(0),
+			Part:         &part,
+		})
+	}
+
+	s.text.WriteString(delta)
+
+	s.emit(StreamEvent{
+		Type:         "response.output_text.delta",
+		ItemID:       s.message.ID,
+		OutputIndex:  lo.ToPtr(s.messageOutputIndex),
+		ContentIndex: lo.ToPtr(0),
+		Delta:        lo.ToPtr(delta),
+	})
+}
+
+func (s *Stream) handleToolCall(position int, toolCall openai.ToolCall) {
+	index := lo.FromPtrOr(toolCall.Index, position)
+
+	call, ok := s.calls[index]
+	if !ok {
+		call = &streamedCall{
+			item:        newFunctionCall(openai.ToolCall{ID: toolCall.ID, Function: openai.FunctionCall{Name: toolCall.Function.Name}}),
+			outputIndex: len(s.response.Output),
+		}
+
+		s.calls[index] = call
+		s.callsInOrder = append(s.callsInOrder, call)
+		s.response.Output = append(s.response.Output, call.item)
+
+		s.emit(StreamEvent{
+			Type:        "response.output_item.added",
+			OutputIndex: lo.ToPtr(call.outputIndex),
+			Item:        snapshotCall(call.item),
+		})
+	}
+
+	if toolCall.Function.Arguments == "" {
+		return
+	}
+
+	call.arguments.WriteString(toolCall.Function.Arguments)
+
+	s.emit(StreamEvent{
+		Type:        "response.function_call_arguments.delta",
+		ItemID:      call.item.ID,
+		OutputIndex: lo.ToPtr(call.outputIndex),
+		Delta:       lo.ToPtr(toolCall.Function.Arguments),
+	})
+}
+
+func (s *Stream) handle(chunk openai.ChatCompletionStreamResponse) {
+	if chunk.Model != "" {
+		s.response.Model = chunk.Model
+	}
+	if chunk.Usage != nil {
+		s.response.Usage = usageOf(*chunk.Usage)
+	}
+	if len(chunk.Choices) == 0 {
+		return
+	}
+
+	choice := chunk.Choices[0]
+	if choice.Delta.Content != "" {
+		s.handleContent(choice.Delta.Content)
+	}
+
+	for i, toolCall := range choice.Delta.ToolCalls {
+		s.handleToolCall(i, toolCall)
+	}
+
+	if choice.FinishReason != "" {
+		s.finishReason = choice.FinishReason
+	}
+}
+
+func (s *Stream) complete() {
+	if s.message != nil {
+		text := s.text.String()
+		part := newOutputText(text)
+
+		s.message.Content[0] = part
+		s.message.Status = StatusCompleted
+
+		s.emit(StreamEvent{
+			Type:         "response.output_text.done",
+			ItemID:       s.message.ID,
+			OutputIndex:  lo.ToPtr(s.messageOutputIndex),
+			ContentIndex: lo.ToPtr(0),
+			Text:         lo.ToPtr(text),
+		})
+		s.emit(StreamEvent{
+			Type:         "response.content_part.done",
+			ItemID:       s.message.ID,
+			OutputIndex:  lo.ToPtr(s.messageOutputIndex),
+			ContentIndex: lo.ToPtr(0),
+			Part:         &part,
+		})
+		s.emit(StreamEvent{
+			Type:        "response.output_item.done",
+			OutputIndex: lo.ToPtr(s.messageOutputIndex),
+			Item:        snapshotMessage(s.message),
+		})
+	}
+
+	for _, call := range s.callsInOrder {
+		call.item.Arguments = call.arguments.String()
+		call.item.Status = StatusCompleted
+
+		s.emit(StreamEvent{
+			Type:        "response.function_call_arguments.done",
+			ItemID:      call.item.ID,
+			OutputIndex: lo.ToPtr(call.outputIndex),
+			Arguments:   lo.ToPtr(call.item.Arguments),
+		})
+		s.emit(StreamEvent{
+			Type:        "response.output_item.done",
+			OutputIndex: lo.ToPtr(call.outputIndex),
+			Item:        snapshotCall(call.item),
+		})
+	}
+
+	finish(s.response, s.finishReason)
+	s.onComplete(s.response)
+
+	s.emit(StreamEvent{
+		Type:     lo.Ternary(s.response.Status == StatusIncomplete, "response.incomplete", "response.completed"),
+		Response: s.snapshotResponse(),
+	})
+}
+
+func (s *Stream) fail(err error) {
+	apiErr := upstreams.AsAPIError(err)
+
+	s.response.Status = StatusFailed
+	s.response.Error = &ResponseError{
+		Code:    apiErr.Code,
+		Message: lo.Ternary(apiErr.Detail == "", apiErr.Title, apiErr.Detail),
+	}
+
+	s.emit(StreamEvent{Type: "response.failed", Response: s.snapshotResponse()})
+}
+
+// Recv returns the next event of the stream, or io.EOF once the terminal
+// event, one of response.completed, response.incomplete and response.failed,
+// has been returned.
+func (s *Stream) Recv() (StreamEvent, error) {
+	for len(s.pending) == 0 {
+		if s.done {
+			return StreamEvent{}, io.EOF
+		}
+
+		chunk, err := s.chatStream.Recv()
+		if errors.Is(err, io.EOF) {
+			s.complete()
+			s.done = true
+
+			continue
+		}
+		if err != nil {
+			s.fail(err)
+			s.done = true
+
+			continue
+		}
+
+		s.handle(chunk)
+	}
+
+	event := s.pending[0]
+	s.pending = s.pending[1:]
+
+	return event, nil
+}
+
+func (s *Stream) Close() error {
+	return s.chatStream.Close()
+}
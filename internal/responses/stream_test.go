@@ -0,0 +1,261 @@
+package responses
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chunks streams its chunks, then err, or io.EOF when nil.
+type chunks struct {
+	chunks []openai.ChatCompletionStreamResponse
+	err    error
+	closed bool
+}
+
+func (c *chunks) Recv() (openai.ChatCompletionStreamResponse, error) {
+	if len(c.chunks) == 0 {
+		return openai.ChatCompletionStreamResponse{}, lo.Ternary(c.err == nil, io.EOF, c.err)
+	}
+
+	chunk := c.chunks[0]
+	c.chunks = c.chunks[1:]
+
+	return chunk, nil
+}
+
+func (c *chunks) Close() error {
+	c.closed = true
+	return nil
+}
+
+func contentChunk(content string) openai.ChatCompletionStreamResponse {
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: content}}},
+	}
+}
+
+func toolCallChunk(index int, id string, name string, arguments string) openai.ChatCompletionStreamResponse {
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{{
+			Index:    lo.ToPtr(index),
+			ID:       id,
+			Function: openai.FunctionCall{Name: name, Arguments: arguments},
+		}}}}},
+	}
+}
+
+func finishChunk(reason openai.FinishReason) openai.ChatCompletionStreamResponse {
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{{FinishReason: reason}},
+	}
+}
+
+func usageChunk() openai.ChatCompletionStreamResponse {
+	return openai.ChatCompletionStreamResponse{
+		Model: "gpt-4o-2024-08-06",
+		Usage: &openai.Usage{PromptTokens: 3, CompletionTokens: 5, TotalTokens: 8},
+	}
+}
+
+func TestStream(t *testing.T) {
+	testCases := []struct {
+		name   string
+		chunks chunks
+		events []string
+		status string
+		stored bool
+		check  func(t *testing.T, events []StreamEvent, response *Response)
+	}{
+		{
+			name: "Text",
+			chunks: chunks{chunks: []openai.ChatCompletionStreamResponse{
+				contentChunk("Hello"), contentChunk(" world"), finishChunk(openai.FinishReasonStop), usageChunk(),
+			}},
+			events: []string{
+				"response.created",
+				"response.in_progress",
+				"response.output_item.added",
+				"response.content_part.added",
+				"response.output_text.delta",
+				"response.output_text.delta",
+				"response.output_text.done",
+				"response.content_part.done",
+				"response.output_item.done",
+				"response.completed",
+			},
+			status: StatusCompleted,
+			stored: true,
+			check: func(t *testing.T, events []StreamEvent, response *Response) {
+				t.Helper()
+
+				assert.Equal(t, "Hello world", lo.FromPtr(events[6].Text))
+				// The events queued before the text is done do not observe
+				// it.
+				assert.Empty(t, events[0].Response.Output)
+				assert.Equal(t, StatusInProgress, events[2].Item.(*OutputMessage).Status)
+				assert.Equal(t, StatusCompleted, events[8].Item.(*OutputMessage).Status)
+
+				require.Len(t, response.Output, 1)
+				assert.Equal(t, "Hello world", response.Output[0].(*OutputMessage).Content[0].Text)
+				assert.Equal(t, "gpt-4o-2024-08-06", response.Model)
+				assert.Equal(t, 8, response.Usage.TotalTokens)
+			},
+		},
+		{
+			name: "ToolCalls",
+			chunks: chunks{chunks: []openai.ChatCompletionStreamResponse{
+				toolCallChunk(0, "call_1", "weather", ""),
+				toolCallChunk(0, "", "", `{"city":`),
+				toolCallChunk(1, "call_2", "time", "{}"),
+				toolCallChunk(0, "", "", `"Paris"}`),
+				finishChunk(openai.FinishReasonToolCalls),
+			}},
+			events: []string{
+				"response.created",
+				"response.in_progress",
+				"response.output_item.added",
+				"response.function_call_arguments.delta",
+				"response.output_item.added",
+				"response.function_call_arguments.delta",
+				"response.function_call_arguments.delta",
+				"response.function_call_arguments.done",
+				"response.output_item.done",
+				"response.function_call_arguments.done",
+				"response.output_item.done",
+				"response.completed",
+			},
+			status: StatusCompleted,
+			stored: true,
+			check: func(t *testing.T, events []StreamEvent, response *Response) {
+				t.Helper()
+
+				assert.Equal(t, `{"city":"Paris"}`, lo.FromPtr(events[7].Arguments))
+				assert.Equal(t, 0, lo.FromPtr(events[7].OutputIndex))
+				assert.Equal(t, "{}", lo.FromPtr(events[9].Arguments))
+				assert.Equal(t, 1, lo.FromPtr(events[9].OutputIndex))
+
+				require.Len(t, response.Output, 2)
+				assert.Equal(t, "call_1", response.Output[0].(*FunctionCall).CallID)
+				assert.Equal(t, "call_2", response.Output[1].(*FunctionCall).CallID)
+			},
+		},
+		{
+			name: "Length",
+			chunks: chunks{chunks: []openai.ChatCompletionStreamResponse{
+				contentChunk("Once upon"), finishChunk(openai.FinishReasonLength),
+			}},
+			events: []string{
+				"response.created",
+				"response.in_progress",
+				"response.output_item.added",
+				"response.content_part.added",
+				"response.output_text.delta",
+				"response.output_text.done",
+				"response.content_part.done",
+				"response.output_item.done",
+				"response.incomplete",
+			},
+			status: StatusIncomplete,
+			stored: true,
+			check: func(t *testing.T, _ []StreamEvent, response *Response) {
+				t.Helper()
+
+				require.NotNil(t, response.IncompleteDetails)
+				assert.Equal(t, "max_output_tokens", response.IncompleteDetails.Reason)
+			},
+		},
+		{
+			name: "Failed",
+			chunks: chunks{
+				chunks: []openai.ChatCompletionStreamResponse{contentChunk("Hello")},
+				err:    &openai.APIError{HTTPStatusCode: http.StatusBadGateway, Message: "upstream failed"},
+			},
+			events: []string{
+				"response.created",
+				"response.in_progress",
+				"response.output_item.added",
+				"response.content_part.added",
+				"response.output_text.delta",
+				"response.failed",
+			},
+			status: StatusFailed,
+			check: func(t *testing.T, _ []StreamEvent, response *Response) {
+				t.Helper()
+
+				require.NotNil(t, response.Error)
+				assert.NotEmpty(t, response.Error.Message)
+			},
+		},
+		{
+			name:   "FailedBeforeOutput",
+			chunks: chunks{err: errors.New("connection reset")},
+			events: []string{
+				"response.created",
+				"response.in_progress",
+				"response.failed",
+			},
+			status: StatusFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stored *Response
+
+			stream := newStream(&tc.chunks, newResponse(decodeRequest(t, `{"model":"gpt-4o","input":"Hi","stream":true}`)), func(response *Response) {
+				stored = response
+			})
+
+			var events []StreamEvent
+
+			for {
+				event, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				require.NoError(t, err)
+
+				events = append(events, event)
+			}
+
+			// Receiving after the terminal event keeps returning io.EOF.
+			_, err := stream.Recv()
+			require.ErrorIs(t, err, io.EOF)
+
+			require.NoError(t, stream.Close())
+			assert.True(t, tc.chunks.closed)
+
+			assert.Equal(t, tc.events, lo.Map(events, func(item StreamEvent, _ int) string {
+				return item.Type
+			}))
+
+			for i, event := range events {
+				assert.Equal(t, i, event.SequenceNumber)
+			}
+
+			terminal := events[len(events)-1].Response
+			require.NotNil(t, terminal)
+			assert.Equal(t, tc.status, terminal.Status)
+
+			// Only the responses which were answered are stored.
+			if tc.stored {
+				require.NotNil(t, stored)
+				assert.Equal(t, terminal.ID, stored.ID)
+			} else {
+				assert.Nil(t, stored)
+			}
+
+			if tc.check != nil {
+				tc.check(t, events, terminal)
+			}
+		})
+	}
+}
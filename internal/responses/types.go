@@ -0,0 +1,299 @@
+package responses
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	ItemTypeMessage            = "message"
+	ItemTypeFunctionCall       = "function_call"
+	ItemTypeFunctionCallOutput = "function_call_output"
+
+	ContentTypeInputText  = "input_text"
+	ContentTypeInputImage = "input_image"
+	ContentTypeOutputText = "output_text"
+	ContentTypeRefusal    = "refusal"
+
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+	StatusIncomplete = "incomplete"
+	StatusFailed     = "failed"
+)
+
+// ContentPart is a part of the content of an input message.
+type ContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Refusal  string `json:"refusal,omitempty"`
+}
+
+// MessageContent is either a plain string or a list of content parts, a
+// plain string is decoded as a single input_text part.
+type MessageContent []ContentPart
+
+func (c *MessageContent) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var text string
+
+		err := json.Unmarshal(data, &text)
+		if err != nil {
+			return err
+		}
+
+		*c = MessageContent{{Type: ContentTypeInputText, Text: text}}
+
+		return nil
+	}
+
+	var parts []ContentPart
+
+	err := json.Unmarshal(data, &parts)
+	if err != nil {
+		return err
+	}
+
+	*c = parts
+
+	return nil
+}
+
+// InputItem is an item of the input of a response, it is either a message,
+// a function call issued by the model or the output of a function call.
+type InputItem struct {
+	Type      string         `json:"type,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	Status    string         `json:"status,omitempty"`
+	Role      string         `json:"role,omitempty"`
+	Content   MessageContent `json:"content,omitempty"`
+	CallID    string         `json:"call_id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Arguments string         `json:"arguments,omitempty"`
+	Output    string         `json:"output,omitempty"`
+}
+
+// Input is either a plain string or a list of input items, a plain string is
+// decoded as a single user message.
+type Input []InputItem
+
+func (i *Input) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var text string
+
+		err := json.Unmarshal(data, &text)
+		if err != nil {
+			return err
+		}
+
+		*i = Input{{
+			Type:    ItemTypeMessage,
+			Role:    "user",
+			Content: MessageContent{{Type: ContentTypeInputText, Text: text}},
+		}}
+
+		return nil
+	}
+
+	var items []InputItem
+
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+
+	*i = items
+
+	return nil
+}
+
+type Tool struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+type TextFormat struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+type TextConfig struct {
+	Format TextFormat `json:"format"`
+}
+
+// Request is the request body of POST /v1/responses.
+type Request struct {
+	Model              string            `json:"model"`
+	Input              Input             `json:"input"`
+	Instructions       *string           `json:"instructions,omitempty"`
+	PreviousResponseID *string           `json:"previous_response_id,omitempty"`
+	Store              *bool             `json:"store,omitempty"`
+	Stream             bool              `json:"stream,omitempty"`
+	Temperature        *float32          `json:"temperature,omitempty"`
+	TopP               *float32          `json:"top_p,omitempty"`
+	MaxOutputTokens    *int              `json:"max_output_tokens,omitempty"`
+	Tools              []Tool            `json:"tools,omitempty"`
+	ToolChoice         json.RawMessage   `json:"tool_choice,omitempty"`
+	ParallelToolCalls  *bool             `json:"parallel_tool_calls,omitempty"`
+	Text               *TextConfig       `json:"text,omitempty"`
+	User               string            `json:"user,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// OutputItem is an item of the output of a response, either an
+// *OutputMessage or a *FunctionCall.
+type OutputItem interface {
+	outputItem()
+}
+
+type OutputText struct {
+	Type        string `json:"type"`
+	Text        string `json:"text"`
+	Annotations []any  `json:"annotations"`
+}
+
+type OutputMessage struct {
+	Type    string       `json:"type"`
+	ID      string       `json:"id"`
+	Status  string       `json:"status"`
+	Role    string       `json:"role"`
+	Content []OutputText `json:"content"`
+}
+
+func (*OutputMessage) outputItem() {}
+
+type FunctionCall struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	CallID    string `json:"call_id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+func (*FunctionCall) outputItem() {}
+
+type InputTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+type OutputTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+type Usage struct {
+	InputTokens         int                 `json:"input_tokens"`
+	InputTokensDetails  InputTokensDetails  `json:"input_tokens_details"`
+	OutputTokens        int                 `json:"output_tokens"`
+	OutputTokensDetails OutputTokensDetails `json:"output_tokens_details"`
+	TotalTokens         int                 `json:"total_tokens"`
+}
+
+type ResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type IncompleteDetails struct {
+	Reason string `json:"reason"`
+}
+
+// Response is the response object of the Responses API.
+type Response struct {
+	ID                 string             `json:"id"`
+	Object             string             `json:"object"`
+	CreatedAt          int64              `json:"created_at"`
+	Status             string             `json:"status"`
+	Error              *ResponseError     `json:"error"`
+	IncompleteDetails  *IncompleteDetails `json:"incomplete_details"`
+	Instructions       *string            `json:"instructions"`
+	MaxOutputTokens    *int               `json:"max_output_tokens"`
+	Model              string             `json:"model"`
+	Output             []OutputItem       `json:"output"`
+	ParallelToolCalls  bool               `json:"parallel_tool_calls"`
+	PreviousResponseID *string            `json:"previous_response_id"`
+	Store              bool               `json:"store"`
+	Temperature        *float32           `json:"temperature"`
+	TopP               *float32           `json:"top_p"`
+	Text               TextConfig         `json:"text"`
+	ToolChoice         any                `json:"tool_choice"`
+	Tools              []Tool             `json:"tools"`
+	Usage              *Usage             `json:"usage"`
+	User               *string            `json:"user"`
+	Metadata           map[string]string  `json:"metadata"`
+}
+
+// UnmarshalJSON decodes the items of the output by their type, e.g. when the
+// response is read back from the store.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type response Response
+
+	var decoded struct {
+		response
+
+		Output []json.RawMessage `json:"output"`
+	}
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*r = Response(decoded.response)
+	r.Output = make([]OutputItem, 0, len(decoded.Output))
+
+	for _, raw := range decoded.Output {
+		var typed struct {
+			Type string `json:"type"`
+		}
+
+		err := json.Unmarshal(raw, &typed)
+		if err != nil {
+			return err
+		}
+
+		var item OutputItem
+
+		switch typed.Type {
+		case ItemTypeMessage:
+			item = &OutputMessage{}
+		case ItemTypeFunctionCall:
+			item = &FunctionCall{}
+		default:
+			return fmt.Errorf("unsupported output item type %q", typed.Type)
+		}
+
+		err = json.Unmarshal(raw, item)
+		if err != nil {
+			return err
+		}
+
+		r.Output = append(r.Output, item)
+	}
+
+	return nil
+}
+
+// StreamEvent is a typed event of a streamed response, named after its
+// type, e.g. response.output_text.delta.
+type StreamEvent struct {
+	Type           string      `json:"type"`
+	SequenceNumber int         `json:"sequence_number"`
+	Response       *Response   `json:"response,omitempty"`
+	OutputIndex    *int        `json:"output_index,omitempty"`
+	ContentIndex   *int        `json:"content_index,omitempty"`
+	ItemID         string      `json:"item_id,omitempty"`
+	Item           OutputItem  `json:"item,omitempty"`
+	Part           *OutputText `json:"part,omitempty"`
+	Delta          *string     `json:"delta,omitempty"`
+	Text           *string     `json:"text,omitempty"`
+	Arguments      *string     `json:"arguments,omitempty"`
+}
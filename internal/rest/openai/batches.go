@@ -0,0 +1,186 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"github.com/lingticio/llmg/internal/batches"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+type DeleteFileResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+type ListBatchesResponse struct {
+	Object  string          `json:"object"`
+	Data    []batches.Batch `json:"data"`
+	FirstID *string         `json:"first_id"`
+	LastID  *string         `json:"last_id"`
+	HasMore bool            `json:"has_more"`
+}
+
+func batchesAPIError(err error) *apierrors.Error {
+	switch {
+	case errors.Is(err, batches.ErrFileNotFound), errors.Is(err, batches.ErrBatchNotFound):
+		return apierrors.NewErrNotFound().WithDetail(err.Error())
+	case errors.Is(err, batches.ErrInvalidRequest):
+		return apierrors.NewErrInvalidArgument().WithDetail(err.Error())
+	case errors.Is(err, batches.ErrBatchTerminal):
+		return apierrors.NewBadRequest().WithDetail(err.Error())
+	case errors.Is(err, authstorage.ErrScopeNotGranted):
+		return endpoints.AsAPIError(err)
+	default:
+		return apierrors.NewErrInternal().WithError(err).WithCaller()
+	}
+}
+
+func (h *Handlers) CreateFile(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return respondError(c, apierrors.NewBadRequest().WithDetail("missing file in multipart form").WithSourceParameter("file"))
+	}
+
+	content, err := header.Open()
+	if err != nil {
+		return respondError(c, apierrors.NewErrInternal().WithError(err).WithCaller())
+	}
+
+	defer content.Close()
+
+	file, err := h.batches.CreateFile(ctx, endpoint, header.Filename, c.FormValue("purpose"), content)
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, file)
+}
+
+func (h *Handlers) GetFile(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	file, err := h.batches.GetFile(ctx, endpoint, c.Param("id"))
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, file)
+}
+
+func (h *Handlers) GetFileContent(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	content, err := h.batches.OpenFileContent(ctx, endpoint, c.Param("id"))
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	defer content.Close()
+
+	return c.Stream(http.StatusOK, "application/jsonl", content)
+}
+
+func (h *Handlers) DeleteFile(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	err := h.batches.DeleteFile(ctx, endpoint, c.Param("id"))
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, DeleteFileResponse{
+		ID:      c.Param("id"),
+		Object:  "file",
+		Deleted: true,
+	})
+}
+
+func (h *Handlers) CreateBatch(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	var request batches.CreateRequest
+
+	apiErr := bindJSON(c, &request)
+	if apiErr != nil {
+		return respondError(c, apiErr)
+	}
+
+	batch, err := h.batches.Create(ctx, endpoint, request)
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+func (h *Handlers) GetBatch(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	batch, err := h.batches.Get(ctx, endpoint, c.Param("id"))
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+func (h *Handlers) CancelBatch(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	batch, err := h.batches.Cancel(ctx, endpoint, c.Param("id"))
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+func (h *Handlers) ListBatches(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	var limit int
+
+	if c.QueryParam("limit") != "" {
+		parsed, err := strconv.Atoi(c.QueryParam("limit"))
+		if err != nil {
+			return respondError(c, apierrors.NewErrInvalidArgument().WithDetail("limit must be an integer").WithSourceParameter("limit"))
+		}
+
+		limit = parsed
+	}
+
+	list, hasMore, err := h.batches.List(ctx, endpoint, c.QueryParam("after"), limit)
+	if err != nil {
+		return respondError(c, batchesAPIError(err))
+	}
+
+	response := ListBatchesResponse{
+		Object:  "list",
+		Data:    list,
+		HasMore: hasMore,
+	}
+	if len(list) > 0 {
+		response.FirstID = lo.ToPtr(list[0].ID)
+		response.LastID = lo.ToPtr(list[len(list)-1].ID)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,174 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/internal/batches"
+)
+
+func uploadBatchFile(t *testing.T, serverURL string, apiKey string, lines ...string) batches.File {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	require.NoError(t, writer.WriteField("purpose", batches.FilePurposeBatch))
+
+	part, err := writer.CreateFormFile("file", "input.jsonl")
+	require.NoError(t, err)
+
+	_, err = part.Write([]byte(strings.Join(lines, "\n") + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	request, err := http.NewRequest(http.MethodPost, serverURL+"/v1/files", body) //nolint:noctx
+	require.NoError(t, err)
+
+	request.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+apiKey)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	defer response.Body.Close()
+
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var file batches.File
+
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&file))
+
+	return file
+}
+
+// awaitBatch creates a batch of the input file, and polls it until it is
+// finished.
+func awaitBatch(t *testing.T, serverURL string, apiKey string, inputFileID string) batches.Batch {
+	t.Helper()
+
+	response := doJSON(t, http.MethodPost, serverURL+"/v1/batches", apiKey, `{"input_file_id":"`+inputFileID+`","endpoint":"/v1/chat/completions","completion_window":"24h"}`)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var batch batches.Batch
+
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&batch))
+
+	require.Eventually(t, func() bool {
+		response := doJSON(t, http.MethodGet, serverURL+"/v1/batches/"+batch.ID, apiKey, "")
+		if response.StatusCode != http.StatusOK {
+			return false
+		}
+
+		batch = batches.Batch{}
+
+		return json.NewDecoder(response.Body).Decode(&batch) == nil && batch.Terminal()
+	}, 10*time.Second, 50*time.Millisecond)
+
+	return batch
+}
+
+func downloadResults(t *testing.T, serverURL string, apiKey string, fileID *string) map[string]batches.ResultLine {
+	t.Helper()
+
+	require.NotNil(t, fileID)
+
+	response := doJSON(t, http.MethodGet, serverURL+"/v1/files/"+*fileID+"/content", apiKey, "")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	results := make(map[string]batches.ResultLine)
+
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		var result batches.ResultLine
+
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &result))
+
+		results[result.CustomID] = result
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return results
+}
+
+func TestBatches(t *testing.T) {
+	server := newTestServer(t, newTestUpstream(t).URL)
+
+	file := uploadBatchFile(t, server.URL, "sk-test",
+		`{"custom_id":"answered","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}}`,
+		`{"custom_id":"rejected","method":"POST","url":"/v1/chat/completions","body":{"model":"`+modelInvalid+`","messages":[{"role":"user","content":"Hi"}]}}`,
+		`{"custom_id":"malformed","method":"POST","url":"/v1/chat/completions","body":"Hi"}`,
+	)
+
+	batch := awaitBatch(t, server.URL, "sk-test", file.ID)
+	require.Equal(t, batches.StatusCompleted, batch.Status)
+	assert.Equal(t, batches.RequestCounts{Total: 3, Completed: 1, Failed: 2}, batch.RequestCounts)
+
+	// Every result is written to the output file, the failed ones to the
+	// error file as well, each identified by its custom_id.
+	output := downloadResults(t, server.URL, "sk-test", batch.OutputFileID)
+	require.Len(t, output, 3)
+	require.Contains(t, output, "answered")
+	require.NotNil(t, output["answered"].Response)
+	assert.Nil(t, output["answered"].Error)
+	assert.Equal(t, http.StatusOK, output["answered"].Response.StatusCode)
+
+	body, err := json.Marshal(output["answered"].Response.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "echo: Hi")
+
+	errs := downloadResults(t, server.URL, "sk-test", batch.ErrorFileID)
+	require.Len(t, errs, 2)
+	assert.NotContains(t, errs, "answered")
+	assert.Equal(t, output["rejected"].Error, errs["rejected"].Error)
+	require.Contains(t, errs, "rejected")
+	require.NotNil(t, errs["rejected"].Error)
+	assert.Nil(t, errs["rejected"].Response)
+	assert.Contains(t, errs["rejected"].Error.Message, "the model does not exist")
+	require.Contains(t, errs, "malformed")
+	require.NotNil(t, errs["malformed"].Error)
+	assert.Equal(t, "invalid_request", errs["malformed"].Error.Code)
+
+	// The keys of the endpoint not granted the batches scope cannot read the
+	// results.
+	response := doJSON(t, http.MethodGet, server.URL+"/v1/files/"+*batch.OutputFileID+"/content", "sk-embeddings", "")
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func TestBatches_InvalidLines(t *testing.T) {
+	server := newTestServer(t, newTestUpstream(t).URL)
+
+	file := uploadBatchFile(t, server.URL, "sk-test",
+		`{"custom_id":"first","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}}`,
+		`{"custom_id":"second",`,
+		`{"custom_id":"first","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}}`,
+		`{"custom_id":"third","method":"POST","url":"/v1/embeddings","body":{"model":"text-embedding-3-small","input":"Hi"}}`,
+	)
+
+	batch := awaitBatch(t, server.URL, "sk-test", file.ID)
+	require.Equal(t, batches.StatusFailed, batch.Status)
+	assert.Nil(t, batch.OutputFileID)
+	assert.Nil(t, batch.ErrorFileID)
+
+	// Every invalid line is reported along with its line number, and none of
+	// the requests is sent.
+	require.NotNil(t, batch.Errors)
+	assert.Equal(t, []string{"invalid_json_line", "duplicate_custom_id", "mismatched_endpoint"}, lo.Map(batch.Errors.Data, func(item batches.BatchError, _ int) string {
+		return item.Code
+	}))
+	assert.Equal(t, []int{2, 3, 4}, lo.Map(batch.Errors.Data, func(item batches.BatchError, _ int) int {
+		return lo.FromPtr(item.Line)
+	}))
+}
@@ -0,0 +1,72 @@
+package openai
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"github.com/lingticio/llmg/pkg/apierrors"
+)
+
+// ErrorResponse is the error envelope of the OpenAI REST API, which the SDKs
+// parse the errors of the gateway from.
+type ErrorResponse struct {
+	Error ErrorObject `json:"error"`
+}
+
+type ErrorObject struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    *string `json:"code"`
+}
+
+// errorTypeOf returns the type OpenAI reports the errors of status with.
+func errorTypeOf(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return "authentication_error"
+	case status == http.StatusPaymentRequired:
+		return "insufficient_quota"
+	case status == http.StatusForbidden:
+		return "permission_error"
+	case status == http.StatusNotFound:
+		return "not_found_error"
+	case status == http.StatusTooManyRequests:
+		return "rate_limit_error"
+	case status >= http.StatusInternalServerError:
+		return "server_error"
+	default:
+		return "invalid_request_error"
+	}
+}
+
+// errorResponseOf maps apiErr onto the OpenAI error envelope, its code in
+// lower case, e.g. api_key_revoked, and the parameter of its source, if
+// any.
+func errorResponseOf(apiErr *apierrors.Error) ErrorResponse {
+	response := ErrorResponse{
+		Error: ErrorObject{
+			Message: lo.Ternary(apiErr.Detail == "", apiErr.Title, apiErr.Detail),
+			Type:    errorTypeOf(int(apiErr.Status)), //nolint:gosec
+			Code:    lo.ToPtr(strings.ToLower(apiErr.Code)),
+		},
+	}
+
+	if apiErr.Source != nil {
+		param := lo.CoalesceOrEmpty(strings.TrimPrefix(apiErr.Source.Pointer, "/"), apiErr.Source.Parameter, apiErr.Source.Header)
+		if param != "" {
+			response.Error.Param = lo.ToPtr(strings.ReplaceAll(param, "/", "."))
+		}
+	}
+
+	return response
+}
+
+// respondError responds apiErr in the OpenAI error envelope, with its
+// status.
+func respondError(c echo.Context, apiErr *apierrors.Error) error {
+	return c.JSON(int(apiErr.Status), errorResponseOf(apiErr)) //nolint:gosec
+}
@@ -0,0 +1,286 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nekomeowww/xo/logger"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/batches"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/responses"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/util/eventsource"
+	grpcpkg "github.com/lingticio/llmg/pkg/util/grpc"
+)
+
+type NewHandlersParams struct {
+	fx.In
+
+	Logger         *logger.Logger
+	Authenticator  *endpoints.Authenticator
+	UpstreamModels *upstreams.Models
+	Gateway        *upstreams.Gateway
+	Responses      *responses.Responses
+	Batches        *batches.Batches
+}
+
+// Handlers serves the OpenAI compatible REST API, so that SDKs and tools
+// which only speak the OpenAI REST shape can use the gateway directly.
+type Handlers struct {
+	logger         *logger.Logger
+	authenticator  *endpoints.Authenticator
+	upstreamModels *upstreams.Models
+	gateway        *upstreams.Gateway
+	responses      *responses.Responses
+	batches        *batches.Batches
+}
+
+func NewHandlers() func(params NewHandlersParams) *Handlers {
+	return func(params NewHandlersParams) *Handlers {
+		return &Handlers{
+			logger:         params.Logger,
+			authenticator:  params.Authenticator,
+			upstreamModels: params.UpstreamModels,
+			gateway:        params.Gateway,
+			responses:      params.Responses,
+			batches:        params.Batches,
+		}
+	}
+}
+
+func (h *Handlers) Install(register *grpcpkg.Register) {
+	// Requests are authenticated before their body is read, so that the
+	// callers not allowed to are rejected as such, rather than for their
+	// body.
+	chat := h.authenticate(authstorage.ScopeChat)
+	embeddings := h.authenticate(authstorage.ScopeEmbeddings)
+	models := h.authenticate(authstorage.ScopeModels)
+	batch := h.authenticate(authstorage.ScopeBatches)
+
+	// Requests may address another endpoint than the one of their API key by
+	// its alias, except for files and batches, which are processed in the
+	// background as the endpoint of the API key.
+	for _, prefix := range []string{"/v1", "/v1/e/:alias"} {
+		register.RegisterEchoHandler(prefix+"/chat/completions", http.MethodPost, chat(h.CreateChatCompletion))
+		register.RegisterEchoHandler(prefix+"/embeddings", http.MethodPost, embeddings(h.CreateEmbeddings))
+		register.RegisterEchoHandler(prefix+"/models", http.MethodGet, models(h.ListModels))
+		register.RegisterEchoHandler(prefix+"/responses", http.MethodPost, chat(h.CreateResponse))
+		register.RegisterEchoHandler(prefix+"/responses/:id", http.MethodGet, chat(h.GetResponse))
+		register.RegisterEchoHandler(prefix+"/responses/:id", http.MethodDelete, chat(h.DeleteResponse))
+	}
+
+	register.RegisterEchoHandler("/v1/files", http.MethodPost, batch(h.CreateFile))
+	register.RegisterEchoHandler("/v1/files/:id", http.MethodGet, batch(h.GetFile))
+	register.RegisterEchoHandler("/v1/files/:id", http.MethodDelete, batch(h.DeleteFile))
+	register.RegisterEchoHandler("/v1/files/:id/content", http.MethodGet, batch(h.GetFileContent))
+	register.RegisterEchoHandler("/v1/batches", http.MethodPost, batch(h.CreateBatch))
+	register.RegisterEchoHandler("/v1/batches", http.MethodGet, batch(h.ListBatches))
+	register.RegisterEchoHandler("/v1/batches/:id", http.MethodGet, batch(h.GetBatch))
+	register.RegisterEchoHandler("/v1/batches/:id/cancel", http.MethodPost, batch(h.CancelBatch))
+}
+
+func apiKeyFromRequest(r *http.Request) string {
+	apiKey := r.Header.Get("X-Api-Key")
+	if apiKey != "" {
+		return apiKey
+	}
+
+	return strings.TrimPrefix(r.Header.Get(echo.HeaderAuthorization), "Bearer ")
+}
+
+// endpointFromRequest authenticates the request, and requires its API key to
+// be granted scope. The endpoint is the one addressed by the alias in the
+// path, if any. API keys not issued by the gateway are rejected, unless
+// passthrough to the X-Base-Url header is enabled, see
+// endpoints.Authenticator.Resolve.
+func (h *Handlers) endpointFromRequest(c echo.Context, scope authstorage.Scope) (*authstorage.Endpoint, *apierrors.Error) {
+	apiKey := apiKeyFromRequest(c.Request())
+	if apiKey == "" {
+		return nil, apierrors.NewErrUnauthorized().WithDetail("missing API key in Authorization or X-Api-Key header")
+	}
+
+	endpoint, err := h.authenticator.ResolveAlias(c.Request().Context(), apiKey, c.Request().Header.Get("X-Base-Url"), c.Param("alias"))
+	if err == nil {
+		err = endpoints.RequireScope(endpoint, scope)
+	}
+	if err != nil {
+		return nil, endpoints.AsAPIError(err)
+	}
+
+	return endpoint, nil
+}
+
+// authenticate authenticates the requests with endpointFromRequest, and
+// attaches their endpoint to the context of the request, see
+// endpoints.EndpointFromContext.
+func (h *Handlers) authenticate(scope authstorage.Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			endpoint, apiErr := h.endpointFromRequest(c, scope)
+			if apiErr != nil {
+				return respondError(c, apiErr)
+			}
+
+			c.SetRequest(c.Request().WithContext(endpoints.WithEndpoint(c.Request().Context(), endpoint)))
+
+			return next(c)
+		}
+	}
+}
+
+func bindJSON(c echo.Context, v any) *apierrors.Error {
+	err := json.NewDecoder(c.Request().Body).Decode(v)
+	if err != nil {
+		return apierrors.NewBadRequest().WithDetail("malformed request body: " + err.Error())
+	}
+
+	return nil
+}
+
+// bindChatCompletionRequest binds the chat completion request of the body,
+// keeping a temperature of 0, which would be omitted otherwise, as
+// exactcache.ZeroTemperature.
+func bindChatCompletionRequest(c echo.Context, request *openai.ChatCompletionRequest) *apierrors.Error {
+	var body json.RawMessage
+
+	apiErr := bindJSON(c, &body)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	var temperature struct {
+		Temperature *float32 `json:"temperature"`
+	}
+
+	err := json.Unmarshal(body, &temperature)
+	if err == nil {
+		err = json.Unmarshal(body, request)
+	}
+	if err != nil {
+		return apierrors.NewBadRequest().WithDetail("malformed request body: " + err.Error())
+	}
+	if temperature.Temperature != nil {
+		request.Temperature = exactcache.Temperature(*temperature.Temperature)
+	}
+
+	return nil
+}
+
+func (h *Handlers) CreateChatCompletion(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	var request openai.ChatCompletionRequest
+
+	apiErr := bindChatCompletionRequest(c, &request)
+	if apiErr != nil {
+		return respondError(c, apiErr)
+	}
+	if request.Model == "" {
+		return respondError(c, apierrors.NewErrInvalidArgument().WithDetail("model is required").WithSourcePointer("/model"))
+	}
+	if len(request.Messages) == 0 {
+		return respondError(c, apierrors.NewErrInvalidArgument().WithDetail("messages must not be empty").WithSourcePointer("/messages"))
+	}
+
+	if request.Stream {
+		return h.createChatCompletionStream(c, endpoint, request)
+	}
+
+	response, err := h.gateway.CreateChatCompletion(ctx, endpoint, request)
+	if err != nil {
+		return respondError(c, upstreams.AsAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Handlers) createChatCompletionStream(c echo.Context, endpoint *authstorage.Endpoint, request openai.ChatCompletionRequest) error {
+	ctx := c.Request().Context()
+
+	stream, err := h.gateway.CreateChatCompletionStream(ctx, endpoint, request)
+	if err != nil {
+		return respondError(c, upstreams.AsAPIError(err))
+	}
+
+	defer stream.Close()
+
+	es := eventsource.NewEventSource[any](eventsource.WithEchoResponse(c.Response()))
+
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			h.logger.Error("failed to receive chat completion stream", zap.Error(err))
+
+			// Headers are already flushed by now, the error can only be
+			// reported as the last event of the stream.
+			return es.SendJSON(errorResponseOf(upstreams.AsAPIError(err)))
+		}
+
+		err = es.SendJSON(response)
+		if err != nil {
+			h.logger.Error("failed to send chat completion stream", zap.Error(err))
+			return nil
+		}
+	}
+
+	return es.SendRaw([]byte("[DONE]"))
+}
+
+func (h *Handlers) CreateEmbeddings(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	var request openai.EmbeddingRequest
+
+	apiErr := bindJSON(c, &request)
+	if apiErr != nil {
+		return respondError(c, apiErr)
+	}
+	if request.Model == "" {
+		return respondError(c, apierrors.NewErrInvalidArgument().WithDetail("model is required").WithSourcePointer("/model"))
+	}
+	if request.Input == nil {
+		return respondError(c, apierrors.NewErrInvalidArgument().WithDetail("input is required").WithSourcePointer("/input"))
+	}
+
+	response, err := h.gateway.CreateEmbeddings(ctx, endpoint, request)
+	if err != nil {
+		return respondError(c, upstreams.AsAPIError(err))
+	}
+
+	if request.EncodingFormat == openai.EmbeddingEncodingFormatBase64 {
+		return c.JSON(http.StatusOK, upstreams.Base64EmbeddingResponseOf(response))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Handlers) ListModels(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	models, err := h.upstreamModels.List(ctx, endpoint)
+	if err != nil {
+		return respondError(c, apierrors.NewErrUnavailable().WithError(err))
+	}
+
+	return c.JSON(http.StatusOK, ListModelsResponse{
+		Object: "list",
+		Data:   models,
+	})
+}
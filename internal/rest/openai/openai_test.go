@@ -0,0 +1,324 @@
+package openai
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/lingticio/llmg/internal/batches"
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/responses"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+	grpcpkg "github.com/lingticio/llmg/pkg/util/grpc"
+)
+
+// modelInvalid is rejected by the upstream of the tests.
+const modelInvalid = "invalid"
+
+// newTestUpstream serves chat completions answering the content of the last
+// message, streamed in two chunks when requested.
+func newTestUpstream(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil || r.URL.Path != "/v1/chat/completions" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if request.Model == modelInvalid {
+			w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"the model does not exist","type":"invalid_request_error","code":"model_not_found"}}`))
+
+			return
+		}
+
+		content := "echo: " + request.Messages[len(request.Messages)-1].Content
+		usage := openai.Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}
+
+		if !request.Stream {
+			w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			_ = json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+				ID:    "chatcmpl-test",
+				Model: request.Model,
+				Choices: []openai.ChatCompletionChoice{{
+					Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content},
+					FinishReason: openai.FinishReasonStop,
+				}},
+				Usage: usage,
+			})
+
+			return
+		}
+
+		w.Header().Set(echo.HeaderContentType, "text/event-stream")
+
+		for _, chunk := range []openai.ChatCompletionStreamResponse{
+			{ID: "chatcmpl-test", Model: request.Model, Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "echo: "}}}},
+			{ID: "chatcmpl-test", Model: request.Model, Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: strings.TrimPrefix(content, "echo: ")}, FinishReason: openai.FinishReasonStop}}},
+			{ID: "chatcmpl-test", Model: request.Model, Choices: []openai.ChatCompletionStreamChoice{}, Usage: &usage},
+		} {
+			data, _ := json.Marshal(chunk)
+			_, _ = w.Write([]byte("data: " + string(data) + "\n\n"))
+		}
+
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestServer serves the handlers with the routes of a single endpoint,
+// whose API keys are:
+//   - sk-test, granted every scope but admin,
+//   - sk-embeddings, granted the embeddings scope only,
+//   - sk-revoked, which is disabled.
+func newTestServer(t *testing.T, upstreamURL string) *httptest.Server {
+	t.Helper()
+
+	config := &configs.Config{
+		Routes: configs.Routes{
+			Tenants: []configs.Tenant{
+				{
+					ID: "tenant",
+					Teams: []configs.Team{{
+						ID: "team",
+						Groups: []configs.Group{{
+							ID: "group",
+							Endpoints: []configs.Endpoint{{
+								ID:     "endpoint",
+								APIKey: "sk-test",
+								APIKeys: []configs.EndpointAPIKey{
+									{Key: "sk-embeddings", Scopes: []string{"embeddings"}},
+									{Key: "sk-revoked", Disabled: true},
+								},
+							}},
+						}},
+					}},
+					Upstream: &metadata.UpstreamSingleOrMultiple{
+						Upstream: &metadata.Upstream{
+							OpenAI: metadata.UpstreamOpenAI{
+								BaseURL: upstreamURL + "/v1",
+								APIKey:  "sk-upstream",
+								Compatible: metadata.UpstreamOpenAICompatible{
+									Chat: metadata.UpstreamOpenAICompatibleChat{Usage: true, Stream: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Endpoints: configs.Endpoints{Provider: configs.EndpointsProviderConfig},
+		Batches: configs.Batches{
+			Storage:     configs.BatchesStorageFilesystem,
+			Directory:   t.TempDir(),
+			Concurrency: 2, //nolint:mnd
+		},
+		Responses: configs.Responses{Storage: configs.ResponsesStorageMemory},
+		Usage: configs.Usage{
+			Storage:   configs.UsageStorageJSONL,
+			Directory: t.TempDir(),
+		},
+	}
+
+	var handlers *Handlers
+
+	app := fxtest.New(t,
+		fx.NopLogger,
+		fx.Supply(config),
+		fx.Provide(func() (*logger.Logger, error) {
+			return logger.NewLogger(logger.WithLevel(zapcore.FatalLevel))
+		}),
+		datastore.Modules(),
+		endpoints.Modules(),
+		upstreams.Modules(),
+		responses.Modules(),
+		batches.Modules(),
+		fx.Provide(NewHandlers()),
+		fx.Populate(&handlers),
+	)
+
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	register := grpcpkg.NewRegister()
+	handlers.Install(register)
+
+	e := echo.New()
+
+	for path, methodHandlers := range register.EchoHandlers {
+		for method, handler := range methodHandlers {
+			e.Add(method, path, handler)
+		}
+	}
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func doJSON(t *testing.T, method string, url string, apiKey string, body string) *http.Response {
+	t.Helper()
+
+	request, err := http.NewRequest(method, url, strings.NewReader(body)) //nolint:noctx
+	require.NoError(t, err)
+
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if apiKey != "" {
+		request.Header.Set(echo.HeaderAuthorization, "Bearer "+apiKey)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = response.Body.Close()
+	})
+
+	return response
+}
+
+func TestCreateChatCompletion_Unauthenticated(t *testing.T) {
+	server := newTestServer(t, newTestUpstream(t).URL)
+
+	tests := []struct {
+		name      string
+		apiKey    string
+		body      string
+		status    int
+		errorType string
+		code      string
+	}{
+		{name: "MissingAPIKey", status: http.StatusUnauthorized, errorType: "authentication_error", code: "unauthorized"},
+		{name: "UnknownAPIKey", apiKey: "sk-unknown", status: http.StatusUnauthorized, errorType: "authentication_error", code: "unauthorized"},
+		{name: "RevokedAPIKey", apiKey: "sk-revoked", status: http.StatusUnauthorized, errorType: "authentication_error", code: "api_key_revoked"},
+		{name: "InsufficientScope", apiKey: "sk-embeddings", status: http.StatusForbidden, errorType: "permission_error", code: "insufficient_scope"},
+		// Requests are authenticated before their body is read.
+		{name: "MissingAPIKeyMalformedBody", body: `{"model":`, status: http.StatusUnauthorized, errorType: "authentication_error", code: "unauthorized"},
+		{name: "InsufficientScopeInvalidBody", apiKey: "sk-embeddings", body: `{}`, status: http.StatusForbidden, errorType: "permission_error", code: "insufficient_scope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := lo.CoalesceOrEmpty(tt.body, `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}`)
+
+			response := doJSON(t, http.MethodPost, server.URL+"/v1/chat/completions", tt.apiKey, body)
+			assert.Equal(t, tt.status, response.StatusCode)
+
+			var errorResponse ErrorResponse
+
+			require.NoError(t, json.NewDecoder(response.Body).Decode(&errorResponse))
+			assert.Equal(t, tt.errorType, errorResponse.Error.Type)
+			assert.Equal(t, tt.code, lo.FromPtr(errorResponse.Error.Code))
+			assert.NotEmpty(t, errorResponse.Error.Message)
+		})
+	}
+}
+
+func TestCreateChatCompletion_InvalidRequest(t *testing.T) {
+	server := newTestServer(t, newTestUpstream(t).URL)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   string
+		param  *string
+	}{
+		{name: "MalformedBody", body: `{"model":`, status: http.StatusBadRequest, code: "bad_request"},
+		{name: "MissingModel", body: `{"messages":[{"role":"user","content":"Hi"}]}`, status: http.StatusBadRequest, code: "invalid_argument", param: lo.ToPtr("model")},
+		{name: "RejectedByUpstream", body: `{"model":"` + modelInvalid + `","messages":[{"role":"user","content":"Hi"}]}`, status: http.StatusBadRequest, code: "bad_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := doJSON(t, http.MethodPost, server.URL+"/v1/chat/completions", "sk-test", tt.body)
+			assert.Equal(t, tt.status, response.StatusCode)
+
+			var errorResponse ErrorResponse
+
+			require.NoError(t, json.NewDecoder(response.Body).Decode(&errorResponse))
+			assert.Equal(t, "invalid_request_error", errorResponse.Error.Type)
+			assert.Equal(t, tt.code, lo.FromPtr(errorResponse.Error.Code))
+			assert.Equal(t, tt.param, errorResponse.Error.Param)
+		})
+	}
+}
+
+func TestCreateChatCompletion(t *testing.T) {
+	server := newTestServer(t, newTestUpstream(t).URL)
+
+	response := doJSON(t, http.MethodPost, server.URL+"/v1/chat/completions", "sk-test", `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}`)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var body openai.ChatCompletionResponse
+
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+	require.Len(t, body.Choices, 1)
+	assert.Equal(t, "echo: Hi", body.Choices[0].Message.Content)
+}
+
+func TestCreateChatCompletion_Stream(t *testing.T) {
+	server := newTestServer(t, newTestUpstream(t).URL)
+
+	response := doJSON(t, http.MethodPost, server.URL+"/v1/chat/completions", "sk-test", `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}],"stream":true}`)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, response.Header.Get(echo.HeaderContentType), "text/event-stream")
+
+	var (
+		data    []string
+		content string
+	)
+
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+
+		data = append(data, line)
+		if line == "[DONE]" {
+			continue
+		}
+
+		var chunk openai.ChatCompletionStreamResponse
+
+		require.NoError(t, json.Unmarshal([]byte(line), &chunk))
+
+		for _, choice := range chunk.Choices {
+			content += choice.Delta.Content
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+
+	// The stream is terminated by [DONE], after every chunk of the upstream.
+	require.NotEmpty(t, data)
+	assert.Equal(t, "[DONE]", data[len(data)-1])
+	assert.Equal(t, 1, strings.Count(strings.Join(data, "\n"), "[DONE]"))
+	assert.Equal(t, "echo: Hi", content)
+}
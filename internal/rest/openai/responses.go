@@ -0,0 +1,108 @@
+package openai
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/responses"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/apierrors"
+	"github.com/lingticio/llmg/pkg/util/eventsource"
+)
+
+type DeleteResponseResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+func responsesAPIError(err error) *apierrors.Error {
+	switch {
+	case errors.Is(err, responses.ErrResponseNotFound):
+		return apierrors.NewErrNotFound().WithDetail(err.Error())
+	case errors.Is(err, responses.ErrInvalidRequest):
+		return apierrors.NewErrInvalidArgument().WithDetail(err.Error())
+	default:
+		return upstreams.AsAPIError(err)
+	}
+}
+
+func (h *Handlers) CreateResponse(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	var request responses.Request
+
+	apiErr := bindJSON(c, &request)
+	if apiErr != nil {
+		return respondError(c, apiErr)
+	}
+
+	if !request.Stream {
+		response, err := h.responses.Create(ctx, endpoint, request)
+		if err != nil {
+			return respondError(c, responsesAPIError(err))
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+
+	stream, err := h.responses.CreateStream(ctx, endpoint, request)
+	if err != nil {
+		return respondError(c, responsesAPIError(err))
+	}
+
+	defer stream.Close()
+
+	es := eventsource.NewEventSource[responses.StreamEvent](eventsource.WithEchoResponse(c.Response()))
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			h.logger.Error("failed to receive response stream", zap.Error(err))
+			return nil
+		}
+
+		err = es.SendEvent(event.Type, event)
+		if err != nil {
+			h.logger.Error("failed to send response stream", zap.Error(err))
+			return nil
+		}
+	}
+}
+
+func (h *Handlers) GetResponse(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	response, err := h.responses.Get(ctx, endpoint, c.Param("id"))
+	if err != nil {
+		return respondError(c, responsesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Handlers) DeleteResponse(c echo.Context) error {
+	ctx := c.Request().Context()
+	endpoint := endpoints.EndpointFromContext(ctx)
+
+	err := h.responses.Delete(ctx, endpoint, c.Param("id"))
+	if err != nil {
+		return respondError(c, responsesAPIError(err))
+	}
+
+	return c.JSON(http.StatusOK, DeleteResponseResponse{
+		ID:      c.Param("id"),
+		Object:  "response.deleted",
+		Deleted: true,
+	})
+}
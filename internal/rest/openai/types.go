@@ -0,0 +1,10 @@
+package openai
+
+import (
+	"github.com/lingticio/llmg/internal/upstreams"
+)
+
+type ListModelsResponse struct {
+	Object string            `json:"object"`
+	Data   []upstreams.Model `json:"data"`
+}
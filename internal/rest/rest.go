@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"go.uber.org/fx"
+
+	adminapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/admin"
+	usageapiv1 "github.com/lingticio/llmg/apis/llmgapi/v1/usage"
+	"github.com/lingticio/llmg/internal/rest/openai"
+	"github.com/lingticio/llmg/internal/websockets"
+	grpcpkg "github.com/lingticio/llmg/pkg/util/grpc"
+)
+
+func Modules() fx.Option {
+	return fx.Options(
+		fx.Provide(openai.NewHandlers()),
+		fx.Provide(websockets.NewHandlers()),
+		fx.Provide(NewRegister()),
+	)
+}
+
+type NewRegisterParams struct {
+	fx.In
+
+	OpenAI     *openai.Handlers
+	WebSockets *websockets.Handlers
+}
+
+// NewRegister collects the handlers served by the HTTP gateway server.
+func NewRegister() func(params NewRegisterParams) *grpcpkg.Register {
+	return func(params NewRegisterParams) *grpcpkg.Register {
+		register := grpcpkg.NewRegister()
+		params.OpenAI.Install(register)
+		params.WebSockets.Install(register)
+		register.RegisterHTTPHandler(adminapiv1.RegisterAdminServiceHandler)
+		register.RegisterHTTPHandler(usageapiv1.RegisterUsageServiceHandler)
+
+		return register
+	}
+}
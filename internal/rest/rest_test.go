@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/lingticio/llmg/internal/batches"
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/responses"
+	"github.com/lingticio/llmg/internal/upstreams"
+	grpcpkg "github.com/lingticio/llmg/pkg/util/grpc"
+)
+
+// loadConfig loads the configuration of routes, under the defaults.
+func loadConfig(t *testing.T, routes string) *configs.Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	configFilePath := filepath.Join(dir, "config.yaml")
+
+	require.NoError(t, os.WriteFile(configFilePath, []byte(`
+batches:
+  directory: `+filepath.Join(dir, "batches")+`
+usage:
+  directory: `+filepath.Join(dir, "usage")+`
+`+routes), 0o600))
+
+	config, err := configs.NewConfig("lingticio", "llmg", configFilePath, "")()
+	require.NoError(t, err)
+
+	return config
+}
+
+func modules(config *configs.Config, register **grpcpkg.Register) fx.Option {
+	return fx.Options(
+		fx.NopLogger,
+		fx.Supply(config),
+		fx.Provide(func() (*logger.Logger, error) {
+			return logger.NewLogger(logger.WithLevel(zapcore.FatalLevel))
+		}),
+		datastore.Modules(),
+		endpoints.Modules(),
+		upstreams.Modules(),
+		responses.Modules(),
+		batches.Modules(),
+		Modules(),
+		fx.Populate(register),
+	)
+}
+
+// TestModules_WithoutRedis starts the HTTP gateway with the default
+// configuration, and routes declaring neither rate limits nor budgets, which
+// do not require Redis.
+func TestModules_WithoutRedis(t *testing.T) {
+	config := loadConfig(t, `
+configs:
+  tenants:
+    - id: tenant
+      upstream:
+        openai:
+          base_url: http://localhost/v1
+          api_key: sk-upstream
+      teams:
+        - id: team
+          groups:
+            - id: group
+              endpoints:
+                - id: endpoint
+                  api_key: sk-test
+`)
+	assert.Empty(t, config.Redis.Host)
+
+	var register *grpcpkg.Register
+
+	app := fxtest.New(t, modules(config, &register))
+
+	app.RequireStart()
+	app.RequireStop()
+
+	assert.NotEmpty(t, register.EchoHandlers)
+}
+
+// TestModules_BudgetsWithoutPrices fails to start the HTTP gateway with
+// routes declaring budgets, without the prices of the models they alias.
+func TestModules_BudgetsWithoutPrices(t *testing.T) {
+	routes := `
+configs:
+  tenants:
+    - id: tenant
+      budget:
+        monthly:
+          hard: 100
+      upstream:
+        openai:
+          base_url: http://localhost/v1
+          api_key: sk-upstream
+          model_aliases:
+            fast: gpt-4o-mini
+            smart: gpt-4o
+      teams:
+        - id: team
+          groups:
+            - id: group
+              endpoints:
+                - id: endpoint
+                  api_key: sk-test
+`
+
+	tests := []struct {
+		name    string
+		prices  string
+		wantErr string
+	}{
+		{name: "NoPrices", wantErr: "budgets.prices are required by the budgets declared in the routes"},
+		{name: "MissingPrices", prices: `
+budgets:
+  prices:
+    gpt-4o-mini:
+      prompt: 0.15
+      completion: 0.6
+`, wantErr: "budgets.prices are missing the models aliased by the routes, which declare budgets: gpt-4o"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var register *grpcpkg.Register
+
+			err := fx.New(modules(loadConfig(t, routes+tt.prices), &register)).Err()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
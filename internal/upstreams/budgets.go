@@ -0,0 +1,186 @@
+package upstreams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/pkg/budgets"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+)
+
+// webhookTimeout bounds how long posting a warning to the webhook may take.
+const webhookTimeout = 10 * time.Second
+
+type NewBudgetsParams struct {
+	fx.In
+
+	Config  *configs.Config
+	Logger  *logger.Logger
+	Rueidis *datastore.Rueidis
+}
+
+// Budgets enforces the budgets of the endpoints, pricing the usage of the
+// requests with the prices of the configuration, and notifies the webhook
+// of the soft budgets once they are exceeded.
+type Budgets struct {
+	logger     *logger.Logger
+	tracker    *budgets.Tracker
+	prices     budgets.Prices
+	webhookURL string
+}
+
+// NewBudgets returns the budgets of the endpoints, either declared in the
+// routes or set through the admin API. Redis is required when the routes
+// declare budgets, and connected whenever it is configured otherwise, no
+// request is tracked without it. So are the prices of the models the
+// routes alias, see configs.Budgets.
+func NewBudgets() func(params NewBudgetsParams) (*Budgets, error) {
+	return func(params NewBudgetsParams) (*Budgets, error) {
+		prices := make(budgets.Prices, len(params.Config.Budgets.Prices))
+		for model, price := range params.Config.Budgets.Prices {
+			prices[model] = budgets.Price{Prompt: price.Prompt, Completion: price.Completion}
+		}
+
+		if params.Config.Routes.HasBudgets() {
+			if len(prices) == 0 {
+				return nil, errors.New("budgets.prices are required by the budgets declared in the routes")
+			}
+
+			unpriced := lo.Reject(params.Config.Routes.AliasedModels(), func(model string, _ int) bool {
+				return prices.Priced(model)
+			})
+			if len(unpriced) > 0 {
+				return nil, fmt.Errorf("budgets.prices are missing the models aliased by the routes, which declare budgets: %s", strings.Join(unpriced, ", "))
+			}
+		}
+
+		b := &Budgets{
+			logger:     params.Logger,
+			prices:     prices,
+			webhookURL: params.Config.Budgets.WebhookURL,
+		}
+		if !params.Config.Routes.HasBudgets() && params.Config.Redis.Host == "" {
+			return b, nil
+		}
+
+		client, err := params.Rueidis.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		b.tracker = budgets.NewTracker()(client)
+
+		return b, nil
+	}
+}
+
+// checkPriced rejects the requests to model, as sent to the upstream, of the
+// endpoints with hard budgets when model has no price, since they would be
+// spent on without being charged. The requests of the endpoints with soft
+// budgets only are allowed, which is logged.
+func (b *Budgets) checkPriced(endpoint *authstorage.Endpoint, model string) error {
+	if b.tracker == nil || len(endpoint.Budgets) == 0 || b.prices.Priced(model) {
+		return nil
+	}
+	if budgets.AnyHard(endpoint.Budgets) {
+		return fmt.Errorf("%w: %s, the hard budgets of the endpoint cannot be enforced on it", budgets.ErrUnpriced, model)
+	}
+
+	b.logger.Warn("model is not priced, the request is not charged to the soft budgets of endpoint",
+		zap.String("endpoint_id", endpoint.ID),
+		zap.String("model", model),
+	)
+
+	return nil
+}
+
+// cost prices the tokens of a request to model, as sent to the upstream.
+func (b *Budgets) cost(model string, promptTokens int64, completionTokens int64) budgets.Cost {
+	return b.prices.Cost(model, promptTokens, completionTokens)
+}
+
+// webhookEvent is posted to the webhook for each soft budget exceeded.
+type webhookEvent struct {
+	Type string `json:"type"`
+	budgets.Warning
+	CreatedAt int64 `json:"created_at"`
+}
+
+// notify posts the warnings crossing a soft budget to the webhook, in the
+// background so that the requests are not held by it. Failures are logged.
+func (b *Budgets) notify(warnings []budgets.Warning) {
+	for _, warning := range warnings {
+		if !warning.Crossed {
+			continue
+		}
+
+		b.logger.Warn("soft budget exceeded",
+			zap.String("scope", warning.Scope),
+			zap.String("window", string(warning.Window)),
+			zap.String("period", warning.Period),
+			zap.Float64("spent", warning.Spent),
+			zap.Float64("soft", warning.Soft),
+		)
+
+		if b.webhookURL == "" {
+			continue
+		}
+
+		go func() {
+			err := b.post(webhookEvent{
+				Type:      "budget.soft_limit_exceeded",
+				Warning:   warning,
+				CreatedAt: time.Now().Unix(),
+			})
+			if err != nil {
+				b.logger.Error("failed to notify the webhook of the budgets",
+					zap.String("scope", warning.Scope),
+					zap.Error(err),
+				)
+			}
+		}()
+	}
+}
+
+func (b *Budgets) post(event webhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("the webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
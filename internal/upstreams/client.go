@@ -0,0 +1,63 @@
+package upstreams
+
+import (
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/lingticio/llmg/pkg/secrets"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+type headerTransport struct {
+	header http.Header
+	base   http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	for key, values := range t.header {
+		req.Header.Del(key)
+
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// ClientConfig builds the OpenAI client configuration for the given upstream,
+// resolving its API key when it is a secret reference.
+func ClientConfig(resolver *secrets.Resolver, upstream *metadata.Upstream) (openai.ClientConfig, error) {
+	apiKey, err := resolver.Resolve(upstream.OpenAI.APIKey)
+	if err != nil {
+		return openai.ClientConfig{}, err
+	}
+
+	config := openai.DefaultConfig(apiKey)
+	if upstream.OpenAI.BaseURL != "" {
+		config.BaseURL = upstream.OpenAI.BaseURL
+	}
+	if len(upstream.OpenAI.ExtraHeaders) > 0 {
+		config.HTTPClient = &http.Client{
+			Transport: &headerTransport{
+				header: upstream.OpenAI.ExtraHeaders,
+				base:   http.DefaultTransport,
+			},
+		}
+	}
+
+	return config, nil
+}
+
+// NewClient creates an OpenAI client for the given upstream.
+func NewClient(resolver *secrets.Resolver, upstream *metadata.Upstream) (*openai.Client, error) {
+	config, err := ClientConfig(resolver, upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	return openai.NewClientWithConfig(config), nil
+}
@@ -0,0 +1,51 @@
+package upstreams
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+)
+
+type Base64Embedding struct {
+	Object    string `json:"object"`
+	Embedding string `json:"embedding"`
+	Index     int    `json:"index"`
+}
+
+type Base64EmbeddingResponse struct {
+	Object string                `json:"object"`
+	Data   []Base64Embedding     `json:"data"`
+	Model  openai.EmbeddingModel `json:"model"`
+	Usage  openai.Usage          `json:"usage"`
+}
+
+// encodeEmbedding encodes the embedding as little-endian float32 values, the
+// same layout OpenAI uses for the base64 encoding format.
+func encodeEmbedding(embedding []float32) string {
+	b := make([]byte, 4*len(embedding))
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(v))
+	}
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// Base64EmbeddingResponseOf encodes the embeddings of the response, the
+// upstream client always decodes base64 embeddings into floats.
+func Base64EmbeddingResponseOf(response openai.EmbeddingResponse) Base64EmbeddingResponse {
+	return Base64EmbeddingResponse{
+		Object: response.Object,
+		Data: lo.Map(response.Data, func(item openai.Embedding, _ int) Base64Embedding {
+			return Base64Embedding{
+				Object:    item.Object,
+				Embedding: encodeEmbedding(item.Embedding),
+				Index:     item.Index,
+			}
+		}),
+		Model: response.Model,
+		Usage: response.Usage,
+	}
+}
@@ -0,0 +1,112 @@
+package upstreams
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/lingticio/llmg/pkg/apierrors"
+	"github.com/lingticio/llmg/pkg/budgets"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/secrets"
+	"github.com/lingticio/llmg/pkg/usage"
+)
+
+func apiErrorFromStatusCode(statusCode int) *apierrors.Error {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return apierrors.NewQuotaExceeded()
+	case statusCode == http.StatusUnauthorized:
+		return apierrors.NewErrUnauthorized()
+	case statusCode == http.StatusForbidden:
+		return apierrors.NewPermissionDenied()
+	case statusCode == http.StatusNotFound:
+		return apierrors.NewErrNotFound()
+	case statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+		return apierrors.NewBadRequest()
+	default:
+		return apierrors.NewErrUnavailable()
+	}
+}
+
+// AsAPIError maps an error returned while calling upstreams onto the API
+// error the gateway should respond with, preserving the status reported by
+// the upstream.
+func AsAPIError(err error) *apierrors.Error {
+	if errors.Is(err, ErrNoUpstream) {
+		return apierrors.NewErrUnavailable().WithDetail(err.Error())
+	}
+	// The x-ratelimit-* and Retry-After headers are reported separately,
+	// see headers.WithReporter.
+	if errors.Is(err, ratelimits.ErrExceeded) {
+		return apierrors.NewQuotaExceeded().WithDetail(err.Error())
+	}
+	if errors.Is(err, budgets.ErrExceeded) {
+		return apierrors.NewPaymentRequired().WithDetail(err.Error())
+	}
+	if errors.Is(err, budgets.ErrUnpriced) {
+		return apierrors.NewPermissionDenied().WithDetail(err.Error())
+	}
+	if errors.Is(err, scheduling.ErrUnknownClass) {
+		return apierrors.NewErrInvalidArgument().WithDetail(err.Error())
+	}
+	if errors.Is(err, scheduling.ErrClassNotPermitted) {
+		return apierrors.NewPermissionDenied().WithDetail(err.Error())
+	}
+	if errors.Is(err, scheduling.ErrQueueTimeout) {
+		return apierrors.NewErrUnavailable().WithDetail(err.Error())
+	}
+	if errors.Is(err, usage.ErrInvalidQuery) {
+		return apierrors.NewErrInvalidArgument().WithDetail(err.Error())
+	}
+	if errors.Is(err, ErrUsageNotVisible) {
+		return apierrors.NewPermissionDenied().WithDetail(err.Error())
+	}
+	// The reference of the secret is not reported back, it is only of
+	// interest to the operators of the gateway.
+	if errors.Is(err, secrets.ErrUnresolvable) {
+		return apierrors.NewErrUnavailable().WithDetail("the API key of the upstream cannot be resolved")
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErrorFromStatusCode(apiErr.HTTPStatusCode).WithDetail(apiErr.Message)
+	}
+
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		return apiErrorFromStatusCode(requestErr.HTTPStatusCode).WithDetail(requestErr.Error())
+	}
+
+	return apierrors.NewErrInternal().WithError(err).WithCaller()
+}
+
+// Retryable reports whether the request that failed with err may succeed
+// when sent again, i.e. the endpoint or the upstream was rate limited, the
+// upstream was busy, or it failed on its side.
+func Retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ratelimits.ErrExceeded) || errors.Is(err, scheduling.ErrQueueTimeout) {
+		return true
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode == http.StatusTooManyRequests || apiErr.HTTPStatusCode >= http.StatusInternalServerError
+	}
+
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.HTTPStatusCode == http.StatusTooManyRequests || requestErr.HTTPStatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,126 @@
+package upstreams
+
+import (
+	"context"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+type NewExactCacheParams struct {
+	fx.In
+
+	Config  *configs.Config
+	Rueidis *datastore.Rueidis
+}
+
+// ExactCache is where the answers of the deterministic chat completions of
+// the endpoints opting in are cached.
+type ExactCache struct {
+	cache *exactcache.Cache
+	ttl   time.Duration
+}
+
+// NewExactCache returns the exact cache, which is nil unless enabled, in
+// which case no answer is cached.
+func NewExactCache() func(params NewExactCacheParams) (*ExactCache, error) {
+	return func(params NewExactCacheParams) (*ExactCache, error) {
+		if !params.Config.ExactCache.Enabled {
+			return nil, nil
+		}
+
+		client, err := params.Rueidis.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		return &ExactCache{
+			cache: exactcache.NewCache()(client),
+			ttl:   params.Config.ExactCache.TTL,
+		}, nil
+	}
+}
+
+// exactLookup is a deterministic chat completion looked up in the exact
+// cache, with which its answer is cached on a miss.
+type exactLookup struct {
+	endpoint *authstorage.Endpoint
+	key      string
+}
+
+// lookupExactCache returns the answer cached for the exact same request,
+// along with the lookup to cache the answer of request with on a miss. Both
+// are nil when the endpoint does not opt in, request is not deterministic,
+// or bypasses the cache with no-store. Requests refreshing the cache with
+// no-cache are never answered from it. The cache never fails the request,
+// its failures are logged and handled as misses.
+func (g *Gateway) lookupExactCache(ctx context.Context, endpoint *authstorage.Endpoint, request openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, *exactLookup) {
+	control := exactcache.ControlFromContext(ctx)
+	if g.exactCache == nil || endpoint.ExactCache == nil || !exactcache.Deterministic(request) || !control.Store() {
+		return nil, nil
+	}
+
+	key, err := exactcache.Key(request)
+	if err != nil {
+		g.logger.Warn("failed to key the chat completion, the exact cache is skipped", zap.Error(err))
+		return nil, nil
+	}
+
+	lookup := &exactLookup{endpoint: endpoint, key: key}
+	if !control.Lookup() {
+		headers.Report(ctx, exactcache.CacheHeader(exactcache.CacheRefresh))
+		return nil, lookup
+	}
+
+	cached, err := g.exactCache.cache.Get(ctx, endpoint.ID, key)
+	if err != nil {
+		g.logger.Warn("failed to get the answer cached of the chat completion", zap.Error(err))
+	}
+	if cached != nil {
+		cached.Created = time.Now().Unix()
+		// The answer is not charged to the endpoint again.
+		cached.Usage = openai.Usage{}
+
+		headers.Report(ctx, exactcache.CacheHeader(exactcache.CacheHit))
+
+		return cached, nil
+	}
+
+	headers.Report(ctx, exactcache.CacheHeader(exactcache.CacheMiss))
+
+	return nil, lookup
+}
+
+// storeExactCache caches response, the answer of the request of lookup, in
+// the background, unless lookup is nil or the response is not complete.
+func (g *Gateway) storeExactCache(ctx context.Context, lookup *exactLookup, response openai.ChatCompletionResponse) {
+	if lookup == nil || !exactcache.AcceptsResponse(response) {
+		return
+	}
+
+	ttl := lookup.endpoint.ExactCache.TTL
+	if ttl <= 0 {
+		ttl = g.exactCache.ttl
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), settleTimeout)
+		defer cancel()
+
+		err := g.exactCache.cache.Set(ctx, lookup.endpoint.ID, lookup.key, response, ttl)
+		if err != nil {
+			g.logger.Warn("failed to cache the answer of the chat completion",
+				zap.String("endpoint_id", lookup.endpoint.ID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
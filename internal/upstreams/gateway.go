@@ -0,0 +1,683 @@
+package upstreams
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/pkg/budgets"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/secrets"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+	usagepkg "github.com/lingticio/llmg/pkg/usage"
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+var (
+	ErrNoUpstream = errors.New("no upstream is available for the endpoint")
+)
+
+type NewGatewayParams struct {
+	fx.In
+
+	Logger        *logger.Logger
+	Secrets       *secrets.Resolver
+	RateLimiter   *ratelimits.Limiter
+	Budgets       *Budgets
+	Usage         *UsageLedger
+	Scheduler     *scheduling.Scheduler
+	SemanticCache *SemanticCache
+	ExactCache    *ExactCache
+}
+
+// Gateway routes the requests of an endpoint to one of its upstreams, within
+// the rate limits and the budgets of the endpoint, and records their usage.
+// The requests of the upstreams of constrained capacity are queued until
+// they are dispatched by the scheduler, and the chat completions of the
+// endpoints opting in are served from the exact and the semantic caches
+// when possible.
+type Gateway struct {
+	logger        *logger.Logger
+	secrets       *secrets.Resolver
+	rateLimiter   *ratelimits.Limiter
+	budgets       *Budgets
+	usage         *UsageLedger
+	scheduler     *scheduling.Scheduler
+	semanticCache *SemanticCache
+	exactCache    *ExactCache
+}
+
+func NewGateway() func(params NewGatewayParams) *Gateway {
+	return func(params NewGatewayParams) *Gateway {
+		return &Gateway{
+			logger:        params.Logger,
+			secrets:       params.Secrets,
+			rateLimiter:   params.RateLimiter,
+			budgets:       params.Budgets,
+			usage:         params.Usage,
+			scheduler:     params.Scheduler,
+			semanticCache: params.SemanticCache,
+			exactCache:    params.ExactCache,
+		}
+	}
+}
+
+// settleTimeout bounds how long settling a request may take once the
+// request is done, and its context possibly canceled.
+const settleTimeout = 5 * time.Second
+
+// statusClientClosedRequest is the status of the requests canceled by their
+// caller, as logged by nginx.
+const statusClientClosedRequest = 499
+
+// meteredRequest is what a request is estimated to cost, and is metered by.
+type meteredRequest struct {
+	operation string
+	// model is the model requested by the caller, upstreamModel the model
+	// sent to the upstream, which prices the request.
+	model            string
+	upstreamModel    string
+	user             string
+	promptTokens     int64
+	completionTokens int64
+}
+
+// reservation is what a request reserved from the rate limits and the
+// budgets of its endpoint, and from the capacity of its upstream, until its
+// usage is known and recorded.
+type reservation struct {
+	gateway   *Gateway
+	endpoint  *authstorage.Endpoint
+	upstream  *metadata.Upstream
+	request   meteredRequest
+	startedAt time.Time
+
+	rateLimit *ratelimits.Reservation
+	budget    *budgets.Reservation
+	ticket    *scheduling.Ticket
+}
+
+// reserve reserves a request, and the tokens it is estimated to cost, from
+// the budgets and the rate limits of the endpoint, then waits for the
+// scheduler to dispatch it to upstream in its priority class. The requests
+// exceeding them are rejected with a *budgets.ExceededError or a
+// *ratelimits.ExceededError, and the ones to models without price with
+// budgets.ErrUnpriced when the endpoint has hard budgets. Requests are
+// neither limited nor tracked while Redis fails, which is logged.
+func (g *Gateway) reserve(ctx context.Context, endpoint *authstorage.Endpoint, upstream *metadata.Upstream, request meteredRequest) (*reservation, error) {
+	class, err := endpoint.Priority.Resolve(scheduling.RequestedClassFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &reservation{
+		gateway:   g,
+		endpoint:  endpoint,
+		upstream:  upstream,
+		request:   request,
+		startedAt: time.Now(),
+	}
+
+	err = g.budgets.checkPriced(endpoint, request.upstreamModel)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := g.budgets.tracker.Reserve(ctx, endpoint.Tenant.ID(), endpoint.Budgets, g.budgets.cost(request.upstreamModel, request.promptTokens, request.completionTokens))
+	if err != nil {
+		if errors.Is(err, budgets.ErrExceeded) {
+			return nil, err
+		}
+
+		g.logger.Error("failed to reserve the budgets of endpoint, the request is not tracked",
+			zap.String("endpoint_id", endpoint.ID),
+			zap.Error(err),
+		)
+	}
+	if budget != nil {
+		r.budget = budget
+		g.budgets.notify(budget.Warnings)
+	}
+
+	rateLimit, err := g.rateLimiter.Acquire(ctx, endpoint.Tenant.ID(), endpoint.RateLimits, request.promptTokens+request.completionTokens)
+	if err != nil {
+		if errors.Is(err, ratelimits.ErrExceeded) {
+			// The request is not sent, what it reserved from the budgets
+			// is given back.
+			r.release(ctx, 0, 0)
+			return nil, err
+		}
+
+		g.logger.Error("failed to acquire the rate limits of endpoint, the request is not limited",
+			zap.String("endpoint_id", endpoint.ID),
+			zap.Error(err),
+		)
+	}
+
+	r.rateLimit = rateLimit
+
+	ticket, err := g.scheduler.Acquire(ctx, upstream.OpenAI.BaseURL, scheduling.Flow{Tenant: endpoint.Tenant.ID(), Class: class})
+	if err != nil {
+		r.release(ctx, 0, 0)
+		return nil, err
+	}
+
+	r.ticket = ticket
+	headers.Report(ctx, ticket.Header())
+
+	return r, nil
+}
+
+// release charges the tokens the request actually used, and what they cost,
+// instead of what it was estimated to cost.
+func (r *reservation) release(ctx context.Context, promptTokens int64, completionTokens int64) {
+	if r.rateLimit == nil && r.budget == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), settleTimeout)
+	defer cancel()
+
+	err := r.rateLimit.Correct(ctx, promptTokens+completionTokens)
+	if err != nil {
+		r.gateway.logger.Warn("failed to correct the tokens charged to the rate limits", zap.Error(err))
+	}
+
+	warnings, err := r.budget.Settle(ctx, r.gateway.budgets.cost(r.request.upstreamModel, promptTokens, completionTokens))
+	if err != nil {
+		r.gateway.logger.Warn("failed to settle the cost charged to the budgets", zap.Error(err))
+	}
+
+	r.gateway.budgets.notify(warnings)
+}
+
+// settle gives back the capacity of the upstream held by the request,
+// charges what it actually used instead of what it was estimated to cost,
+// and records its usage. The usage of the requests which failed with err
+// after the upstream started answering, e.g. streams canceled midway, is
+// charged as well. The estimates stay charged when usage is nil, i.e. the
+// upstream did not report it, while nothing is charged for the requests
+// which failed before the upstream answered anything, for which usage is nil.
+func (r *reservation) settle(ctx context.Context, usage *openai.Usage, err error) {
+	r.ticket.Release()
+
+	promptTokens, completionTokens, cachedTokens := r.request.promptTokens, r.request.completionTokens, int64(0)
+
+	switch {
+	case usage != nil:
+		promptTokens, completionTokens = int64(usage.PromptTokens), int64(usage.CompletionTokens)
+		if usage.PromptTokensDetails != nil {
+			cachedTokens = int64(usage.PromptTokensDetails.CachedTokens)
+		}
+	case err != nil:
+		promptTokens, completionTokens = 0, 0
+	}
+
+	r.release(ctx, promptTokens, completionTokens)
+
+	status := http.StatusOK
+	if errors.Is(err, context.Canceled) {
+		status = statusClientClosedRequest
+	} else if err != nil {
+		status = int(AsAPIError(err).Status)
+	}
+
+	r.gateway.usage.Record(usagepkg.Event{
+		Time:             r.startedAt,
+		TenantID:         r.endpoint.Tenant.ID(),
+		TeamID:           r.endpoint.Team.ID(),
+		GroupID:          r.endpoint.Group.ID(),
+		EndpointID:       r.endpoint.ID,
+		Operation:        r.request.operation,
+		Model:            r.request.model,
+		Upstream:         r.upstream.OpenAI.BaseURL,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		CachedTokens:     cachedTokens,
+		Cost:             r.gateway.budgets.cost(r.request.upstreamModel, promptTokens, completionTokens).USD(),
+		Latency:          time.Since(r.startedAt),
+		Status:           status,
+		User:             r.request.user,
+	})
+}
+
+// reportedUsage is the usage of a response, nil when the upstream did not
+// report it.
+func reportedUsage(usage openai.Usage) *openai.Usage {
+	if usage.TotalTokens == 0 {
+		return nil
+	}
+
+	return &usage
+}
+
+func upstreamWeight(upstream *metadata.Upstream) uint {
+	if upstream.OpenAI.Weight == nil {
+		return 1
+	}
+
+	return *upstream.OpenAI.Weight
+}
+
+// SelectUpstream picks one of the upstreams of the endpoint that satisfies
+// accept, randomly and proportionally to their weights. Upstreams with a
+// weight of 0 are never selected.
+func SelectUpstream(endpoint *authstorage.Endpoint, accept func(upstream *metadata.Upstream) bool) (*metadata.Upstream, error) {
+	if endpoint == nil || endpoint.Upstream == nil {
+		return nil, ErrNoUpstream
+	}
+
+	candidates := lo.Filter(endpoint.Upstream.GetUpstreams(), func(item *metadata.Upstream, _ int) bool {
+		return item != nil && upstreamWeight(item) > 0 && (accept == nil || accept(item))
+	})
+	if len(candidates) == 0 {
+		return nil, ErrNoUpstream
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	var total uint
+	for _, candidate := range candidates {
+		total += upstreamWeight(candidate)
+	}
+
+	n := rand.UintN(total) //nolint:gosec
+	for _, candidate := range candidates {
+		weight := upstreamWeight(candidate)
+		if n < weight {
+			return candidate, nil
+		}
+
+		n -= weight
+	}
+
+	return candidates[len(candidates)-1], nil
+}
+
+// newClient creates the client of the upstream, the secret references which
+// cannot be resolved are logged here, since they are not reported back to
+// the caller.
+func (g *Gateway) newClient(upstream *metadata.Upstream) (*openai.Client, error) {
+	client, err := NewClient(g.secrets, upstream)
+	if err != nil {
+		g.logger.Error("failed to resolve the API key of upstream",
+			zap.String("base_url", upstream.OpenAI.BaseURL),
+			zap.Error(err),
+		)
+
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func acceptsEmbeddings(upstream *metadata.Upstream) bool {
+	return upstream.OpenAI.Compatible.Embeddings
+}
+
+// chatCompletionChunks are the chunks of a chat completion stream, either
+// streamed by an upstream or replayed from a cache.
+type chatCompletionChunks interface {
+	Recv() (openai.ChatCompletionStreamResponse, error)
+	Close() error
+}
+
+// replayedChunks replays the chunks of an answer cached.
+type replayedChunks struct {
+	chunks []openai.ChatCompletionStreamResponse
+}
+
+func (r *replayedChunks) Recv() (openai.ChatCompletionStreamResponse, error) {
+	if len(r.chunks) == 0 {
+		return openai.ChatCompletionStreamResponse{}, io.EOF
+	}
+
+	chunk := r.chunks[0]
+	r.chunks = r.chunks[1:]
+
+	return chunk, nil
+}
+
+func (r *replayedChunks) Close() error {
+	return nil
+}
+
+// ChatCompletionStream wraps the stream of an upstream, reporting the model
+// name requested by the caller instead of the aliased upstream model. What is
+// charged to the rate limits and the budgets of the endpoint is settled, and
+// the usage of the stream recorded, once the stream is closed, when its cost
+// is finally known. The answer is then cached as well, once fully streamed.
+// The streams of the answers served from the caches replay them chunk by
+// chunk, and are neither charged nor recorded.
+type ChatCompletionStream struct {
+	chunks chatCompletionChunks
+
+	model string
+
+	ctx         context.Context
+	gateway     *Gateway
+	reservation *reservation
+	closeOnce   sync.Once
+	// usage is reported by the upstreams supporting stream_options, the
+	// tokens of the content streamed are estimated otherwise.
+	usage            *openai.Usage
+	completionTokens int64
+	// received is whether any chunk was received, the streams which failed
+	// before are not charged.
+	received bool
+	err      error
+
+	// response accumulates the chunks of the answer to cache, which are
+	// cached once the stream is done, with the lookups of the caches which
+	// missed.
+	response *exactcache.Accumulator
+	done     bool
+	exact    *exactLookup
+	semantic *semanticLookup
+}
+
+func (s *ChatCompletionStream) Recv() (openai.ChatCompletionStreamResponse, error) {
+	response, err := s.chunks.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.done = true
+		} else {
+			s.err = err
+		}
+
+		return response, err
+	}
+	if s.model != "" {
+		response.Model = s.model
+	}
+	if response.Usage != nil {
+		s.usage = response.Usage
+	}
+
+	s.received = true
+
+	for _, choice := range response.Choices {
+		s.completionTokens += ratelimits.EstimateTextTokens(choice.Delta.Content)
+	}
+
+	if s.response != nil {
+		s.response.Add(response)
+	}
+
+	return response, nil
+}
+
+func (s *ChatCompletionStream) Close() error {
+	s.closeOnce.Do(func() {
+		if s.reservation == nil {
+			return
+		}
+
+		// The streams closed before they are done were abandoned by their
+		// caller.
+		err := s.err
+		if err == nil && !s.done {
+			err = context.Canceled
+		}
+
+		// The prompt, and the tokens streamed so far, are charged for the
+		// streams interrupted once the upstream started answering.
+		usage := s.usage
+		if usage == nil && (s.received || s.done) {
+			usage = &openai.Usage{
+				PromptTokens:     int(s.reservation.request.promptTokens),
+				CompletionTokens: int(s.completionTokens),
+			}
+		}
+
+		s.reservation.settle(s.ctx, usage, err)
+
+		if s.response != nil && s.done && s.err == nil {
+			response := s.response.Response()
+
+			s.gateway.storeExactCache(s.ctx, s.exact, response)
+			s.gateway.storeSemanticCache(s.ctx, s.semantic, response)
+		}
+	})
+
+	return s.chunks.Close()
+}
+
+func meteredChatCompletion(model string, request openai.ChatCompletionRequest) meteredRequest {
+	return meteredRequest{
+		operation:        usagepkg.OperationChatCompletions,
+		model:            model,
+		upstreamModel:    request.Model,
+		user:             request.User,
+		promptTokens:     ratelimits.EstimateChatCompletionPromptTokens(request),
+		completionTokens: ratelimits.EstimateChatCompletionMaxTokens(request),
+	}
+}
+
+// requestedModel returns the model name the response should report, which is
+// empty when the requested model is not an alias.
+func requestedModel(upstream *metadata.Upstream, model string) string {
+	if upstream.OpenAI.ModelOfAlias(model) == model {
+		return ""
+	}
+
+	return model
+}
+
+// lookupCaches looks request up in the exact cache, then in the semantic
+// cache, and returns the answer cached, if any, along with the lookups to
+// cache the answer of request with otherwise. Answers from the caches are
+// charged as requests to the rate limits of the endpoint, which may reject
+// them with a *ratelimits.ExceededError, and recorded as usage costing
+// nothing.
+func (g *Gateway) lookupCaches(ctx context.Context, endpoint *authstorage.Endpoint, request openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, *exactLookup, *semanticLookup, error) {
+	startedAt := time.Now()
+
+	cached, exact := g.lookupExactCache(ctx, endpoint, request)
+	if cached != nil {
+		return cached, nil, nil, g.chargeCacheHit(ctx, endpoint, request, usagepkg.CacheExact, startedAt)
+	}
+
+	cached, semantic := g.lookupSemanticCache(ctx, endpoint, request)
+	if cached != nil {
+		return cached, nil, nil, g.chargeCacheHit(ctx, endpoint, request, usagepkg.CacheSemantic, startedAt)
+	}
+
+	return nil, exact, semantic, nil
+}
+
+// chargeCacheHit charges a request answered from cache to the rate limits of
+// endpoint, without any token, and records it. Requests are not limited
+// while Redis fails, which is logged.
+func (g *Gateway) chargeCacheHit(ctx context.Context, endpoint *authstorage.Endpoint, request openai.ChatCompletionRequest, cache string, startedAt time.Time) error {
+	_, err := g.rateLimiter.Acquire(ctx, endpoint.Tenant.ID(), endpoint.RateLimits, 0)
+	if err != nil {
+		if errors.Is(err, ratelimits.ErrExceeded) {
+			return err
+		}
+
+		g.logger.Error("failed to acquire the rate limits of endpoint, the request is not limited",
+			zap.String("endpoint_id", endpoint.ID),
+			zap.Error(err),
+		)
+	}
+
+	g.usage.Record(usagepkg.Event{
+		Time:       startedAt,
+		TenantID:   endpoint.Tenant.ID(),
+		TeamID:     endpoint.Team.ID(),
+		GroupID:    endpoint.Group.ID(),
+		EndpointID: endpoint.ID,
+		Operation:  usagepkg.OperationChatCompletions,
+		Model:      request.Model,
+		Cache:      cache,
+		Latency:    time.Since(startedAt),
+		Status:     http.StatusOK,
+		User:       request.User,
+	})
+
+	return nil
+}
+
+func (g *Gateway) CreateChatCompletion(ctx context.Context, endpoint *authstorage.Endpoint, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	cached, exact, semantic, err := g.lookupCaches(ctx, endpoint, request)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+	if cached != nil {
+		return *cached, nil
+	}
+
+	upstream, err := SelectUpstream(endpoint, nil)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+
+	requested := request.Model
+	model := requestedModel(upstream, request.Model)
+	request.Model = upstream.OpenAI.ModelOfAlias(request.Model)
+
+	client, err := g.newClient(upstream)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+
+	reservation, err := g.reserve(ctx, endpoint, upstream, meteredChatCompletion(requested, request))
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+
+	response, err := client.CreateChatCompletion(ctx, request)
+	if err != nil {
+		reservation.settle(ctx, nil, err)
+		return openai.ChatCompletionResponse{}, err
+	}
+
+	reservation.settle(ctx, reportedUsage(response.Usage), nil)
+
+	if model != "" {
+		response.Model = model
+	}
+
+	g.storeExactCache(ctx, exact, response)
+	g.storeSemanticCache(ctx, semantic, response)
+
+	return response, nil
+}
+
+func (g *Gateway) CreateChatCompletionStream(ctx context.Context, endpoint *authstorage.Endpoint, request openai.ChatCompletionRequest) (*ChatCompletionStream, error) {
+	cached, exact, semantic, err := g.lookupCaches(ctx, endpoint, request)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		includeUsage := request.StreamOptions != nil && request.StreamOptions.IncludeUsage
+
+		return &ChatCompletionStream{
+			chunks:  &replayedChunks{chunks: exactcache.Replay(*cached, includeUsage)},
+			ctx:     ctx,
+			gateway: g,
+		}, nil
+	}
+
+	upstream, err := SelectUpstream(endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := request.Model
+	model := requestedModel(upstream, request.Model)
+	request.Model = upstream.OpenAI.ModelOfAlias(request.Model)
+	request.Stream = true
+
+	// Not every upstream understands stream_options, it is only forwarded to
+	// the ones declaring support for usage in streams.
+	if !upstream.OpenAI.Compatible.Chat.Usage {
+		request.StreamOptions = nil
+	}
+
+	client, err := g.newClient(upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := g.reserve(ctx, endpoint, upstream, meteredChatCompletion(requested, request))
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.CreateChatCompletionStream(ctx, request)
+	if err != nil {
+		reservation.settle(ctx, nil, err)
+		return nil, err
+	}
+
+	s := &ChatCompletionStream{
+		chunks:      stream,
+		model:       model,
+		ctx:         ctx,
+		gateway:     g,
+		reservation: reservation,
+		exact:       exact,
+		semantic:    semantic,
+	}
+	if exact != nil || semantic != nil {
+		s.response = &exactcache.Accumulator{}
+	}
+
+	return s, nil
+}
+
+func (g *Gateway) CreateEmbeddings(ctx context.Context, endpoint *authstorage.Endpoint, request openai.EmbeddingRequest) (openai.EmbeddingResponse, error) {
+	upstream, err := SelectUpstream(endpoint, acceptsEmbeddings)
+	if err != nil {
+		return openai.EmbeddingResponse{}, err
+	}
+
+	requested := string(request.Model)
+	model := requestedModel(upstream, requested)
+	request.Model = openai.EmbeddingModel(upstream.OpenAI.ModelOfAlias(requested))
+
+	client, err := g.newClient(upstream)
+	if err != nil {
+		return openai.EmbeddingResponse{}, err
+	}
+
+	reservation, err := g.reserve(ctx, endpoint, upstream, meteredRequest{
+		operation:     usagepkg.OperationEmbeddings,
+		model:         requested,
+		upstreamModel: string(request.Model),
+		user:          request.User,
+		promptTokens:  ratelimits.EstimateEmbeddingsTokens(request),
+	})
+	if err != nil {
+		return openai.EmbeddingResponse{}, err
+	}
+
+	response, err := client.CreateEmbeddings(ctx, request)
+	if err != nil {
+		reservation.settle(ctx, nil, err)
+		return openai.EmbeddingResponse{}, err
+	}
+
+	reservation.settle(ctx, reportedUsage(response.Usage), nil)
+	if model != "" {
+		response.Model = openai.EmbeddingModel(model)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,185 @@
+package upstreams
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/datastore"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/secrets"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+const (
+	modelsCacheKeyPrefix = "upstreams:models:"
+	modelsCacheTTL       = 5 * time.Minute
+)
+
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+type NewModelsParams struct {
+	fx.In
+
+	Logger  *logger.Logger
+	Cache   *datastore.Cache
+	Secrets *secrets.Resolver
+}
+
+// Models lists the models served by the upstreams of an endpoint.
+type Models struct {
+	logger  *logger.Logger
+	cache   *datastore.Cache
+	secrets *secrets.Resolver
+}
+
+func NewModels() func(params NewModelsParams) *Models {
+	return func(params NewModelsParams) *Models {
+		return &Models{
+			logger:  params.Logger,
+			cache:   params.Cache,
+			secrets: params.Secrets,
+		}
+	}
+}
+
+func modelsListableUpstreams(endpoint *authstorage.Endpoint) []*metadata.Upstream {
+	if endpoint == nil || endpoint.Upstream == nil {
+		return make([]*metadata.Upstream, 0)
+	}
+
+	return lo.Filter(endpoint.Upstream.GetUpstreams(), func(item *metadata.Upstream, _ int) bool {
+		return item != nil && item.OpenAI.Compatible.Models
+	})
+}
+
+// modelsCacheKey derives the cache key from the upstream definitions rather
+// than the endpoint itself, so that endpoints sharing upstreams share the
+// cached list, and changed upstreams never hit a stale entry.
+func modelsCacheKey(upstreams []*metadata.Upstream) (string, error) {
+	b, err := json.Marshal(upstreams)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+
+	return modelsCacheKeyPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+func (m *Models) listOne(ctx context.Context, upstream *metadata.Upstream) ([]Model, error) {
+	client, err := NewClient(m.secrets, upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(list.Models, func(item openai.Model, _ int) Model {
+		return Model{
+			ID:      upstream.OpenAI.AliasOfModel(item.ID),
+			Object:  item.Object,
+			Created: item.CreatedAt,
+			OwnedBy: item.OwnedBy,
+		}
+	}), nil
+}
+
+// List returns the de-duplicated models of all the upstreams reachable from
+// the endpoint, sorted by ID. Upstreams that fail to respond are skipped as
+// long as at least one of them succeeds.
+func (m *Models) List(ctx context.Context, endpoint *authstorage.Endpoint) ([]Model, error) {
+	upstreams := modelsListableUpstreams(endpoint)
+	if len(upstreams) == 0 {
+		return make([]Model, 0), nil
+	}
+
+	cacheKey, err := modelsCacheKey(upstreams)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, ok := m.cache.Get(cacheKey)
+	if ok {
+		models, ok := cached.([]Model)
+		if ok {
+			return models, nil
+		}
+	}
+
+	results := make([][]Model, len(upstreams))
+	errs := make([]error, len(upstreams))
+
+	var wg sync.WaitGroup
+
+	for i, upstream := range upstreams {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i], errs[i] = m.listOne(ctx, upstream)
+		}()
+	}
+
+	wg.Wait()
+
+	seen := make(map[string]struct{})
+	models := make([]Model, 0)
+
+	for i := range upstreams {
+		if errs[i] != nil {
+			m.logger.Warn("failed to list models of upstream",
+				zap.String("base_url", upstreams[i].OpenAI.BaseURL),
+				zap.Error(errs[i]),
+			)
+
+			continue
+		}
+
+		for _, model := range results[i] {
+			if _, ok := seen[model.ID]; ok {
+				continue
+			}
+
+			seen[model.ID] = struct{}{}
+			models = append(models, model)
+		}
+	}
+
+	failed := lo.Compact(errs)
+	if len(failed) == len(upstreams) {
+		return nil, errors.Join(failed...)
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].ID < models[j].ID
+	})
+
+	// Partial results are not cached, the failed upstreams should be retried
+	// by the next request.
+	if len(failed) == 0 {
+		m.cache.Set(cacheKey, models, modelsCacheTTL)
+	}
+
+	return models, nil
+}
@@ -0,0 +1,187 @@
+package upstreams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/lingticio/llmg/internal/datastore"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/secrets"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+type testModelsUpstream struct {
+	*httptest.Server
+
+	requests atomic.Int64
+}
+
+// newTestModelsUpstream lists models, or fails with 500 when models is nil.
+func newTestModelsUpstream(t *testing.T, models ...string) *testModelsUpstream {
+	t.Helper()
+
+	upstream := &testModelsUpstream{}
+
+	upstream.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream.requests.Add(1)
+
+		if r.URL.Path != "/v1/models" || models == nil {
+			http.Error(w, `{"error":{"message":"unavailable"}}`, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openai.ModelsList{
+			Models: lo.Map(models, func(item string, _ int) openai.Model {
+				return openai.Model{ID: item, Object: "model", OwnedBy: "test"}
+			}),
+		})
+	}))
+
+	t.Cleanup(upstream.Close)
+
+	return upstream
+}
+
+func newTestModels(t *testing.T) *Models {
+	t.Helper()
+
+	log, err := logger.NewLogger(logger.WithLevel(zapcore.FatalLevel))
+	require.NoError(t, err)
+
+	cache, err := datastore.NewCache()(datastore.NewCacheParams{})
+	require.NoError(t, err)
+
+	return NewModels()(NewModelsParams{
+		Logger:  log,
+		Cache:   cache,
+		Secrets: secrets.NewResolver()(),
+	})
+}
+
+func upstreamOf(serverURL string, aliases map[string]string) *metadata.Upstream {
+	return &metadata.Upstream{
+		OpenAI: metadata.UpstreamOpenAI{
+			BaseURL:      serverURL + "/v1",
+			APIKey:       "sk-upstream",
+			Compatible:   metadata.UpstreamOpenAICompatible{Models: true},
+			ModelAliases: aliases,
+		},
+	}
+}
+
+func endpointOf(upstreams ...*metadata.Upstream) *authstorage.Endpoint {
+	return &authstorage.Endpoint{
+		Upstream: &metadata.UpstreamSingleOrMultiple{Group: upstreams},
+	}
+}
+
+func idsOf(models []Model) []string {
+	return lo.Map(models, func(item Model, _ int) string {
+		return item.ID
+	})
+}
+
+func TestModels_List(t *testing.T) {
+	t.Run("DeduplicatedAndAliased", func(t *testing.T) {
+		first := newTestModelsUpstream(t, "gpt-4o", "gpt-4o-mini")
+		second := newTestModelsUpstream(t, "gpt-4o", "o1")
+
+		models, err := newTestModels(t).List(context.Background(), endpointOf(
+			upstreamOf(first.URL, nil),
+			// The models of the upstream are listed by their alias.
+			upstreamOf(second.URL, map[string]string{"reasoning": "o1"}),
+		))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gpt-4o", "gpt-4o-mini", "reasoning"}, idsOf(models))
+	})
+
+	t.Run("NotListable", func(t *testing.T) {
+		upstream := newTestModelsUpstream(t, "gpt-4o")
+
+		notListable := upstreamOf(upstream.URL, nil)
+		notListable.OpenAI.Compatible.Models = false
+
+		models, err := newTestModels(t).List(context.Background(), endpointOf(notListable))
+		require.NoError(t, err)
+		assert.Empty(t, models)
+		assert.Zero(t, upstream.requests.Load())
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		upstream := newTestModelsUpstream(t, "gpt-4o")
+		models := newTestModels(t)
+
+		for range 2 {
+			listed, err := models.List(context.Background(), endpointOf(upstreamOf(upstream.URL, nil)))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"gpt-4o"}, idsOf(listed))
+		}
+
+		assert.Equal(t, int64(1), upstream.requests.Load())
+
+		// Changing the upstreams changes the cache key, the list is not
+		// served from the entry of the previous ones.
+		listed, err := models.List(context.Background(), endpointOf(upstreamOf(upstream.URL, map[string]string{"smart": "gpt-4o"})))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"smart"}, idsOf(listed))
+		assert.Equal(t, int64(2), upstream.requests.Load())
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		succeeding := newTestModelsUpstream(t, "gpt-4o")
+		failing := newTestModelsUpstream(t)
+		models := newTestModels(t)
+		endpoint := endpointOf(upstreamOf(succeeding.URL, nil), upstreamOf(failing.URL, nil))
+
+		// The models of the upstreams responding are returned, but not
+		// cached, so that the failed upstreams are retried.
+		for range 2 {
+			listed, err := models.List(context.Background(), endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"gpt-4o"}, idsOf(listed))
+		}
+
+		assert.Equal(t, int64(2), succeeding.requests.Load())
+		assert.Positive(t, failing.requests.Load())
+	})
+
+	t.Run("AllFailed", func(t *testing.T) {
+		first := newTestModelsUpstream(t)
+		second := newTestModelsUpstream(t)
+
+		models, err := newTestModels(t).List(context.Background(), endpointOf(upstreamOf(first.URL, nil), upstreamOf(second.URL, nil)))
+		require.Error(t, err)
+		assert.Nil(t, models)
+	})
+}
+
+func TestModelsCacheKey(t *testing.T) {
+	key, err := modelsCacheKey([]*metadata.Upstream{upstreamOf("https://first", nil)})
+	require.NoError(t, err)
+
+	same, err := modelsCacheKey([]*metadata.Upstream{upstreamOf("https://first", nil)})
+	require.NoError(t, err)
+	assert.Equal(t, key, same)
+
+	for _, upstreams := range [][]*metadata.Upstream{
+		{upstreamOf("https://second", nil)},
+		{upstreamOf("https://first", map[string]string{"smart": "gpt-4o"})},
+		{upstreamOf("https://first", nil), upstreamOf("https://second", nil)},
+	} {
+		changed, err := modelsCacheKey(upstreams)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, changed)
+	}
+}
@@ -0,0 +1,36 @@
+package upstreams
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+)
+
+type NewRateLimiterParams struct {
+	fx.In
+
+	Config  *configs.Config
+	Rueidis *datastore.Rueidis
+}
+
+// NewRateLimiter returns the limiter enforcing the rate limits of the
+// endpoints, either declared in the routes or set through the admin API.
+// Redis is required when the routes declare rate limits, and connected
+// whenever it is configured otherwise, the limiter is nil without it, which
+// allows every request.
+func NewRateLimiter() func(params NewRateLimiterParams) (*ratelimits.Limiter, error) {
+	return func(params NewRateLimiterParams) (*ratelimits.Limiter, error) {
+		if !params.Config.Routes.HasRateLimits() && params.Config.Redis.Host == "" {
+			return nil, nil
+		}
+
+		client, err := params.Rueidis.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		return ratelimits.NewLimiter()(client), nil
+	}
+}
@@ -0,0 +1,53 @@
+package upstreams
+
+import (
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/pkg/scheduling"
+)
+
+type NewSchedulerParams struct {
+	fx.In
+
+	Config *configs.Config
+}
+
+// NewScheduler returns the scheduler of the upstreams of constrained
+// capacity. The scheduler is nil when no upstream is constrained, which
+// dispatches every request immediately. The capacities are those of the
+// replica, which schedules its own requests only. The weights of unknown
+// classes are rejected.
+func NewScheduler() func(params NewSchedulerParams) (*scheduling.Scheduler, error) {
+	return func(params NewSchedulerParams) (*scheduling.Scheduler, error) {
+		weights := make(map[scheduling.Class]int)
+
+		for name, weight := range params.Config.Scheduling.Weights {
+			class, err := scheduling.ParseClass(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid scheduling weights: %w", err)
+			}
+
+			weights[class] = weight
+		}
+
+		capacities := make(map[string]int)
+
+		for baseURL, upstream := range params.Config.Scheduling.Upstreams {
+			if upstream.MaxConcurrency > 0 {
+				capacities[baseURL] = upstream.MaxConcurrency
+			}
+		}
+		if len(capacities) == 0 {
+			return nil, nil
+		}
+
+		return scheduling.NewScheduler()(scheduling.Options{
+			Capacities: capacities,
+			Weights:    weights,
+			MaxWait:    params.Config.Scheduling.MaxWait,
+		}), nil
+	}
+}
@@ -0,0 +1,330 @@
+package upstreams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/semanticcache"
+	semanticcachehnsw "github.com/lingticio/llmg/pkg/semanticcache/hnsw"
+	semanticcacherueidis "github.com/lingticio/llmg/pkg/semanticcache/rueidis"
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+// CachedChatCompletion is an answer of an upstream, as cached along with
+// the embedding of its question.
+type CachedChatCompletion struct {
+	EndpointID string `json:"endpoint_id"`
+	// Model is the model requested by the caller, possibly an alias.
+	Model string `json:"model"`
+	// Text is the text embedded, kept to troubleshoot the answers served.
+	Text     string                        `json:"text"`
+	Response openai.ChatCompletionResponse `json:"response"`
+}
+
+type NewSemanticCacheParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *configs.Config
+	Logger    *logger.Logger
+	Rueidis   *datastore.Rueidis
+}
+
+// SemanticCache is where the answers of the chat completions of the
+// endpoints opting in are cached, along with the embeddings of their
+// questions.
+type SemanticCache struct {
+	cache          semanticcache.Cache[CachedChatCompletion]
+	embeddingModel string
+	threshold      float64
+	ttl            time.Duration
+}
+
+// NewSemanticCache returns the semantic cache, which is nil unless enabled,
+// in which case no answer is cached.
+func NewSemanticCache() func(params NewSemanticCacheParams) (*SemanticCache, error) {
+	return func(params NewSemanticCacheParams) (*SemanticCache, error) {
+		if !params.Config.SemanticCache.Enabled {
+			return nil, nil
+		}
+
+		c := &SemanticCache{
+			embeddingModel: params.Config.SemanticCache.EmbeddingModel,
+			threshold:      params.Config.SemanticCache.Threshold,
+			ttl:            params.Config.SemanticCache.TTL,
+		}
+
+		switch params.Config.SemanticCache.Backend {
+		case configs.SemanticCacheBackendRedis, "":
+			opts, err := redisSemanticCacheOptions(params.Config.SemanticCache.Redis)
+			if err != nil {
+				return nil, err
+			}
+
+			client, err := params.Rueidis.Client()
+			if err != nil {
+				return nil, err
+			}
+
+			c.cache = semanticcacherueidis.RueidisJSON[CachedChatCompletion](params.Config.SemanticCache.Index, client, opts...)
+		case configs.SemanticCacheBackendMemory:
+			c.cache = newMemorySemanticCache(params.Lifecycle, params.Logger, params.Config.SemanticCache.Memory)
+		default:
+			return nil, fmt.Errorf("unsupported semantic cache backend %q", params.Config.SemanticCache.Backend)
+		}
+
+		return c, nil
+	}
+}
+
+// redisSemanticCacheOptions returns the options of the index of the redis
+// backend, the defaults of the index for the ones not configured.
+func redisSemanticCacheOptions(config configs.SemanticCacheRedis) ([]semanticcacherueidis.RueidisJSONCallOption, error) {
+	var opts []semanticcacherueidis.RueidisJSONCallOption
+
+	switch algorithm := semanticcacherueidis.Algorithm(strings.ToUpper(config.Algorithm)); algorithm {
+	case semanticcacherueidis.AlgorithmFLAT, "":
+	case semanticcacherueidis.AlgorithmHNSW:
+		opts = append(opts, semanticcacherueidis.WithHNSW(config.M, config.EfConstruction, config.EfRuntime))
+	default:
+		return nil, fmt.Errorf("unsupported semantic cache algorithm %q", config.Algorithm)
+	}
+
+	switch vectorType := semanticcacherueidis.VectorType(strings.ToUpper(config.Type)); vectorType {
+	case "":
+	case semanticcacherueidis.VectorTypeFloat32, semanticcacherueidis.VectorTypeFloat64:
+		opts = append(opts, semanticcacherueidis.WithVectorType(vectorType))
+	default:
+		return nil, fmt.Errorf("unsupported semantic cache vector type %q", config.Type)
+	}
+
+	switch metric := semanticcacherueidis.DistanceMetric(strings.ToUpper(config.DistanceMetric)); metric {
+	case "":
+	case semanticcacherueidis.DistanceMetricCosine, semanticcacherueidis.DistanceMetricIP, semanticcacherueidis.DistanceMetricL2:
+		opts = append(opts, semanticcacherueidis.WithDistanceMetric(metric))
+	default:
+		return nil, fmt.Errorf("unsupported semantic cache distance metric %q", config.DistanceMetric)
+	}
+
+	return opts, nil
+}
+
+// newMemorySemanticCache returns the HNSW index of the memory backend, which
+// evicts the answers expired, and saves them to the snapshot when
+// configured, every snapshot interval, and loads them on startup.
+func newMemorySemanticCache(lifecycle fx.Lifecycle, log *logger.Logger, config configs.SemanticCacheMemory) *semanticcachehnsw.SemanticCacheHNSW[CachedChatCompletion] {
+	cache := semanticcachehnsw.HNSW[CachedChatCompletion](semanticcachehnsw.WithMaxBytes(config.MaxBytes))
+
+	interval := config.SnapshotInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	save := func() {
+		if config.Snapshot == "" {
+			return
+		}
+
+		err := cache.SaveSnapshot(config.Snapshot)
+		if err != nil {
+			log.Error("failed to save the snapshot of the semantic cache", zap.String("path", config.Snapshot), zap.Error(err))
+		}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if config.Snapshot != "" {
+				err := cache.LoadSnapshot(config.Snapshot)
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					log.Error("failed to load the snapshot of the semantic cache, starting empty", zap.String("path", config.Snapshot), zap.Error(err))
+				}
+			}
+
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ticker.C:
+						cache.EvictExpired()
+						save()
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(stop)
+
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			save()
+
+			return nil
+		},
+	})
+
+	return cache
+}
+
+// semanticLookup is the question of a chat completion looked up in the
+// semantic cache, with which its answer is cached on a miss.
+type semanticLookup struct {
+	endpoint *authstorage.Endpoint
+	model    string
+	text     string
+	vector   []float64
+	// tags scope the answers served to the question, and the answer cached.
+	tags semanticcache.Tags
+}
+
+// embed embeds text with the upstreams of the endpoint, as any request of the
+// endpoint.
+func (g *Gateway) embed(ctx context.Context, endpoint *authstorage.Endpoint, text string) ([]float64, error) {
+	response, err := g.CreateEmbeddings(ctx, endpoint, openai.EmbeddingRequest{
+		Input: []string{text},
+		Model: openai.EmbeddingModel(g.semanticCache.embeddingModel),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		return nil, ErrNoUpstream
+	}
+
+	return lo.Map(response.Data[0].Embedding, func(item float32, _ int) float64 {
+		return float64(item)
+	}), nil
+}
+
+// lookupSemanticCache returns the answer cached for a question similar
+// enough to the one of request, along with the lookup to cache the answer
+// of request with on a miss. Both are nil when the endpoint does not opt in,
+// request cannot be cached, or bypasses the caches with no-store. Requests
+// refreshing the caches with no-cache are never answered from it. The cache
+// never fails the request, its failures are logged and handled as misses.
+func (g *Gateway) lookupSemanticCache(ctx context.Context, endpoint *authstorage.Endpoint, request openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, *semanticLookup) {
+	control := exactcache.ControlFromContext(ctx)
+	if g.semanticCache == nil || endpoint.SemanticCache == nil || !semanticcache.Cacheable(request) || !control.Store() {
+		return nil, nil
+	}
+
+	text := semanticcache.Text(request)
+
+	vector, err := g.embed(ctx, endpoint, text)
+	if err != nil {
+		g.logger.Warn("failed to embed the question of the chat completion, the semantic cache is skipped",
+			zap.String("endpoint_id", endpoint.ID),
+			zap.Error(err),
+		)
+
+		return nil, nil
+	}
+
+	lookup := &semanticLookup{
+		endpoint: endpoint,
+		model:    request.Model,
+		text:     text,
+		vector:   vector,
+		tags: semanticcache.Tags{
+			Tenant:       endpoint.Tenant.ID(),
+			Team:         endpoint.Team.ID(),
+			Endpoint:     endpoint.ID,
+			Model:        request.Model,
+			SystemPrompt: semanticcache.SystemPromptHash(request),
+		},
+	}
+	if !control.Lookup() {
+		headers.Report(ctx, semanticcache.MissHeader())
+		return nil, lookup
+	}
+
+	threshold := endpoint.SemanticCache.Threshold
+	if threshold <= 0 {
+		threshold = g.semanticCache.threshold
+	}
+
+	// Only the answers of the same endpoint, model and system prompt are
+	// candidates, so that no answer is served to another tenant.
+	retrieved, err := g.semanticCache.cache.RetrieveFirstByVectors(ctx, vector,
+		semanticcache.WithFilter(lookup.tags),
+		semanticcache.WithMinSimilarity(threshold),
+	)
+	if err != nil {
+		g.logger.Warn("failed to retrieve the answers cached of the chat completion", zap.Error(err))
+	}
+	if retrieved != nil && retrieved.Object != nil {
+		response := retrieved.Object.Response
+		response.Created = time.Now().Unix()
+		// The answer is not charged to the endpoint again.
+		response.Usage = openai.Usage{}
+
+		headers.Report(ctx, semanticcache.HitHeader(retrieved.Score))
+
+		return &response, nil
+	}
+
+	headers.Report(ctx, semanticcache.MissHeader())
+
+	return nil, lookup
+}
+
+// storeSemanticCache caches response, the answer of the question of lookup,
+// in the background, unless lookup is nil or the response is not complete.
+func (g *Gateway) storeSemanticCache(ctx context.Context, lookup *semanticLookup, response openai.ChatCompletionResponse) {
+	if lookup == nil || !semanticcache.AcceptsResponse(response) {
+		return
+	}
+
+	ttl := lookup.endpoint.SemanticCache.TTL
+	if ttl <= 0 {
+		ttl = g.semanticCache.ttl
+	}
+
+	cached := &CachedChatCompletion{
+		EndpointID: lookup.endpoint.ID,
+		Model:      lookup.model,
+		Text:       lookup.text,
+		Response:   response,
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), settleTimeout)
+		defer cancel()
+
+		_, err := g.semanticCache.cache.CacheVectors(ctx, cached, lookup.vector, ttl, semanticcache.WithTags(lookup.tags))
+		if err != nil {
+			g.logger.Warn("failed to cache the answer of the chat completion",
+				zap.String("endpoint_id", lookup.endpoint.ID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
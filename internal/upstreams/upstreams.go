@@ -0,0 +1,21 @@
+package upstreams
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/lingticio/llmg/pkg/secrets"
+)
+
+func Modules() fx.Option {
+	return fx.Options(
+		fx.Provide(secrets.NewResolver()),
+		fx.Provide(NewRateLimiter()),
+		fx.Provide(NewBudgets()),
+		fx.Provide(NewUsageLedger()),
+		fx.Provide(NewScheduler()),
+		fx.Provide(NewSemanticCache()),
+		fx.Provide(NewExactCache()),
+		fx.Provide(NewModels()),
+		fx.Provide(NewGateway()),
+	)
+}
@@ -0,0 +1,249 @@
+package upstreams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nekomeowww/xo/logger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/usage"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+)
+
+const (
+	usageEventIDLength = 24
+	// usageBufferSize bounds the events waiting to be written, the events
+	// recorded beyond it are dropped rather than holding the requests.
+	usageBufferSize = 4096
+	// usageBatchSize and usageFlushInterval bound how many events are
+	// written at once, and how long they wait to be.
+	usageBatchSize     = 256
+	usageFlushInterval = time.Second
+	usageWriteTimeout  = 10 * time.Second
+)
+
+// ErrUsageNotVisible is returned when the usage of another tenant than the
+// one of the endpoint is queried, or of another team without the admin
+// scope.
+var ErrUsageNotVisible = errors.New("usage not visible to the endpoint")
+
+type NewUsageLedgerParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *configs.Config
+	Logger    *logger.Logger
+	Rueidis   *datastore.Rueidis
+}
+
+// UsageLedger records the usage events of the requests served by the gateway
+// in the background, into the storage of the configuration, and into Redis
+// streams when enabled. The queries of the last retention of Redis are
+// served from Redis, the other ones from the storage.
+type UsageLedger struct {
+	logger *logger.Logger
+
+	storage usage.Sink
+	redis   *usage.RedisSink
+
+	events chan usage.Event
+	done   chan struct{}
+}
+
+func NewUsageLedger() func(params NewUsageLedgerParams) (*UsageLedger, error) {
+	return func(params NewUsageLedgerParams) (*UsageLedger, error) {
+		l := &UsageLedger{
+			logger: params.Logger,
+			events: make(chan usage.Event, usageBufferSize),
+			done:   make(chan struct{}),
+		}
+
+		var onStop []func() error
+
+		switch params.Config.Usage.Storage {
+		case configs.UsageStorageJSONL, "":
+			sink, err := usage.NewJSONLSink(params.Config.Usage.Directory)
+			if err != nil {
+				return nil, err
+			}
+
+			l.storage = sink
+		case configs.UsageStorageSQL:
+			db, err := datastore.NewRDS()(params.Config.Usage.Database)
+			if err != nil {
+				return nil, err
+			}
+
+			sink := usage.NewSQLSink()(db)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			err = sink.Migrate(ctx)
+			if err != nil {
+				_ = db.Close()
+				return nil, err
+			}
+
+			l.storage = sink
+			onStop = append(onStop, db.Close)
+		default:
+			return nil, fmt.Errorf("unsupported usage storage %q", params.Config.Usage.Storage)
+		}
+
+		if params.Config.Usage.Redis.Enabled {
+			client, err := params.Rueidis.Client()
+			if err != nil {
+				return nil, err
+			}
+
+			l.redis = usage.NewRedisSink()(client, params.Config.Usage.Redis.Retention)
+		}
+
+		params.Lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				go l.run()
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				params.Logger.Info("flushing usage events...")
+				close(l.events)
+
+				select {
+				case <-l.done:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+
+				for _, stop := range onStop {
+					err := stop()
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+		})
+
+		return l, nil
+	}
+}
+
+// sinks are where the events are written.
+func (l *UsageLedger) sinks() []usage.Sink {
+	if l.redis == nil {
+		return []usage.Sink{l.storage}
+	}
+
+	return []usage.Sink{l.storage, l.redis}
+}
+
+// Record records the event in the background.
+func (l *UsageLedger) Record(event usage.Event) {
+	if event.ID == "" {
+		event.ID = "usage_" + nanoid.NewWithLength(usageEventIDLength)
+	}
+
+	select {
+	case l.events <- event:
+	default:
+		l.logger.Warn("usage events are recorded faster than they are written, the event is dropped",
+			zap.String("tenant_id", event.TenantID),
+			zap.String("endpoint_id", event.EndpointID),
+		)
+	}
+}
+
+// run writes the events recorded, in batches, until the ledger is stopped.
+func (l *UsageLedger) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(usageFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]usage.Event, 0, usageBatchSize)
+
+	for {
+		select {
+		case event, ok := <-l.events:
+			if !ok {
+				l.write(batch)
+				return
+			}
+
+			batch = append(batch, event)
+			if len(batch) < usageBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		l.write(batch)
+		batch = batch[:0]
+	}
+}
+
+func (l *UsageLedger) write(events []usage.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), usageWriteTimeout)
+	defer cancel()
+
+	for _, sink := range l.sinks() {
+		err := sink.Write(ctx, events)
+		if err != nil {
+			l.logger.Error("failed to write usage events",
+				zap.String("sink", fmt.Sprintf("%T", sink)),
+				zap.Int("events", len(events)),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// QueryOf aggregates the usage events selected by the query on behalf of
+// endpoint. The query defaults to the tenant of the endpoint, and to its team
+// unless the API key of the endpoint is granted the admin scope, in which
+// case the usage of any team of the tenant may be queried. The usage of other
+// tenants is never visible to endpoints.
+func (l *UsageLedger) QueryOf(ctx context.Context, endpoint *authstorage.Endpoint, query usage.Query) ([]usage.Bucket, error) {
+	admin := endpoint.HasScope(authstorage.ScopeAdmin)
+
+	if query.TenantID == "" {
+		query.TenantID = endpoint.Tenant.ID()
+	}
+	if query.TenantID != endpoint.Tenant.ID() {
+		return nil, fmt.Errorf("%w: the usage of other tenants cannot be queried", ErrUsageNotVisible)
+	}
+	if query.TeamID == "" && !admin {
+		query.TeamID = endpoint.Team.ID()
+	}
+	if !admin && query.TeamID != endpoint.Team.ID() {
+		return nil, fmt.Errorf("%w: the admin scope is required to query other teams", ErrUsageNotVisible)
+	}
+
+	return l.Query(ctx, query)
+}
+
+// Query aggregates the usage events selected by the query.
+func (l *UsageLedger) Query(ctx context.Context, query usage.Query) ([]usage.Bucket, error) {
+	sink := l.storage
+	if l.redis != nil && !query.Since.IsZero() && query.Since.After(time.Now().Add(-l.redis.Retention())) {
+		sink = l.redis
+	}
+
+	return usage.Aggregate(ctx, sink, query)
+}
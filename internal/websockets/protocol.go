@@ -0,0 +1,100 @@
+package websockets
+
+import (
+	"encoding/json"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// Messages sent by the client.
+const (
+	TypeSessionAuthenticate = "session.authenticate"
+	TypeSessionUpdate       = "session.update"
+	TypeSessionReset        = "session.reset"
+	TypeChatTurn            = "chat.turn"
+	TypeChatCancel          = "chat.cancel"
+	TypeToolResult          = "tool.result"
+	TypePing                = "ping"
+)
+
+// Messages sent by the server.
+const (
+	TypeSessionAuthenticated = "session.authenticated"
+	TypeSessionUpdated       = "session.updated"
+	TypeChatStarted          = "chat.started"
+	TypeChatDelta            = "chat.delta"
+	TypeChatToolCalls        = "chat.tool_calls"
+	TypeChatCompleted        = "chat.completed"
+	TypeChatCancelled        = "chat.cancelled"
+	TypeError                = "error"
+	TypePong                 = "pong"
+)
+
+// Codes of the error messages.
+const (
+	ErrorCodeInvalidMessage       = "invalid_message"
+	ErrorCodeUnauthenticated      = "unauthenticated"
+	ErrorCodeAPIKeyExpired        = "api_key_expired"
+	ErrorCodeAPIKeyRevoked        = "api_key_revoked"
+	ErrorCodeInsufficientScope    = "insufficient_scope"
+	ErrorCodeAlreadyAuthenticated = "already_authenticated"
+	ErrorCodeModelRequired        = "model_required"
+	ErrorCodeGenerationInProgress = "generation_in_progress"
+	ErrorCodeNoGeneration         = "no_generation"
+	ErrorCodeToolResultsPending   = "tool_results_pending"
+	ErrorCodeUnknownToolCall      = "unknown_tool_call"
+	ErrorCodeUpstream             = "upstream_error"
+)
+
+// SessionConfig is how the turns of a session are generated.
+type SessionConfig struct {
+	Model        string        `json:"model"`
+	Instructions string        `json:"instructions,omitempty"`
+	Tools        []openai.Tool `json:"tools,omitempty"`
+	ToolChoice   any           `json:"tool_choice,omitempty"`
+	Temperature  float32       `json:"temperature,omitempty"`
+	MaxTokens    int           `json:"max_tokens,omitempty"`
+}
+
+// ClientMessage is a message sent by the client, which fields are set
+// depends on the type.
+type ClientMessage struct {
+	Type string `json:"type"`
+
+	// session.authenticate
+	APIKey string `json:"api_key,omitempty"`
+
+	// session.update, only the fields present are updated.
+	Session json.RawMessage `json:"session,omitempty"`
+
+	// chat.turn and chat.cancel
+	TurnID  string `json:"turn_id,omitempty"`
+	Content string `json:"content,omitempty"`
+
+	// tool.result
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	Output     string `json:"output,omitempty"`
+}
+
+type ErrorPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ServerMessage is a message sent by the server, which fields are set
+// depends on the type.
+type ServerMessage struct {
+	Type string `json:"type"`
+
+	SessionID string         `json:"session_id,omitempty"`
+	Session   *SessionConfig `json:"session,omitempty"`
+
+	TurnID       string                        `json:"turn_id,omitempty"`
+	Delta        string                        `json:"delta,omitempty"`
+	ToolCalls    []openai.ToolCall             `json:"tool_calls,omitempty"`
+	Message      *openai.ChatCompletionMessage `json:"message,omitempty"`
+	FinishReason openai.FinishReason           `json:"finish_reason,omitempty"`
+	Usage        *openai.Usage                 `json:"usage,omitempty"`
+
+	Error *ErrorPayload `json:"error,omitempty"`
+}
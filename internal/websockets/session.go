@@ -0,0 +1,456 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/upstreams"
+	authstorage "github.com/lingticio/llmg/pkg/configs/cfgproviders"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/zap"
+)
+
+const idLength = 24
+
+// session is the state of a socket, the conversation lives as long as the
+// socket does.
+type session struct {
+	handlers *Handlers
+	id       string
+
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+
+	mutex            sync.Mutex
+	apiKey           string
+	endpoint         *authstorage.Endpoint
+	config           SessionConfig
+	history          []openai.ChatCompletionMessage
+	pendingToolCalls map[string]struct{}
+
+	turnID string
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newSession(handlers *Handlers, conn *websocket.Conn) *session {
+	return &session{
+		handlers:         handlers,
+		id:               "sess_" + nanoid.NewWithLength(idLength),
+		conn:             conn,
+		history:          make([]openai.ChatCompletionMessage, 0),
+		pendingToolCalls: make(map[string]struct{}),
+	}
+}
+
+func (s *session) send(message ServerMessage) {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	err := s.conn.WriteJSON(message)
+	if err != nil {
+		s.handlers.logger.Debug("failed to write websocket message", zap.String("session_id", s.id), zap.Error(err))
+	}
+}
+
+func (s *session) sendError(turnID string, code string, message string) {
+	s.send(ServerMessage{
+		Type:   TypeError,
+		TurnID: turnID,
+		Error:  &ErrorPayload{Code: code, Message: message},
+	})
+}
+
+// serve reads the messages of the client until the socket is closed, the
+// in-flight generation is cancelled then.
+func (s *session) serve(ctx context.Context) {
+	defer func() {
+		s.mutex.Lock()
+		if s.cancel != nil {
+			s.cancel()
+		}
+		s.mutex.Unlock()
+
+		s.wg.Wait()
+	}()
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) && !errors.Is(err, io.EOF) {
+				s.handlers.logger.Debug("failed to read websocket message", zap.String("session_id", s.id), zap.Error(err))
+			}
+
+			return
+		}
+
+		var message ClientMessage
+
+		err = json.Unmarshal(data, &message)
+		if err != nil {
+			s.sendError("", ErrorCodeInvalidMessage, err.Error())
+			continue
+		}
+
+		s.handle(ctx, message)
+	}
+}
+
+func (s *session) handle(ctx context.Context, message ClientMessage) {
+	if message.Type == TypePing {
+		s.send(ServerMessage{Type: TypePong})
+		return
+	}
+	if message.Type == TypeSessionAuthenticate {
+		s.authenticate(ctx, message)
+		return
+	}
+
+	s.mutex.Lock()
+	authenticated := s.endpoint != nil
+	s.mutex.Unlock()
+
+	if !authenticated {
+		s.sendError(message.TurnID, ErrorCodeUnauthenticated, "the session must be authenticated first with session.authenticate")
+		return
+	}
+
+	switch message.Type {
+	case TypeSessionUpdate:
+		s.update(message)
+	case TypeSessionReset:
+		s.reset()
+	case TypeChatTurn:
+		s.turn(ctx, message)
+	case TypeChatCancel:
+		s.cancelTurn(message)
+	case TypeToolResult:
+		s.toolResult(ctx, message)
+	default:
+		s.sendError(message.TurnID, ErrorCodeInvalidMessage, "unknown message type "+message.Type)
+	}
+}
+
+func errorCodeOf(err error) string {
+	switch {
+	case errors.Is(err, authstorage.ErrAPIKeyExpired):
+		return ErrorCodeAPIKeyExpired
+	case errors.Is(err, authstorage.ErrAPIKeyRevoked):
+		return ErrorCodeAPIKeyRevoked
+	case errors.Is(err, authstorage.ErrScopeNotGranted):
+		return ErrorCodeInsufficientScope
+	default:
+		return ErrorCodeUnauthenticated
+	}
+}
+
+// authenticateEndpoint authenticates the API key of the session, which must
+// be issued by the gateway, and granted the chat scope.
+func (s *session) authenticateEndpoint(ctx context.Context, apiKey string) (*authstorage.Endpoint, error) {
+	endpoint, err := s.handlers.authenticator.Authenticate(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = endpoints.RequireScope(endpoint, authstorage.ScopeChat)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (s *session) authenticate(ctx context.Context, message ClientMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.endpoint != nil {
+		s.sendError("", ErrorCodeAlreadyAuthenticated, "the session is already authenticated")
+		return
+	}
+	if message.APIKey == "" {
+		s.sendError("", ErrorCodeUnauthenticated, "api_key is required")
+		return
+	}
+
+	endpoint, err := s.authenticateEndpoint(ctx, message.APIKey)
+	if err != nil {
+		s.sendError("", errorCodeOf(err), err.Error())
+		return
+	}
+
+	s.apiKey = message.APIKey
+	s.endpoint = endpoint
+
+	s.send(ServerMessage{
+		Type:      TypeSessionAuthenticated,
+		SessionID: s.id,
+		Session:   lo.ToPtr(s.config),
+	})
+}
+
+func (s *session) update(message ClientMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(message.Session) > 0 {
+		config := s.config
+
+		err := json.Unmarshal(message.Session, &config)
+		if err != nil {
+			s.sendError("", ErrorCodeInvalidMessage, err.Error())
+			return
+		}
+
+		s.config = config
+	}
+
+	s.send(ServerMessage{
+		Type:      TypeSessionUpdated,
+		SessionID: s.id,
+		Session:   lo.ToPtr(s.config),
+	})
+}
+
+func (s *session) reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.cancel != nil {
+		s.sendError(s.turnID, ErrorCodeGenerationInProgress, "the history cannot be reset while generating")
+		return
+	}
+
+	s.history = make([]openai.ChatCompletionMessage, 0)
+	s.pendingToolCalls = make(map[string]struct{})
+
+	s.send(ServerMessage{
+		Type:      TypeSessionUpdated,
+		SessionID: s.id,
+		Session:   lo.ToPtr(s.config),
+	})
+}
+
+func (s *session) turn(ctx context.Context, message ClientMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.cancel != nil {
+		s.sendError(message.TurnID, ErrorCodeGenerationInProgress, "a turn is already being generated, cancel it first")
+		return
+	}
+	if len(s.pendingToolCalls) > 0 {
+		s.sendError(message.TurnID, ErrorCodeToolResultsPending, "the results of the pending tool calls must be sent first")
+		return
+	}
+	if s.config.Model == "" {
+		s.sendError(message.TurnID, ErrorCodeModelRequired, "the model must be set with session.update first")
+		return
+	}
+
+	s.history = append(s.history, openai.ChatCompletionMessage{
+		Role:    openai.ChatMessageRoleUser,
+		Content: message.Content,
+	})
+
+	s.start(ctx, lo.Ternary(message.TurnID == "", "turn_"+nanoid.NewWithLength(idLength), message.TurnID))
+}
+
+func (s *session) cancelTurn(message ClientMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.cancel == nil || (message.TurnID != "" && message.TurnID != s.turnID) {
+		s.sendError(message.TurnID, ErrorCodeNoGeneration, "no such turn is being generated")
+		return
+	}
+
+	s.cancel()
+}
+
+// toolResult records the result of a tool call, the turn that issued the
+// calls is resumed once the results of all of them are received.
+func (s *session) toolResult(ctx context.Context, message ClientMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.pendingToolCalls[message.ToolCallID]; !ok {
+		s.sendError(message.TurnID, ErrorCodeUnknownToolCall, "no pending tool call with id "+message.ToolCallID)
+		return
+	}
+
+	delete(s.pendingToolCalls, message.ToolCallID)
+
+	s.history = append(s.history, openai.ChatCompletionMessage{
+		Role:       openai.ChatMessageRoleTool,
+		Content:    message.Output,
+		ToolCallID: message.ToolCallID,
+	})
+
+	if len(s.pendingToolCalls) == 0 {
+		s.start(ctx, s.turnID)
+	}
+}
+
+// start generates the next assistant message in the background, s.mutex must
+// be held. The API key is authenticated again, so that keys that expired, or
+// were revoked, since the session was authenticated stop being accepted.
+func (s *session) start(ctx context.Context, turnID string) {
+	endpoint, err := s.authenticateEndpoint(ctx, s.apiKey)
+	if err != nil {
+		s.sendError(turnID, errorCodeOf(err), err.Error())
+		return
+	}
+
+	s.endpoint = endpoint
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	s.turnID = turnID
+	s.cancel = cancel
+
+	messages := make([]openai.ChatCompletionMessage, 0, len(s.history)+1)
+	if s.config.Instructions != "" {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: s.config.Instructions,
+		})
+	}
+
+	messages = append(messages, s.history...)
+
+	request := openai.ChatCompletionRequest{
+		Model:         s.config.Model,
+		Messages:      messages,
+		Tools:         s.config.Tools,
+		ToolChoice:    s.config.ToolChoice,
+		Temperature:   s.config.Temperature,
+		MaxTokens:     s.config.MaxTokens,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	}
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+
+		s.generate(ctx, turnID, request)
+	}()
+}
+
+func (s *session) generate(ctx context.Context, turnID string, request openai.ChatCompletionRequest) {
+	s.send(ServerMessage{Type: TypeChatStarted, TurnID: turnID})
+
+	message, finishReason, usage, err := s.stream(ctx, turnID, request)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.cancel = nil
+
+	// Tool calls interrupted halfway cannot be answered, they are dropped,
+	// while the content streamed before a cancellation is kept since it is
+	// what the client has already seen.
+	if ctx.Err() != nil {
+		message.ToolCalls = nil
+	}
+	if message.Content != "" || len(message.ToolCalls) > 0 {
+		s.history = append(s.history, message)
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		s.send(ServerMessage{Type: TypeChatCancelled, TurnID: turnID, Message: &message})
+	case err != nil:
+		apiErr := upstreams.AsAPIError(err)
+		s.sendError(turnID, ErrorCodeUpstream, lo.Ternary(apiErr.Detail == "", apiErr.Title, apiErr.Detail))
+	default:
+		if len(message.ToolCalls) > 0 {
+			for _, call := range message.ToolCalls {
+				s.pendingToolCalls[call.ID] = struct{}{}
+			}
+
+			s.send(ServerMessage{Type: TypeChatToolCalls, TurnID: turnID, ToolCalls: message.ToolCalls})
+		}
+
+		s.send(ServerMessage{
+			Type:         TypeChatCompleted,
+			TurnID:       turnID,
+			Message:      &message,
+			FinishReason: finishReason,
+			Usage:        usage,
+		})
+	}
+}
+
+// stream relays the deltas of the generation to the client, and returns the
+// assistant message accumulated so far even when the stream fails.
+func (s *session) stream(ctx context.Context, turnID string, request openai.ChatCompletionRequest) (message openai.ChatCompletionMessage, finishReason openai.FinishReason, usage *openai.Usage, err error) {
+	message = openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant}
+
+	stream, err := s.handlers.gateway.CreateChatCompletionStream(ctx, s.endpoint, request)
+	if err != nil {
+		return message, "", nil, err
+	}
+
+	defer stream.Close()
+
+	calls := make(map[int]*openai.ToolCall)
+	callsInOrder := make([]*openai.ToolCall, 0)
+
+	defer func() {
+		message.ToolCalls = lo.Map(callsInOrder, func(item *openai.ToolCall, _ int) openai.ToolCall {
+			return *item
+		})
+	}()
+
+	for {
+		var chunk openai.ChatCompletionStreamResponse
+
+		chunk, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return message, finishReason, usage, nil
+		}
+		if err != nil {
+			return message, finishReason, usage, err
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		choice := chunk.Choices[0]
+		if choice.FinishReason != "" {
+			finishReason = choice.FinishReason
+		}
+		if choice.Delta.Content != "" {
+			message.Content += choice.Delta.Content
+
+			s.send(ServerMessage{Type: TypeChatDelta, TurnID: turnID, Delta: choice.Delta.Content})
+		}
+
+		for i, delta := range choice.Delta.ToolCalls {
+			index := lo.FromPtrOr(delta.Index, i)
+
+			call, ok := calls[index]
+			if !ok {
+				call = &openai.ToolCall{ID: delta.ID, Type: openai.ToolTypeFunction}
+				calls[index] = call
+				callsInOrder = append(callsInOrder, call)
+			}
+
+			call.Function.Name += delta.Function.Name
+			call.Function.Arguments += delta.Function.Arguments
+		}
+	}
+}
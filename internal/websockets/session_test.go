@@ -0,0 +1,248 @@
+package websockets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/nekomeowww/xo/logger"
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/upstreams"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+	grpcpkg "github.com/lingticio/llmg/pkg/util/grpc"
+)
+
+// contentHang makes the upstream of the tests stream a first delta, then
+// hang until the request is canceled.
+const contentHang = "hang"
+
+type testUpstream struct {
+	*httptest.Server
+
+	requests chan openai.ChatCompletionRequest
+	canceled chan struct{}
+}
+
+// newTestUpstream streams chat completions answering the content of the last
+// message.
+func newTestUpstream(t *testing.T) *testUpstream {
+	t.Helper()
+
+	upstream := &testUpstream{
+		requests: make(chan openai.ChatCompletionRequest, 8), //nolint:mnd
+		canceled: make(chan struct{}, 8),                     //nolint:mnd
+	}
+
+	upstream.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		upstream.requests <- request
+
+		send := func(chunk openai.ChatCompletionStreamResponse) {
+			data, _ := json.Marshal(chunk)
+			_, _ = w.Write([]byte("data: " + string(data) + "\n\n"))
+			w.(http.Flusher).Flush()
+		}
+
+		w.Header().Set(echo.HeaderContentType, "text/event-stream")
+
+		content := request.Messages[len(request.Messages)-1].Content
+		if content == contentHang {
+			send(openai.ChatCompletionStreamResponse{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "Hello"}}}})
+
+			<-r.Context().Done()
+			upstream.canceled <- struct{}{}
+
+			return
+		}
+
+		send(openai.ChatCompletionStreamResponse{Choices: []openai.ChatCompletionStreamChoice{{Delta: openai.ChatCompletionStreamChoiceDelta{Content: "echo: " + content}, FinishReason: openai.FinishReasonStop}}})
+
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+
+	t.Cleanup(upstream.Close)
+
+	return upstream
+}
+
+// newTestServer serves chat sessions with the routes of a single endpoint,
+// whose API key is sk-test.
+func newTestServer(t *testing.T, upstreamURL string) *httptest.Server {
+	t.Helper()
+
+	config := &configs.Config{
+		Routes: configs.Routes{
+			Tenants: []configs.Tenant{
+				{
+					ID: "tenant",
+					Teams: []configs.Team{{
+						ID: "team",
+						Groups: []configs.Group{{
+							ID:        "group",
+							Endpoints: []configs.Endpoint{{ID: "endpoint", APIKey: "sk-test"}},
+						}},
+					}},
+					Upstream: &metadata.UpstreamSingleOrMultiple{
+						Upstream: &metadata.Upstream{
+							OpenAI: metadata.UpstreamOpenAI{
+								BaseURL: upstreamURL + "/v1",
+								APIKey:  "sk-upstream",
+								Compatible: metadata.UpstreamOpenAICompatible{
+									Chat: metadata.UpstreamOpenAICompatibleChat{Stream: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Endpoints: configs.Endpoints{Provider: configs.EndpointsProviderConfig},
+		Usage: configs.Usage{
+			Storage:   configs.UsageStorageJSONL,
+			Directory: t.TempDir(),
+		},
+	}
+
+	var handlers *Handlers
+
+	app := fxtest.New(t,
+		fx.NopLogger,
+		fx.Supply(config),
+		fx.Provide(func() (*logger.Logger, error) {
+			return logger.NewLogger(logger.WithLevel(zapcore.FatalLevel))
+		}),
+		datastore.Modules(),
+		endpoints.Modules(),
+		upstreams.Modules(),
+		fx.Provide(NewHandlers()),
+		fx.Populate(&handlers),
+	)
+
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	register := grpcpkg.NewRegister()
+	handlers.Install(register)
+
+	e := echo.New()
+
+	for path, methodHandlers := range register.EchoHandlers {
+		for method, handler := range methodHandlers {
+			e.Add(method, path, handler)
+		}
+	}
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func dial(t *testing.T, serverURL string) *websocket.Conn {
+	t.Helper()
+
+	conn, response, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(serverURL, "http")+"/v1/chat/ws", nil)
+	require.NoError(t, err)
+
+	_ = response.Body.Close()
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+// exchange sends message, and returns the messages received until one of
+// type until.
+func exchange(t *testing.T, conn *websocket.Conn, message ClientMessage, until string) []ServerMessage {
+	t.Helper()
+
+	require.NoError(t, conn.WriteJSON(message))
+
+	received := make([]ServerMessage, 0)
+
+	for {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+		var reply ServerMessage
+
+		require.NoError(t, conn.ReadJSON(&reply))
+
+		received = append(received, reply)
+		if reply.Type == until {
+			return received
+		}
+	}
+}
+
+func typesOf(messages []ServerMessage) []string {
+	return lo.Map(messages, func(item ServerMessage, _ int) string {
+		return item.Type
+	})
+}
+
+func TestSession_Cancel(t *testing.T) {
+	upstream := newTestUpstream(t)
+	conn := dial(t, newTestServer(t, upstream.URL).URL)
+
+	exchange(t, conn, ClientMessage{Type: TypeSessionAuthenticate, APIKey: "sk-test"}, TypeSessionAuthenticated)
+	exchange(t, conn, ClientMessage{Type: TypeSessionUpdate, Session: json.RawMessage(`{"model":"gpt-4o"}`)}, TypeSessionUpdated)
+
+	received := exchange(t, conn, ClientMessage{Type: TypeChatTurn, TurnID: "turn_1", Content: contentHang}, TypeChatDelta)
+	assert.Equal(t, []string{TypeChatStarted, TypeChatDelta}, typesOf(received))
+	assert.Equal(t, "Hello", received[1].Delta)
+
+	// Cancelling mid-stream cancels the request to the upstream, and reports
+	// the content streamed so far.
+	received = exchange(t, conn, ClientMessage{Type: TypeChatCancel, TurnID: "turn_1"}, TypeChatCancelled)
+	assert.Equal(t, []string{TypeChatCancelled}, typesOf(received))
+	assert.Equal(t, "turn_1", received[0].TurnID)
+	require.NotNil(t, received[0].Message)
+	assert.Equal(t, "Hello", received[0].Message.Content)
+
+	select {
+	case <-upstream.canceled:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the request to the upstream was not canceled")
+	}
+
+	received = exchange(t, conn, ClientMessage{Type: TypeChatCancel, TurnID: "turn_1"}, TypeError)
+	require.NotNil(t, received[0].Error)
+	assert.Equal(t, ErrorCodeNoGeneration, received[0].Error.Code)
+
+	// The content streamed before the cancellation is kept in the history of
+	// the next turns.
+	received = exchange(t, conn, ClientMessage{Type: TypeChatTurn, TurnID: "turn_2", Content: "Again"}, TypeChatCompleted)
+	assert.Equal(t, []string{TypeChatStarted, TypeChatDelta, TypeChatCompleted}, typesOf(received))
+	assert.Equal(t, "echo: Again", received[2].Message.Content)
+
+	<-upstream.requests
+
+	request := <-upstream.requests
+	assert.Equal(t, []string{contentHang, "Hello", "Again"}, lo.Map(request.Messages, func(item openai.ChatCompletionMessage, _ int) string {
+		return item.Content
+	}))
+}
@@ -0,0 +1,101 @@
+package websockets
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/nekomeowww/xo/logger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/internal/endpoints"
+	"github.com/lingticio/llmg/internal/upstreams"
+	grpcpkg "github.com/lingticio/llmg/pkg/util/grpc"
+)
+
+const maxMessageSize = 4 << 20
+
+type NewHandlersParams struct {
+	fx.In
+
+	Config        *configs.Config
+	Logger        *logger.Logger
+	Authenticator *endpoints.Authenticator
+	Gateway       *upstreams.Gateway
+}
+
+// Handlers serves chat sessions over WebSocket, the protocol is documented in
+// docs/designs/2 - WebSocket Chat Protocol.md.
+type Handlers struct {
+	logger        *logger.Logger
+	authenticator *endpoints.Authenticator
+	gateway       *upstreams.Gateway
+
+	upgrader websocket.Upgrader
+}
+
+func NewHandlers() func(params NewHandlersParams) *Handlers {
+	return func(params NewHandlersParams) *Handlers {
+		return &Handlers{
+			logger:        params.Logger,
+			authenticator: params.Authenticator,
+			gateway:       params.Gateway,
+			upgrader: websocket.Upgrader{
+				CheckOrigin:     checkOrigin(params.Config.HTTP.AllowedOrigins),
+				ReadBufferSize:  1024, //nolint:mnd
+				WriteBufferSize: 1024, //nolint:mnd
+			},
+		}
+	}
+}
+
+// checkOrigin accepts the requests of the origin of the gateway, and of the
+// allowed origins. Requests without an origin are not sent by browsers, and
+// are accepted as well.
+func checkOrigin(allowed []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+
+		return slices.Contains(allowed, strings.TrimRight(origin, "/"))
+	}
+}
+
+func (h *Handlers) Install(register *grpcpkg.Register) {
+	register.RegisterEchoHandler("/v1/chat/ws", http.MethodGet, h.Chat)
+}
+
+func (h *Handlers) Chat(c echo.Context) error {
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// The upgrader has already responded with the error.
+		h.logger.Debug("failed to upgrade websocket", zap.Error(err))
+		return nil
+	}
+
+	defer conn.Close()
+
+	conn.SetReadLimit(maxMessageSize)
+
+	// The request context is done as soon as the handler of the upgraded
+	// connection is considered finished by some servers, the session lives
+	// as long as the socket instead.
+	ctx := context.WithoutCancel(c.Request().Context())
+
+	newSession(h, conn).serve(ctx)
+
+	return nil
+}
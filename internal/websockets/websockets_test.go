@@ -0,0 +1,39 @@
+package websockets
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	t.Parallel()
+
+	check := checkOrigin([]string{"https://app.example.com"})
+
+	tests := []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{name: "NoOrigin", want: true},
+		{name: "SameOrigin", origin: "http://gateway.example.com", want: true},
+		{name: "AllowedOrigin", origin: "https://app.example.com", want: true},
+		{name: "OtherOrigin", origin: "https://attacker.example.com", want: false},
+		{name: "MalformedOrigin", origin: "://", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("GET", "http://gateway.example.com/v1/chat/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+
+			assert.Equal(t, tt.want, check(r))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/lingticio/llmg/apis/jsonapi"
 	"github.com/samber/lo"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/runtime/protoiface"
@@ -46,6 +47,20 @@ func (e *Error) AsEchoResponse(c echo.Context) error {
 	return c.JSON(resp.HTTPStatus(), resp)
 }
 
+// AsGraphQLError converts the error to a GraphQL error, the code, status and
+// title are carried in the extensions.
+func (e *Error) AsGraphQLError() *gqlerror.Error {
+	return &gqlerror.Error{
+		Err:     e.rawError,
+		Message: lo.Ternary(e.Detail == "", e.Title, e.Detail),
+		Extensions: map[string]any{
+			"code":   e.Code,
+			"status": e.Status,
+			"title":  e.Title,
+		},
+	}
+}
+
 func (e *Error) Caller() *jsonapi.ErrorCaller {
 	return e.caller
 }
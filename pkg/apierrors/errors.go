@@ -65,3 +65,39 @@ func NewForbidden() *Error {
 		WithTitle("Forbidden").
 		WithDetail("You do not have permission to access the requested resources")
 }
+
+func NewErrAPIKeyExpired() *Error {
+	return NewError(http.StatusUnauthorized, codes.Unauthenticated, "API_KEY_EXPIRED").
+		WithTitle("API Key Expired").
+		WithDetail("The API key has expired")
+}
+
+func NewErrAPIKeyRevoked() *Error {
+	return NewError(http.StatusUnauthorized, codes.Unauthenticated, "API_KEY_REVOKED").
+		WithTitle("API Key Revoked").
+		WithDetail("The API key has been revoked")
+}
+
+func NewErrInsufficientScope() *Error {
+	return NewError(http.StatusForbidden, codes.PermissionDenied, "INSUFFICIENT_SCOPE").
+		WithTitle("Insufficient Scope").
+		WithDetail("The API key is not allowed to access the requested resources")
+}
+
+func NewErrAlreadyExists() *Error {
+	return NewError(http.StatusConflict, codes.AlreadyExists, "ALREADY_EXISTS").
+		WithTitle("Already Exists").
+		WithDetail("The resources to create already exist")
+}
+
+func NewErrConflict() *Error {
+	return NewError(http.StatusConflict, codes.FailedPrecondition, "CONFLICT").
+		WithTitle("Conflict").
+		WithDetail("The request conflicts with the current state of the resources")
+}
+
+func NewErrNotImplemented() *Error {
+	return NewError(http.StatusNotImplemented, codes.Unimplemented, "NOT_IMPLEMENTED").
+		WithTitle("Not Implemented").
+		WithDetail("The requested operation is not supported")
+}
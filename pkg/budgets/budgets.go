@@ -0,0 +1,390 @@
+// Package budgets tracks the cost of the requests of tenants, teams, groups
+// and endpoints per day, per month and over their lifetime, across the
+// replicas of the gateway, and enforces budgets on it atomically in Redis.
+package budgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+var (
+	ErrExceeded = errors.New("budget exceeded")
+	ErrUnpriced = errors.New("model is not priced")
+)
+
+// Window is what a budget is spent over.
+type Window string
+
+const (
+	// WindowDaily and WindowMonthly start over every day and every month,
+	// in UTC.
+	WindowDaily    Window = "daily"
+	WindowMonthly  Window = "monthly"
+	WindowLifetime Window = "lifetime"
+)
+
+// expiryGrace keeps what was spent in a period for a while once it is over,
+// so that the requests reserved at the end of the period are still settled
+// in it.
+const expiryGrace = 24 * time.Hour
+
+// periodOf returns the period of the window now belongs to, and when what
+// is spent in it may be forgotten, which is zero for never.
+func (w Window) periodOf(now time.Time) (string, time.Time) {
+	now = now.UTC()
+
+	switch w {
+	case WindowDaily:
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return start.Format(time.DateOnly), start.AddDate(0, 0, 1).Add(expiryGrace)
+	case WindowMonthly:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start.Format("2006-01"), start.AddDate(0, 1, 0).Add(expiryGrace)
+	default:
+		return string(WindowLifetime), time.Time{}
+	}
+}
+
+// Cost is an amount in millionths of USD, so that the cost of tokens priced
+// in USD per million tokens is accounted for exactly.
+type Cost int64
+
+// CostOf converts an amount in USD.
+func CostOf(usd float64) Cost {
+	return Cost(math.Round(usd * 1e6)) //nolint:mnd
+}
+
+// USD converts the cost into USD.
+func (c Cost) USD() float64 {
+	return float64(c) / 1e6 //nolint:mnd
+}
+
+// Rule is a budget, in USD, of a tenant, team, group or endpoint over a
+// window, limits of 0 are not enforced.
+type Rule struct {
+	// Scope identifies who shares the budget, e.g. tenant:acme or
+	// endpoint:chat.
+	Scope  string  `json:"scope" yaml:"scope"`
+	Window Window  `json:"window" yaml:"window"`
+	Hard   float64 `json:"hard,omitempty" yaml:"hard,omitempty"`
+	Soft   float64 `json:"soft,omitempty" yaml:"soft,omitempty"`
+}
+
+// AnyHard reports whether any of rules has a hard limit.
+func AnyHard(rules []Rule) bool {
+	return slices.ContainsFunc(rules, func(rule Rule) bool {
+		return rule.Hard > 0
+	})
+}
+
+// Warning is about a soft budget being spent.
+type Warning struct {
+	Scope  string  `json:"scope"`
+	Window Window  `json:"window"`
+	Period string  `json:"period"`
+	Spent  float64 `json:"spent"`
+	Soft   float64 `json:"soft"`
+	Hard   float64 `json:"hard,omitempty"`
+	// Crossed is set for the request which spent the soft budget, which is
+	// the only one to notify about it, e.g. through a webhook.
+	Crossed bool `json:"-"`
+}
+
+// ExceededError rejects the requests of endpoints whose hard budget is
+// spent, or would be by the costs reserved for the requests in flight, it
+// matches ErrExceeded.
+type ExceededError struct {
+	Rule   Rule
+	Period string
+	Spent  float64
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s: the %s budget of %s of %s USD is spent",
+		ErrExceeded, e.Rule.Window, e.Rule.Scope, strconv.FormatFloat(e.Rule.Hard, 'f', -1, 64))
+}
+
+func (e *ExceededError) Unwrap() error {
+	return ErrExceeded
+}
+
+// reserveScript charges the cost to every budget of KEYS, or to none of
+// them when it would exceed the hard limit of any. Each key holds what was
+// spent in the period of its budget.
+//
+// ARGV holds the cost, the hard limit, 0 for none, and when the period
+// expires, in milliseconds since epoch, 0 for never, of each budget.
+//
+// It returns 0, followed by the index of the budget exceeded and what was
+// spent, when it is exceeded, or 1, followed by what was spent before and
+// after the cost is charged to each budget otherwise.
+var reserveScript = rueidis.NewLuaScript(`
+local spent = {}
+
+for i, key in ipairs(KEYS) do
+  local cost = tonumber(ARGV[3 * i - 2])
+  local hard = tonumber(ARGV[3 * i - 1])
+  local current = tonumber(redis.call('GET', key) or '0')
+  if hard > 0 and current + cost > hard then
+    return { 0, i, current }
+  end
+
+  spent[i] = current
+end
+
+local result = { 1 }
+
+for i, key in ipairs(KEYS) do
+  local after = redis.call('INCRBY', key, ARGV[3 * i - 2])
+  local expire_at = tonumber(ARGV[3 * i])
+  if expire_at > 0 then
+    redis.call('PEXPIREAT', key, expire_at)
+  end
+
+  table.insert(result, spent[i])
+  table.insert(result, after)
+end
+
+return result
+`)
+
+// settleScript charges the difference between the actual cost of requests
+// and the cost reserved for them to each budget of KEYS, never leaving what
+// was spent negative.
+//
+// ARGV holds the difference, negative for requests costing less than
+// reserved, and when the period expires, as for reserveScript, of each
+// budget.
+//
+// It returns what was spent before and after the difference is charged to
+// each budget.
+var settleScript = rueidis.NewLuaScript(`
+local result = {}
+
+for i, key in ipairs(KEYS) do
+  local delta = tonumber(ARGV[2 * i - 1])
+  local before = tonumber(redis.call('GET', key) or '0')
+  if before + delta < 0 then
+    delta = -before
+  end
+
+  local after = redis.call('INCRBY', key, delta)
+  local expire_at = tonumber(ARGV[2 * i])
+  if expire_at > 0 then
+    redis.call('PEXPIREAT', key, expire_at)
+  end
+
+  table.insert(result, before)
+  table.insert(result, after)
+end
+
+return result
+`)
+
+// budget is a rule in the period it is charged in.
+type budget struct {
+	rule     Rule
+	key      string
+	period   string
+	expireAt time.Time
+}
+
+func (b budget) expireAtArg() string {
+	if b.expireAt.IsZero() {
+		return "0"
+	}
+
+	return strconv.FormatInt(b.expireAt.UnixMilli(), 10)
+}
+
+// warningOf returns the warning about the soft limit of the budget when
+// after is spent, before being spent until then.
+func (b budget) warningOf(before Cost, after Cost) (Warning, bool) {
+	soft := CostOf(b.rule.Soft)
+	if soft <= 0 || after < soft {
+		return Warning{}, false
+	}
+
+	return Warning{
+		Scope:   b.rule.Scope,
+		Window:  b.rule.Window,
+		Period:  b.period,
+		Spent:   after.USD(),
+		Soft:    b.rule.Soft,
+		Hard:    b.rule.Hard,
+		Crossed: before < soft,
+	}, true
+}
+
+// warningsOf pairs the values of what was spent before and after, as
+// returned by the scripts, with budgets.
+func warningsOf(budgets []budget, values []int64) []Warning {
+	var warnings []Warning
+
+	for i, budget := range budgets {
+		warning, ok := budget.warningOf(Cost(values[2*i]), Cost(values[2*i+1]))
+		if ok {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings
+}
+
+// Tracker tracks what is spent in Redis. The nil Tracker tracks nothing, and
+// allows every request.
+type Tracker struct {
+	rueidis rueidis.Client
+	now     func() time.Time
+}
+
+func NewTracker() func(client rueidis.Client) *Tracker {
+	return func(client rueidis.Client) *Tracker {
+		return &Tracker{
+			rueidis: client,
+			now:     time.Now,
+		}
+	}
+}
+
+func (t *Tracker) budgetsOf(tenantID string, rules []Rule) []budget {
+	now := t.now()
+	budgets := make([]budget, 0, len(rules))
+
+	for _, rule := range rules {
+		if rule.Hard <= 0 && rule.Soft <= 0 {
+			continue
+		}
+
+		period, expireAt := rule.Window.periodOf(now)
+
+		budgets = append(budgets, budget{
+			rule:     rule,
+			key:      rediskeys.BudgetSpentByScope4.Format(tenantID, rule.Scope, rule.Window, period),
+			period:   period,
+			expireAt: expireAt,
+		})
+	}
+
+	return budgets
+}
+
+// Reserve charges the cost a request is estimated to cost to each of rules,
+// which all belong to the tenant of tenantID, so that they are charged
+// atomically in the same slot on clusters. Requests which would exceed the
+// hard limit of any of the rules are charged nothing, and are rejected with
+// an *ExceededError. The warnings about the soft limits spent are reported
+// to the reporter of ctx, see headers.WithReporter.
+//
+// Since the costs of the requests in flight are reserved, concurrent
+// requests cannot overspend the budgets by more than what they cost beyond
+// their estimates. Reservations are settled with Reservation.Settle once
+// the actual cost is known, e.g. at the end of streams. The reservation is
+// nil when no rule limits the request.
+func (t *Tracker) Reserve(ctx context.Context, tenantID string, rules []Rule, cost Cost) (*Reservation, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	budgets := t.budgetsOf(tenantID, rules)
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+
+	cost = max(cost, 0)
+	keys := make([]string, 0, len(budgets))
+	args := make([]string, 0, 3*len(budgets)) //nolint:mnd
+
+	for _, budget := range budgets {
+		keys = append(keys, budget.key)
+		args = append(args,
+			strconv.FormatInt(int64(cost), 10),
+			strconv.FormatInt(int64(CostOf(budget.rule.Hard)), 10),
+			budget.expireAtArg(),
+		)
+	}
+
+	values, err := reserveScript.Exec(ctx, t.rueidis, keys, args).AsIntSlice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 3 && values[0] == 0 { //nolint:mnd
+		exceeded := budgets[values[1]-1]
+
+		return nil, &ExceededError{
+			Rule:   exceeded.rule,
+			Period: exceeded.period,
+			Spent:  Cost(values[2]).USD(),
+		}
+	}
+	if len(values) != 1+2*len(budgets) {
+		return nil, fmt.Errorf("unexpected reply of %d values from the budget script", len(values))
+	}
+
+	warnings := warningsOf(budgets, values[1:])
+
+	headers.Report(ctx, WarningsHeader(warnings))
+
+	return &Reservation{
+		tracker:  t,
+		budgets:  budgets,
+		cost:     cost,
+		Warnings: warnings,
+	}, nil
+}
+
+// Reservation is what a request allowed by Tracker.Reserve was charged.
+type Reservation struct {
+	tracker *Tracker
+	budgets []budget
+	cost    Cost
+
+	// Warnings are about the soft limits spent once the request was
+	// charged.
+	Warnings []Warning
+}
+
+// Settle charges the difference between the actual cost of the request and
+// the cost reserved for it, which is given back when the request cost less,
+// to the budgets of the periods it was reserved in. It returns the warnings
+// about the soft limits spent once settled, and does nothing on the nil
+// Reservation.
+func (r *Reservation) Settle(ctx context.Context, cost Cost) ([]Warning, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	delta := max(cost, 0) - r.cost
+	if delta == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(r.budgets))
+	args := make([]string, 0, 2*len(r.budgets)) //nolint:mnd
+
+	for _, budget := range r.budgets {
+		keys = append(keys, budget.key)
+		args = append(args, strconv.FormatInt(int64(delta), 10), budget.expireAtArg())
+	}
+
+	values, err := settleScript.Exec(ctx, r.tracker.rueidis, keys, args).AsIntSlice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 2*len(r.budgets) {
+		return nil, fmt.Errorf("unexpected reply of %d values from the budget script", len(values))
+	}
+
+	return warningsOf(r.budgets, values), nil
+}
@@ -0,0 +1,182 @@
+package budgets
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+func newTestTracker(t *testing.T) (*Tracker, rueidis.Client) {
+	t.Helper()
+
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(r.Close)
+
+	return NewTracker()(r), r
+}
+
+// newTenantID keeps the budgets of the tests apart from the ones of former
+// runs.
+func newTenantID(t *testing.T) string {
+	t.Helper()
+
+	return t.Name() + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+func TestTracker_Reserve(t *testing.T) {
+	t.Run("Hard", func(t *testing.T) {
+		tracker, _ := newTestTracker(t)
+		tenantID := newTenantID(t)
+		rules := []Rule{
+			{Scope: "tenant:" + tenantID, Window: WindowMonthly, Hard: 1},
+			{Scope: "endpoint:chat", Window: WindowDaily, Hard: 0.5},
+		}
+
+		reservation, err := tracker.Reserve(context.Background(), tenantID, rules, CostOf(0.3))
+		require.NoError(t, err)
+		require.NotNil(t, reservation)
+		assert.Empty(t, reservation.Warnings)
+
+		_, err = tracker.Reserve(context.Background(), tenantID, rules, CostOf(0.3))
+		require.ErrorIs(t, err, ErrExceeded)
+
+		var exceededErr *ExceededError
+		require.ErrorAs(t, err, &exceededErr)
+		assert.Equal(t, "endpoint:chat", exceededErr.Rule.Scope)
+		assert.Equal(t, WindowDaily, exceededErr.Rule.Window)
+		assert.InDelta(t, 0.3, exceededErr.Spent, 1e-9)
+
+		// The request actually cost less than reserved, the rejected request
+		// was charged nothing.
+		_, err = reservation.Settle(context.Background(), CostOf(0.1))
+		require.NoError(t, err)
+
+		_, err = tracker.Reserve(context.Background(), tenantID, rules, CostOf(0.3))
+		require.NoError(t, err)
+	})
+
+	t.Run("Soft", func(t *testing.T) {
+		tracker, _ := newTestTracker(t)
+		tenantID := newTenantID(t)
+		rules := []Rule{{Scope: "team:team", Window: WindowLifetime, Soft: 1, Hard: 2}}
+
+		var reported http.Header
+
+		ctx := headers.WithReporter(context.Background(), func(header http.Header) {
+			reported = header
+		})
+
+		reservation, err := tracker.Reserve(ctx, tenantID, rules, CostOf(0.5))
+		require.NoError(t, err)
+		assert.Empty(t, reservation.Warnings)
+		assert.Nil(t, reported)
+
+		// The soft limit is only crossed once the stream ends.
+		warnings, err := reservation.Settle(ctx, CostOf(1.25))
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.True(t, warnings[0].Crossed)
+		assert.Equal(t, "lifetime", warnings[0].Period)
+		assert.InDelta(t, 1.25, warnings[0].Spent, 1e-9)
+
+		reservation, err = tracker.Reserve(ctx, tenantID, rules, CostOf(0.25))
+		require.NoError(t, err)
+		require.Len(t, reservation.Warnings, 1)
+		assert.False(t, reservation.Warnings[0].Crossed)
+		assert.Equal(t, "scope=team:team; window=lifetime; period=lifetime; spent=1.5; soft=1; hard=2", reported.Get(HeaderWarning))
+	})
+
+	t.Run("ConcurrentStreams", func(t *testing.T) {
+		tracker, client := newTestTracker(t)
+		tenantID := newTenantID(t)
+		rules := []Rule{{Scope: "endpoint:chat", Window: WindowDaily, Hard: 10}}
+
+		var wg sync.WaitGroup
+
+		for range 20 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				reservation, err := tracker.Reserve(context.Background(), tenantID, rules, CostOf(0.2))
+				assert.NoError(t, err)
+
+				_, err = reservation.Settle(context.Background(), CostOf(0.35))
+				assert.NoError(t, err)
+			}()
+		}
+
+		wg.Wait()
+
+		period, _ := WindowDaily.periodOf(time.Now())
+
+		spent, err := client.Do(context.Background(), client.B().Get().Key(rediskeys.BudgetSpentByScope4.Format(tenantID, "endpoint:chat", WindowDaily, period)).Build()).AsInt64()
+		require.NoError(t, err)
+		assert.Equal(t, int64(CostOf(7)), spent)
+	})
+
+	t.Run("Untracked", func(t *testing.T) {
+		var tracker *Tracker
+
+		reservation, err := tracker.Reserve(context.Background(), "tenant", []Rule{{Scope: "endpoint:chat", Window: WindowDaily, Hard: 1}}, CostOf(1))
+		require.NoError(t, err)
+		assert.Nil(t, reservation)
+
+		warnings, err := reservation.Settle(context.Background(), CostOf(2))
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+}
+
+func TestWindow_periodOf(t *testing.T) {
+	now := time.Date(2024, time.December, 31, 23, 30, 0, 0, time.FixedZone("UTC+8", 8*60*60))
+
+	period, expireAt := WindowDaily.periodOf(now)
+	assert.Equal(t, "2024-12-31", period)
+	assert.Equal(t, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), expireAt)
+
+	period, expireAt = WindowMonthly.periodOf(now)
+	assert.Equal(t, "2024-12", period)
+	assert.Equal(t, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), expireAt)
+
+	period, expireAt = WindowLifetime.periodOf(now)
+	assert.Equal(t, "lifetime", period)
+	assert.True(t, expireAt.IsZero())
+}
+
+func TestPrices_Cost(t *testing.T) {
+	prices := Prices{"gpt-4o": {Prompt: 2.5, Completion: 10}}
+
+	assert.Equal(t, CostOf(0.0025+0.005), prices.Cost("gpt-4o", 1000, 500))
+	assert.Equal(t, Cost(0), prices.Cost("unknown", 1000, 500))
+	assert.InDelta(t, 0.3, CostOf(0.3).USD(), 1e-9)
+}
+
+func TestPrices_Priced(t *testing.T) {
+	prices := Prices{"gpt-4o": {Prompt: 2.5, Completion: 10}, "free": {}}
+
+	assert.True(t, prices.Priced("gpt-4o"))
+	assert.True(t, prices.Priced("free"))
+	assert.False(t, prices.Priced("unknown"))
+}
+
+func TestAnyHard(t *testing.T) {
+	assert.False(t, AnyHard(nil))
+	assert.False(t, AnyHard([]Rule{{Scope: "tenant:acme", Window: WindowDaily, Soft: 1}}))
+	assert.True(t, AnyHard([]Rule{{Scope: "tenant:acme", Window: WindowDaily, Soft: 1}, {Scope: "endpoint:chat", Window: WindowMonthly, Hard: 10}}))
+}
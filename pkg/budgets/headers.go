@@ -0,0 +1,51 @@
+package budgets
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+const (
+	HeaderWarning = "x-llmg-budget-warning"
+)
+
+func init() {
+	headers.Register(HeaderWarning)
+}
+
+func formatUSD(usd float64) string {
+	return strconv.FormatFloat(usd, 'f', -1, 64)
+}
+
+// String formats the warning as a value of the x-llmg-budget-warning
+// header, e.g. scope=tenant:acme; window=daily; period=2024-01-01;
+// spent=8.5; soft=8; hard=10.
+func (w Warning) String() string {
+	fields := []string{
+		"scope=" + w.Scope,
+		"window=" + string(w.Window),
+		"period=" + w.Period,
+		"spent=" + formatUSD(w.Spent),
+		"soft=" + formatUSD(w.Soft),
+	}
+	if w.Hard > 0 {
+		fields = append(fields, "hard="+formatUSD(w.Hard))
+	}
+
+	return strings.Join(fields, "; ")
+}
+
+// WarningsHeader returns the x-llmg-budget-warning header of warnings, one
+// value per warning.
+func WarningsHeader(warnings []Warning) http.Header {
+	header := make(http.Header)
+
+	for _, warning := range warnings {
+		header.Add(HeaderWarning, warning.String())
+	}
+
+	return header
+}
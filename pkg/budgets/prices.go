@@ -0,0 +1,33 @@
+package budgets
+
+import (
+	"math"
+)
+
+// Price is the price, in USD per million tokens, of a model.
+type Price struct {
+	Prompt     float64
+	Completion float64
+}
+
+// Prices are the prices of models by their name.
+type Prices map[string]Price
+
+// Priced reports whether model has a price, which may be 0 for free models.
+func (p Prices) Priced(model string) bool {
+	_, ok := p[model]
+	return ok
+}
+
+// Cost returns what the tokens cost with model, models without price cost
+// nothing, see Priced.
+func (p Prices) Cost(model string, promptTokens int64, completionTokens int64) Cost {
+	price, ok := p[model]
+	if !ok {
+		return 0
+	}
+
+	// A price per million tokens is the cost of each token in millionths
+	// of USD.
+	return Cost(math.Round(float64(promptTokens)*price.Prompt + float64(completionTokens)*price.Completion))
+}
@@ -0,0 +1,93 @@
+package authstorage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+// ErrHierarchyNotEmpty is returned when deleting a tenant, team or group
+// that still has teams, groups or endpoints.
+var ErrHierarchyNotEmpty = errors.New("tenant, team or group is not empty")
+
+// TenantRecord is a tenant as managed by administrators, Upstream is the
+// override of the tenant itself.
+type TenantRecord struct {
+	ID       string                             `json:"id"`
+	Upstream *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty"`
+	Policy   *Policy                            `json:"policy,omitempty"`
+}
+
+// TeamRecord is a team as managed by administrators, Upstream is the
+// override of the team itself.
+type TeamRecord struct {
+	ID       string                             `json:"id"`
+	TenantID string                             `json:"tenantId"`
+	Upstream *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty"`
+	Policy   *Policy                            `json:"policy,omitempty"`
+}
+
+// GroupRecord is a group as managed by administrators, Upstream is the
+// override of the group itself. ParentGroupID is empty for the groups that
+// are not nested.
+type GroupRecord struct {
+	ID            string                             `json:"id"`
+	TeamID        string                             `json:"teamId"`
+	ParentGroupID string                             `json:"parentGroupId,omitempty"`
+	Upstream      *metadata.UpstreamSingleOrMultiple `json:"upstream,omitempty"`
+	Policy        *Policy                            `json:"policy,omitempty"`
+}
+
+// EndpointProviderAdministrable manages tenants, teams, groups and endpoints,
+// e.g. through the admin API. Unlike the endpoints returned by
+// EndpointProviderQueryable, the upstreams returned are the overrides of the
+// tenant, team, group or endpoint itself, not the inherited ones. Lists are
+// sorted by ID.
+type EndpointProviderAdministrable interface {
+	EndpointProviderQueryable
+	EndpointProviderMutable
+	EndpointProviderAPIKeyMutable
+
+	// ConfigureOneTenant creates the tenant if it does not exist yet.
+	ConfigureOneTenant(ctx context.Context, tenantID string) error
+	// ConfigureOneTeam creates the team, or moves it to the tenant.
+	ConfigureOneTeam(ctx context.Context, tenantID string, teamID string) error
+	// ConfigureOneGroup creates the group, or moves it to the team. The
+	// group is nested in parentGroupID when it is not empty.
+	ConfigureOneGroup(ctx context.Context, teamID string, parentGroupID string, groupID string) error
+
+	// FindOneTenant returns ErrTenantNotFound when the tenant does not exist.
+	FindOneTenant(ctx context.Context, tenantID string) (*TenantRecord, error)
+	// FindOneTeam returns ErrTeamNotFound when the team does not exist.
+	FindOneTeam(ctx context.Context, teamID string) (*TeamRecord, error)
+	// FindOneGroup returns ErrGroupNotFound when the group does not exist.
+	FindOneGroup(ctx context.Context, groupID string) (*GroupRecord, error)
+	// FindOneByID returns the endpoint without any API key, or
+	// ErrEndpointNotFound when the endpoint does not exist.
+	FindOneByID(ctx context.Context, endpointID string) (*Endpoint, error)
+
+	// ConfigureOnePolicyForTenant, ConfigureOnePolicyForTeam,
+	// ConfigureOnePolicyForGroup and ConfigureOnePolicyForEndpoint set the
+	// policy of the tenant, team, group or endpoint, which the endpoints found
+	// by EndpointProviderQueryable enforce. A nil policy removes it.
+	ConfigureOnePolicyForTenant(ctx context.Context, tenantID string, policy *Policy) error
+	ConfigureOnePolicyForTeam(ctx context.Context, teamID string, policy *Policy) error
+	ConfigureOnePolicyForGroup(ctx context.Context, groupID string, policy *Policy) error
+	ConfigureOnePolicyForEndpoint(ctx context.Context, endpointID string, policy *Policy) error
+
+	ListTenants(ctx context.Context) ([]TenantRecord, error)
+	ListTeams(ctx context.Context, tenantID string) ([]TeamRecord, error)
+	// ListGroups returns the groups of the team, nested ones included.
+	ListGroups(ctx context.Context, teamID string) ([]GroupRecord, error)
+	ListEndpoints(ctx context.Context, groupID string) ([]Endpoint, error)
+
+	// DeleteOneTenant, DeleteOneTeam and DeleteOneGroup return
+	// ErrHierarchyNotEmpty unless the teams, groups and endpoints they have
+	// are deleted first.
+	DeleteOneTenant(ctx context.Context, tenantID string) error
+	DeleteOneTeam(ctx context.Context, teamID string) error
+	DeleteOneGroup(ctx context.Context, groupID string) error
+	// DeleteOneEndpoint deletes the endpoint along with its API keys.
+	DeleteOneEndpoint(ctx context.Context, endpointID string) error
+}
@@ -0,0 +1,214 @@
+package authstorage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/pkg/budgets"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+// testEndpointProviderAdministrable manages a hierarchy with IDs prefixed by
+// prefix, so that providers backed by a shared storage may be tested.
+func testEndpointProviderAdministrable(t *testing.T, provider EndpointProviderAdministrable, prefix string) {
+	t.Helper()
+
+	ctx := context.Background()
+	id := func(name string) string { return prefix + name }
+
+	require.NoError(t, provider.ConfigureOneTenant(ctx, id("tenant")))
+	require.NoError(t, provider.ConfigureOneUpstreamForTenant(ctx, id("tenant"), newTestUpstream("tenant")))
+	require.NoError(t, provider.ConfigureOneTeam(ctx, id("tenant"), id("team")))
+	require.NoError(t, provider.ConfigureOneGroup(ctx, id("team"), "", id("group")))
+	require.NoError(t, provider.ConfigureOneGroup(ctx, id("team"), id("group"), id("nested")))
+	require.NoError(t, provider.ConfigureOneUpstreamForGroup(ctx, id("nested"), newTestUpstream("nested")))
+
+	tenant, err := provider.FindOneTenant(ctx, id("tenant"))
+	require.NoError(t, err)
+	require.NotNil(t, tenant.Upstream)
+	assert.Equal(t, "tenant", tenant.Upstream.Upstream.OpenAI.BaseURL)
+
+	team, err := provider.FindOneTeam(ctx, id("team"))
+	require.NoError(t, err)
+	assert.Equal(t, id("tenant"), team.TenantID)
+	assert.Nil(t, team.Upstream)
+
+	group, err := provider.FindOneGroup(ctx, id("nested"))
+	require.NoError(t, err)
+	assert.Equal(t, id("team"), group.TeamID)
+	assert.Equal(t, id("group"), group.ParentGroupID)
+	require.NotNil(t, group.Upstream)
+
+	_, err = provider.FindOneTenant(ctx, id("unknown"))
+	require.ErrorIs(t, err, ErrTenantNotFound)
+	_, err = provider.FindOneTeam(ctx, id("unknown"))
+	require.ErrorIs(t, err, ErrTeamNotFound)
+	_, err = provider.FindOneGroup(ctx, id("unknown"))
+	require.ErrorIs(t, err, ErrGroupNotFound)
+
+	err = provider.ConfigureOne(ctx, id("apiKey"), id("alias"), &Endpoint{
+		Tenant: metadata.Tenant{Id: id("tenant")},
+		Team:   metadata.Team{Id: id("team")},
+		Group:  metadata.Group{Id: id("group")},
+		ID:     id("endpoint"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, provider.AddOneAPIKey(ctx, id("endpoint"), id("otherAPIKey"), APIKeyOptions{}))
+	require.NoError(t, provider.ConfigureOneUpstreamForEndpoint(ctx, id("endpoint"), newTestUpstream("endpoint")))
+
+	endpoint, err := provider.FindOneByID(ctx, id("endpoint"))
+	require.NoError(t, err)
+	assert.Equal(t, id("alias"), endpoint.Alias)
+	assert.Equal(t, id("group"), endpoint.Group.Id)
+	assert.Empty(t, endpoint.APIKey)
+	require.NotNil(t, endpoint.Upstream)
+	assert.Equal(t, "endpoint", endpoint.Upstream.Upstream.OpenAI.BaseURL)
+
+	// Removing the override of the endpoint falls back to the tenant.
+	require.NoError(t, provider.ConfigureOneUpstreamForEndpoint(ctx, id("endpoint"), nil))
+
+	endpoint, err = provider.FindOneByID(ctx, id("endpoint"))
+	require.NoError(t, err)
+	assert.Nil(t, endpoint.Upstream)
+
+	issued, err := provider.FindOneByAPIKey(ctx, id("apiKey"))
+	require.NoError(t, err)
+	require.NotNil(t, issued)
+	assert.Equal(t, "tenant", issued.Upstream.Upstream.OpenAI.BaseURL)
+
+	owned := func(ids []string) []string {
+		return lo.Filter(ids, func(item string, _ int) bool { return strings.HasPrefix(item, prefix) })
+	}
+
+	tenants, err := provider.ListTenants(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{id("tenant")}, owned(lo.Map(tenants, func(item TenantRecord, _ int) string { return item.ID })))
+
+	teams, err := provider.ListTeams(ctx, id("tenant"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{id("team")}, lo.Map(teams, func(item TeamRecord, _ int) string { return item.ID }))
+
+	groups, err := provider.ListGroups(ctx, id("team"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{id("group"), id("nested")}, lo.Map(groups, func(item GroupRecord, _ int) string { return item.ID }))
+
+	endpoints, err := provider.ListEndpoints(ctx, id("group"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{id("endpoint")}, lo.Map(endpoints, func(item Endpoint, _ int) string { return item.ID }))
+
+	require.ErrorIs(t, provider.DeleteOneTenant(ctx, id("tenant")), ErrHierarchyNotEmpty)
+	require.ErrorIs(t, provider.DeleteOneTeam(ctx, id("team")), ErrHierarchyNotEmpty)
+	require.ErrorIs(t, provider.DeleteOneGroup(ctx, id("group")), ErrHierarchyNotEmpty)
+
+	require.NoError(t, provider.DeleteOneEndpoint(ctx, id("endpoint")))
+	require.ErrorIs(t, provider.DeleteOneEndpoint(ctx, id("endpoint")), ErrEndpointNotFound)
+
+	// The API keys of the endpoint are deleted along with it.
+	for _, apiKey := range []string{id("apiKey"), id("otherAPIKey")} {
+		issued, err = provider.FindOneByAPIKey(ctx, apiKey)
+		if err == nil {
+			assert.Nil(t, issued)
+		} else {
+			require.ErrorIs(t, err, ErrAPIKeyNotFound)
+		}
+	}
+
+	require.NoError(t, provider.DeleteOneGroup(ctx, id("nested")))
+	require.NoError(t, provider.DeleteOneGroup(ctx, id("group")))
+	require.NoError(t, provider.DeleteOneTeam(ctx, id("team")))
+	require.NoError(t, provider.DeleteOneTenant(ctx, id("tenant")))
+
+	_, err = provider.FindOneTenant(ctx, id("tenant"))
+	require.ErrorIs(t, err, ErrTenantNotFound)
+	require.ErrorIs(t, provider.DeleteOneTenant(ctx, id("tenant")), ErrTenantNotFound)
+}
+
+// testEndpointProviderPolicies sets the policies of a hierarchy with IDs
+// prefixed by prefix, and checks they are enforced on its endpoint.
+func testEndpointProviderPolicies(t *testing.T, provider EndpointProviderAdministrable, prefix string) {
+	t.Helper()
+
+	ctx := context.Background()
+	id := func(name string) string { return prefix + name }
+
+	require.NoError(t, provider.ConfigureOneUpstreamForTenant(ctx, id("tenant"), newTestUpstream("tenant")))
+	require.NoError(t, provider.ConfigureOneTeam(ctx, id("tenant"), id("team")))
+	require.NoError(t, provider.ConfigureOneGroup(ctx, id("team"), "", id("group")))
+
+	err := provider.ConfigureOne(ctx, id("apiKey"), "", &Endpoint{
+		Tenant: metadata.Tenant{Id: id("tenant")},
+		Team:   metadata.Team{Id: id("team")},
+		Group:  metadata.Group{Id: id("group")},
+		ID:     id("endpoint"),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, provider.ConfigureOnePolicyForTenant(ctx, id("tenant"), &Policy{
+		RateLimit: &configs.RateLimit{RequestsPerMinute: 100},
+	}))
+	require.NoError(t, provider.ConfigureOnePolicyForTeam(ctx, id("team"), &Policy{
+		Budget: &configs.Budget{Monthly: &configs.BudgetLimit{Hard: 10}},
+	}))
+	require.NoError(t, provider.ConfigureOnePolicyForGroup(ctx, id("group"), &Policy{
+		RateLimit: &configs.RateLimit{TokensPerMinute: 1000},
+	}))
+	require.NoError(t, provider.ConfigureOnePolicyForEndpoint(ctx, id("endpoint"), &Policy{
+		Priority:   &configs.Priority{Class: "batch", Permitted: []string{"standard"}},
+		ExactCache: &configs.EndpointExactCache{Enabled: true},
+	}))
+
+	tenant, err := provider.FindOneTenant(ctx, id("tenant"))
+	require.NoError(t, err)
+	require.NotNil(t, tenant.Policy)
+	assert.Equal(t, int64(100), tenant.Policy.RateLimit.RequestsPerMinute)
+
+	endpoint, err := provider.FindOneByAPIKey(ctx, id("apiKey"))
+	require.NoError(t, err)
+	require.NotNil(t, endpoint)
+	assert.Equal(t, []ratelimits.Rule{
+		{Scope: "tenant:" + id("tenant"), RequestsPerMinute: 100},
+		{Scope: "group:" + id("group"), TokensPerMinute: 1000},
+	}, endpoint.RateLimits)
+	assert.Equal(t, []budgets.Rule{
+		{Scope: "team:" + id("team"), Window: budgets.WindowMonthly, Hard: 10},
+	}, endpoint.Budgets)
+	assert.Equal(t, scheduling.ClassBatch, endpoint.Priority.Class)
+	assert.Equal(t, []scheduling.Class{scheduling.ClassStandard}, endpoint.Priority.Permitted)
+	assert.NotNil(t, endpoint.ExactCache)
+	assert.Nil(t, endpoint.SemanticCache)
+
+	record, err := provider.FindOneByID(ctx, id("endpoint"))
+	require.NoError(t, err)
+	require.NotNil(t, record.Policy)
+	assert.Equal(t, "batch", record.Policy.Priority.Class)
+
+	// Removing a policy lifts its limits.
+	require.NoError(t, provider.ConfigureOnePolicyForTenant(ctx, id("tenant"), nil))
+
+	endpoint, err = provider.FindOneByAPIKey(ctx, id("apiKey"))
+	require.NoError(t, err)
+	assert.Equal(t, []ratelimits.Rule{
+		{Scope: "group:" + id("group"), TokensPerMinute: 1000},
+	}, endpoint.RateLimits)
+
+	require.NoError(t, provider.DeleteOneEndpoint(ctx, id("endpoint")))
+	require.NoError(t, provider.DeleteOneGroup(ctx, id("group")))
+	require.NoError(t, provider.DeleteOneTeam(ctx, id("team")))
+	require.NoError(t, provider.DeleteOneTenant(ctx, id("tenant")))
+}
+
+func TestNewAPIKey(t *testing.T) {
+	apiKey := NewAPIKey()
+
+	assert.True(t, strings.HasPrefix(apiKey, "sk-"))
+	assert.Len(t, apiKey, len("sk-")+apiKeyLength)
+	assert.NotEqual(t, apiKey, NewAPIKey())
+}
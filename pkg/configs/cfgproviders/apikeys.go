@@ -0,0 +1,38 @@
+package authstorage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+)
+
+// apiKeyPrefixLength is the length of the beginning of API keys kept for
+// display.
+const apiKeyPrefixLength = 8
+
+// apiKeyLength is the length of the random part of the API keys minted by
+// NewAPIKey.
+const apiKeyLength = 48
+
+// NewAPIKey mints a random API key.
+func NewAPIKey() string {
+	return "sk-" + nanoid.NewWithLength(apiKeyLength)
+}
+
+// HashAPIKey digests apiKey with HMAC-SHA256 keyed by secret. Providers store
+// and look up API keys by their digest, so that the keys cannot be recovered
+// from the storage.
+func HashAPIKey(secret string, apiKey string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(apiKey))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// APIKeyPrefix returns the beginning of apiKey kept for display, at most half
+// of the key is revealed.
+func APIKeyPrefix(apiKey string) string {
+	return apiKey[:min(apiKeyPrefixLength, len(apiKey)/2)] //nolint:mnd
+}
@@ -0,0 +1,59 @@
+package authstorage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashAPIKey(t *testing.T) {
+	digest := HashAPIKey("secret", "apiKey")
+
+	assert.Len(t, digest, 64)
+	assert.NotContains(t, digest, "apiKey")
+	assert.Equal(t, digest, HashAPIKey("secret", "apiKey"))
+	assert.NotEqual(t, digest, HashAPIKey("other", "apiKey"))
+	assert.NotEqual(t, digest, HashAPIKey("secret", "otherAPIKey"))
+}
+
+func TestAPIKeyPrefix(t *testing.T) {
+	assert.Equal(t, "sk-abcde", APIKeyPrefix("sk-abcdefghijklmnop"))
+	assert.Equal(t, "api", APIKeyPrefix("apiKey"))
+	assert.Empty(t, APIKeyPrefix("a"))
+	assert.Empty(t, APIKeyPrefix(""))
+}
+
+func TestAPIKeyOptions(t *testing.T) {
+	now := time.Now()
+
+	t.Run("CheckActive", func(t *testing.T) {
+		assert.NoError(t, APIKeyOptions{}.CheckActive(now))
+		assert.NoError(t, APIKeyOptions{ExpiresAt: lo.ToPtr(now.Add(time.Second))}.CheckActive(now))
+		assert.ErrorIs(t, APIKeyOptions{ExpiresAt: &now}.CheckActive(now), ErrAPIKeyExpired)
+		assert.ErrorIs(t, APIKeyOptions{Disabled: true, ExpiresAt: &now}.CheckActive(now), ErrAPIKeyRevoked)
+	})
+
+	t.Run("HasScope", func(t *testing.T) {
+		unrestricted := APIKeyOptions{}
+		assert.True(t, unrestricted.HasScope(ScopeChat))
+		assert.True(t, unrestricted.HasScope(ScopeEmbeddings))
+		assert.False(t, unrestricted.HasScope(ScopeAdmin))
+
+		restricted := APIKeyOptions{Scopes: []Scope{ScopeEmbeddings, ScopeAdmin}}
+		assert.False(t, restricted.HasScope(ScopeChat))
+		assert.True(t, restricted.HasScope(ScopeEmbeddings))
+		assert.True(t, restricted.HasScope(ScopeAdmin))
+	})
+
+	t.Run("HasAlias", func(t *testing.T) {
+		assert.False(t, APIKeyOptions{}.HasAlias("summarizer"))
+
+		granted := APIKeyOptions{Aliases: []string{"summarizer", "chatbot"}}
+		assert.True(t, granted.HasAlias("summarizer"))
+		assert.True(t, granted.HasAlias("chatbot"))
+		assert.False(t, granted.HasAlias("translator"))
+		assert.False(t, granted.HasAlias(""))
+	})
+}
@@ -2,10 +2,91 @@ package authstorage
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
+	"github.com/lingticio/llmg/pkg/budgets"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/semanticcache"
 	"github.com/lingticio/llmg/pkg/types/metadata"
 )
 
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAliasNotFound  = errors.New("alias not found")
+	// ErrHierarchyNotFound is returned when no upstream is configured for
+	// the tenant, team or group looked up by FindOneByHierarchy.
+	ErrHierarchyNotFound = errors.New("tenant, team or group not found")
+	ErrEndpointNotFound  = errors.New("endpoint not found")
+	ErrAPIKeyExists      = errors.New("api key already exists")
+	ErrAPIKeyExpired     = errors.New("api key expired")
+	ErrAPIKeyRevoked     = errors.New("api key revoked")
+	ErrScopeNotGranted   = errors.New("scope not granted to the api key")
+	// ErrAliasNotGranted is returned when an API key addresses an endpoint
+	// by an alias it is not granted.
+	ErrAliasNotGranted = errors.New("alias not granted to the api key")
+)
+
+// Scope is what an API key may be used for.
+type Scope string
+
+const (
+	ScopeChat       Scope = "chat"
+	ScopeEmbeddings Scope = "embeddings"
+	ScopeModels     Scope = "models"
+	ScopeBatches    Scope = "batches"
+	ScopeUsage      Scope = "usage"
+	ScopeAdmin      Scope = "admin"
+)
+
+// Scopes are all the scopes API keys may be granted.
+var Scopes = []Scope{ScopeChat, ScopeEmbeddings, ScopeModels, ScopeBatches, ScopeUsage, ScopeAdmin}
+
+// APIKeyOptions is the lifecycle of an API key.
+type APIKeyOptions struct {
+	// ExpiresAt is when the key stops being accepted, nil for never.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	// Disabled keys are revoked, and never accepted again.
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	// Scopes are what the key may be used for. Keys without scopes may be
+	// used for anything but ScopeAdmin.
+	Scopes []Scope `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	// Aliases are the aliases of the endpoints the key may address besides
+	// its own endpoint, whose alias is always granted.
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+}
+
+// CheckActive returns ErrAPIKeyRevoked or ErrAPIKeyExpired when the key may
+// not be used at now.
+func (o APIKeyOptions) CheckActive(now time.Time) error {
+	if o.Disabled {
+		return ErrAPIKeyRevoked
+	}
+	if o.ExpiresAt != nil && !now.Before(*o.ExpiresAt) {
+		return ErrAPIKeyExpired
+	}
+
+	return nil
+}
+
+// HasScope reports whether the key may be used for scope.
+func (o APIKeyOptions) HasScope(scope Scope) bool {
+	if len(o.Scopes) == 0 {
+		return scope != ScopeAdmin
+	}
+
+	return slices.Contains(o.Scopes, scope)
+}
+
+// HasAlias reports whether the key may address the endpoint aliased alias,
+// besides its own endpoint.
+func (o APIKeyOptions) HasAlias(alias string) bool {
+	return alias != "" && slices.Contains(o.Aliases, alias)
+}
+
 type Endpoint struct {
 	metadata.UnimplementedMetadata
 
@@ -17,6 +98,33 @@ type Endpoint struct {
 	ID     string `json:"id" yaml:"id"`
 	Alias  string `json:"alias" yaml:"alias"`
 	APIKey string `json:"apiKey"`
+	// APIKeyPrefix is the beginning of the API key, kept for display by
+	// providers that only store the digest of the key.
+	APIKeyPrefix string `json:"apiKeyPrefix,omitempty" yaml:"apiKeyPrefix,omitempty"`
+
+	// APIKeyOptions is the lifecycle of the API key the endpoint was found
+	// by.
+	APIKeyOptions `yaml:",inline"`
+
+	// RateLimits are the rate limits of the tenant, team, groups and the
+	// endpoint itself, which all apply to the requests of the endpoint.
+	RateLimits []ratelimits.Rule `json:"-" yaml:"-"`
+	// Budgets are the budgets of the tenant, team, groups and the endpoint
+	// itself, which all apply to the requests of the endpoint.
+	Budgets []budgets.Rule `json:"-" yaml:"-"`
+	// Priority is the priority class of the requests of the endpoint.
+	Priority scheduling.Policy `json:"-" yaml:"-"`
+	// SemanticCache is the semantic caching of the chat completions of the
+	// endpoint, nil when the endpoint does not opt in.
+	SemanticCache *semanticcache.Policy `json:"-" yaml:"-"`
+	// ExactCache is the exact caching of the deterministic chat completions
+	// of the endpoint, nil when the endpoint does not opt in.
+	ExactCache *exactcache.Policy `json:"-" yaml:"-"`
+
+	// Policy is the policy of the endpoint itself, as set through
+	// EndpointProviderAdministrable, only returned by FindOneByID and
+	// ListEndpoints, which do not return the enforced policies above.
+	Policy *Policy `json:"-" yaml:"-"`
 }
 
 type EndpointProviderQueryable interface {
@@ -24,6 +132,24 @@ type EndpointProviderQueryable interface {
 	FindOneByAlias(ctx context.Context, alias string) (*Endpoint, error)
 }
 
+// EndpointProviderHierarchyQueryable looks up tenants, teams and groups
+// directly, for callers that are not authenticated as an endpoint, e.g. with
+// a JWT carrying the tenant in its claims. The endpoint returned has no ID,
+// alias nor API key, and inherits the upstream of the group, then of the
+// team, then of the tenant. teamID and groupID may be empty.
+type EndpointProviderHierarchyQueryable interface {
+	FindOneByHierarchy(ctx context.Context, tenantID string, teamID string, groupID string) (*Endpoint, error)
+}
+
+// EndpointProviderEffectiveQueryable looks up endpoints by their ID, for the
+// work processed in the background on behalf of an endpoint, e.g. batches,
+// which does not keep the credentials it was submitted with. As with
+// FindOneByAlias, the endpoint returned has no API key, and inherits its
+// upstream. It returns ErrEndpointNotFound when the endpoint does not exist.
+type EndpointProviderEffectiveQueryable interface {
+	FindOneEffectiveByID(ctx context.Context, endpointID string) (*Endpoint, error)
+}
+
 type EndpointProviderMutable interface {
 	ConfigureOneUpstreamForTenant(ctx context.Context, tenantID string, upstream *metadata.UpstreamSingleOrMultiple) error
 	ConfigureOneUpstreamForTeam(ctx context.Context, teamID string, upstream *metadata.UpstreamSingleOrMultiple) error
@@ -32,6 +158,60 @@ type EndpointProviderMutable interface {
 	ConfigureOne(ctx context.Context, apiKey string, alias string, endpoint *Endpoint) error
 }
 
+// EndpointProviderAPIKeyMutable manages the API keys of endpoints. An
+// endpoint may have several active keys, so that keys can be rotated without
+// downtime, see RotateOneAPIKey. Changes are made to the storage shared by
+// the replicas of the gateway, and therefore are effective immediately.
+type EndpointProviderAPIKeyMutable interface {
+	// AddOneAPIKey issues apiKey for the endpoint besides the keys issued
+	// before, it returns ErrEndpointNotFound when the endpoint does not
+	// exist, and ErrAPIKeyExists when apiKey is already issued.
+	AddOneAPIKey(ctx context.Context, endpointID string, apiKey string, options APIKeyOptions) error
+	// ExpireOneAPIKey sets when apiKey stops being accepted, it returns
+	// ErrAPIKeyNotFound when apiKey is not issued.
+	ExpireOneAPIKey(ctx context.Context, apiKey string, expiresAt time.Time) error
+	// RevokeOneAPIKey disables apiKey, it returns ErrAPIKeyNotFound when
+	// apiKey is not issued.
+	RevokeOneAPIKey(ctx context.Context, apiKey string) error
+}
+
+// RotateOneAPIKey issues newAPIKey, with the same scopes, aliases and expiry,
+// for the endpoint of apiKey, and lets apiKey expire once gracePeriod
+// elapsed, so that callers have time to switch to the new key.
+func RotateOneAPIKey(ctx context.Context, provider interface {
+	EndpointProviderQueryable
+	EndpointProviderAPIKeyMutable
+}, apiKey string, newAPIKey string, gracePeriod time.Duration) error {
+	endpoint, err := provider.FindOneByAPIKey(ctx, apiKey)
+	if err != nil {
+		return err
+	}
+	if endpoint == nil {
+		return ErrAPIKeyNotFound
+	}
+
+	err = endpoint.CheckActive(time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = provider.AddOneAPIKey(ctx, endpoint.ID, newAPIKey, APIKeyOptions{
+		ExpiresAt: endpoint.ExpiresAt,
+		Scopes:    endpoint.Scopes,
+		Aliases:   endpoint.Aliases,
+	})
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(gracePeriod)
+	if endpoint.ExpiresAt != nil && endpoint.ExpiresAt.Before(expiresAt) {
+		return nil
+	}
+
+	return provider.ExpireOneAPIKey(ctx, apiKey, expiresAt)
+}
+
 type EndpointProvider interface {
 	EndpointProviderQueryable
 }
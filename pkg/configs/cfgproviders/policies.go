@@ -0,0 +1,162 @@
+package authstorage
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/pkg/budgets"
+	"github.com/lingticio/llmg/pkg/exactcache"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+	"github.com/lingticio/llmg/pkg/scheduling"
+	"github.com/lingticio/llmg/pkg/semanticcache"
+)
+
+// Policy is the rate limit and the budget of a tenant, team, group or
+// endpoint, as declared in the routes of the configuration, or set through
+// the admin API for the providers storing the hierarchy. The priority and
+// the caching are only honored for endpoints.
+type Policy struct {
+	RateLimit     *configs.RateLimit             `json:"rateLimit,omitempty" yaml:"rate_limit,omitempty"`
+	Budget        *configs.Budget                `json:"budget,omitempty" yaml:"budget,omitempty"`
+	Priority      *configs.Priority              `json:"priority,omitempty" yaml:"priority,omitempty"`
+	SemanticCache *configs.EndpointSemanticCache `json:"semanticCache,omitempty" yaml:"semantic_cache,omitempty"`
+	ExactCache    *configs.EndpointExactCache    `json:"exactCache,omitempty" yaml:"exact_cache,omitempty"`
+}
+
+// level is a level of the hierarchy an endpoint belongs to, scoped as the
+// upstream layers are, along with its policy, nil when it has none.
+type level struct {
+	scope  string
+	policy *Policy
+}
+
+func tenantLevel(tenantID string, policy *Policy) level {
+	return level{scope: "tenant:" + tenantID, policy: policy}
+}
+
+func teamLevel(teamID string, policy *Policy) level {
+	return level{scope: "team:" + teamID, policy: policy}
+}
+
+func groupLevel(groupID string, policy *Policy) level {
+	return level{scope: "group:" + groupID, policy: policy}
+}
+
+func endpointLevel(endpointID string, policy *Policy) level {
+	return level{scope: "endpoint:" + endpointID, policy: policy}
+}
+
+// rateLimitsOf returns the rate limits declared by the levels of the
+// endpoint.
+func rateLimitsOf(levels []level) []ratelimits.Rule {
+	var rules []ratelimits.Rule
+
+	for _, level := range levels {
+		if level.policy == nil {
+			continue
+		}
+
+		rateLimit := level.policy.RateLimit
+		if rateLimit == nil || (rateLimit.RequestsPerMinute <= 0 && rateLimit.TokensPerMinute <= 0) {
+			continue
+		}
+
+		rules = append(rules, ratelimits.Rule{
+			Scope:             level.scope,
+			RequestsPerMinute: rateLimit.RequestsPerMinute,
+			TokensPerMinute:   rateLimit.TokensPerMinute,
+		})
+	}
+
+	return rules
+}
+
+// budgetsOf returns the budgets declared by the levels of the endpoint.
+func budgetsOf(levels []level) []budgets.Rule {
+	var rules []budgets.Rule
+
+	for _, level := range levels {
+		if level.policy == nil || level.policy.Budget == nil {
+			continue
+		}
+
+		budget := level.policy.Budget
+		windows := []budgets.Window{budgets.WindowDaily, budgets.WindowMonthly, budgets.WindowLifetime}
+		limits := []*configs.BudgetLimit{budget.Daily, budget.Monthly, budget.Lifetime}
+
+		for i, limit := range limits {
+			if limit == nil || (limit.Hard <= 0 && limit.Soft <= 0) {
+				continue
+			}
+
+			rules = append(rules, budgets.Rule{
+				Scope:  level.scope,
+				Window: windows[i],
+				Hard:   limit.Hard,
+				Soft:   limit.Soft,
+			})
+		}
+	}
+
+	return rules
+}
+
+// priorityOf returns the priority of the endpoint, the classes it declares
+// which are unknown are ignored.
+func priorityOf(priority *configs.Priority) scheduling.Policy {
+	if priority == nil {
+		return scheduling.Policy{}
+	}
+
+	class, err := scheduling.ParseClass(priority.Class)
+	if err != nil {
+		class = ""
+	}
+
+	return scheduling.Policy{
+		Class: class,
+		Permitted: lo.FilterMap(priority.Permitted, func(item string, _ int) (scheduling.Class, bool) {
+			permitted, err := scheduling.ParseClass(item)
+			return permitted, err == nil
+		}),
+	}
+}
+
+// semanticCacheOf returns the semantic caching of the endpoint, nil unless
+// it opts in.
+func semanticCacheOf(semanticCache *configs.EndpointSemanticCache) *semanticcache.Policy {
+	if semanticCache == nil || !semanticCache.Enabled {
+		return nil
+	}
+
+	return &semanticcache.Policy{
+		Threshold: semanticCache.Threshold,
+		TTL:       semanticCache.TTL,
+	}
+}
+
+// exactCacheOf returns the exact caching of the endpoint, nil unless it opts
+// in.
+func exactCacheOf(exactCache *configs.EndpointExactCache) *exactcache.Policy {
+	if exactCache == nil || !exactCache.Enabled {
+		return nil
+	}
+
+	return &exactcache.Policy{TTL: exactCache.TTL}
+}
+
+// applyPolicies enforces the rate limits and the budgets of every level on
+// endpoint, along with the priority and the caching of own, the policy of
+// the endpoint itself, which is nil for the endpoints of a hierarchy.
+func applyPolicies(endpoint *Endpoint, levels []level, own *Policy) {
+	endpoint.RateLimits = rateLimitsOf(levels)
+	endpoint.Budgets = budgetsOf(levels)
+
+	if own == nil {
+		return
+	}
+
+	endpoint.Priority = priorityOf(own.Priority)
+	endpoint.SemanticCache = semanticCacheOf(own.SemanticCache)
+	endpoint.ExactCache = exactCacheOf(own.ExactCache)
+}
@@ -1,16 +1,1171 @@
 package authstorage
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+var (
+	ErrTenantNotFound = errors.New("tenant not found")
+	ErrTeamNotFound   = errors.New("team not found")
+	ErrGroupNotFound  = errors.New("group not found")
+)
 
 var _ EndpointProvider = (*RDSEndpointAuthProvider)(nil)
+var _ EndpointProviderMutable = (*RDSEndpointAuthProvider)(nil)
+var _ EndpointProviderHierarchyQueryable = (*RDSEndpointAuthProvider)(nil)
+var _ EndpointProviderAPIKeyMutable = (*RDSEndpointAuthProvider)(nil)
+var _ EndpointProviderAdministrable = (*RDSEndpointAuthProvider)(nil)
+var _ EndpointProviderUpstreamExplainable = (*RDSEndpointAuthProvider)(nil)
+var _ EndpointProviderEffectiveQueryable = (*RDSEndpointAuthProvider)(nil)
+
+// rdsMigrations are applied in order, each of them exactly once. The
+// statements are written in the subset of SQL shared by Postgres and SQLite,
+// upstreams and policies are stored as JSON in TEXT columns.
+var rdsMigrations = [][]string{
+	{
+		`CREATE TABLE IF NOT EXISTS llmg_tenants (
+			id TEXT PRIMARY KEY,
+			upstream TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS llmg_teams (
+			id TEXT PRIMARY KEY,
+			tenant_id TEXT NOT NULL REFERENCES llmg_tenants (id) ON DELETE CASCADE,
+			upstream TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS llmg_groups (
+			id TEXT PRIMARY KEY,
+			team_id TEXT NOT NULL REFERENCES llmg_teams (id) ON DELETE CASCADE,
+			parent_group_id TEXT REFERENCES llmg_groups (id) ON DELETE CASCADE,
+			upstream TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS llmg_endpoints (
+			id TEXT PRIMARY KEY,
+			group_id TEXT NOT NULL REFERENCES llmg_groups (id) ON DELETE CASCADE,
+			alias TEXT UNIQUE,
+			api_key TEXT NOT NULL UNIQUE,
+			upstream TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS llmg_teams_tenant_id_idx ON llmg_teams (tenant_id)`,
+		`CREATE INDEX IF NOT EXISTS llmg_groups_team_id_idx ON llmg_groups (team_id)`,
+		`CREATE INDEX IF NOT EXISTS llmg_endpoints_group_id_idx ON llmg_endpoints (group_id)`,
+	},
+	// API keys are moved to their own table, so that endpoints may have
+	// several of them, each with its own lifecycle.
+	{
+		`ALTER TABLE llmg_endpoints RENAME TO llmg_endpoints_v1`,
+		`CREATE TABLE llmg_endpoints (
+			id TEXT PRIMARY KEY,
+			group_id TEXT NOT NULL REFERENCES llmg_groups (id) ON DELETE CASCADE,
+			alias TEXT UNIQUE,
+			upstream TEXT
+		)`,
+		`INSERT INTO llmg_endpoints (id, group_id, alias, upstream) SELECT id, group_id, alias, upstream FROM llmg_endpoints_v1`,
+		`CREATE TABLE llmg_api_keys (
+			api_key TEXT PRIMARY KEY,
+			endpoint_id TEXT NOT NULL REFERENCES llmg_endpoints (id) ON DELETE CASCADE,
+			expires_at BIGINT,
+			disabled BOOLEAN NOT NULL DEFAULT FALSE,
+			scopes TEXT
+		)`,
+		`INSERT INTO llmg_api_keys (api_key, endpoint_id) SELECT api_key, id FROM llmg_endpoints_v1`,
+		`DROP TABLE llmg_endpoints_v1`,
+		`CREATE INDEX llmg_endpoints_group_id_idx ON llmg_endpoints (group_id)`,
+		`CREATE INDEX llmg_api_keys_endpoint_id_idx ON llmg_api_keys (endpoint_id)`,
+	},
+	// API keys may address other endpoints by their aliases.
+	{
+		`ALTER TABLE llmg_api_keys ADD COLUMN aliases TEXT`,
+	},
+	// Tenants, teams, groups and endpoints may have rate limits, budgets,
+	// and for endpoints priorities and caching, see Policy.
+	{
+		`ALTER TABLE llmg_tenants ADD COLUMN policy TEXT`,
+		`ALTER TABLE llmg_teams ADD COLUMN policy TEXT`,
+		`ALTER TABLE llmg_groups ADD COLUMN policy TEXT`,
+		`ALTER TABLE llmg_endpoints ADD COLUMN policy TEXT`,
+	},
+	// API keys are stored by their digest, see HashAPIKey, along with the
+	// beginning of the key kept for display. The keys stored so far are
+	// digested by digestAPIKeys.
+	{
+		`ALTER TABLE llmg_api_keys RENAME COLUMN api_key TO api_key_digest`,
+		`ALTER TABLE llmg_api_keys ADD COLUMN api_key_prefix TEXT`,
+	},
+}
 
+// rdsMigrationAPIKeyDigests is the version of the migration storing the API
+// keys by their digest, which cannot be computed by the statements.
+const rdsMigrationAPIKeyDigests = 5
+
+// RDSEndpointAuthProvider resolves endpoints from a relational database, either
+// Postgres or SQLite. Tenants, teams, groups, which may be nested, and
+// endpoints are stored in their own tables, each with an optional upstream
+// override and policy. Endpoints may have several API keys, which are not
+// stored, endpoints are looked up by the digest of their API keys, see
+// HashAPIKey, and only the prefix of the keys is kept for display.
 type RDSEndpointAuthProvider struct {
+	db           *sql.DB
+	apiKeySecret string
+}
+
+func NewRDSEndpointAuthProvider() func(db *sql.DB, apiKeySecret string) *RDSEndpointAuthProvider {
+	return func(db *sql.DB, apiKeySecret string) *RDSEndpointAuthProvider {
+		return &RDSEndpointAuthProvider{
+			db:           db,
+			apiKeySecret: apiKeySecret,
+		}
+	}
+}
+
+// Migrate brings the schema up to date.
+func (s *RDSEndpointAuthProvider) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS llmg_schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema migrations table: %w", err)
+	}
+
+	var current int
+
+	err = s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM llmg_schema_migrations`).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for i := current; i < len(rdsMigrations); i++ {
+		err = s.withTx(ctx, func(tx *sql.Tx) error {
+			for _, statement := range rdsMigrations[i] {
+				_, err := tx.ExecContext(ctx, statement)
+				if err != nil {
+					return err
+				}
+			}
+
+			if i+1 == rdsMigrationAPIKeyDigests {
+				err := s.digestAPIKeys(ctx, tx)
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err := tx.ExecContext(ctx, `INSERT INTO llmg_schema_migrations (version, applied_at) VALUES ($1, $2)`, i+1, time.Now().Unix())
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply schema migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// digestAPIKeys replaces the API keys stored in plaintext by earlier versions
+// with their digest and prefix.
+func (s *RDSEndpointAuthProvider) digestAPIKeys(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT api_key_digest FROM llmg_api_keys WHERE api_key_prefix IS NULL`)
+	if err != nil {
+		return err
+	}
+
+	apiKeys := make([]string, 0)
+
+	for rows.Next() {
+		var apiKey string
+
+		err = rows.Scan(&apiKey)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	for _, apiKey := range apiKeys {
+		_, err = tx.ExecContext(ctx, `UPDATE llmg_api_keys SET api_key_digest = $1, api_key_prefix = $2 WHERE api_key_digest = $3`,
+			HashAPIKey(s.apiKeySecret, apiKey),
+			APIKeyPrefix(apiKey),
+			apiKey,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *RDSEndpointAuthProvider) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// marshalColumn stores value as JSON, NULL when nil.
+func marshalColumn[T any](value *T) (sql.NullString, error) {
+	if value == nil {
+		return sql.NullString{}, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(valueBytes), Valid: true}, nil
+}
+
+func unmarshalColumn[T any](value sql.NullString) (*T, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+
+	var result T
+
+	err := json.Unmarshal([]byte(value.String), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func marshalUpstream(upstream *metadata.UpstreamSingleOrMultiple) (sql.NullString, error) {
+	return marshalColumn(upstream)
+}
+
+func unmarshalUpstream(upstream sql.NullString) (*metadata.UpstreamSingleOrMultiple, error) {
+	return unmarshalColumn[metadata.UpstreamSingleOrMultiple](upstream)
+}
+
+func unmarshalPolicy(policy sql.NullString) (*Policy, error) {
+	return unmarshalColumn[Policy](policy)
+}
+
+// rdsOverrides are the upstream and policy columns of a tenant, team, group
+// or endpoint.
+type rdsOverrides struct {
+	upstream sql.NullString
+	policy   sql.NullString
+}
+
+func (o rdsOverrides) unmarshal() (*metadata.UpstreamSingleOrMultiple, *Policy, error) {
+	upstream, err := unmarshalUpstream(o.upstream)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policy, err := unmarshalPolicy(o.policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return upstream, policy, nil
+}
+
+const rdsSelectEndpoint = `SELECT
+	e.id, e.alias, e.upstream, e.policy,
+	g.id,
+	t.id, t.upstream, t.policy,
+	tn.id, tn.upstream, tn.policy
+FROM llmg_endpoints e
+JOIN llmg_groups g ON g.id = e.group_id
+JOIN llmg_teams t ON t.id = g.team_id
+JOIN llmg_tenants tn ON tn.id = t.tenant_id
+`
+
+// groupLayers returns the upstream overrides and the policies of groupID,
+// and of the groups it is nested in, from the outermost one.
+func (s *RDSEndpointAuthProvider) groupLayers(ctx context.Context, groupID string) ([]metadata.UpstreamLayer, []level, error) {
+	layers := make([]metadata.UpstreamLayer, 0)
+	levels := make([]level, 0)
+	visited := make([]string, 0)
+
+	for groupID != "" && len(visited) < maxGroupDepth && !slices.Contains(visited, groupID) {
+		var (
+			parentGroupID sql.NullString
+			overrides     rdsOverrides
+		)
+
+		err := s.db.QueryRowContext(ctx, `SELECT parent_group_id, upstream, policy FROM llmg_groups WHERE id = $1`, groupID).
+			Scan(&parentGroupID, &overrides.upstream, &overrides.policy)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				break
+			}
+
+			return nil, nil, err
+		}
+
+		upstream, policy, err := overrides.unmarshal()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		layers = append(layers, groupLayer(groupID, upstream))
+		levels = append(levels, groupLevel(groupID, policy))
+		visited = append(visited, groupID)
+		groupID = parentGroupID.String
+	}
+
+	slices.Reverse(layers)
+	slices.Reverse(levels)
+
+	return layers, levels, nil
+}
+
+// layersOf returns the upstream overrides of the tenant, team, groups and
+// endpoint, in the order they are merged in, along with their policies.
+// Levels without ID are skipped.
+func (s *RDSEndpointAuthProvider) layersOf(ctx context.Context, endpoint *Endpoint, tenant, team, own rdsOverrides) ([]metadata.UpstreamLayer, []level, error) {
+	layers := make([]metadata.UpstreamLayer, 0)
+	levels := make([]level, 0)
+
+	for _, item := range []struct {
+		id        string
+		overrides rdsOverrides
+		layer     func(id string, upstream *metadata.UpstreamSingleOrMultiple) metadata.UpstreamLayer
+		level     func(id string, policy *Policy) level
+	}{
+		{id: endpoint.Tenant.ID(), overrides: tenant, layer: tenantLayer, level: tenantLevel},
+		{id: endpoint.Team.ID(), overrides: team, layer: teamLayer, level: teamLevel},
+	} {
+		if item.id == "" {
+			continue
+		}
+
+		upstream, policy, err := item.overrides.unmarshal()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		layers = append(layers, item.layer(item.id, upstream))
+		levels = append(levels, item.level(item.id, policy))
+	}
+
+	groupLayers, groupLevels, err := s.groupLayers(ctx, endpoint.Group.ID())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layers = append(layers, groupLayers...)
+	levels = append(levels, groupLevels...)
+
+	if endpoint.ID != "" {
+		upstream, policy, err := own.unmarshal()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		layers = append(layers, endpointLayer(endpoint.ID, upstream))
+		levels = append(levels, endpointLevel(endpoint.ID, policy))
+	}
+
+	return layers, levels, nil
+}
+
+// findOneWithLayers returns the endpoint without its upstream, along with
+// the upstream overrides it is merged from and the policies it is subject
+// to.
+func (s *RDSEndpointAuthProvider) findOneWithLayers(ctx context.Context, where string, arg string) (*Endpoint, []metadata.UpstreamLayer, []level, error) {
+	var (
+		endpointID, groupID, teamID, tenantID string
+		alias                                 sql.NullString
+		own, team, tenant                     rdsOverrides
+	)
+
+	err := s.db.QueryRowContext(ctx, rdsSelectEndpoint+where, arg).Scan(
+		&endpointID, &alias, &own.upstream, &own.policy,
+		&groupID,
+		&teamID, &team.upstream, &team.policy,
+		&tenantID, &tenant.upstream, &tenant.policy,
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	endpoint := &Endpoint{
+		Tenant: metadata.Tenant{Id: tenantID},
+		Team:   metadata.Team{Id: teamID},
+		Group:  metadata.Group{Id: groupID},
+		ID:     endpointID,
+		Alias:  alias.String,
+	}
+
+	layers, levels, err := s.layersOf(ctx, endpoint, tenant, team, own)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return endpoint, layers, levels, nil
+}
+
+func (s *RDSEndpointAuthProvider) findOne(ctx context.Context, where string, arg string) (*Endpoint, error) {
+	endpoint, layers, levels, err := s.findOneWithLayers(ctx, where, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same as ConfigEndpointProvider.findUpstream, the overrides are merged
+	// from the tenant down to the endpoint.
+	endpoint.Upstream = metadata.MergeUpstreams(layers...).Upstream
+	applyPolicies(endpoint, levels, lo.LastOrEmpty(levels).policy)
+
+	return endpoint, nil
+}
+
+func (s *RDSEndpointAuthProvider) ExplainUpstream(ctx context.Context, endpointID string) (*metadata.EffectiveUpstream, error) {
+	_, layers, _, err := s.findOneWithLayers(ctx, `WHERE e.id = $1`, endpointID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEndpointNotFound
+		}
+
+		return nil, err
+	}
+
+	return lo.ToPtr(metadata.MergeUpstreams(layers...)), nil
+}
+
+// marshalList stores the scopes or aliases of API keys as JSON arrays, NULL
+// when empty.
+func marshalList[T any](items []T) (sql.NullString, error) {
+	if len(items) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	itemsBytes, err := json.Marshal(items)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(itemsBytes), Valid: true}, nil
+}
+
+func unmarshalList[T any](value sql.NullString, items *[]T) error {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(value.String), items)
+}
+
+func marshalExpiresAt(expiresAt *time.Time) sql.NullInt64 {
+	if expiresAt == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: expiresAt.Unix(), Valid: true}
 }
 
 func (s *RDSEndpointAuthProvider) FindOneByAPIKey(ctx context.Context, apiKey string) (*Endpoint, error) {
-	return &Endpoint{}, nil
+	var (
+		endpointID string
+		expiresAt  sql.NullInt64
+		disabled   bool
+		scopes     sql.NullString
+		aliases    sql.NullString
+		prefix     sql.NullString
+	)
+
+	err := s.db.QueryRowContext(ctx, `SELECT endpoint_id, expires_at, disabled, scopes, aliases, api_key_prefix FROM llmg_api_keys WHERE api_key_digest = $1`, HashAPIKey(s.apiKeySecret, apiKey)).
+		Scan(&endpointID, &expiresAt, &disabled, &scopes, &aliases, &prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	endpoint, err := s.findOne(ctx, `WHERE e.id = $1`, endpointID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	endpoint.APIKey = apiKey
+	endpoint.APIKeyPrefix = prefix.String
+	endpoint.Disabled = disabled
+
+	if expiresAt.Valid {
+		endpoint.ExpiresAt = lo.ToPtr(time.Unix(expiresAt.Int64, 0))
+	}
+
+	err = unmarshalList(scopes, &endpoint.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = unmarshalList(aliases, &endpoint.Aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
 }
 
 func (s *RDSEndpointAuthProvider) FindOneByAlias(ctx context.Context, alias string) (*Endpoint, error) {
-	return &Endpoint{}, nil
+	endpoint, err := s.findOne(ctx, `WHERE e.alias = $1`, alias)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAliasNotFound
+		}
+
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (s *RDSEndpointAuthProvider) FindOneEffectiveByID(ctx context.Context, endpointID string) (*Endpoint, error) {
+	endpoint, err := s.findOne(ctx, `WHERE e.id = $1`, endpointID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEndpointNotFound
+		}
+
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (s *RDSEndpointAuthProvider) FindOneByHierarchy(ctx context.Context, tenantID string, teamID string, groupID string) (*Endpoint, error) {
+	var (
+		foundTeamID, foundGroupID sql.NullString
+		team, tenant              rdsOverrides
+	)
+
+	var err error
+
+	switch {
+	case groupID != "":
+		err = s.db.QueryRowContext(ctx, `SELECT t.id, g.id, t.upstream, t.policy, tn.upstream, tn.policy
+			FROM llmg_groups g
+			JOIN llmg_teams t ON t.id = g.team_id
+			JOIN llmg_tenants tn ON tn.id = t.tenant_id
+			WHERE g.id = $1 AND tn.id = $2 AND ($3 = '' OR t.id = $3)`, groupID, tenantID, teamID).
+			Scan(&foundTeamID, &foundGroupID, &team.upstream, &team.policy, &tenant.upstream, &tenant.policy)
+	case teamID != "":
+		err = s.db.QueryRowContext(ctx, `SELECT t.id, t.upstream, t.policy, tn.upstream, tn.policy
+			FROM llmg_teams t
+			JOIN llmg_tenants tn ON tn.id = t.tenant_id
+			WHERE t.id = $1 AND tn.id = $2`, teamID, tenantID).
+			Scan(&foundTeamID, &team.upstream, &team.policy, &tenant.upstream, &tenant.policy)
+	default:
+		err = s.db.QueryRowContext(ctx, `SELECT upstream, policy FROM llmg_tenants WHERE id = $1`, tenantID).
+			Scan(&tenant.upstream, &tenant.policy)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrHierarchyNotFound
+		}
+
+		return nil, err
+	}
+
+	endpoint := &Endpoint{
+		Tenant: metadata.Tenant{Id: tenantID},
+		Team:   metadata.Team{Id: foundTeamID.String},
+		Group:  metadata.Group{Id: foundGroupID.String},
+	}
+
+	layers, levels, err := s.layersOf(ctx, endpoint, tenant, team, rdsOverrides{})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.Upstream = metadata.MergeUpstreams(layers...).Upstream
+	if endpoint.Upstream == nil {
+		return nil, ErrHierarchyNotFound
+	}
+
+	applyPolicies(endpoint, levels, nil)
+
+	return endpoint, nil
+}
+
+// ConfigureOneTenant creates the tenant if it does not exist yet.
+func (s *RDSEndpointAuthProvider) ConfigureOneTenant(ctx context.Context, tenantID string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO llmg_tenants (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`, tenantID)
+	return err
+}
+
+// ConfigureOneTeam creates the team, or moves it to the tenant.
+func (s *RDSEndpointAuthProvider) ConfigureOneTeam(ctx context.Context, tenantID string, teamID string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO llmg_teams (id, tenant_id) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET tenant_id = excluded.tenant_id`, teamID, tenantID)
+
+	return err
+}
+
+// ConfigureOneGroup creates the group, or moves it to the team. The group is
+// nested in parentGroupID when it is not empty.
+func (s *RDSEndpointAuthProvider) ConfigureOneGroup(ctx context.Context, teamID string, parentGroupID string, groupID string) error {
+	parent := sql.NullString{String: parentGroupID, Valid: parentGroupID != ""}
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO llmg_groups (id, team_id, parent_group_id) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET team_id = excluded.team_id, parent_group_id = excluded.parent_group_id`, groupID, teamID, parent)
+
+	return err
+}
+
+func (s *RDSEndpointAuthProvider) configureUpstream(ctx context.Context, table string, id string, upstream *metadata.UpstreamSingleOrMultiple, errNotFound error) error {
+	value, err := marshalUpstream(upstream)
+	if err != nil {
+		return err
+	}
+
+	return s.configureColumn(ctx, table, "upstream", id, value, errNotFound)
+}
+
+func (s *RDSEndpointAuthProvider) configurePolicy(ctx context.Context, table string, id string, policy *Policy, errNotFound error) error {
+	value, err := marshalColumn(policy)
+	if err != nil {
+		return err
+	}
+
+	return s.configureColumn(ctx, table, "policy", id, value, errNotFound)
+}
+
+// configureColumn sets column of the row of table, or errNotFound when the
+// row does not exist.
+func (s *RDSEndpointAuthProvider) configureColumn(ctx context.Context, table string, column string, id string, value sql.NullString, errNotFound error) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE `+table+` SET `+column+` = $1 WHERE id = $2`, value, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
+
+// ConfigureOneUpstreamForTenant overrides the upstream of the tenant, creating
+// the tenant if it does not exist yet. A nil upstream removes the override.
+func (s *RDSEndpointAuthProvider) ConfigureOneUpstreamForTenant(ctx context.Context, tenantID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	err := s.ConfigureOneTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	return s.configureUpstream(ctx, "llmg_tenants", tenantID, upstream, ErrTenantNotFound)
+}
+
+// ConfigureOneUpstreamForTeam overrides the upstream of an existing team. A
+// nil upstream removes the override.
+func (s *RDSEndpointAuthProvider) ConfigureOneUpstreamForTeam(ctx context.Context, teamID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	return s.configureUpstream(ctx, "llmg_teams", teamID, upstream, ErrTeamNotFound)
+}
+
+// ConfigureOneUpstreamForGroup overrides the upstream of an existing group. A
+// nil upstream removes the override.
+func (s *RDSEndpointAuthProvider) ConfigureOneUpstreamForGroup(ctx context.Context, groupID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	return s.configureUpstream(ctx, "llmg_groups", groupID, upstream, ErrGroupNotFound)
+}
+
+// ConfigureOneUpstreamForEndpoint overrides the upstream of an existing
+// endpoint. A nil upstream removes the override.
+func (s *RDSEndpointAuthProvider) ConfigureOneUpstreamForEndpoint(ctx context.Context, endpointID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	return s.configureUpstream(ctx, "llmg_endpoints", endpointID, upstream, ErrEndpointNotFound)
+}
+
+// ConfigureOnePolicyForTenant sets the policy of the tenant, creating the
+// tenant if it does not exist yet. A nil policy removes it.
+func (s *RDSEndpointAuthProvider) ConfigureOnePolicyForTenant(ctx context.Context, tenantID string, policy *Policy) error {
+	err := s.ConfigureOneTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	return s.configurePolicy(ctx, "llmg_tenants", tenantID, policy, ErrTenantNotFound)
+}
+
+// ConfigureOnePolicyForTeam sets the policy of an existing team. A nil
+// policy removes it.
+func (s *RDSEndpointAuthProvider) ConfigureOnePolicyForTeam(ctx context.Context, teamID string, policy *Policy) error {
+	return s.configurePolicy(ctx, "llmg_teams", teamID, policy, ErrTeamNotFound)
+}
+
+// ConfigureOnePolicyForGroup sets the policy of an existing group. A nil
+// policy removes it.
+func (s *RDSEndpointAuthProvider) ConfigureOnePolicyForGroup(ctx context.Context, groupID string, policy *Policy) error {
+	return s.configurePolicy(ctx, "llmg_groups", groupID, policy, ErrGroupNotFound)
+}
+
+// ConfigureOnePolicyForEndpoint sets the policy of an existing endpoint. A
+// nil policy removes it.
+func (s *RDSEndpointAuthProvider) ConfigureOnePolicyForEndpoint(ctx context.Context, endpointID string, policy *Policy) error {
+	return s.configurePolicy(ctx, "llmg_endpoints", endpointID, policy, ErrEndpointNotFound)
+}
+
+// ConfigureOne creates or updates the endpoint, along with its tenant, team
+// and group when they do not exist yet. The upstream of the endpoint is
+// overridden with endpoint.Upstream, and its API keys are replaced with
+// apiKey, with the lifecycle of endpoint.APIKeyOptions.
+func (s *RDSEndpointAuthProvider) ConfigureOne(ctx context.Context, apiKey string, alias string, endpoint *Endpoint) error {
+	upstream, err := marshalUpstream(endpoint.Upstream)
+	if err != nil {
+		return err
+	}
+
+	scopes, err := marshalList(endpoint.Scopes)
+	if err != nil {
+		return err
+	}
+
+	aliases, err := marshalList(endpoint.Aliases)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO llmg_tenants (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`, endpoint.Tenant.ID())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO llmg_teams (id, tenant_id) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING`, endpoint.Team.ID(), endpoint.Tenant.ID())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO llmg_groups (id, team_id) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING`, endpoint.Group.ID(), endpoint.Team.ID())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO llmg_endpoints (id, group_id, alias, upstream) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (id) DO UPDATE SET
+				group_id = excluded.group_id,
+				alias = excluded.alias,
+				upstream = excluded.upstream`,
+			endpoint.ID,
+			endpoint.Group.ID(),
+			sql.NullString{String: alias, Valid: alias != ""},
+			upstream,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM llmg_api_keys WHERE endpoint_id = $1 OR api_key_digest = $2`, endpoint.ID, HashAPIKey(s.apiKeySecret, apiKey))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO llmg_api_keys (api_key_digest, api_key_prefix, endpoint_id, expires_at, disabled, scopes, aliases) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			HashAPIKey(s.apiKeySecret, apiKey),
+			APIKeyPrefix(apiKey),
+			endpoint.ID,
+			marshalExpiresAt(endpoint.ExpiresAt),
+			endpoint.Disabled,
+			scopes,
+			aliases,
+		)
+
+		return err
+	})
+}
+
+func (s *RDSEndpointAuthProvider) AddOneAPIKey(ctx context.Context, endpointID string, apiKey string, options APIKeyOptions) error {
+	scopes, err := marshalList(options.Scopes)
+	if err != nil {
+		return err
+	}
+
+	aliases, err := marshalList(options.Aliases)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists int
+
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM llmg_endpoints WHERE id = $1`, endpointID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists == 0 {
+			return ErrEndpointNotFound
+		}
+
+		result, err := tx.ExecContext(ctx, `INSERT INTO llmg_api_keys (api_key_digest, api_key_prefix, endpoint_id, expires_at, disabled, scopes, aliases) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (api_key_digest) DO NOTHING`,
+			HashAPIKey(s.apiKeySecret, apiKey),
+			APIKeyPrefix(apiKey),
+			endpointID,
+			marshalExpiresAt(options.ExpiresAt),
+			options.Disabled,
+			scopes,
+			aliases,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrAPIKeyExists
+		}
+
+		return nil
+	})
+}
+
+func (s *RDSEndpointAuthProvider) updateAPIKey(ctx context.Context, apiKey string, set string, arg any) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE llmg_api_keys SET `+set+` = $1 WHERE api_key_digest = $2`, arg, HashAPIKey(s.apiKeySecret, apiKey))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (s *RDSEndpointAuthProvider) ExpireOneAPIKey(ctx context.Context, apiKey string, expiresAt time.Time) error {
+	return s.updateAPIKey(ctx, apiKey, "expires_at", expiresAt.Unix())
+}
+
+func (s *RDSEndpointAuthProvider) RevokeOneAPIKey(ctx context.Context, apiKey string) error {
+	return s.updateAPIKey(ctx, apiKey, "disabled", true)
+}
+
+func (s *RDSEndpointAuthProvider) FindOneTenant(ctx context.Context, tenantID string) (*TenantRecord, error) {
+	var overrides rdsOverrides
+
+	err := s.db.QueryRowContext(ctx, `SELECT upstream, policy FROM llmg_tenants WHERE id = $1`, tenantID).Scan(&overrides.upstream, &overrides.policy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTenantNotFound
+		}
+
+		return nil, err
+	}
+
+	tenant := &TenantRecord{ID: tenantID}
+
+	tenant.Upstream, tenant.Policy, err = overrides.unmarshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+func (s *RDSEndpointAuthProvider) FindOneTeam(ctx context.Context, teamID string) (*TeamRecord, error) {
+	var overrides rdsOverrides
+
+	team := &TeamRecord{ID: teamID}
+
+	err := s.db.QueryRowContext(ctx, `SELECT tenant_id, upstream, policy FROM llmg_teams WHERE id = $1`, teamID).Scan(&team.TenantID, &overrides.upstream, &overrides.policy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTeamNotFound
+		}
+
+		return nil, err
+	}
+
+	team.Upstream, team.Policy, err = overrides.unmarshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (s *RDSEndpointAuthProvider) FindOneGroup(ctx context.Context, groupID string) (*GroupRecord, error) {
+	var (
+		parentGroupID sql.NullString
+		overrides     rdsOverrides
+	)
+
+	group := &GroupRecord{ID: groupID}
+
+	err := s.db.QueryRowContext(ctx, `SELECT team_id, parent_group_id, upstream, policy FROM llmg_groups WHERE id = $1`, groupID).
+		Scan(&group.TeamID, &parentGroupID, &overrides.upstream, &overrides.policy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGroupNotFound
+		}
+
+		return nil, err
+	}
+
+	group.ParentGroupID = parentGroupID.String
+
+	group.Upstream, group.Policy, err = overrides.unmarshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+const rdsSelectEndpointRecord = `SELECT e.id, e.alias, e.upstream, e.policy, g.id, t.id, t.tenant_id
+FROM llmg_endpoints e
+JOIN llmg_groups g ON g.id = e.group_id
+JOIN llmg_teams t ON t.id = g.team_id
+`
+
+func scanEndpointRecord(row interface{ Scan(dest ...any) error }) (*Endpoint, error) {
+	var (
+		endpointID, groupID, teamID, tenantID string
+		alias                                 sql.NullString
+		overrides                             rdsOverrides
+	)
+
+	err := row.Scan(&endpointID, &alias, &overrides.upstream, &overrides.policy, &groupID, &teamID, &tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, policy, err := overrides.unmarshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Endpoint{
+		Tenant:   metadata.Tenant{Id: tenantID},
+		Team:     metadata.Team{Id: teamID},
+		Group:    metadata.Group{Id: groupID},
+		Upstream: upstream,
+		Policy:   policy,
+		ID:       endpointID,
+		Alias:    alias.String,
+	}, nil
+}
+
+func (s *RDSEndpointAuthProvider) FindOneByID(ctx context.Context, endpointID string) (*Endpoint, error) {
+	endpoint, err := scanEndpointRecord(s.db.QueryRowContext(ctx, rdsSelectEndpointRecord+`WHERE e.id = $1`, endpointID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEndpointNotFound
+		}
+
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+// list scans each row of query with scan.
+func list[T any](ctx context.Context, db *sql.DB, scan func(rows *sql.Rows) (T, error), query string, args ...any) ([]T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]T, 0)
+
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *RDSEndpointAuthProvider) ListTenants(ctx context.Context) ([]TenantRecord, error) {
+	return list(ctx, s.db, func(rows *sql.Rows) (TenantRecord, error) {
+		var (
+			tenant    TenantRecord
+			overrides rdsOverrides
+		)
+
+		err := rows.Scan(&tenant.ID, &overrides.upstream, &overrides.policy)
+		if err != nil {
+			return tenant, err
+		}
+
+		tenant.Upstream, tenant.Policy, err = overrides.unmarshal()
+
+		return tenant, err
+	}, `SELECT id, upstream, policy FROM llmg_tenants ORDER BY id`)
+}
+
+func (s *RDSEndpointAuthProvider) ListTeams(ctx context.Context, tenantID string) ([]TeamRecord, error) {
+	return list(ctx, s.db, func(rows *sql.Rows) (TeamRecord, error) {
+		var (
+			team      TeamRecord
+			overrides rdsOverrides
+		)
+
+		err := rows.Scan(&team.ID, &team.TenantID, &overrides.upstream, &overrides.policy)
+		if err != nil {
+			return team, err
+		}
+
+		team.Upstream, team.Policy, err = overrides.unmarshal()
+
+		return team, err
+	}, `SELECT id, tenant_id, upstream, policy FROM llmg_teams WHERE tenant_id = $1 ORDER BY id`, tenantID)
+}
+
+func (s *RDSEndpointAuthProvider) ListGroups(ctx context.Context, teamID string) ([]GroupRecord, error) {
+	return list(ctx, s.db, func(rows *sql.Rows) (GroupRecord, error) {
+		var (
+			group         GroupRecord
+			parentGroupID sql.NullString
+			overrides     rdsOverrides
+		)
+
+		err := rows.Scan(&group.ID, &group.TeamID, &parentGroupID, &overrides.upstream, &overrides.policy)
+		if err != nil {
+			return group, err
+		}
+
+		group.ParentGroupID = parentGroupID.String
+		group.Upstream, group.Policy, err = overrides.unmarshal()
+
+		return group, err
+	}, `SELECT id, team_id, parent_group_id, upstream, policy FROM llmg_groups WHERE team_id = $1 ORDER BY id`, teamID)
+}
+
+func (s *RDSEndpointAuthProvider) ListEndpoints(ctx context.Context, groupID string) ([]Endpoint, error) {
+	return list(ctx, s.db, func(rows *sql.Rows) (Endpoint, error) {
+		endpoint, err := scanEndpointRecord(rows)
+		if err != nil {
+			return Endpoint{}, err
+		}
+
+		return *endpoint, nil
+	}, rdsSelectEndpointRecord+`WHERE e.group_id = $1 ORDER BY e.id`, groupID)
+}
+
+// deleteOne deletes the row of table unless any of the children queries
+// counts rows referencing it.
+func (s *RDSEndpointAuthProvider) deleteOne(ctx context.Context, table string, id string, errNotFound error, children ...string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, child := range children {
+			var count int
+
+			err := tx.QueryRowContext(ctx, child, id).Scan(&count)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrHierarchyNotEmpty
+			}
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errNotFound
+		}
+
+		return nil
+	})
+}
+
+func (s *RDSEndpointAuthProvider) DeleteOneTenant(ctx context.Context, tenantID string) error {
+	return s.deleteOne(ctx, "llmg_tenants", tenantID, ErrTenantNotFound,
+		`SELECT COUNT(*) FROM llmg_teams WHERE tenant_id = $1`,
+	)
+}
+
+func (s *RDSEndpointAuthProvider) DeleteOneTeam(ctx context.Context, teamID string) error {
+	return s.deleteOne(ctx, "llmg_teams", teamID, ErrTeamNotFound,
+		`SELECT COUNT(*) FROM llmg_groups WHERE team_id = $1`,
+	)
+}
+
+func (s *RDSEndpointAuthProvider) DeleteOneGroup(ctx context.Context, groupID string) error {
+	return s.deleteOne(ctx, "llmg_groups", groupID, ErrGroupNotFound,
+		`SELECT COUNT(*) FROM llmg_groups WHERE parent_group_id = $1`,
+		`SELECT COUNT(*) FROM llmg_endpoints WHERE group_id = $1`,
+	)
+}
+
+// DeleteOneEndpoint deletes the endpoint along with its API keys, which are
+// deleted explicitly since SQLite does not enforce foreign keys by default.
+func (s *RDSEndpointAuthProvider) DeleteOneEndpoint(ctx context.Context, endpointID string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM llmg_api_keys WHERE endpoint_id = $1`, endpointID)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM llmg_endpoints WHERE id = $1`, endpointID)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrEndpointNotFound
+		}
+
+		return nil
+	})
 }
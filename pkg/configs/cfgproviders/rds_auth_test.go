@@ -1 +1,389 @@
 package authstorage
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lingticio/llmg/pkg/types/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+func newTestRDSEndpointAuthProvider(t *testing.T) *RDSEndpointAuthProvider {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	provider := NewRDSEndpointAuthProvider()(db, "secret")
+
+	err = provider.Migrate(context.Background())
+	require.NoError(t, err)
+
+	// Migrating again is a no-op.
+	err = provider.Migrate(context.Background())
+	require.NoError(t, err)
+
+	return provider
+}
+
+func newTestUpstream(baseURL string) *metadata.UpstreamSingleOrMultiple {
+	return &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{
+			OpenAI: metadata.UpstreamOpenAI{
+				BaseURL: baseURL,
+				APIKey:  "apiKey",
+			},
+		},
+	}
+}
+
+func TestRDSEndpointAuthProvider_FindOneByAPIKey(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	err := provider.ConfigureOne(ctx, "apiKey", "alias", &Endpoint{
+		Tenant: metadata.Tenant{Id: "tenantId"},
+		Team:   metadata.Team{Id: "teamId"},
+		Group:  metadata.Group{Id: "groupId"},
+		ID:     "endpointId",
+	})
+	require.NoError(t, err)
+
+	err = provider.ConfigureOneUpstreamForTenant(ctx, "tenantId", newTestUpstream("tenant"))
+	require.NoError(t, err)
+
+	endpoint, err := provider.FindOneByAPIKey(ctx, "apiKey")
+	require.NoError(t, err)
+
+	assert.Equal(t, "endpointId", endpoint.ID)
+	assert.Equal(t, "alias", endpoint.Alias)
+	assert.Equal(t, "apiKey", endpoint.APIKey)
+	assert.Equal(t, APIKeyPrefix("apiKey"), endpoint.APIKeyPrefix)
+	assert.Equal(t, "tenantId", endpoint.Tenant.Id)
+	assert.Equal(t, "teamId", endpoint.Team.Id)
+	assert.Equal(t, "groupId", endpoint.Group.Id)
+	require.NotNil(t, endpoint.Upstream)
+	assert.Equal(t, "tenant", endpoint.Upstream.Upstream.OpenAI.BaseURL)
+
+	_, err = provider.FindOneByAPIKey(ctx, "unknown")
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+
+	// The API key itself is not stored.
+	assertAPIKeysDigested(t, provider, "apiKey")
+}
+
+// assertAPIKeysDigested asserts that the API keys stored are apiKeys, by
+// their digest and prefix only.
+func assertAPIKeysDigested(t *testing.T, provider *RDSEndpointAuthProvider, apiKeys ...string) {
+	t.Helper()
+
+	rows, err := provider.db.QueryContext(context.Background(), `SELECT api_key_digest, api_key_prefix FROM llmg_api_keys ORDER BY api_key_digest`)
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	stored := make(map[string]string)
+
+	for rows.Next() {
+		var digest, prefix string
+
+		require.NoError(t, rows.Scan(&digest, &prefix))
+
+		stored[digest] = prefix
+	}
+
+	require.NoError(t, rows.Err())
+
+	expected := make(map[string]string)
+	for _, apiKey := range apiKeys {
+		expected[HashAPIKey("secret", apiKey)] = APIKeyPrefix(apiKey)
+	}
+
+	assert.Equal(t, expected, stored)
+}
+
+func TestRDSEndpointAuthProvider_FindOneByAlias(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	err := provider.ConfigureOne(ctx, "apiKey", "alias", &Endpoint{
+		Tenant:   metadata.Tenant{Id: "tenantId"},
+		Team:     metadata.Team{Id: "teamId"},
+		Group:    metadata.Group{Id: "groupId"},
+		ID:       "endpointId",
+		Upstream: newTestUpstream("endpoint"),
+	})
+	require.NoError(t, err)
+
+	endpoint, err := provider.FindOneByAlias(ctx, "alias")
+	require.NoError(t, err)
+
+	assert.Equal(t, "endpointId", endpoint.ID)
+	require.NotNil(t, endpoint.Upstream)
+	assert.Equal(t, "endpoint", endpoint.Upstream.Upstream.OpenAI.BaseURL)
+
+	_, err = provider.FindOneByAlias(ctx, "unknown")
+	require.ErrorIs(t, err, ErrAliasNotFound)
+}
+
+func TestRDSEndpointAuthProvider_FindOneEffectiveByID(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	err := provider.ConfigureOneUpstreamForTenant(ctx, "tenantId", newTestUpstream("tenant"))
+	require.NoError(t, err)
+
+	err = provider.ConfigureOne(ctx, "apiKey", "", &Endpoint{
+		Tenant: metadata.Tenant{Id: "tenantId"},
+		Team:   metadata.Team{Id: "teamId"},
+		Group:  metadata.Group{Id: "groupId"},
+		ID:     "endpointId",
+	})
+	require.NoError(t, err)
+
+	endpoint, err := provider.FindOneEffectiveByID(ctx, "endpointId")
+	require.NoError(t, err)
+
+	assert.Equal(t, "endpointId", endpoint.ID)
+	assert.Equal(t, "tenantId", endpoint.Tenant.ID())
+	assert.Empty(t, endpoint.APIKey)
+	require.NotNil(t, endpoint.Upstream)
+	assert.Equal(t, "tenant", endpoint.Upstream.Upstream.OpenAI.BaseURL)
+
+	_, err = provider.FindOneEffectiveByID(ctx, "unknown")
+	require.ErrorIs(t, err, ErrEndpointNotFound)
+}
+
+func TestRDSEndpointAuthProvider_UpstreamInheritance(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	require.NoError(t, provider.ConfigureOneTenant(ctx, "tenantId"))
+	require.NoError(t, provider.ConfigureOneTeam(ctx, "tenantId", "teamId"))
+	require.NoError(t, provider.ConfigureOneGroup(ctx, "teamId", "", "parentGroupId"))
+	require.NoError(t, provider.ConfigureOneGroup(ctx, "teamId", "parentGroupId", "groupId"))
+
+	err := provider.ConfigureOne(ctx, "apiKey", "", &Endpoint{
+		Tenant: metadata.Tenant{Id: "tenantId"},
+		Team:   metadata.Team{Id: "teamId"},
+		Group:  metadata.Group{Id: "groupId"},
+		ID:     "endpointId",
+	})
+	require.NoError(t, err)
+
+	upstreamOf := func() *metadata.UpstreamSingleOrMultiple {
+		endpoint, err := provider.FindOneByAPIKey(ctx, "apiKey")
+		require.NoError(t, err)
+
+		return endpoint.Upstream
+	}
+
+	assert.Nil(t, upstreamOf())
+
+	require.NoError(t, provider.ConfigureOneUpstreamForTenant(ctx, "tenantId", newTestUpstream("tenant")))
+	assert.Equal(t, "tenant", upstreamOf().Upstream.OpenAI.BaseURL)
+
+	require.NoError(t, provider.ConfigureOneUpstreamForTeam(ctx, "teamId", newTestUpstream("team")))
+	assert.Equal(t, "team", upstreamOf().Upstream.OpenAI.BaseURL)
+
+	// The groups the group of the endpoint is nested in are inherited too.
+	require.NoError(t, provider.ConfigureOneUpstreamForGroup(ctx, "parentGroupId", newTestUpstream("parentGroup")))
+	assert.Equal(t, "parentGroup", upstreamOf().Upstream.OpenAI.BaseURL)
+
+	require.NoError(t, provider.ConfigureOneUpstreamForGroup(ctx, "groupId", newTestUpstream("group")))
+	assert.Equal(t, "group", upstreamOf().Upstream.OpenAI.BaseURL)
+
+	require.NoError(t, provider.ConfigureOneUpstreamForEndpoint(ctx, "endpointId", newTestUpstream("endpoint")))
+	assert.Equal(t, "endpoint", upstreamOf().Upstream.OpenAI.BaseURL)
+
+	require.NoError(t, provider.ConfigureOneUpstreamForEndpoint(ctx, "endpointId", nil))
+	assert.Equal(t, "group", upstreamOf().Upstream.OpenAI.BaseURL)
+
+	// Overrides are merged field by field.
+	require.NoError(t, provider.ConfigureOneUpstreamForEndpoint(ctx, "endpointId", &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{
+			OpenAI: metadata.UpstreamOpenAI{
+				ExtraHeaders: http.Header{"X-Source": []string{"endpoint"}},
+			},
+		},
+	}))
+	assert.Equal(t, "group", upstreamOf().Upstream.OpenAI.BaseURL)
+	assert.Equal(t, "apiKey", upstreamOf().Upstream.OpenAI.APIKey)
+	assert.Equal(t, "endpoint", upstreamOf().Upstream.OpenAI.ExtraHeaders.Get("X-Source"))
+
+	explained, err := provider.ExplainUpstream(ctx, "endpointId")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"openai.base_url":               "group:groupId",
+		"openai.api_key":                "group:groupId",
+		"openai.extra_headers.X-Source": "endpoint:endpointId",
+	}, explained.Sources)
+
+	_, err = provider.ExplainUpstream(ctx, "unknown")
+	require.ErrorIs(t, err, ErrEndpointNotFound)
+
+	endpoint, err := provider.FindOneByAPIKey(ctx, "apiKey")
+	require.NoError(t, err)
+	assert.Empty(t, endpoint.Alias)
+}
+
+func TestRDSEndpointAuthProvider_ConfigureOneUpstreamNotFound(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	require.ErrorIs(t, provider.ConfigureOneUpstreamForTeam(ctx, "teamId", newTestUpstream("team")), ErrTeamNotFound)
+	require.ErrorIs(t, provider.ConfigureOneUpstreamForGroup(ctx, "groupId", newTestUpstream("group")), ErrGroupNotFound)
+	require.ErrorIs(t, provider.ConfigureOneUpstreamForEndpoint(ctx, "endpointId", newTestUpstream("endpoint")), ErrEndpointNotFound)
+}
+
+func TestRDSEndpointAuthProvider_ConfigureOneUpdates(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	endpoint := &Endpoint{
+		Tenant: metadata.Tenant{Id: "tenantId"},
+		Team:   metadata.Team{Id: "teamId"},
+		Group:  metadata.Group{Id: "groupId"},
+		ID:     "endpointId",
+	}
+
+	require.NoError(t, provider.ConfigureOne(ctx, "apiKey", "alias", endpoint))
+	require.NoError(t, provider.ConfigureOne(ctx, "rotatedAPIKey", "renamed", endpoint))
+
+	_, err := provider.FindOneByAPIKey(ctx, "apiKey")
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+
+	found, err := provider.FindOneByAPIKey(ctx, "rotatedAPIKey")
+	require.NoError(t, err)
+	assert.Equal(t, "endpointId", found.ID)
+	assert.Equal(t, "renamed", found.Alias)
+
+	assertAPIKeysDigested(t, provider, "rotatedAPIKey")
+}
+
+func TestRDSEndpointAuthProvider_APIKeyLifecycle(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	err := provider.ConfigureOne(ctx, "apiKey", "", &Endpoint{
+		Tenant:   metadata.Tenant{Id: "tenantId"},
+		Team:     metadata.Team{Id: "teamId"},
+		Group:    metadata.Group{Id: "groupId"},
+		ID:       "endpointId",
+		Upstream: newTestUpstream("endpoint"),
+		APIKeyOptions: APIKeyOptions{
+			ExpiresAt: &expiresAt,
+			Scopes:    []Scope{ScopeChat},
+			Aliases:   []string{"summarizer"},
+		},
+	})
+	require.NoError(t, err)
+
+	endpoint, err := provider.FindOneByAPIKey(ctx, "apiKey")
+	require.NoError(t, err)
+	require.NotNil(t, endpoint.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*endpoint.ExpiresAt))
+	assert.Equal(t, []Scope{ScopeChat}, endpoint.Scopes)
+	assert.Equal(t, []string{"summarizer"}, endpoint.Aliases)
+	assert.False(t, endpoint.Disabled)
+
+	require.ErrorIs(t, provider.AddOneAPIKey(ctx, "unknown", "newAPIKey", APIKeyOptions{}), ErrEndpointNotFound)
+
+	err = RotateOneAPIKey(ctx, provider, "apiKey", "newAPIKey", time.Minute)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, provider.AddOneAPIKey(ctx, "endpointId", "newAPIKey", APIKeyOptions{}), ErrAPIKeyExists)
+
+	// Both keys are active during the grace period.
+	rotated, err := provider.FindOneByAPIKey(ctx, "newAPIKey")
+	require.NoError(t, err)
+	assert.Equal(t, "endpointId", rotated.ID)
+	assert.Equal(t, []Scope{ScopeChat}, rotated.Scopes)
+	assert.Equal(t, []string{"summarizer"}, rotated.Aliases)
+	require.NoError(t, rotated.CheckActive(time.Now()))
+
+	endpoint, err = provider.FindOneByAPIKey(ctx, "apiKey")
+	require.NoError(t, err)
+	require.NoError(t, endpoint.CheckActive(time.Now()))
+	require.ErrorIs(t, endpoint.CheckActive(time.Now().Add(2*time.Minute)), ErrAPIKeyExpired)
+
+	require.NoError(t, provider.RevokeOneAPIKey(ctx, "apiKey"))
+
+	endpoint, err = provider.FindOneByAPIKey(ctx, "apiKey")
+	require.NoError(t, err)
+	require.ErrorIs(t, endpoint.CheckActive(time.Now()), ErrAPIKeyRevoked)
+
+	require.ErrorIs(t, provider.RevokeOneAPIKey(ctx, "unknown"), ErrAPIKeyNotFound)
+	require.ErrorIs(t, provider.ExpireOneAPIKey(ctx, "unknown", time.Now()), ErrAPIKeyNotFound)
+
+	assertAPIKeysDigested(t, provider, "apiKey", "newAPIKey")
+}
+
+func TestRDSEndpointAuthProvider_MigrateAPIKeys(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db.SetMaxOpenConns(1)
+
+	defer db.Close()
+
+	// Endpoints configured before API keys were moved to their own table.
+	migrations := rdsMigrations
+	rdsMigrations = migrations[:1]
+
+	provider := NewRDSEndpointAuthProvider()(db, "secret")
+	require.NoError(t, provider.Migrate(ctx))
+
+	rdsMigrations = migrations
+
+	require.NoError(t, provider.ConfigureOneUpstreamForTenant(ctx, "tenantId", newTestUpstream("tenant")))
+
+	for _, statement := range []string{
+		`INSERT INTO llmg_teams (id, tenant_id) VALUES ('teamId', 'tenantId')`,
+		`INSERT INTO llmg_groups (id, team_id) VALUES ('groupId', 'teamId')`,
+		`INSERT INTO llmg_endpoints (id, group_id, alias, api_key) VALUES ('endpointId', 'groupId', 'alias', 'apiKey')`,
+	} {
+		_, err = db.ExecContext(ctx, statement)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, provider.Migrate(ctx))
+
+	endpoint, err := provider.FindOneByAPIKey(ctx, "apiKey")
+	require.NoError(t, err)
+	assert.Equal(t, "endpointId", endpoint.ID)
+	assert.Equal(t, "alias", endpoint.Alias)
+	assert.Equal(t, APIKeyPrefix("apiKey"), endpoint.APIKeyPrefix)
+	assert.Empty(t, endpoint.Scopes)
+	require.NoError(t, endpoint.CheckActive(time.Now()))
+
+	// The API keys stored in plaintext are replaced with their digest.
+	assertAPIKeysDigested(t, provider, "apiKey")
+}
+
+func TestRDSEndpointAuthProvider_Administrable(t *testing.T) {
+	testEndpointProviderAdministrable(t, newTestRDSEndpointAuthProvider(t), "")
+}
+
+func TestRDSEndpointAuthProvider_Policies(t *testing.T) {
+	provider := newTestRDSEndpointAuthProvider(t)
+
+	testEndpointProviderPolicies(t, provider, "")
+	require.ErrorIs(t, provider.ConfigureOnePolicyForTeam(context.Background(), "unknown", &Policy{}), ErrTeamNotFound)
+}
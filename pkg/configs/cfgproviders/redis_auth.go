@@ -3,27 +3,48 @@ package authstorage
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/lingticio/llmg/pkg/types/metadata"
 	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
 	"github.com/redis/rueidis"
+	"github.com/samber/lo"
 )
 
 var _ EndpointProvider = (*RedisEndpointProvider)(nil)
+var _ EndpointProviderHierarchyQueryable = (*RedisEndpointProvider)(nil)
+var _ EndpointProviderAPIKeyMutable = (*RedisEndpointProvider)(nil)
+var _ EndpointProviderAdministrable = (*RedisEndpointProvider)(nil)
+var _ EndpointProviderUpstreamExplainable = (*RedisEndpointProvider)(nil)
+var _ EndpointProviderEffectiveQueryable = (*RedisEndpointProvider)(nil)
 
+// RedisEndpointProvider stores endpoints in Redis. API keys are not stored,
+// endpoints are looked up by the digest of their API key, see HashAPIKey,
+// and only the prefix of the key is kept for display. Tenants, teams and
+// groups are indexed in hashes for administration, once they are configured
+// through ConfigureOne, ConfigureOneTenant, ConfigureOneTeam or
+// ConfigureOneGroup. Endpoints looked up by API key or alias are cached in
+// the process when created by NewCachedRedisEndpointAuthProvider.
 type RedisEndpointProvider struct {
-	rueidis rueidis.Client
+	rueidis      rueidis.Client
+	apiKeySecret string
+	cache        *redisEndpointsCache
 }
 
-func NewRedisEndpointAuthProvider() func(rueidis.Client) EndpointProvider {
-	return func(r rueidis.Client) EndpointProvider {
+func NewRedisEndpointAuthProvider() func(client rueidis.Client, apiKeySecret string) EndpointProvider {
+	return func(r rueidis.Client, apiKeySecret string) EndpointProvider {
 		return &RedisEndpointProvider{
-			rueidis: r,
+			rueidis:      r,
+			apiKeySecret: apiKeySecret,
 		}
 	}
 }
 
-func (s *RedisEndpointProvider) findUpstreamByRoutesOrGroupID(ctx context.Context, key string) (*metadata.UpstreamSingleOrMultiple, error) {
+// findOne returns the value stored by key, nil when it is not set.
+func findOne[T any](ctx context.Context, s *RedisEndpointProvider, key string) (*T, error) {
 	cmd := s.rueidis.B().
 		Get().
 		Key(key).
@@ -38,118 +59,251 @@ func (s *RedisEndpointProvider) findUpstreamByRoutesOrGroupID(ctx context.Contex
 		return nil, err
 	}
 
-	var upstream metadata.UpstreamSingleOrMultiple
+	var result T
 
-	err = json.Unmarshal([]byte(res), &upstream)
+	err = json.Unmarshal([]byte(res), &result)
 	if err != nil {
 		return nil, err
 	}
 
-	return &upstream, nil
+	return &result, nil
 }
 
-func (s *RedisEndpointProvider) findUpstreamFromEndpointMetadata(ctx context.Context, endpointMetadata Endpoint) (*metadata.UpstreamSingleOrMultiple, error) {
-	pipes := []func(ctx context.Context) (*metadata.UpstreamSingleOrMultiple, error){
-		func(ctx context.Context) (*metadata.UpstreamSingleOrMultiple, error) {
-			if endpointMetadata.ID == "" {
-				return nil, nil
-			}
+func (s *RedisEndpointProvider) findUpstreamByRoutesOrGroupID(ctx context.Context, key string) (*metadata.UpstreamSingleOrMultiple, error) {
+	return findOne[metadata.UpstreamSingleOrMultiple](ctx, s, key)
+}
 
-			return s.findUpstreamByRoutesOrGroupID(ctx, rediskeys.EndpointUpstreamByEndpointID1.Format(endpointMetadata.ID))
-		},
-		func(ctx context.Context) (*metadata.UpstreamSingleOrMultiple, error) {
-			if endpointMetadata.Group.ID() == "" {
-				return nil, nil
-			}
+// findOverrides returns the upstream override and the policy of a tenant,
+// team, group or endpoint, as stored by upstreamKey and policyKey.
+func (s *RedisEndpointProvider) findOverrides(ctx context.Context, upstreamKey string, policyKey string) (*metadata.UpstreamSingleOrMultiple, *Policy, error) {
+	upstream, err := s.findUpstreamByRoutesOrGroupID(ctx, upstreamKey)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			return s.findUpstreamByRoutesOrGroupID(ctx, rediskeys.EndpointUpstreamByGroupID1.Format(endpointMetadata.Group.ID()))
-		},
-		func(ctx context.Context) (*metadata.UpstreamSingleOrMultiple, error) {
-			if endpointMetadata.Team.ID() == "" {
-				return nil, nil
-			}
+	policy, err := findOne[Policy](ctx, s, policyKey)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			return s.findUpstreamByRoutesOrGroupID(ctx, rediskeys.EndpointUpstreamByTeamID1.Format(endpointMetadata.Team.ID()))
-		},
-		func(ctx context.Context) (*metadata.UpstreamSingleOrMultiple, error) {
-			if endpointMetadata.Tenant.ID() == "" {
-				return nil, nil
-			}
+	return upstream, policy, nil
+}
 
-			return s.findUpstreamByRoutesOrGroupID(ctx, rediskeys.EndpointUpstreamByTenantID1.Format(endpointMetadata.Tenant.ID()))
-		},
+func (s *RedisEndpointProvider) parentGroupOf(ctx context.Context, groupID string) (string, error) {
+	find := func(ctx context.Context) (string, error) {
+		group, err := getRecord[GroupRecord](ctx, s, rediskeys.EndpointGroups0.Format(), groupID)
+		if err != nil || group == nil {
+			return "", err
+		}
+
+		return group.ParentGroupID, nil
 	}
+	if s.cache == nil {
+		return find(ctx)
+	}
+
+	return s.cache.parentGroup(ctx, groupID, find)
+}
 
-	var upstream *metadata.UpstreamSingleOrMultiple
+// parentGroupsOf returns the groups groupID is nested in, from the
+// outermost one, as indexed by ConfigureOneGroup.
+func (s *RedisEndpointProvider) parentGroupsOf(ctx context.Context, groupID string) ([]string, error) {
+	parents := make([]string, 0)
 
-	for _, pipe := range pipes {
-		up, err := pipe(ctx)
+	for len(parents) < maxGroupDepth {
+		parentGroupID, err := s.parentGroupOf(ctx, groupID)
 		if err != nil {
 			return nil, err
 		}
-		if up != nil {
-			upstream = up
+		if parentGroupID == "" || parentGroupID == groupID || slices.Contains(parents, parentGroupID) {
 			break
 		}
+
+		groupID = parentGroupID
+		parents = append(parents, groupID)
 	}
 
-	if upstream == nil {
-		return nil, nil
+	slices.Reverse(parents)
+
+	return parents, nil
+}
+
+// layersOf returns the upstream overrides of the tenant, team, groups and
+// the endpoint itself, in the order they are merged in, along with their
+// policies. Levels without ID are skipped.
+func (s *RedisEndpointProvider) layersOf(ctx context.Context, endpointMetadata Endpoint) ([]metadata.UpstreamLayer, []level, error) {
+	type redisLevel struct {
+		layer       func(id string, upstream *metadata.UpstreamSingleOrMultiple) metadata.UpstreamLayer
+		level       func(id string, policy *Policy) level
+		upstreamKey rediskeys.Key
+		policyKey   rediskeys.Key
+		id          string
+	}
+
+	levels := []redisLevel{
+		{layer: tenantLayer, level: tenantLevel, upstreamKey: rediskeys.EndpointUpstreamByTenantID1, policyKey: rediskeys.EndpointPolicyByTenantID1, id: endpointMetadata.Tenant.ID()},
+		{layer: teamLayer, level: teamLevel, upstreamKey: rediskeys.EndpointUpstreamByTeamID1, policyKey: rediskeys.EndpointPolicyByTeamID1, id: endpointMetadata.Team.ID()},
+	}
+
+	if endpointMetadata.Group.ID() != "" {
+		parents, err := s.parentGroupsOf(ctx, endpointMetadata.Group.ID())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, groupID := range append(parents, endpointMetadata.Group.ID()) {
+			levels = append(levels, redisLevel{layer: groupLayer, level: groupLevel, upstreamKey: rediskeys.EndpointUpstreamByGroupID1, policyKey: rediskeys.EndpointPolicyByGroupID1, id: groupID})
+		}
+	}
+
+	levels = append(levels, redisLevel{layer: endpointLayer, level: endpointLevel, upstreamKey: rediskeys.EndpointUpstreamByEndpointID1, policyKey: rediskeys.EndpointPolicyByEndpointID1, id: endpointMetadata.ID})
+	levels = lo.Filter(levels, func(item redisLevel, _ int) bool { return item.id != "" })
+
+	// The upstreams and the policies are fetched together, the upstreams
+	// first.
+	keys := append(
+		lo.Map(levels, func(item redisLevel, _ int) string { return item.upstreamKey.Format(item.id) }),
+		lo.Map(levels, func(item redisLevel, _ int) string { return item.policyKey.Format(item.id) })...,
+	)
+
+	values, err := findAll[json.RawMessage](ctx, s, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layers := make([]metadata.UpstreamLayer, 0, len(levels))
+	policies := make([]level, 0, len(levels))
+
+	for i, item := range levels {
+		upstream, err := unmarshalRaw[metadata.UpstreamSingleOrMultiple](values[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal %s: %w", keys[i], err)
+		}
+
+		policy, err := unmarshalRaw[Policy](values[len(levels)+i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal %s: %w", keys[len(levels)+i], err)
+		}
+
+		layers = append(layers, item.layer(item.id, upstream))
+		policies = append(policies, item.level(item.id, policy))
 	}
 
-	return upstream, nil
+	return layers, policies, nil
 }
 
-func (s *RedisEndpointProvider) ConfigureOneUpstreamForTenant(ctx context.Context, tenantID string, upstream *metadata.UpstreamSingleOrMultiple) error {
-	endpointUpstreamBytes, err := json.Marshal(upstream)
+func unmarshalRaw[T any](raw *json.RawMessage) (*T, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	var result T
+
+	err := json.Unmarshal(*raw, &result)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	cmd := s.rueidis.B().
-		Set().
-		Key(rediskeys.EndpointUpstreamByTenantID1.Format(tenantID)).
-		Value(string(endpointUpstreamBytes)).
-		Build()
+	return &result, nil
+}
 
-	err = s.rueidis.Do(ctx, cmd).Error()
+// resolve merges the upstream of the endpoint, see metadata.MergeUpstreams,
+// and applies the policies of its tenant, team, groups and of the endpoint
+// itself, see applyPolicies.
+func (s *RedisEndpointProvider) resolve(ctx context.Context, endpoint *Endpoint) error {
+	layers, levels, err := s.layersOf(ctx, *endpoint)
 	if err != nil {
 		return err
 	}
 
+	endpoint.Upstream = metadata.MergeUpstreams(layers...).Upstream
+
+	var own *Policy
+	if endpoint.ID != "" {
+		own = lo.LastOrEmpty(levels).policy
+	}
+
+	applyPolicies(endpoint, levels, own)
+
 	return nil
 }
 
-func (s *RedisEndpointProvider) ConfigureOneUpstreamForTeam(ctx context.Context, teamID string, upstream *metadata.UpstreamSingleOrMultiple) error {
-	endpointUpstreamBytes, err := json.Marshal(upstream)
+func (s *RedisEndpointProvider) FindOneEffectiveByID(ctx context.Context, endpointID string) (*Endpoint, error) {
+	endpointMetadata, err := s.getEndpoint(ctx, rediskeys.EndpointMetadataByEndpointID1.Format(endpointID))
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if endpointMetadata == nil {
+		return nil, ErrEndpointNotFound
 	}
 
-	cmd := s.rueidis.B().
-		Set().
-		Key(rediskeys.EndpointUpstreamByTeamID1.Format(teamID)).
-		Value(string(endpointUpstreamBytes)).
-		Build()
+	endpoint := &Endpoint{
+		Tenant: endpointMetadata.Tenant,
+		Team:   endpointMetadata.Team,
+		Group:  endpointMetadata.Group,
+		ID:     endpointMetadata.ID,
+		Alias:  endpointMetadata.Alias,
+	}
 
-	err = s.rueidis.Do(ctx, cmd).Error()
+	err = s.resolve(ctx, endpoint)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if endpoint.Upstream == nil {
+		return nil, ErrEndpointNotFound
 	}
 
-	return nil
+	return endpoint, nil
 }
 
-func (s *RedisEndpointProvider) ConfigureOneUpstreamForGroup(ctx context.Context, groupID string, upstream *metadata.UpstreamSingleOrMultiple) error {
-	endpointUpstreamBytes, err := json.Marshal(upstream)
+func (s *RedisEndpointProvider) ExplainUpstream(ctx context.Context, endpointID string) (*metadata.EffectiveUpstream, error) {
+	endpoint, err := s.getEndpoint(ctx, rediskeys.EndpointMetadataByEndpointID1.Format(endpointID))
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil {
+		return nil, ErrEndpointNotFound
+	}
+
+	layers, _, err := s.layersOf(ctx, *endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.ToPtr(metadata.MergeUpstreams(layers...)), nil
+}
+
+// configureUpstream stores upstream by key, a nil upstream removes the
+// override.
+func (s *RedisEndpointProvider) configureUpstream(ctx context.Context, key string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	return configureOne(ctx, s, key, upstream)
+}
+
+// configurePolicy stores policy by key, a nil policy removes it.
+func (s *RedisEndpointProvider) configurePolicy(ctx context.Context, key string, policy *Policy) error {
+	return configureOne(ctx, s, key, policy)
+}
+
+// configureOne stores value by key as JSON, a nil value deletes the key.
+func configureOne[T any](ctx context.Context, s *RedisEndpointProvider, key string, value *T) error {
+	if value == nil {
+		err := s.rueidis.Do(ctx, s.rueidis.B().Del().Key(key).Build()).Error()
+		if err != nil {
+			return err
+		}
+
+		return s.invalidate(ctx)
+	}
+
+	valueBytes, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
 
 	cmd := s.rueidis.B().
 		Set().
-		Key(rediskeys.EndpointUpstreamByGroupID1.Format(groupID)).
-		Value(string(endpointUpstreamBytes)).
+		Key(key).
+		Value(string(valueBytes)).
 		Build()
 
 	err = s.rueidis.Do(ctx, cmd).Error()
@@ -157,38 +311,91 @@ func (s *RedisEndpointProvider) ConfigureOneUpstreamForGroup(ctx context.Context
 		return err
 	}
 
-	return nil
+	return s.invalidate(ctx)
 }
 
-func (s *RedisEndpointProvider) ConfigureOneUpstreamForEndpoint(ctx context.Context, endpointID string, upstream *metadata.UpstreamSingleOrMultiple) error {
-	endpointUpstreamBytes, err := json.Marshal(upstream)
+// ConfigureOneUpstreamForTenant overrides the upstream of the tenant, creating
+// the tenant if it does not exist yet. A nil upstream removes the override.
+func (s *RedisEndpointProvider) ConfigureOneUpstreamForTenant(ctx context.Context, tenantID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	err := s.ConfigureOneTenant(ctx, tenantID)
 	if err != nil {
 		return err
 	}
 
-	cmd := s.rueidis.B().
-		Set().
-		Key(rediskeys.EndpointUpstreamByEndpointID1.Format(endpointID)).
-		Value(string(endpointUpstreamBytes)).
-		Build()
+	return s.configureUpstream(ctx, rediskeys.EndpointUpstreamByTenantID1.Format(tenantID), upstream)
+}
 
-	err = s.rueidis.Do(ctx, cmd).Error()
+func (s *RedisEndpointProvider) ConfigureOneUpstreamForTeam(ctx context.Context, teamID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	return s.configureUpstream(ctx, rediskeys.EndpointUpstreamByTeamID1.Format(teamID), upstream)
+}
+
+func (s *RedisEndpointProvider) ConfigureOneUpstreamForGroup(ctx context.Context, groupID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	return s.configureUpstream(ctx, rediskeys.EndpointUpstreamByGroupID1.Format(groupID), upstream)
+}
+
+func (s *RedisEndpointProvider) ConfigureOneUpstreamForEndpoint(ctx context.Context, endpointID string, upstream *metadata.UpstreamSingleOrMultiple) error {
+	return s.configureUpstream(ctx, rediskeys.EndpointUpstreamByEndpointID1.Format(endpointID), upstream)
+}
+
+// ConfigureOnePolicyForTenant sets the policy of the tenant, creating the
+// tenant if it does not exist yet. A nil policy removes it.
+func (s *RedisEndpointProvider) ConfigureOnePolicyForTenant(ctx context.Context, tenantID string, policy *Policy) error {
+	err := s.ConfigureOneTenant(ctx, tenantID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.configurePolicy(ctx, rediskeys.EndpointPolicyByTenantID1.Format(tenantID), policy)
+}
+
+func (s *RedisEndpointProvider) ConfigureOnePolicyForTeam(ctx context.Context, teamID string, policy *Policy) error {
+	return s.configurePolicy(ctx, rediskeys.EndpointPolicyByTeamID1.Format(teamID), policy)
+}
+
+func (s *RedisEndpointProvider) ConfigureOnePolicyForGroup(ctx context.Context, groupID string, policy *Policy) error {
+	return s.configurePolicy(ctx, rediskeys.EndpointPolicyByGroupID1.Format(groupID), policy)
+}
+
+func (s *RedisEndpointProvider) ConfigureOnePolicyForEndpoint(ctx context.Context, endpointID string, policy *Policy) error {
+	return s.configurePolicy(ctx, rediskeys.EndpointPolicyByEndpointID1.Format(endpointID), policy)
+}
+
+// storedEndpoint is endpoint as stored, without the API key.
+func storedEndpoint(endpoint Endpoint, apiKey string) Endpoint {
+	endpoint.APIKey = ""
+	endpoint.APIKeyPrefix = APIKeyPrefix(apiKey)
+
+	return endpoint
+}
+
+// storedEndpointRecord is endpoint as stored by its ID, without any API key,
+// for issuing more keys to the endpoint.
+func storedEndpointRecord(endpoint Endpoint) Endpoint {
+	endpoint.APIKey = ""
+	endpoint.APIKeyPrefix = ""
+	endpoint.APIKeyOptions = APIKeyOptions{}
+
+	return endpoint
 }
 
 func (s *RedisEndpointProvider) ConfigureOne(ctx context.Context, apiKey string, alias string, endpoint *Endpoint) error {
-	endpointMetadataBytes, err := json.Marshal(endpoint)
+	err := s.indexHierarchy(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Same as the other providers, the endpoint is aliased by alias.
+	endpoint = lo.ToPtr(*endpoint)
+	endpoint.Alias = alias
+
+	endpointMetadataBytes, err := json.Marshal(storedEndpoint(*endpoint, apiKey))
 	if err != nil {
 		return err
 	}
 
 	cmd := s.rueidis.B().
 		Set().
-		Key(rediskeys.EndpointMetadataByAPIKey1.Format(apiKey)).
+		Key(rediskeys.EndpointMetadataByAPIKeyDigest1.Format(HashAPIKey(s.apiKeySecret, apiKey))).
 		Value(string(endpointMetadataBytes)).
 		Build()
 
@@ -197,10 +404,15 @@ func (s *RedisEndpointProvider) ConfigureOne(ctx context.Context, apiKey string,
 		return err
 	}
 
+	endpointRecordBytes, err := json.Marshal(storedEndpointRecord(*endpoint))
+	if err != nil {
+		return err
+	}
+
 	cmd = s.rueidis.B().
 		Set().
-		Key(rediskeys.EndpointMetadataByAlias1.Format(alias)).
-		Value(string(endpointMetadataBytes)).
+		Key(rediskeys.EndpointMetadataByEndpointID1.Format(endpoint.ID)).
+		Value(string(endpointRecordBytes)).
 		Build()
 
 	err = s.rueidis.Do(ctx, cmd).Error()
@@ -208,13 +420,47 @@ func (s *RedisEndpointProvider) ConfigureOne(ctx context.Context, apiKey string,
 		return err
 	}
 
-	return nil
+	if alias != "" {
+		cmd = s.rueidis.B().
+			Set().
+			Key(rediskeys.EndpointMetadataByAlias1.Format(alias)).
+			Value(string(endpointMetadataBytes)).
+			Build()
+
+		err = s.rueidis.Do(ctx, cmd).Error()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Unknown API keys and aliases are cached too.
+	return s.invalidate(ctx)
 }
 
 func (s *RedisEndpointProvider) FindOneByAPIKey(ctx context.Context, apiKey string) (*Endpoint, error) {
+	digest := HashAPIKey(s.apiKeySecret, apiKey)
+	if s.cache == nil {
+		return s.findOneByAPIKeyDigest(ctx, digest, apiKey)
+	}
+
+	// Endpoints are cached by the digest of their API key, so that API keys
+	// are not kept in memory longer than the requests using them.
+	endpoint, err := s.cache.endpoint(ctx, "api_key_digest", digest, func(ctx context.Context) (*Endpoint, error) {
+		return s.findOneByAPIKeyDigest(ctx, digest, "")
+	})
+	if err != nil || endpoint == nil {
+		return nil, err
+	}
+
+	endpoint.APIKey = apiKey
+
+	return endpoint, nil
+}
+
+func (s *RedisEndpointProvider) findOneByAPIKeyDigest(ctx context.Context, digest string, apiKey string) (*Endpoint, error) {
 	cmd := s.rueidis.B().
 		Get().
-		Key(rediskeys.EndpointMetadataByAPIKey1.Format(apiKey)).
+		Key(rediskeys.EndpointMetadataByAPIKeyDigest1.Format(digest)).
 		Build()
 
 	res, err := s.rueidis.Do(ctx, cmd).ToString()
@@ -233,26 +479,39 @@ func (s *RedisEndpointProvider) FindOneByAPIKey(ctx context.Context, apiKey stri
 		return nil, err
 	}
 
-	upstream, err := s.findUpstreamFromEndpointMetadata(ctx, endpointMetadata)
+	endpoint := &Endpoint{
+		Tenant:        endpointMetadata.Tenant,
+		Team:          endpointMetadata.Team,
+		Group:         endpointMetadata.Group,
+		ID:            endpointMetadata.ID,
+		Alias:         endpointMetadata.Alias,
+		APIKey:        apiKey,
+		APIKeyPrefix:  endpointMetadata.APIKeyPrefix,
+		APIKeyOptions: endpointMetadata.APIKeyOptions,
+	}
+
+	err = s.resolve(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
-	if upstream == nil {
+	if endpoint.Upstream == nil {
 		return nil, nil
 	}
 
-	return &Endpoint{
-		Tenant:   endpointMetadata.Tenant,
-		Team:     endpointMetadata.Team,
-		Group:    endpointMetadata.Group,
-		Upstream: upstream,
-		ID:       endpointMetadata.ID,
-		Alias:    endpointMetadata.Alias,
-		APIKey:   endpointMetadata.APIKey,
-	}, nil
+	return endpoint, nil
 }
 
 func (s *RedisEndpointProvider) FindOneByAlias(ctx context.Context, alias string) (*Endpoint, error) {
+	if s.cache == nil {
+		return s.findOneByAlias(ctx, alias)
+	}
+
+	return s.cache.endpoint(ctx, "alias", alias, func(ctx context.Context) (*Endpoint, error) {
+		return s.findOneByAlias(ctx, alias)
+	})
+}
+
+func (s *RedisEndpointProvider) findOneByAlias(ctx context.Context, alias string) (*Endpoint, error) {
 	cmd := s.rueidis.B().
 		Get().
 		Key(rediskeys.EndpointMetadataByAlias1.Format(alias)).
@@ -274,21 +533,684 @@ func (s *RedisEndpointProvider) FindOneByAlias(ctx context.Context, alias string
 		return nil, err
 	}
 
-	upstream, err := s.findUpstreamFromEndpointMetadata(ctx, endpointMetadata)
+	endpoint := &Endpoint{
+		Tenant:       endpointMetadata.Tenant,
+		Team:         endpointMetadata.Team,
+		Group:        endpointMetadata.Group,
+		ID:           endpointMetadata.ID,
+		Alias:        endpointMetadata.Alias,
+		APIKeyPrefix: endpointMetadata.APIKeyPrefix,
+	}
+
+	err = s.resolve(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
-	if upstream == nil {
+	if endpoint.Upstream == nil {
 		return nil, nil
 	}
 
-	return &Endpoint{
-		Tenant:   endpointMetadata.Tenant,
-		Team:     endpointMetadata.Team,
-		Group:    endpointMetadata.Group,
-		Upstream: upstream,
-		ID:       endpointMetadata.ID,
-		Alias:    endpointMetadata.Alias,
-		APIKey:   endpointMetadata.APIKey,
-	}, nil
+	return endpoint, nil
+}
+
+func (s *RedisEndpointProvider) FindOneByHierarchy(ctx context.Context, tenantID string, teamID string, groupID string) (*Endpoint, error) {
+	endpoint := Endpoint{
+		Tenant: metadata.Tenant{Id: tenantID},
+		Team:   metadata.Team{Id: teamID},
+		Group:  metadata.Group{Id: groupID},
+	}
+
+	err := s.resolve(ctx, &endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Upstream == nil {
+		return nil, ErrHierarchyNotFound
+	}
+
+	return &endpoint, nil
+}
+
+// scan calls fn with each key matching pattern.
+func (s *RedisEndpointProvider) scan(ctx context.Context, pattern string, fn func(key string) error) error {
+	var cursor uint64
+
+	for {
+		cmd := s.rueidis.B().
+			Scan().
+			Cursor(cursor).
+			Match(pattern).
+			Count(100). //nolint:mnd
+			Build()
+
+		entry, err := s.rueidis.Do(ctx, cmd).AsScanEntry()
+		if err != nil {
+			return err
+		}
+
+		for _, key := range entry.Elements {
+			err = fn(key)
+			if err != nil {
+				return err
+			}
+		}
+
+		cursor = entry.Cursor
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+func (s *RedisEndpointProvider) getEndpoint(ctx context.Context, key string) (*Endpoint, error) {
+	res, err := s.rueidis.Do(ctx, s.rueidis.B().Get().Key(key).Build()).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var endpoint Endpoint
+
+	err = json.Unmarshal([]byte(res), &endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+	}
+
+	return &endpoint, nil
+}
+
+// MigrateAPIKeys migrates the endpoints stored by their plaintext API key to
+// be stored by the digest of the key, and removes the plaintext keys from the
+// endpoints stored by alias. Migrating is idempotent, and endpoints already
+// configured by the digest of their key are kept as is. It returns the number
+// of API keys migrated.
+func (s *RedisEndpointProvider) MigrateAPIKeys(ctx context.Context) (int, error) {
+	var migrated int
+
+	legacyKeyPrefix := rediskeys.EndpointMetadataByAPIKey1.Format("")
+
+	err := s.scan(ctx, rediskeys.EndpointMetadataByAPIKey1.Format("*"), func(key string) error {
+		endpoint, err := s.getEndpoint(ctx, key)
+		if err != nil {
+			return err
+		}
+		if endpoint != nil {
+			apiKey := strings.TrimPrefix(key, legacyKeyPrefix)
+
+			endpointMetadataBytes, err := json.Marshal(storedEndpoint(*endpoint, apiKey))
+			if err != nil {
+				return err
+			}
+
+			cmd := s.rueidis.B().
+				Set().
+				Key(rediskeys.EndpointMetadataByAPIKeyDigest1.Format(HashAPIKey(s.apiKeySecret, apiKey))).
+				Value(string(endpointMetadataBytes)).
+				Nx().
+				Build()
+
+			err = s.rueidis.Do(ctx, cmd).Error()
+			if err != nil && !rueidis.IsRedisNil(err) {
+				return err
+			}
+
+			if endpoint.ID != "" {
+				endpointRecordBytes, err := json.Marshal(storedEndpointRecord(*endpoint))
+				if err != nil {
+					return err
+				}
+
+				cmd = s.rueidis.B().
+					Set().
+					Key(rediskeys.EndpointMetadataByEndpointID1.Format(endpoint.ID)).
+					Value(string(endpointRecordBytes)).
+					Nx().
+					Build()
+
+				err = s.rueidis.Do(ctx, cmd).Error()
+				if err != nil && !rueidis.IsRedisNil(err) {
+					return err
+				}
+			}
+		}
+
+		err = s.rueidis.Do(ctx, s.rueidis.B().Del().Key(key).Build()).Error()
+		if err != nil {
+			return err
+		}
+
+		migrated++
+
+		return nil
+	})
+	if err != nil {
+		return migrated, err
+	}
+
+	err = s.scan(ctx, rediskeys.EndpointMetadataByAlias1.Format("*"), func(key string) error {
+		endpoint, err := s.getEndpoint(ctx, key)
+		if err != nil {
+			return err
+		}
+		if endpoint == nil || endpoint.APIKey == "" {
+			return nil
+		}
+
+		endpointMetadataBytes, err := json.Marshal(storedEndpoint(*endpoint, endpoint.APIKey))
+		if err != nil {
+			return err
+		}
+
+		return s.rueidis.Do(ctx, s.rueidis.B().Set().Key(key).Value(string(endpointMetadataBytes)).Build()).Error()
+	})
+	if err != nil {
+		return migrated, err
+	}
+
+	return migrated, s.invalidate(ctx)
+}
+
+func (s *RedisEndpointProvider) AddOneAPIKey(ctx context.Context, endpointID string, apiKey string, options APIKeyOptions) error {
+	endpoint, err := s.getEndpoint(ctx, rediskeys.EndpointMetadataByEndpointID1.Format(endpointID))
+	if err != nil {
+		return err
+	}
+	if endpoint == nil {
+		return ErrEndpointNotFound
+	}
+
+	endpoint.APIKeyOptions = options
+
+	endpointMetadataBytes, err := json.Marshal(storedEndpoint(*endpoint, apiKey))
+	if err != nil {
+		return err
+	}
+
+	cmd := s.rueidis.B().
+		Set().
+		Key(rediskeys.EndpointMetadataByAPIKeyDigest1.Format(HashAPIKey(s.apiKeySecret, apiKey))).
+		Value(string(endpointMetadataBytes)).
+		Nx().
+		Build()
+
+	err = s.rueidis.Do(ctx, cmd).Error()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return ErrAPIKeyExists
+		}
+
+		return err
+	}
+
+	return s.invalidate(ctx)
+}
+
+// updateAPIKey applies update to the lifecycle of apiKey. Keys are only
+// updated when they were not changed concurrently.
+func (s *RedisEndpointProvider) updateAPIKey(ctx context.Context, apiKey string, update func(options *APIKeyOptions)) error {
+	key := rediskeys.EndpointMetadataByAPIKeyDigest1.Format(HashAPIKey(s.apiKeySecret, apiKey))
+
+	err := s.rueidis.Dedicated(func(client rueidis.DedicatedClient) error {
+		err := client.Do(ctx, client.B().Watch().Key(key).Build()).Error()
+		if err != nil {
+			return err
+		}
+
+		res, err := client.Do(ctx, client.B().Get().Key(key).Build()).ToString()
+		if err != nil {
+			if rueidis.IsRedisNil(err) {
+				return ErrAPIKeyNotFound
+			}
+
+			return err
+		}
+
+		var endpoint Endpoint
+
+		err = json.Unmarshal([]byte(res), &endpoint)
+		if err != nil {
+			return err
+		}
+
+		update(&endpoint.APIKeyOptions)
+
+		endpointMetadataBytes, err := json.Marshal(endpoint)
+		if err != nil {
+			return err
+		}
+
+		results := client.DoMulti(ctx,
+			client.B().Multi().Build(),
+			client.B().Set().Key(key).Value(string(endpointMetadataBytes)).Build(),
+			client.B().Exec().Build(),
+		)
+		for _, result := range results {
+			err = result.Error()
+			if err != nil {
+				if rueidis.IsRedisNil(err) {
+					return fmt.Errorf("api key %s was changed concurrently", APIKeyPrefix(apiKey))
+				}
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.invalidate(ctx)
+}
+
+func (s *RedisEndpointProvider) ExpireOneAPIKey(ctx context.Context, apiKey string, expiresAt time.Time) error {
+	return s.updateAPIKey(ctx, apiKey, func(options *APIKeyOptions) {
+		options.ExpiresAt = &expiresAt
+	})
+}
+
+func (s *RedisEndpointProvider) RevokeOneAPIKey(ctx context.Context, apiKey string) error {
+	return s.updateAPIKey(ctx, apiKey, func(options *APIKeyOptions) {
+		options.Disabled = true
+	})
+}
+
+// setRecord stores record by id in the hash key, unless id is already stored
+// when nx is set.
+func (s *RedisEndpointProvider) setRecord(ctx context.Context, key string, id string, record any, nx bool) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if nx {
+		return s.rueidis.Do(ctx, s.rueidis.B().Hsetnx().Key(key).Field(id).Value(string(recordBytes)).Build()).Error()
+	}
+
+	return s.rueidis.Do(ctx, s.rueidis.B().Hset().Key(key).FieldValue().FieldValue(id, string(recordBytes)).Build()).Error()
+}
+
+func getRecord[T any](ctx context.Context, s *RedisEndpointProvider, key string, id string) (*T, error) {
+	res, err := s.rueidis.Do(ctx, s.rueidis.B().Hget().Key(key).Field(id).Build()).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var record T
+
+	err = json.Unmarshal([]byte(res), &record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s of %s: %w", id, key, err)
+	}
+
+	return &record, nil
+}
+
+// listRecords returns the records of the hash key for which keep returns
+// true, sorted by idOf.
+func listRecords[T any](ctx context.Context, s *RedisEndpointProvider, key string, idOf func(T) string, keep func(T) bool) ([]T, error) {
+	values, err := s.rueidis.Do(ctx, s.rueidis.B().Hvals().Key(key).Build()).AsStrSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]T, 0, len(values))
+
+	for _, value := range values {
+		var record T
+
+		err = json.Unmarshal([]byte(value), &record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal a record of %s: %w", key, err)
+		}
+		if keep(record) {
+			records = append(records, record)
+		}
+	}
+
+	slices.SortFunc(records, func(a, b T) int {
+		return strings.Compare(idOf(a), idOf(b))
+	})
+
+	return records, nil
+}
+
+// indexHierarchy indexes the tenant, team and group of endpoint, unless they
+// are indexed already.
+func (s *RedisEndpointProvider) indexHierarchy(ctx context.Context, endpoint *Endpoint) error {
+	if endpoint.Tenant.ID() != "" {
+		err := s.setRecord(ctx, rediskeys.EndpointTenants0.Format(), endpoint.Tenant.ID(), TenantRecord{ID: endpoint.Tenant.ID()}, true)
+		if err != nil {
+			return err
+		}
+	}
+	if endpoint.Team.ID() != "" {
+		err := s.setRecord(ctx, rediskeys.EndpointTeams0.Format(), endpoint.Team.ID(), TeamRecord{ID: endpoint.Team.ID(), TenantID: endpoint.Tenant.ID()}, true)
+		if err != nil {
+			return err
+		}
+	}
+	if endpoint.Group.ID() != "" {
+		err := s.setRecord(ctx, rediskeys.EndpointGroups0.Format(), endpoint.Group.ID(), GroupRecord{ID: endpoint.Group.ID(), TeamID: endpoint.Team.ID()}, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *RedisEndpointProvider) ConfigureOneTenant(ctx context.Context, tenantID string) error {
+	return s.setRecord(ctx, rediskeys.EndpointTenants0.Format(), tenantID, TenantRecord{ID: tenantID}, true)
+}
+
+func (s *RedisEndpointProvider) ConfigureOneTeam(ctx context.Context, tenantID string, teamID string) error {
+	return s.setRecord(ctx, rediskeys.EndpointTeams0.Format(), teamID, TeamRecord{ID: teamID, TenantID: tenantID}, false)
+}
+
+// ConfigureOneGroup indexes the group, which may move it under another
+// parent, and thus change the upstreams its endpoints inherit.
+func (s *RedisEndpointProvider) ConfigureOneGroup(ctx context.Context, teamID string, parentGroupID string, groupID string) error {
+	err := s.setRecord(ctx, rediskeys.EndpointGroups0.Format(), groupID, GroupRecord{ID: groupID, TeamID: teamID, ParentGroupID: parentGroupID}, false)
+	if err != nil {
+		return err
+	}
+
+	return s.invalidate(ctx)
+}
+
+func (s *RedisEndpointProvider) FindOneTenant(ctx context.Context, tenantID string) (*TenantRecord, error) {
+	tenant, err := getRecord[TenantRecord](ctx, s, rediskeys.EndpointTenants0.Format(), tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound
+	}
+
+	tenant.Upstream, tenant.Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByTenantID1.Format(tenantID), rediskeys.EndpointPolicyByTenantID1.Format(tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+func (s *RedisEndpointProvider) FindOneTeam(ctx context.Context, teamID string) (*TeamRecord, error) {
+	team, err := getRecord[TeamRecord](ctx, s, rediskeys.EndpointTeams0.Format(), teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	team.Upstream, team.Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByTeamID1.Format(teamID), rediskeys.EndpointPolicyByTeamID1.Format(teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (s *RedisEndpointProvider) FindOneGroup(ctx context.Context, groupID string) (*GroupRecord, error) {
+	group, err := getRecord[GroupRecord](ctx, s, rediskeys.EndpointGroups0.Format(), groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	group.Upstream, group.Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByGroupID1.Format(groupID), rediskeys.EndpointPolicyByGroupID1.Format(groupID))
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *RedisEndpointProvider) FindOneByID(ctx context.Context, endpointID string) (*Endpoint, error) {
+	endpoint, err := s.getEndpoint(ctx, rediskeys.EndpointMetadataByEndpointID1.Format(endpointID))
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil {
+		return nil, ErrEndpointNotFound
+	}
+
+	endpoint.Upstream, endpoint.Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByEndpointID1.Format(endpointID), rediskeys.EndpointPolicyByEndpointID1.Format(endpointID))
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (s *RedisEndpointProvider) ListTenants(ctx context.Context) ([]TenantRecord, error) {
+	tenants, err := listRecords(ctx, s, rediskeys.EndpointTenants0.Format(), func(item TenantRecord) string { return item.ID }, func(TenantRecord) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tenants {
+		tenants[i].Upstream, tenants[i].Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByTenantID1.Format(tenants[i].ID), rediskeys.EndpointPolicyByTenantID1.Format(tenants[i].ID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tenants, nil
+}
+
+func (s *RedisEndpointProvider) ListTeams(ctx context.Context, tenantID string) ([]TeamRecord, error) {
+	teams, err := listRecords(ctx, s, rediskeys.EndpointTeams0.Format(), func(item TeamRecord) string { return item.ID }, func(item TeamRecord) bool {
+		return item.TenantID == tenantID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range teams {
+		teams[i].Upstream, teams[i].Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByTeamID1.Format(teams[i].ID), rediskeys.EndpointPolicyByTeamID1.Format(teams[i].ID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return teams, nil
+}
+
+func (s *RedisEndpointProvider) ListGroups(ctx context.Context, teamID string) ([]GroupRecord, error) {
+	groups, err := listRecords(ctx, s, rediskeys.EndpointGroups0.Format(), func(item GroupRecord) string { return item.ID }, func(item GroupRecord) bool {
+		return item.TeamID == teamID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range groups {
+		groups[i].Upstream, groups[i].Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByGroupID1.Format(groups[i].ID), rediskeys.EndpointPolicyByGroupID1.Format(groups[i].ID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+// ListEndpoints scans the endpoints stored by their ID, which is fine for
+// administration, but not meant for serving requests.
+func (s *RedisEndpointProvider) ListEndpoints(ctx context.Context, groupID string) ([]Endpoint, error) {
+	endpoints := make([]Endpoint, 0)
+
+	err := s.scan(ctx, rediskeys.EndpointMetadataByEndpointID1.Format("*"), func(key string) error {
+		endpoint, err := s.getEndpoint(ctx, key)
+		if err != nil {
+			return err
+		}
+		if endpoint == nil || endpoint.Group.ID() != groupID {
+			return nil
+		}
+
+		endpoint.Upstream, endpoint.Policy, err = s.findOverrides(ctx, rediskeys.EndpointUpstreamByEndpointID1.Format(endpoint.ID), rediskeys.EndpointPolicyByEndpointID1.Format(endpoint.ID))
+		if err != nil {
+			return err
+		}
+
+		endpoints = append(endpoints, *endpoint)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(endpoints, func(a, b Endpoint) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return endpoints, nil
+}
+
+// deleteRecord deletes id from the hash key along with the upstream override
+// and the policy stored by upstreamKey and policyKey.
+func (s *RedisEndpointProvider) deleteRecord(ctx context.Context, key string, id string, upstreamKey string, policyKey string) error {
+	err := s.rueidis.Do(ctx, s.rueidis.B().Hdel().Key(key).Field(id).Build()).Error()
+	if err != nil {
+		return err
+	}
+
+	err = s.rueidis.Do(ctx, s.rueidis.B().Del().Key(upstreamKey, policyKey).Build()).Error()
+	if err != nil {
+		return err
+	}
+
+	return s.invalidate(ctx)
+}
+
+func (s *RedisEndpointProvider) DeleteOneTenant(ctx context.Context, tenantID string) error {
+	_, err := s.FindOneTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	teams, err := s.ListTeams(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if len(teams) > 0 {
+		return ErrHierarchyNotEmpty
+	}
+
+	return s.deleteRecord(ctx, rediskeys.EndpointTenants0.Format(), tenantID, rediskeys.EndpointUpstreamByTenantID1.Format(tenantID), rediskeys.EndpointPolicyByTenantID1.Format(tenantID))
+}
+
+func (s *RedisEndpointProvider) DeleteOneTeam(ctx context.Context, teamID string) error {
+	_, err := s.FindOneTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	groups, err := s.ListGroups(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		return ErrHierarchyNotEmpty
+	}
+
+	return s.deleteRecord(ctx, rediskeys.EndpointTeams0.Format(), teamID, rediskeys.EndpointUpstreamByTeamID1.Format(teamID), rediskeys.EndpointPolicyByTeamID1.Format(teamID))
+}
+
+func (s *RedisEndpointProvider) DeleteOneGroup(ctx context.Context, groupID string) error {
+	_, err := s.FindOneGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	children, err := listRecords(ctx, s, rediskeys.EndpointGroups0.Format(), func(item GroupRecord) string { return item.ID }, func(item GroupRecord) bool {
+		return item.ParentGroupID == groupID
+	})
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return ErrHierarchyNotEmpty
+	}
+
+	endpoints, err := s.ListEndpoints(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if len(endpoints) > 0 {
+		return ErrHierarchyNotEmpty
+	}
+
+	return s.deleteRecord(ctx, rediskeys.EndpointGroups0.Format(), groupID, rediskeys.EndpointUpstreamByGroupID1.Format(groupID), rediskeys.EndpointPolicyByGroupID1.Format(groupID))
+}
+
+// DeleteOneEndpoint deletes the endpoint, its alias, its upstream override,
+// its policy, and the API keys issued for it, which are found by scanning the endpoints
+// stored by the digest of their key.
+func (s *RedisEndpointProvider) DeleteOneEndpoint(ctx context.Context, endpointID string) error {
+	endpoint, err := s.getEndpoint(ctx, rediskeys.EndpointMetadataByEndpointID1.Format(endpointID))
+	if err != nil {
+		return err
+	}
+	if endpoint == nil {
+		return ErrEndpointNotFound
+	}
+
+	err = s.scan(ctx, rediskeys.EndpointMetadataByAPIKeyDigest1.Format("*"), func(key string) error {
+		issued, err := s.getEndpoint(ctx, key)
+		if err != nil {
+			return err
+		}
+		if issued == nil || issued.ID != endpointID {
+			return nil
+		}
+
+		return s.rueidis.Do(ctx, s.rueidis.B().Del().Key(key).Build()).Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	if endpoint.Alias != "" {
+		aliased, err := s.getEndpoint(ctx, rediskeys.EndpointMetadataByAlias1.Format(endpoint.Alias))
+		if err != nil {
+			return err
+		}
+		if aliased != nil && aliased.ID == endpointID {
+			err = s.rueidis.Do(ctx, s.rueidis.B().Del().Key(rediskeys.EndpointMetadataByAlias1.Format(endpoint.Alias)).Build()).Error()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cmd := s.rueidis.B().
+		Del().
+		Key(
+			rediskeys.EndpointUpstreamByEndpointID1.Format(endpointID),
+			rediskeys.EndpointPolicyByEndpointID1.Format(endpointID),
+			rediskeys.EndpointMetadataByEndpointID1.Format(endpointID),
+		).
+		Build()
+
+	err = s.rueidis.Do(ctx, cmd).Error()
+	if err != nil {
+		return err
+	}
+
+	return s.invalidate(ctx)
 }
@@ -2,9 +2,13 @@ package authstorage
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/lingticio/llmg/internal/datastore"
 	"github.com/lingticio/llmg/pkg/types/metadata"
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
 	"github.com/redis/rueidis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,7 +23,7 @@ func TestRedisEndpointProvider_FindOneByAPIKey(t *testing.T) {
 
 	defer r.Close()
 
-	rp := NewRedisEndpointAuthProvider()(r)
+	rp := NewRedisEndpointAuthProvider()(r, "secret")
 	redisProvider, ok := rp.(*RedisEndpointProvider)
 	require.True(t, ok)
 	require.NotNil(t, redisProvider)
@@ -50,6 +54,7 @@ func TestRedisEndpointProvider_FindOneByAPIKey(t *testing.T) {
 	assert.Equal(t, "endpointId", endpoint.ID)
 	assert.Equal(t, "alias", endpoint.Alias)
 	assert.Equal(t, "apiKey", endpoint.APIKey)
+	assert.Equal(t, "api", endpoint.APIKeyPrefix)
 	assert.Equal(t, "tenantId", endpoint.Tenant.Id)
 	assert.Equal(t, "teamId", endpoint.Team.Id)
 	assert.Equal(t, "groupId", endpoint.Group.Id)
@@ -66,7 +71,7 @@ func TestRedisEndpointProvider_FindOneByAlias(t *testing.T) {
 
 	defer r.Close()
 
-	rp := NewRedisEndpointAuthProvider()(r)
+	rp := NewRedisEndpointAuthProvider()(r, "secret")
 	redisProvider, ok := rp.(*RedisEndpointProvider)
 	require.True(t, ok)
 	require.NotNil(t, redisProvider)
@@ -96,10 +101,281 @@ func TestRedisEndpointProvider_FindOneByAlias(t *testing.T) {
 
 	assert.Equal(t, "endpointId", endpoint.ID)
 	assert.Equal(t, "alias", endpoint.Alias)
-	assert.Equal(t, "apiKey", endpoint.APIKey)
+	assert.Empty(t, endpoint.APIKey)
+	assert.Equal(t, "api", endpoint.APIKeyPrefix)
 	assert.Equal(t, "tenantId", endpoint.Tenant.Id)
 	assert.Equal(t, "teamId", endpoint.Team.Id)
 	assert.Equal(t, "groupId", endpoint.Group.Id)
 	assert.Equal(t, "baseURL", endpoint.Upstream.OpenAI.BaseURL)
 	assert.Equal(t, "apiKey", endpoint.Upstream.OpenAI.APIKey)
+
+	byID, err := redisProvider.FindOneEffectiveByID(context.Background(), "endpointId")
+	require.NoError(t, err)
+
+	assert.Equal(t, "endpointId", byID.ID)
+	assert.Equal(t, "alias", byID.Alias)
+	assert.Empty(t, byID.APIKey)
+	assert.Equal(t, "tenantId", byID.Tenant.Id)
+	assert.Equal(t, endpoint.Upstream, byID.Upstream)
+
+	_, err = redisProvider.FindOneEffectiveByID(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrEndpointNotFound)
+}
+
+func TestRedisEndpointProvider_MigrateAPIKeys(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	defer r.Close()
+
+	ctx := context.Background()
+
+	rp := NewRedisEndpointAuthProvider()(r, "secret")
+	redisProvider, ok := rp.(*RedisEndpointProvider)
+	require.True(t, ok)
+
+	err = redisProvider.ConfigureOneUpstreamForEndpoint(ctx, "legacyEndpointId", &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{
+			OpenAI: metadata.UpstreamOpenAI{
+				BaseURL: "baseURL",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	legacy := `{"id":"legacyEndpointId","alias":"legacyAlias","apiKey":"legacyAPIKey"}`
+
+	for _, key := range []string{
+		rediskeys.EndpointMetadataByAPIKey1.Format("legacyAPIKey"),
+		rediskeys.EndpointMetadataByAlias1.Format("legacyAlias"),
+	} {
+		err = r.Do(ctx, r.B().Set().Key(key).Value(legacy).Build()).Error()
+		require.NoError(t, err)
+	}
+
+	migrated, err := redisProvider.MigrateAPIKeys(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, migrated, 1)
+
+	exists, err := r.Do(ctx, r.B().Exists().Key(rediskeys.EndpointMetadataByAPIKey1.Format("legacyAPIKey")).Build()).AsInt64()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+
+	endpoint, err := rp.FindOneByAPIKey(ctx, "legacyAPIKey")
+	require.NoError(t, err)
+	require.NotNil(t, endpoint)
+	assert.Equal(t, "legacyEndpointId", endpoint.ID)
+	assert.Equal(t, "legacyAPIKey", endpoint.APIKey)
+	assert.Equal(t, "legacy", endpoint.APIKeyPrefix)
+
+	stored, err := r.Do(ctx, r.B().Get().Key(rediskeys.EndpointMetadataByAlias1.Format("legacyAlias")).Build()).ToString()
+	require.NoError(t, err)
+	assert.NotContains(t, stored, "legacyAPIKey")
+
+	// Migrating again is a no-op.
+	migrated, err = redisProvider.MigrateAPIKeys(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, migrated)
+}
+
+func TestRedisEndpointProvider_APIKeyLifecycle(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	defer r.Close()
+
+	ctx := context.Background()
+
+	rp := NewRedisEndpointAuthProvider()(r, "secret")
+	redisProvider, ok := rp.(*RedisEndpointProvider)
+	require.True(t, ok)
+
+	// The key rotated to is left issued by earlier runs.
+	err = r.Do(ctx, r.B().Del().Key(rediskeys.EndpointMetadataByAPIKeyDigest1.Format(HashAPIKey("secret", "newLifecycleAPIKey"))).Build()).Error()
+	require.NoError(t, err)
+
+	err = redisProvider.ConfigureOneUpstreamForEndpoint(ctx, "lifecycleEndpointId", &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{
+			OpenAI: metadata.UpstreamOpenAI{
+				BaseURL: "baseURL",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	err = redisProvider.ConfigureOne(ctx, "lifecycleAPIKey", "", &Endpoint{
+		Tenant:        metadata.Tenant{Id: "tenantId"},
+		ID:            "lifecycleEndpointId",
+		APIKeyOptions: APIKeyOptions{Scopes: []Scope{ScopeChat}, Aliases: []string{"summarizer"}},
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, redisProvider.AddOneAPIKey(ctx, "unknown", "newLifecycleAPIKey", APIKeyOptions{}), ErrEndpointNotFound)
+
+	err = RotateOneAPIKey(ctx, redisProvider, "lifecycleAPIKey", "newLifecycleAPIKey", time.Minute)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, redisProvider.AddOneAPIKey(ctx, "lifecycleEndpointId", "newLifecycleAPIKey", APIKeyOptions{}), ErrAPIKeyExists)
+
+	rotated, err := rp.FindOneByAPIKey(ctx, "newLifecycleAPIKey")
+	require.NoError(t, err)
+	require.NotNil(t, rotated)
+	assert.Equal(t, "lifecycleEndpointId", rotated.ID)
+	assert.Equal(t, "tenantId", rotated.Tenant.Id)
+	assert.Equal(t, []Scope{ScopeChat}, rotated.Scopes)
+	assert.Equal(t, []string{"summarizer"}, rotated.Aliases)
+	require.NoError(t, rotated.CheckActive(time.Now()))
+
+	endpoint, err := rp.FindOneByAPIKey(ctx, "lifecycleAPIKey")
+	require.NoError(t, err)
+	require.NoError(t, endpoint.CheckActive(time.Now()))
+	require.ErrorIs(t, endpoint.CheckActive(time.Now().Add(2*time.Minute)), ErrAPIKeyExpired)
+
+	require.NoError(t, redisProvider.RevokeOneAPIKey(ctx, "lifecycleAPIKey"))
+
+	endpoint, err = rp.FindOneByAPIKey(ctx, "lifecycleAPIKey")
+	require.NoError(t, err)
+	require.ErrorIs(t, endpoint.CheckActive(time.Now()), ErrAPIKeyRevoked)
+
+	require.ErrorIs(t, redisProvider.RevokeOneAPIKey(ctx, "unknown"), ErrAPIKeyNotFound)
+}
+
+func TestRedisEndpointProvider_Administrable(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	defer r.Close()
+
+	rp := NewRedisEndpointAuthProvider()(r, "secret")
+	redisProvider, ok := rp.(*RedisEndpointProvider)
+	require.True(t, ok)
+
+	testEndpointProviderAdministrable(t, redisProvider, "administrable:")
+}
+
+func TestRedisEndpointProvider_Cache(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	defer r.Close()
+
+	newReplica := func() *RedisEndpointProvider {
+		cache, err := datastore.NewCache()(datastore.NewCacheParams{})
+		require.NoError(t, err)
+
+		return NewCachedRedisEndpointAuthProvider()(r, "secret", cache)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	writer := newReplica()
+	reader := newReplica()
+
+	go func() {
+		_ = reader.SubscribeInvalidations(ctx)
+	}()
+
+	// Waits for the subscription, which is not acknowledged by
+	// SubscribeInvalidations.
+	require.Eventually(t, func() bool {
+		require.NoError(t, writer.invalidate(ctx))
+		return reader.cache.generation.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	apiKey := NewAPIKey()
+
+	endpoint, err := reader.FindOneByAPIKey(ctx, apiKey)
+	require.NoError(t, err)
+	require.Nil(t, endpoint)
+
+	err = writer.ConfigureOneGroup(ctx, "cachedTeamId", "", "cachedParentGroupId")
+	require.NoError(t, err)
+	err = writer.ConfigureOneGroup(ctx, "cachedTeamId", "cachedParentGroupId", "cachedGroupId")
+	require.NoError(t, err)
+	err = writer.ConfigureOneUpstreamForTenant(ctx, "cachedTenantId", &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{OpenAI: metadata.UpstreamOpenAI{BaseURL: "tenantURL", APIKey: "tenantKey"}},
+	})
+	require.NoError(t, err)
+	err = writer.ConfigureOneUpstreamForGroup(ctx, "cachedParentGroupId", &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{OpenAI: metadata.UpstreamOpenAI{ExtraHeaders: http.Header{"X-Group": []string{"parentGroup"}}}},
+	})
+	require.NoError(t, err)
+	err = writer.ConfigureOne(ctx, apiKey, "cachedAlias", &Endpoint{
+		Tenant: metadata.Tenant{Id: "cachedTenantId"},
+		Team:   metadata.Team{Id: "cachedTeamId"},
+		Group:  metadata.Group{Id: "cachedGroupId"},
+		ID:     "cachedEndpointId",
+	})
+	require.NoError(t, err)
+
+	// The unknown API key cached by the reader is invalidated by the writer.
+	require.Eventually(t, func() bool {
+		endpoint, err = reader.FindOneByAPIKey(ctx, apiKey)
+		return err == nil && endpoint != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "cachedEndpointId", endpoint.ID)
+	assert.Equal(t, apiKey, endpoint.APIKey)
+	assert.Equal(t, "tenantURL", endpoint.Upstream.GetUpstream().OpenAI.BaseURL)
+	assert.Equal(t, "tenantKey", endpoint.Upstream.GetUpstream().OpenAI.APIKey)
+	assert.Equal(t, "parentGroup", endpoint.Upstream.GetUpstream().OpenAI.ExtraHeaders.Get("X-Group"))
+
+	// Served from the cache, even though Redis no longer has it.
+	err = r.Do(ctx, r.B().Del().Key(rediskeys.EndpointMetadataByAPIKeyDigest1.Format(HashAPIKey("secret", apiKey))).Build()).Error()
+	require.NoError(t, err)
+
+	endpoint, err = reader.FindOneByAPIKey(ctx, apiKey)
+	require.NoError(t, err)
+	require.NotNil(t, endpoint)
+
+	endpoint.Alias = "modified"
+
+	endpoint, err = reader.FindOneByAlias(ctx, "cachedAlias")
+	require.NoError(t, err)
+	require.NotNil(t, endpoint)
+	assert.Equal(t, "cachedAlias", endpoint.Alias)
+
+	// Changes of the upstreams are seen by the reader.
+	err = writer.ConfigureOneUpstreamForGroup(ctx, "cachedParentGroupId", &metadata.UpstreamSingleOrMultiple{
+		Upstream: &metadata.Upstream{OpenAI: metadata.UpstreamOpenAI{BaseURL: "changedParentGroupURL"}},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		endpoint, err = reader.FindOneByAlias(ctx, "cachedAlias")
+		return err == nil && endpoint != nil && endpoint.Upstream.GetUpstream().OpenAI.BaseURL == "changedParentGroupURL"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	endpoint, err = reader.FindOneByAPIKey(ctx, apiKey)
+	require.NoError(t, err)
+	require.Nil(t, endpoint)
+}
+
+func TestRedisEndpointProvider_Policies(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	defer r.Close()
+
+	rp := NewRedisEndpointAuthProvider()(r, "secret")
+	redisProvider, ok := rp.(*RedisEndpointProvider)
+	require.True(t, ok)
+
+	testEndpointProviderPolicies(t, redisProvider, "policies:")
 }
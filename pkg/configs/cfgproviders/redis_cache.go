@@ -0,0 +1,208 @@
+package authstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+)
+
+const (
+	// endpointsCacheTTL bounds how long endpoints are served from the cache
+	// when invalidations are missed, e.g. while resubscribing.
+	endpointsCacheTTL = time.Minute
+	// endpointsNegativeCacheTTL is how long unknown API keys and aliases are
+	// remembered, shorter than endpointsCacheTTL so that the keys handed out
+	// by other replicas, or directly written to Redis, are picked up soon.
+	endpointsNegativeCacheTTL = 10 * time.Second
+)
+
+// redisEndpointsCache caches the endpoints looked up by RedisEndpointProvider,
+// and the parents of groups, in the process. Rather than removing entries
+// one by one, the cache is flushed as a whole by bumping its generation,
+// which is part of the keys, so that lookups in flight while the cache is
+// flushed never store stale endpoints under the current generation.
+type redisEndpointsCache struct {
+	cache      *datastore.Cache
+	instance   string
+	generation atomic.Uint64
+}
+
+// cachedEndpoint is nil for negative entries.
+type cachedEndpoint struct {
+	endpoint *Endpoint
+}
+
+// cachedGroup is the parent of a group, empty for groups which are not
+// nested, or unknown.
+type cachedGroup struct {
+	parentGroupID string
+}
+
+func (c *redisEndpointsCache) key(generation uint64, kind string, id string) string {
+	return "endpoints:redis:" + c.instance + ":" + strconv.FormatUint(generation, 10) + ":" + kind + ":" + id
+}
+
+func (c *redisEndpointsCache) flush() {
+	c.generation.Add(1)
+}
+
+// endpoint returns the endpoint cached for id, a nil endpoint is cached for
+// unknown ids. Endpoints are copied so that callers can modify them.
+func (c *redisEndpointsCache) endpoint(ctx context.Context, kind string, id string, find func(ctx context.Context) (*Endpoint, error)) (*Endpoint, error) {
+	generation := c.generation.Load()
+	key := c.key(generation, kind, id)
+
+	cached, ok := c.cache.Get(key)
+	if ok {
+		entry, ok := cached.(cachedEndpoint)
+		if ok {
+			if entry.endpoint == nil {
+				return nil, nil
+			}
+
+			endpoint := *entry.endpoint
+
+			return &endpoint, nil
+		}
+	}
+
+	endpoint, err := find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil {
+		c.cache.Set(key, cachedEndpoint{}, endpointsNegativeCacheTTL)
+		return nil, nil
+	}
+
+	cachedCopy := *endpoint
+	c.cache.Set(key, cachedEndpoint{endpoint: &cachedCopy}, endpointsCacheTTL)
+
+	return endpoint, nil
+}
+
+func (c *redisEndpointsCache) parentGroup(ctx context.Context, groupID string, find func(ctx context.Context) (string, error)) (string, error) {
+	key := c.key(c.generation.Load(), "group", groupID)
+
+	cached, ok := c.cache.Get(key)
+	if ok {
+		entry, ok := cached.(cachedGroup)
+		if ok {
+			return entry.parentGroupID, nil
+		}
+	}
+
+	parentGroupID, err := find(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.cache.Set(key, cachedGroup{parentGroupID: parentGroupID}, endpointsCacheTTL)
+
+	return parentGroupID, nil
+}
+
+// NewCachedRedisEndpointAuthProvider returns a RedisEndpointProvider caching
+// the endpoints looked up by API key or alias in cache. Replicas flush their
+// cache when another one changes endpoints, as long as they run
+// SubscribeInvalidations.
+func NewCachedRedisEndpointAuthProvider() func(client rueidis.Client, apiKeySecret string, cache *datastore.Cache) *RedisEndpointProvider {
+	return func(r rueidis.Client, apiKeySecret string, cache *datastore.Cache) *RedisEndpointProvider {
+		return &RedisEndpointProvider{
+			rueidis:      r,
+			apiKeySecret: apiKeySecret,
+			cache: &redisEndpointsCache{
+				cache:    cache,
+				instance: nanoid.New(),
+			},
+		}
+	}
+}
+
+// invalidate flushes the cache of every replica, it is called once
+// endpoints, their API keys, the hierarchy or the upstreams changed.
+func (s *RedisEndpointProvider) invalidate(ctx context.Context) error {
+	if s.cache != nil {
+		s.cache.flush()
+	}
+
+	cmd := s.rueidis.B().
+		Publish().
+		Channel(rediskeys.EndpointInvalidations0.Format()).
+		Message(strconv.FormatInt(time.Now().UnixMilli(), 10)).
+		Build()
+
+	return s.rueidis.Do(ctx, cmd).Error()
+}
+
+// SubscribeInvalidations flushes the cache whenever a replica changes
+// endpoints, until ctx is done or the subscription fails. Since
+// invalidations may have been missed by then, the cache is flushed again
+// when it returns, and it should be called again unless ctx is done.
+func (s *RedisEndpointProvider) SubscribeInvalidations(ctx context.Context) error {
+	if s.cache == nil {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	defer s.cache.flush()
+
+	cmd := s.rueidis.B().
+		Subscribe().
+		Channel(rediskeys.EndpointInvalidations0.Format()).
+		Build()
+
+	return s.rueidis.Receive(ctx, cmd, func(rueidis.PubSubMessage) {
+		s.cache.flush()
+	})
+}
+
+// findAll returns the values stored by keys, nil for the keys not set. They
+// are fetched with a single MGET, or GETs pipelined together on clusters
+// where the keys live in different slots, so that looking up the upstreams
+// and policies of endpoints takes one round trip however deep groups are
+// nested.
+func findAll[T any](ctx context.Context, s *RedisEndpointProvider, keys []string) ([]*T, error) {
+	values, err := rueidis.MGet(s.rueidis, ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*T, len(keys))
+
+	for i, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+
+		res, err := value.ToString()
+		if err != nil {
+			if rueidis.IsRedisNil(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		var result T
+
+		err = json.Unmarshal([]byte(res), &result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+		}
+
+		results[i] = &result
+	}
+
+	return results, nil
+}
@@ -2,112 +2,307 @@ package authstorage
 
 import (
 	"context"
-	"errors"
+	"slices"
+
+	"github.com/samber/lo"
 
 	"github.com/lingticio/llmg/internal/configs"
 	"github.com/lingticio/llmg/pkg/types/metadata"
 )
 
 var _ EndpointProvider = (*ConfigEndpointProvider)(nil)
+var _ EndpointProviderHierarchyQueryable = (*ConfigEndpointProvider)(nil)
+var _ EndpointProviderUpstreamExplainable = (*ConfigEndpointProvider)(nil)
+var _ EndpointProviderEffectiveQueryable = (*ConfigEndpointProvider)(nil)
 
 type ConfigEndpointProvider struct {
 	Config *configs.Routes
 }
 
-func (s *ConfigEndpointProvider) findUpstream(endpoint configs.Endpoint, group configs.Group, team configs.Team, tenant configs.Tenant) *metadata.UpstreamSingleOrMultiple {
-	if endpoint.Upstream != nil {
-		return endpoint.Upstream
+func NewConfigEndpointProvider() func(*configs.Routes) EndpointProvider {
+	return func(routes *configs.Routes) EndpointProvider {
+		return &ConfigEndpointProvider{
+			Config: routes,
+		}
+	}
+}
+
+// layersOf returns the upstream overrides of the tenant, the team, the
+// groups from the outermost to the innermost one, and the endpoint, in the
+// order they are merged in.
+func (s *ConfigEndpointProvider) layersOf(tenant configs.Tenant, team configs.Team, groups []configs.Group, endpoint *configs.Endpoint) []metadata.UpstreamLayer {
+	layers := []metadata.UpstreamLayer{
+		tenantLayer(tenant.ID, tenant.Upstream),
+		teamLayer(team.ID, team.Upstream),
 	}
-	if group.Upstream != nil {
-		return group.Upstream
+
+	for _, group := range groups {
+		layers = append(layers, groupLayer(group.ID, group.Upstream))
 	}
-	if team.Upstream != nil {
-		return team.Upstream
+
+	if endpoint != nil {
+		layers = append(layers, endpointLayer(endpoint.ID, endpoint.Upstream))
 	}
 
-	return tenant.Upstream
+	return layers
+}
+
+// findUpstream merges the upstream of the endpoint, see
+// metadata.MergeUpstreams, endpoint may be nil for the upstream of the
+// innermost group.
+func (s *ConfigEndpointProvider) findUpstream(tenant configs.Tenant, team configs.Team, groups []configs.Group, endpoint *configs.Endpoint) *metadata.UpstreamSingleOrMultiple {
+	return metadata.MergeUpstreams(s.layersOf(tenant, team, groups, endpoint)...).Upstream
 }
 
-func (s *ConfigEndpointProvider) searchGroupsForAPIKey(tenantID, teamID string, groups []configs.Group, apiKey string, team configs.Team, tenant configs.Tenant) (*Endpoint, error) {
+// levelsOf returns the policies of the tenant, the team, the groups and the
+// endpoint. endpoint may be nil, as for findUpstream.
+func (s *ConfigEndpointProvider) levelsOf(tenant configs.Tenant, team configs.Team, groups []configs.Group, endpoint *configs.Endpoint) []level {
+	levels := []level{tenantLevel(tenant.ID, &Policy{RateLimit: tenant.RateLimit, Budget: tenant.Budget})}
+
+	if team.ID != "" {
+		levels = append(levels, teamLevel(team.ID, &Policy{RateLimit: team.RateLimit, Budget: team.Budget}))
+	}
+
 	for _, group := range groups {
-		// Search in current group's endpoints
-		for _, endpoint := range group.Endpoints {
-			if endpoint.APIKey == apiKey {
-				return &Endpoint{
-					Tenant:   metadata.Tenant{Id: tenantID},
-					Team:     metadata.Team{Id: teamID},
-					Group:    metadata.Group{Id: group.ID},
-					ID:       endpoint.ID,
-					Alias:    endpoint.Alias,
-					APIKey:   endpoint.APIKey,
-					Upstream: s.findUpstream(endpoint, group, team, tenant),
-				}, nil
-			}
-		}
+		levels = append(levels, groupLevel(group.ID, &Policy{RateLimit: group.RateLimit, Budget: group.Budget}))
+	}
 
-		// Recursively search in nested groups
-		if len(group.Groups) > 0 {
-			metadata, err := s.searchGroupsForAPIKey(tenantID, teamID, group.Groups, apiKey, team, tenant)
-			if err == nil {
-				return metadata, nil
-			}
-		}
+	if endpoint != nil {
+		levels = append(levels, endpointLevel(endpoint.ID, policyOf(*endpoint)))
 	}
 
-	return nil, errors.New("api key not found")
+	return levels
 }
 
-func (s *ConfigEndpointProvider) FindOneByAPIKey(ctx context.Context, apiKey string) (*Endpoint, error) {
+// policyOf returns the policy declared by the endpoint.
+func policyOf(endpoint configs.Endpoint) *Policy {
+	return &Policy{
+		RateLimit:     endpoint.RateLimit,
+		Budget:        endpoint.Budget,
+		Priority:      endpoint.Priority,
+		SemanticCache: endpoint.SemanticCache,
+		ExactCache:    endpoint.ExactCache,
+	}
+}
+
+// configEndpoint is an endpoint of the configuration, along with its
+// tenant, team, and groups from the outermost to the innermost one.
+type configEndpoint struct {
+	tenant   configs.Tenant
+	team     configs.Team
+	groups   []configs.Group
+	endpoint configs.Endpoint
+}
+
+func (e configEndpoint) group() configs.Group {
+	return e.groups[len(e.groups)-1]
+}
+
+// findEndpoint returns the first endpoint for which match returns true.
+func (s *ConfigEndpointProvider) findEndpoint(match func(endpoint configs.Endpoint) bool) (configEndpoint, bool) {
 	for _, tenant := range s.Config.Tenants {
 		for _, team := range tenant.Teams {
-			metadata, err := s.searchGroupsForAPIKey(tenant.ID, team.ID, team.Groups, apiKey, team, tenant)
-			if err == nil {
-				return metadata, nil
+			found, ok := s.searchGroups(team.Groups, nil, match)
+			if ok {
+				found.tenant = tenant
+				found.team = team
+
+				return found, true
 			}
 		}
 	}
 
-	return nil, errors.New("api key not found")
+	return configEndpoint{}, false
 }
 
-func (s *ConfigEndpointProvider) searchGroupsForAlias(tenantID, teamID string, groups []configs.Group, alias string, team configs.Team, tenant configs.Tenant) (*Endpoint, error) {
+func (s *ConfigEndpointProvider) searchGroups(groups []configs.Group, parents []configs.Group, match func(endpoint configs.Endpoint) bool) (configEndpoint, bool) {
 	for _, group := range groups {
+		path := append(slices.Clone(parents), group)
+
 		// Search in current group's endpoints
 		for _, endpoint := range group.Endpoints {
-			if endpoint.Alias == alias {
-				return &Endpoint{
-					Tenant:   metadata.Tenant{Id: tenantID},
-					Team:     metadata.Team{Id: teamID},
-					Group:    metadata.Group{Id: group.ID},
-					ID:       endpoint.ID,
-					Alias:    endpoint.Alias,
-					APIKey:   endpoint.APIKey,
-					Upstream: s.findUpstream(endpoint, group, team, tenant),
-				}, nil
+			if match(endpoint) {
+				return configEndpoint{groups: path, endpoint: endpoint}, true
 			}
 		}
 
 		// Recursively search in nested groups
-		if len(group.Groups) > 0 {
-			metadata, err := s.searchGroupsForAlias(tenantID, teamID, group.Groups, alias, team, tenant)
-			if err == nil {
-				return metadata, nil
-			}
+		found, ok := s.searchGroups(group.Groups, path, match)
+		if ok {
+			return found, true
 		}
 	}
 
-	return nil, errors.New("alias not found")
+	return configEndpoint{}, false
+}
+
+// apiKeyOptionsOf returns the lifecycle of apiKey when it is issued for the
+// endpoint.
+func (s *ConfigEndpointProvider) apiKeyOptionsOf(endpoint configs.Endpoint, apiKey string) (APIKeyOptions, bool) {
+	if endpoint.APIKey != "" && endpoint.APIKey == apiKey {
+		return APIKeyOptions{}, true
+	}
+
+	for _, key := range endpoint.APIKeys {
+		if key.Key != "" && key.Key == apiKey {
+			return APIKeyOptions{
+				ExpiresAt: key.ExpiresAt,
+				Disabled:  key.Disabled,
+				Scopes:    lo.Map(key.Scopes, func(scope string, _ int) Scope { return Scope(scope) }),
+				Aliases:   key.Aliases,
+			}, true
+		}
+	}
+
+	return APIKeyOptions{}, false
+}
+
+// endpointOf returns the endpoint found, along with its upstream and
+// policies, but without any API key.
+func (s *ConfigEndpointProvider) endpointOf(found configEndpoint) *Endpoint {
+	endpoint := &Endpoint{
+		Tenant:   metadata.Tenant{Id: found.tenant.ID},
+		Team:     metadata.Team{Id: found.team.ID},
+		Group:    metadata.Group{Id: found.group().ID},
+		ID:       found.endpoint.ID,
+		Alias:    found.endpoint.Alias,
+		Upstream: s.findUpstream(found.tenant, found.team, found.groups, &found.endpoint),
+	}
+
+	applyPolicies(endpoint, s.levelsOf(found.tenant, found.team, found.groups, &found.endpoint), policyOf(found.endpoint))
+
+	return endpoint
+}
+
+func (s *ConfigEndpointProvider) FindOneByAPIKey(ctx context.Context, apiKey string) (*Endpoint, error) {
+	var options APIKeyOptions
+
+	found, ok := s.findEndpoint(func(endpoint configs.Endpoint) bool {
+		var issued bool
+
+		options, issued = s.apiKeyOptionsOf(endpoint, apiKey)
+
+		return issued
+	})
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	endpoint := s.endpointOf(found)
+	endpoint.APIKey = apiKey
+	endpoint.APIKeyOptions = options
+
+	return endpoint, nil
 }
 
 func (s *ConfigEndpointProvider) FindOneByAlias(ctx context.Context, alias string) (*Endpoint, error) {
+	found, ok := s.findEndpoint(func(endpoint configs.Endpoint) bool {
+		return endpoint.Alias == alias
+	})
+	if !ok {
+		return nil, ErrAliasNotFound
+	}
+
+	endpoint := s.endpointOf(found)
+	endpoint.APIKey = found.endpoint.APIKey
+
+	return endpoint, nil
+}
+
+func (s *ConfigEndpointProvider) FindOneEffectiveByID(ctx context.Context, endpointID string) (*Endpoint, error) {
+	found, ok := s.findEndpoint(func(endpoint configs.Endpoint) bool {
+		return endpoint.ID == endpointID
+	})
+	if !ok {
+		return nil, ErrEndpointNotFound
+	}
+
+	return s.endpointOf(found), nil
+}
+
+func (s *ConfigEndpointProvider) ExplainUpstream(ctx context.Context, endpointID string) (*metadata.EffectiveUpstream, error) {
+	found, ok := s.findEndpoint(func(endpoint configs.Endpoint) bool {
+		return endpoint.ID == endpointID
+	})
+	if !ok {
+		return nil, ErrEndpointNotFound
+	}
+
+	return lo.ToPtr(metadata.MergeUpstreams(s.layersOf(found.tenant, found.team, found.groups, &found.endpoint)...)), nil
+}
+
+// searchGroupsForID returns the groups from the outermost one to the group
+// of groupID.
+func (s *ConfigEndpointProvider) searchGroupsForID(groups []configs.Group, groupID string) ([]configs.Group, bool) {
+	for _, group := range groups {
+		if group.ID == groupID {
+			return []configs.Group{group}, true
+		}
+
+		// Recursively search in nested groups
+		found, ok := s.searchGroupsForID(group.Groups, groupID)
+		if ok {
+			return append([]configs.Group{group}, found...), true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *ConfigEndpointProvider) FindOneByHierarchy(ctx context.Context, tenantID string, teamID string, groupID string) (*Endpoint, error) {
 	for _, tenant := range s.Config.Tenants {
+		if tenant.ID != tenantID {
+			continue
+		}
+		if teamID == "" && groupID == "" {
+			upstream := metadata.MergeUpstreams(tenantLayer(tenant.ID, tenant.Upstream)).Upstream
+			if upstream == nil {
+				return nil, ErrHierarchyNotFound
+			}
+
+			endpoint := &Endpoint{
+				Tenant:   metadata.Tenant{Id: tenant.ID},
+				Upstream: upstream,
+			}
+
+			applyPolicies(endpoint, s.levelsOf(tenant, configs.Team{}, nil, nil), nil)
+
+			return endpoint, nil
+		}
+
 		for _, team := range tenant.Teams {
-			metadata, err := s.searchGroupsForAlias(tenant.ID, team.ID, team.Groups, alias, team, tenant)
-			if err == nil {
-				return metadata, nil
+			if teamID != "" && team.ID != teamID {
+				continue
+			}
+
+			var groups []configs.Group
+
+			if groupID != "" {
+				var ok bool
+
+				groups, ok = s.searchGroupsForID(team.Groups, groupID)
+				if !ok {
+					continue
+				}
 			}
+
+			upstream := s.findUpstream(tenant, team, groups, nil)
+			if upstream == nil {
+				return nil, ErrHierarchyNotFound
+			}
+
+			endpoint := &Endpoint{
+				Tenant:   metadata.Tenant{Id: tenant.ID},
+				Team:     metadata.Team{Id: team.ID},
+				Group:    metadata.Group{Id: groupID},
+				Upstream: upstream,
+			}
+
+			applyPolicies(endpoint, s.levelsOf(tenant, team, groups, nil), nil)
+
+			return endpoint, nil
 		}
 	}
 
-	return nil, errors.New("alias not found")
+	return nil, ErrHierarchyNotFound
 }
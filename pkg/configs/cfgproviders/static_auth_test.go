@@ -2,9 +2,14 @@ package authstorage
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/lingticio/llmg/internal/configs"
+	"github.com/lingticio/llmg/pkg/budgets"
+	"github.com/lingticio/llmg/pkg/ratelimits"
+	"github.com/lingticio/llmg/pkg/scheduling"
 	"github.com/lingticio/llmg/pkg/types/metadata"
 	"github.com/nekomeowww/xo"
 	"github.com/stretchr/testify/assert"
@@ -82,6 +87,57 @@ func TestConfigEndpointProvider_FindOneByAPIKey(t *testing.T) {
 	require.Nil(t, md)
 }
 
+func TestConfigEndpointProvider_FindOneByAPIKeyOfAPIKeys(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	s := &ConfigEndpointProvider{
+		Config: &configs.Routes{
+			Tenants: []configs.Tenant{
+				{
+					ID: "tenantId",
+					Teams: []configs.Team{
+						{
+							ID: "teamId",
+							Groups: []configs.Group{
+								{
+									ID: "groupId",
+									Endpoints: []configs.Endpoint{
+										{
+											ID:     "endpointId",
+											APIKey: "apiKey",
+											APIKeys: []configs.EndpointAPIKey{
+												{Key: "rotatedAPIKey", ExpiresAt: &expiresAt, Scopes: []string{"chat"}, Aliases: []string{"summarizer"}},
+												{Key: "revokedAPIKey", Disabled: true},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	md, err := s.FindOneByAPIKey(context.TODO(), "apiKey")
+	require.NoError(t, err)
+	assert.Equal(t, "endpointId", md.ID)
+	assert.Equal(t, APIKeyOptions{}, md.APIKeyOptions)
+
+	md, err = s.FindOneByAPIKey(context.TODO(), "rotatedAPIKey")
+	require.NoError(t, err)
+	assert.Equal(t, "endpointId", md.ID)
+	assert.Equal(t, "rotatedAPIKey", md.APIKey)
+	assert.Equal(t, &expiresAt, md.ExpiresAt)
+	assert.Equal(t, []Scope{ScopeChat}, md.Scopes)
+	assert.Equal(t, []string{"summarizer"}, md.Aliases)
+
+	md, err = s.FindOneByAPIKey(context.TODO(), "revokedAPIKey")
+	require.NoError(t, err)
+	require.ErrorIs(t, md.CheckActive(time.Now()), ErrAPIKeyRevoked)
+}
+
 func TestConfigEndpointProvider_FindOneByAlias(t *testing.T) {
 	tenantID := xo.RandomHashString(8)
 	teamID := xo.RandomHashString(8)
@@ -254,3 +310,197 @@ func TestConfigAuthStorage_findUpstream(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, teamUpstream, md3.Upstream)
 }
+
+func TestConfigEndpointProvider_ExplainUpstream(t *testing.T) {
+	tenantID := xo.RandomHashString(8)
+	teamID := xo.RandomHashString(8)
+	parentGroupID := xo.RandomHashString(8)
+	groupID := xo.RandomHashString(8)
+
+	s := &ConfigEndpointProvider{
+		Config: &configs.Routes{
+			Tenants: []configs.Tenant{
+				{
+					ID: tenantID,
+					Upstream: &metadata.UpstreamSingleOrMultiple{
+						Upstream: &metadata.Upstream{OpenAI: metadata.UpstreamOpenAI{BaseURL: "tenant-url", APIKey: "tenant-key"}},
+					},
+					Teams: []configs.Team{
+						{
+							ID: teamID,
+							Groups: []configs.Group{
+								{
+									ID: parentGroupID,
+									Upstream: &metadata.UpstreamSingleOrMultiple{
+										Upstream: &metadata.Upstream{OpenAI: metadata.UpstreamOpenAI{ExtraHeaders: http.Header{"X-Group": []string{"parent-group"}}}},
+									},
+									Groups: []configs.Group{
+										{
+											ID: groupID,
+											Endpoints: []configs.Endpoint{
+												{
+													ID:     "endpoint1",
+													APIKey: "key1",
+													Upstream: &metadata.UpstreamSingleOrMultiple{
+														Upstream: &metadata.Upstream{OpenAI: metadata.UpstreamOpenAI{ModelAliases: map[string]string{"fast": "gpt-4o-mini"}}},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	md, err := s.FindOneByAPIKey(context.TODO(), "key1")
+	require.NoError(t, err)
+	require.NotNil(t, md.Upstream)
+	assert.Equal(t, "tenant-url", md.Upstream.GetUpstream().OpenAI.BaseURL)
+	assert.Equal(t, "tenant-key", md.Upstream.GetUpstream().OpenAI.APIKey)
+	assert.Equal(t, "parent-group", md.Upstream.GetUpstream().OpenAI.ExtraHeaders.Get("X-Group"))
+	assert.Equal(t, map[string]string{"fast": "gpt-4o-mini"}, md.Upstream.GetUpstream().OpenAI.ModelAliases)
+
+	effective, err := s.ExplainUpstream(context.TODO(), "endpoint1")
+	require.NoError(t, err)
+	assert.Equal(t, md.Upstream, effective.Upstream)
+	assert.Equal(t, map[string]string{
+		"openai.api_key":               "tenant:" + tenantID,
+		"openai.base_url":              "tenant:" + tenantID,
+		"openai.extra_headers.X-Group": "group:" + parentGroupID,
+		"openai.model_aliases.fast":    "endpoint:endpoint1",
+	}, effective.Sources)
+
+	_, err = s.ExplainUpstream(context.TODO(), "unknown")
+	require.ErrorIs(t, err, ErrEndpointNotFound)
+
+	byID, err := s.FindOneEffectiveByID(context.TODO(), "endpoint1")
+	require.NoError(t, err)
+	assert.Equal(t, md.Upstream, byID.Upstream)
+	assert.Equal(t, groupID, byID.Group.ID())
+	assert.Empty(t, byID.APIKey)
+
+	_, err = s.FindOneEffectiveByID(context.TODO(), "unknown")
+	require.ErrorIs(t, err, ErrEndpointNotFound)
+}
+
+func TestConfigEndpointProvider_RateLimitsAndBudgets(t *testing.T) {
+	s := &ConfigEndpointProvider{
+		Config: &configs.Routes{
+			Tenants: []configs.Tenant{
+				{
+					ID:        "tenant",
+					RateLimit: &configs.RateLimit{RequestsPerMinute: 600, TokensPerMinute: 100000},
+					Budget: &configs.Budget{
+						Daily:    &configs.BudgetLimit{Hard: 10, Soft: 8},
+						Lifetime: &configs.BudgetLimit{Soft: 1000},
+					},
+					Upstream: &metadata.UpstreamSingleOrMultiple{
+						Upstream: &metadata.Upstream{OpenAI: metadata.UpstreamOpenAI{BaseURL: "baseURL"}},
+					},
+					Teams: []configs.Team{
+						{
+							ID: "team",
+							Groups: []configs.Group{
+								{
+									ID:        "outer",
+									RateLimit: &configs.RateLimit{TokensPerMinute: 50000},
+									Groups: []configs.Group{
+										{
+											ID:        "inner",
+											RateLimit: &configs.RateLimit{},
+											Endpoints: []configs.Endpoint{
+												{
+													ID:        "endpoint",
+													Alias:     "chat",
+													APIKey:    "apiKey",
+													RateLimit: &configs.RateLimit{RequestsPerMinute: 60},
+													Budget:    &configs.Budget{Monthly: &configs.BudgetLimit{Hard: 50}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []ratelimits.Rule{
+		{Scope: "tenant:tenant", RequestsPerMinute: 600, TokensPerMinute: 100000},
+		{Scope: "group:outer", TokensPerMinute: 50000},
+		{Scope: "endpoint:endpoint", RequestsPerMinute: 60},
+	}
+
+	expectedBudgets := []budgets.Rule{
+		{Scope: "tenant:tenant", Window: budgets.WindowDaily, Hard: 10, Soft: 8},
+		{Scope: "tenant:tenant", Window: budgets.WindowLifetime, Soft: 1000},
+		{Scope: "endpoint:endpoint", Window: budgets.WindowMonthly, Hard: 50},
+	}
+
+	endpoint, err := s.FindOneByAPIKey(context.Background(), "apiKey")
+	require.NoError(t, err)
+	assert.Equal(t, expected, endpoint.RateLimits)
+	assert.Equal(t, expectedBudgets, endpoint.Budgets)
+
+	endpoint, err = s.FindOneByAlias(context.Background(), "chat")
+	require.NoError(t, err)
+	assert.Equal(t, expected, endpoint.RateLimits)
+
+	endpoint, err = s.FindOneByHierarchy(context.Background(), "tenant", "team", "inner")
+	require.NoError(t, err)
+	assert.Equal(t, expected[:2], endpoint.RateLimits)
+	assert.Equal(t, expectedBudgets[:2], endpoint.Budgets)
+
+	assert.True(t, s.Config.HasRateLimits())
+	assert.True(t, s.Config.HasBudgets())
+	assert.False(t, (&configs.Routes{Tenants: []configs.Tenant{{ID: "tenant"}}}).HasRateLimits())
+}
+
+func TestConfigEndpointProvider_Priority(t *testing.T) {
+	s := &ConfigEndpointProvider{
+		Config: &configs.Routes{
+			Tenants: []configs.Tenant{
+				{
+					ID: "tenant",
+					Teams: []configs.Team{
+						{
+							ID: "team",
+							Groups: []configs.Group{
+								{
+									ID: "group",
+									Endpoints: []configs.Endpoint{
+										{
+											ID:       "backfill",
+											APIKey:   "backfillAPIKey",
+											Priority: &configs.Priority{Class: "batch", Permitted: []string{"standard", "urgent"}},
+										},
+										{
+											ID:     "default",
+											APIKey: "defaultAPIKey",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	endpoint, err := s.FindOneByAPIKey(context.Background(), "backfillAPIKey")
+	require.NoError(t, err)
+	assert.Equal(t, scheduling.Policy{Class: scheduling.ClassBatch, Permitted: []scheduling.Class{scheduling.ClassStandard}}, endpoint.Priority)
+
+	endpoint, err = s.FindOneByAPIKey(context.Background(), "defaultAPIKey")
+	require.NoError(t, err)
+	assert.Equal(t, scheduling.Policy{}, endpoint.Priority)
+}
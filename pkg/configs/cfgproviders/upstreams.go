@@ -0,0 +1,37 @@
+package authstorage
+
+import (
+	"context"
+
+	"github.com/lingticio/llmg/pkg/types/metadata"
+)
+
+// maxGroupDepth bounds how deep groups are walked up when looking up the
+// upstreams of nested groups, in case the groups stored are nested in a
+// cycle.
+const maxGroupDepth = 32
+
+// EndpointProviderUpstreamExplainable explains the upstream of endpoints,
+// i.e. how it is merged from the overrides of the tenant, team and groups,
+// nested ones included, of the endpoint, and of the endpoint itself.
+type EndpointProviderUpstreamExplainable interface {
+	// ExplainUpstream returns ErrEndpointNotFound when the endpoint does not
+	// exist.
+	ExplainUpstream(ctx context.Context, endpointID string) (*metadata.EffectiveUpstream, error)
+}
+
+func tenantLayer(tenantID string, upstream *metadata.UpstreamSingleOrMultiple) metadata.UpstreamLayer {
+	return metadata.UpstreamLayer{Source: "tenant:" + tenantID, Upstream: upstream}
+}
+
+func teamLayer(teamID string, upstream *metadata.UpstreamSingleOrMultiple) metadata.UpstreamLayer {
+	return metadata.UpstreamLayer{Source: "team:" + teamID, Upstream: upstream}
+}
+
+func groupLayer(groupID string, upstream *metadata.UpstreamSingleOrMultiple) metadata.UpstreamLayer {
+	return metadata.UpstreamLayer{Source: "group:" + groupID, Upstream: upstream}
+}
+
+func endpointLayer(endpointID string, upstream *metadata.UpstreamSingleOrMultiple) metadata.UpstreamLayer {
+	return metadata.UpstreamLayer{Source: "endpoint:" + endpointID, Upstream: upstream}
+}
@@ -0,0 +1,61 @@
+package exactcache
+
+import (
+	"context"
+	"strings"
+)
+
+// Control is what a request allows of the caches, as requested with the
+// Cache-Control header.
+type Control struct {
+	// NoCache, requested by no-cache, refreshes the cache: the answer is
+	// not served from the cache, but cached.
+	NoCache bool
+	// NoStore, requested by no-store, bypasses the cache: the answer is
+	// neither served from the cache nor cached.
+	NoStore bool
+}
+
+// ParseControl parses the directives of the values of the Cache-Control
+// header, the ones other than no-cache and no-store are ignored.
+func ParseControl(values ...string) Control {
+	var control Control
+
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			switch strings.ToLower(strings.TrimSpace(directive)) {
+			case "no-cache":
+				control.NoCache = true
+			case "no-store":
+				control.NoStore = true
+			}
+		}
+	}
+
+	return control
+}
+
+// Lookup reports whether the answer may be served from the cache.
+func (c Control) Lookup() bool {
+	return !c.NoCache && !c.NoStore
+}
+
+// Store reports whether the answer may be cached.
+func (c Control) Store() bool {
+	return !c.NoStore
+}
+
+type controlContextKey struct{}
+
+// WithControl attaches the control of the request, e.g. parsed from the
+// Cache-Control header, to ctx.
+func WithControl(ctx context.Context, control Control) context.Context {
+	return context.WithValue(ctx, controlContextKey{}, control)
+}
+
+// ControlFromContext returns the control attached by WithControl, which
+// allows everything when none was attached.
+func ControlFromContext(ctx context.Context) Control {
+	control, _ := ctx.Value(controlContextKey{}).(Control)
+	return control
+}
@@ -0,0 +1,117 @@
+// Package exactcache serves deterministic chat completions, i.e. of a
+// temperature of 0 or of a seed, from the answers cached for the exact same
+// requests.
+package exactcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+)
+
+// ZeroTemperature is the temperature sent for requests of a temperature of
+// 0, which would be omitted, and defaulted to 1 by the upstreams, as the
+// zero value of the temperature of the requests otherwise.
+const ZeroTemperature float32 = math.SmallestNonzeroFloat32
+
+// Temperature returns the temperature to send for a temperature requested
+// explicitly, i.e. ZeroTemperature for 0.
+func Temperature(temperature float32) float32 {
+	if temperature == 0 {
+		return ZeroTemperature
+	}
+
+	return temperature
+}
+
+// Policy is the exact caching of the chat completions of an endpoint.
+type Policy struct {
+	// TTL is how long answers are cached, the default of the cache when 0.
+	TTL time.Duration
+}
+
+// Deterministic reports whether request is of a temperature of 0, or of a
+// seed, and may then be answered from the cache. Requests of log
+// probabilities are not, as they are not replayed.
+func Deterministic(request openai.ChatCompletionRequest) bool {
+	return (request.Seed != nil || request.Temperature == ZeroTemperature) && !request.LogProbs
+}
+
+// canonicalRequest is what identifies the answers of a request, its other
+// fields, e.g. stream or user, do not change them.
+type canonicalRequest struct {
+	Model               string                               `json:"model"`
+	Messages            []openai.ChatCompletionMessage       `json:"messages"`
+	Tools               []openai.Tool                        `json:"tools"`
+	ToolChoice          any                                  `json:"tool_choice"`
+	ParallelToolCalls   any                                  `json:"parallel_tool_calls"`
+	Functions           []openai.FunctionDefinition          `json:"functions"`
+	FunctionCall        any                                  `json:"function_call"`
+	ResponseFormat      *openai.ChatCompletionResponseFormat `json:"response_format"`
+	Seed                *int                                 `json:"seed"`
+	Temperature         float32                              `json:"temperature"`
+	TopP                float32                              `json:"top_p"`
+	N                   int                                  `json:"n"`
+	MaxTokens           int                                  `json:"max_tokens"`
+	MaxCompletionTokens int                                  `json:"max_completion_tokens"`
+	Stop                []string                             `json:"stop"`
+	PresencePenalty     float32                              `json:"presence_penalty"`
+	FrequencyPenalty    float32                              `json:"frequency_penalty"`
+	LogitBias           map[string]int                       `json:"logit_bias"`
+}
+
+// Key returns the key of the answers of request, the hex SHA-256 digest of
+// its model, messages, tools, response format, seed and sampling parameters
+// encoded canonically, i.e. as JSON with the keys of the maps sorted.
+func Key(request openai.ChatCompletionRequest) (string, error) {
+	b, err := json.Marshal(canonicalRequest{
+		Model:               request.Model,
+		Messages:            request.Messages,
+		Tools:               request.Tools,
+		ToolChoice:          request.ToolChoice,
+		ParallelToolCalls:   request.ParallelToolCalls,
+		Functions:           request.Functions,
+		FunctionCall:        request.FunctionCall,
+		ResponseFormat:      request.ResponseFormat,
+		Seed:                request.Seed,
+		Temperature:         request.Temperature,
+		TopP:                request.TopP,
+		N:                   request.N,
+		MaxTokens:           request.MaxTokens,
+		MaxCompletionTokens: request.MaxCompletionTokens,
+		Stop:                request.Stop,
+		PresencePenalty:     request.PresencePenalty,
+		FrequencyPenalty:    request.FrequencyPenalty,
+		LogitBias:           request.LogitBias,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(b)
+
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// AcceptsResponse reports whether response may be cached, i.e. every choice
+// of it finished on its own or on tool calls, rather than on the length
+// limit or the content filter.
+func AcceptsResponse(response openai.ChatCompletionResponse) bool {
+	if len(response.Choices) == 0 {
+		return false
+	}
+
+	return lo.EveryBy(response.Choices, func(item openai.ChatCompletionChoice) bool {
+		switch item.FinishReason {
+		case openai.FinishReasonStop, openai.FinishReasonToolCalls, openai.FinishReasonFunctionCall:
+			return true
+		default:
+			return false
+		}
+	})
+}
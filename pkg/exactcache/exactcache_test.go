@@ -0,0 +1,65 @@
+package exactcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterministic(t *testing.T) {
+	assert.False(t, Deterministic(openai.ChatCompletionRequest{}))
+	assert.False(t, Deterministic(openai.ChatCompletionRequest{Temperature: 0.7}))
+	assert.True(t, Deterministic(openai.ChatCompletionRequest{Temperature: Temperature(0)}))
+	assert.True(t, Deterministic(openai.ChatCompletionRequest{Temperature: 0.7, Seed: lo.ToPtr(42)}))
+	assert.False(t, Deterministic(openai.ChatCompletionRequest{Seed: lo.ToPtr(42), LogProbs: true}))
+}
+
+func TestKey(t *testing.T) {
+	request := openai.ChatCompletionRequest{
+		Model:       "gpt-4o-mini",
+		Messages:    []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello"}},
+		Temperature: ZeroTemperature,
+		LogitBias:   map[string]int{"1": 1, "2": -1},
+	}
+
+	key, err := Key(request)
+	require.NoError(t, err)
+
+	// Neither streaming nor the user change the answers.
+	streamed := request
+	streamed.Stream = true
+	streamed.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+	streamed.User = "user"
+
+	streamedKey, err := Key(streamed)
+	require.NoError(t, err)
+	assert.Equal(t, key, streamedKey)
+
+	seeded := request
+	seeded.Seed = lo.ToPtr(1)
+
+	seededKey, err := Key(seeded)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, seededKey)
+}
+
+func TestParseControl(t *testing.T) {
+	assert.Equal(t, Control{}, ParseControl())
+	assert.True(t, ParseControl("max-age=0").Lookup())
+
+	control := ParseControl("No-Cache, max-age=0")
+	assert.False(t, control.Lookup())
+	assert.True(t, control.Store())
+
+	control = ParseControl("private", "no-store")
+	assert.False(t, control.Lookup())
+	assert.False(t, control.Store())
+
+	ctx := WithControl(context.Background(), control)
+	assert.Equal(t, control, ControlFromContext(ctx))
+	assert.Equal(t, Control{}, ControlFromContext(context.Background()))
+}
@@ -0,0 +1,35 @@
+package exactcache
+
+import (
+	"net/http"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+const (
+	// HeaderCacheControl requests to refresh or to bypass the caches.
+	HeaderCacheControl = "cache-control"
+	// HeaderCache is hit when the answer was served from the exact cache,
+	// miss when it was not but is cached, and refresh when it was not as
+	// requested by no-cache.
+	HeaderCache = "x-llmg-exact-cache"
+)
+
+const (
+	CacheHit     = "hit"
+	CacheMiss    = "miss"
+	CacheRefresh = "refresh"
+)
+
+func init() {
+	headers.Register(HeaderCache)
+}
+
+// CacheHeader returns the x-llmg-exact-cache header of status, one of hit,
+// miss and refresh.
+func CacheHeader(status string) http.Header {
+	header := make(http.Header)
+	header.Set(HeaderCache, status)
+
+	return header
+}
@@ -0,0 +1,61 @@
+package exactcache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+)
+
+// Cache keeps the answers of the deterministic chat completions of the
+// endpoints in Redis, by their Key.
+type Cache struct {
+	rueidis rueidis.Client
+}
+
+func NewCache() func(client rueidis.Client) *Cache {
+	return func(client rueidis.Client) *Cache {
+		return &Cache{rueidis: client}
+	}
+}
+
+// Get returns the answer cached for the key of a request of the endpoint,
+// nil when none is.
+func (c *Cache) Get(ctx context.Context, endpointID string, key string) (*openai.ChatCompletionResponse, error) {
+	cmd := c.rueidis.B().Get().Key(rediskeys.ExactCacheResponseByKey2.Format(endpointID, key)).Build()
+
+	b, err := c.rueidis.Do(ctx, cmd).AsBytes()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var response openai.ChatCompletionResponse
+
+	err = json.Unmarshal(b, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Set caches response, the answer of the request of the endpoint of key, for
+// ttl.
+func (c *Cache) Set(ctx context.Context, endpointID string, key string, response openai.ChatCompletionResponse, ttl time.Duration) error {
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	cmd := c.rueidis.B().Set().Key(rediskeys.ExactCacheResponseByKey2.Format(endpointID, key)).Value(rueidis.BinaryString(b)).Px(ttl).Build()
+
+	return c.rueidis.Do(ctx, cmd).Error()
+}
@@ -0,0 +1,45 @@
+package exactcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(r.Close)
+
+	cache := NewCache()(r)
+	endpointID := t.Name() + "-" + time.Now().Format(time.RFC3339Nano)
+
+	cached, err := cache.Get(context.Background(), endpointID, "key")
+	require.NoError(t, err)
+	assert.Nil(t, cached)
+
+	response := openai.ChatCompletionResponse{ID: "chatcmpl-1", Model: "gpt-4o-mini", Choices: []openai.ChatCompletionChoice{
+		{Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "Hello!"}, FinishReason: openai.FinishReasonStop},
+	}}
+
+	err = cache.Set(context.Background(), endpointID, "key", response, time.Minute)
+	require.NoError(t, err)
+
+	cached, err = cache.Get(context.Background(), endpointID, "key")
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, response, *cached)
+
+	// The answers of the other endpoints are kept apart.
+	cached, err = cache.Get(context.Background(), endpointID+"-other", "key")
+	require.NoError(t, err)
+	assert.Nil(t, cached)
+}
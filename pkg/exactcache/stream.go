@@ -0,0 +1,186 @@
+package exactcache
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+)
+
+const objectChatCompletionChunk = "chat.completion.chunk"
+
+// splitWords splits s before each of its words, keeping the whitespaces
+// preceding them, so that the pieces add up to s.
+func splitWords(s string) []string {
+	var (
+		pieces []string
+		start  int
+		space  bool
+	)
+
+	for i, r := range s {
+		if i > start && space && !unicode.IsSpace(r) {
+			pieces = append(pieces, s[start:i])
+			start = i
+		}
+
+		space = unicode.IsSpace(r)
+	}
+	if start < len(s) {
+		pieces = append(pieces, s[start:])
+	}
+
+	return pieces
+}
+
+// Replay returns the chunks a stream of response would be made of, as an
+// upstream streams them: for each choice, a chunk of its role, a chunk per
+// word of its content, a chunk per tool call, then a chunk of its finish
+// reason. The last chunk reports the usage of response when includeUsage is
+// set, as requested by stream_options.
+func Replay(response openai.ChatCompletionResponse, includeUsage bool) []openai.ChatCompletionStreamResponse {
+	chunk := func(choice openai.ChatCompletionStreamChoice) openai.ChatCompletionStreamResponse {
+		return openai.ChatCompletionStreamResponse{
+			ID:                response.ID,
+			Object:            objectChatCompletionChunk,
+			Created:           response.Created,
+			Model:             response.Model,
+			SystemFingerprint: response.SystemFingerprint,
+			Choices:           []openai.ChatCompletionStreamChoice{choice},
+		}
+	}
+
+	var chunks []openai.ChatCompletionStreamResponse
+
+	for _, choice := range response.Choices {
+		chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+			Index: choice.Index,
+			Delta: openai.ChatCompletionStreamChoiceDelta{Role: lo.CoalesceOrEmpty(choice.Message.Role, openai.ChatMessageRoleAssistant)},
+		}))
+
+		for _, piece := range splitWords(choice.Message.Content) {
+			chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+				Index: choice.Index,
+				Delta: openai.ChatCompletionStreamChoiceDelta{Content: piece},
+			}))
+		}
+
+		if choice.Message.Refusal != "" {
+			chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+				Index: choice.Index,
+				Delta: openai.ChatCompletionStreamChoiceDelta{Refusal: choice.Message.Refusal},
+			}))
+		}
+		if choice.Message.FunctionCall != nil {
+			chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+				Index: choice.Index,
+				Delta: openai.ChatCompletionStreamChoiceDelta{FunctionCall: choice.Message.FunctionCall},
+			}))
+		}
+
+		for i, toolCall := range choice.Message.ToolCalls {
+			toolCall.Index = lo.ToPtr(i)
+
+			chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+				Index: choice.Index,
+				Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{toolCall}},
+			}))
+		}
+
+		chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+			Index:        choice.Index,
+			FinishReason: choice.FinishReason,
+		}))
+	}
+
+	if includeUsage {
+		last := chunk(openai.ChatCompletionStreamChoice{})
+		last.Choices = []openai.ChatCompletionStreamChoice{}
+		last.Usage = lo.ToPtr(response.Usage)
+
+		chunks = append(chunks, last)
+	}
+
+	return chunks
+}
+
+// Accumulator assembles the response of the chunks of a stream, e.g. to
+// cache it once the stream is done.
+type Accumulator struct {
+	response openai.ChatCompletionResponse
+	contents []*strings.Builder
+}
+
+// Add adds the next chunk of the stream.
+func (a *Accumulator) Add(chunk openai.ChatCompletionStreamResponse) {
+	a.response.ID = lo.CoalesceOrEmpty(a.response.ID, chunk.ID)
+	a.response.Created = lo.CoalesceOrEmpty(a.response.Created, chunk.Created)
+	a.response.Model = lo.CoalesceOrEmpty(a.response.Model, chunk.Model)
+	a.response.SystemFingerprint = lo.CoalesceOrEmpty(a.response.SystemFingerprint, chunk.SystemFingerprint)
+
+	if chunk.Usage != nil {
+		a.response.Usage = *chunk.Usage
+	}
+
+	for _, delta := range chunk.Choices {
+		for len(a.response.Choices) <= delta.Index {
+			a.response.Choices = append(a.response.Choices, openai.ChatCompletionChoice{Index: len(a.response.Choices)})
+			a.contents = append(a.contents, &strings.Builder{})
+		}
+
+		choice := &a.response.Choices[delta.Index]
+		if delta.Delta.Role != "" {
+			choice.Message.Role = delta.Delta.Role
+		}
+		if delta.FinishReason != "" {
+			choice.FinishReason = delta.FinishReason
+		}
+
+		a.contents[delta.Index].WriteString(delta.Delta.Content)
+		choice.Message.Refusal += delta.Delta.Refusal
+
+		if delta.Delta.FunctionCall != nil {
+			if choice.Message.FunctionCall == nil {
+				choice.Message.FunctionCall = &openai.FunctionCall{}
+			}
+
+			choice.Message.FunctionCall.Name += delta.Delta.FunctionCall.Name
+			choice.Message.FunctionCall.Arguments += delta.Delta.FunctionCall.Arguments
+		}
+
+		for i, toolCall := range delta.Delta.ToolCalls {
+			index := lo.FromPtrOr(toolCall.Index, i)
+			for len(choice.Message.ToolCalls) <= index {
+				choice.Message.ToolCalls = append(choice.Message.ToolCalls, openai.ToolCall{})
+			}
+
+			call := &choice.Message.ToolCalls[index]
+			call.ID = lo.CoalesceOrEmpty(call.ID, toolCall.ID)
+			call.Type = lo.CoalesceOrEmpty(call.Type, toolCall.Type)
+			call.Function.Name += toolCall.Function.Name
+			call.Function.Arguments += toolCall.Function.Arguments
+		}
+	}
+}
+
+// Response returns the response of the chunks added so far.
+func (a *Accumulator) Response() openai.ChatCompletionResponse {
+	response := a.response
+	response.Object = "chat.completion"
+	response.Choices = make([]openai.ChatCompletionChoice, len(a.response.Choices))
+
+	for i, choice := range a.response.Choices {
+		choice.Message.Content = a.contents[i].String()
+		if len(choice.Message.ToolCalls) > 0 {
+			choice.Message.ToolCalls = lo.Map(choice.Message.ToolCalls, func(item openai.ToolCall, _ int) openai.ToolCall {
+				item.Index = nil
+				return item
+			})
+		}
+
+		response.Choices[i] = choice
+	}
+
+	return response
+}
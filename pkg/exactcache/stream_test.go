@@ -0,0 +1,73 @@
+package exactcache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	response := openai.ChatCompletionResponse{
+		ID:      "chatcmpl-1",
+		Object:  "chat.completion",
+		Created: 1700000000,
+		Model:   "gpt-4o-mini",
+		Choices: []openai.ChatCompletionChoice{
+			{
+				Index:        0,
+				Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "ChatGPT was released on\nNovember 30, 2022."},
+				FinishReason: openai.FinishReasonStop,
+			},
+			{
+				Index: 1,
+				Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, ToolCalls: []openai.ToolCall{
+					{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "search", Arguments: `{"q":"chatgpt"}`}},
+				}},
+				FinishReason: openai.FinishReasonToolCalls,
+			},
+		},
+		Usage: openai.Usage{PromptTokens: 10, CompletionTokens: 12, TotalTokens: 22},
+	}
+
+	chunks := Replay(response, true)
+
+	// The content is streamed word by word.
+	var contents []string
+
+	for _, chunk := range chunks {
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			contents = append(contents, chunk.Choices[0].Delta.Content)
+		}
+	}
+
+	assert.Equal(t, []string{"ChatGPT ", "was ", "released ", "on\n", "November ", "30, ", "2022."}, contents)
+	assert.Equal(t, "ChatGPT was released on\nNovember 30, 2022.", strings.Join(contents, ""))
+
+	last := chunks[len(chunks)-1]
+	assert.Empty(t, last.Choices)
+	require.NotNil(t, last.Usage)
+	assert.Equal(t, 22, last.Usage.TotalTokens)
+
+	var accumulator Accumulator
+	for _, chunk := range chunks {
+		assert.Equal(t, "chat.completion.chunk", chunk.Object)
+		accumulator.Add(chunk)
+	}
+
+	assert.Equal(t, response, accumulator.Response())
+
+	assert.Nil(t, Replay(response, false)[len(chunks)-2].Usage)
+}
+
+func TestAcceptsResponse(t *testing.T) {
+	assert.True(t, AcceptsResponse(openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+		{FinishReason: openai.FinishReasonToolCalls},
+	}}))
+	assert.False(t, AcceptsResponse(openai.ChatCompletionResponse{}))
+	assert.False(t, AcceptsResponse(openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+		{FinishReason: openai.FinishReasonLength},
+	}}))
+}
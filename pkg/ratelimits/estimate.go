@@ -0,0 +1,108 @@
+package ratelimits
+
+import (
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	// bytesPerToken is roughly what a token of English text takes, and
+	// overestimates the tokens of text in most other languages little.
+	bytesPerToken = 4
+	// messageTokens is what the role and the delimiters of each message
+	// cost, and the priming of the reply.
+	messageTokens = 3
+	// imageTokens is what an image costs in low detail, images in high
+	// detail cost more, which is corrected afterwards.
+	imageTokens = 85
+)
+
+// EstimateTextTokens estimates the tokens of text.
+func EstimateTextTokens(text string) int64 {
+	return int64((len(text) + bytesPerToken - 1) / bytesPerToken)
+}
+
+// EstimateChatCompletionTokens estimates the tokens of the prompt of the
+// request, and the tokens it may complete, without a tokenizer. It is only
+// meant to reserve tokens before the request is sent, until the usage
+// reported by the upstream corrects it.
+func EstimateChatCompletionTokens(request openai.ChatCompletionRequest) int64 {
+	return EstimateChatCompletionPromptTokens(request) + EstimateChatCompletionMaxTokens(request)
+}
+
+// EstimateChatCompletionMaxTokens returns the tokens the request may
+// complete at most, for all of its choices, which is 0 when the request does
+// not bound them.
+func EstimateChatCompletionMaxTokens(request openai.ChatCompletionRequest) int64 {
+	maxTokens := request.MaxCompletionTokens
+	if maxTokens == 0 {
+		maxTokens = request.MaxTokens
+	}
+
+	return int64(maxTokens * max(request.N, 1))
+}
+
+// EstimateChatCompletionPromptTokens is EstimateChatCompletionTokens without
+// the tokens the request may complete.
+func EstimateChatCompletionPromptTokens(request openai.ChatCompletionRequest) int64 {
+	var tokens int64 = messageTokens
+
+	for _, message := range request.Messages {
+		tokens += messageTokens + EstimateTextTokens(message.Content) + EstimateTextTokens(message.Name)
+
+		for _, part := range message.MultiContent {
+			switch part.Type {
+			case openai.ChatMessagePartTypeText:
+				tokens += EstimateTextTokens(part.Text)
+			case openai.ChatMessagePartTypeImageURL:
+				tokens += imageTokens
+			}
+		}
+
+		for _, toolCall := range message.ToolCalls {
+			tokens += EstimateTextTokens(toolCall.Function.Name) + EstimateTextTokens(toolCall.Function.Arguments)
+		}
+
+		if message.FunctionCall != nil {
+			tokens += EstimateTextTokens(message.FunctionCall.Name) + EstimateTextTokens(message.FunctionCall.Arguments)
+		}
+	}
+
+	return tokens
+}
+
+// EstimateEmbeddingsTokens estimates the tokens of the inputs of the
+// request, inputs already tokenized are counted exactly.
+func EstimateEmbeddingsTokens(request openai.EmbeddingRequest) int64 {
+	switch input := request.Input.(type) {
+	case string:
+		return EstimateTextTokens(input)
+	case []string:
+		var tokens int64
+		for _, text := range input {
+			tokens += EstimateTextTokens(text)
+		}
+
+		return tokens
+	case []int:
+		return int64(len(input))
+	case [][]int:
+		var tokens int64
+		for _, ids := range input {
+			tokens += int64(len(ids))
+		}
+
+		return tokens
+	case []any:
+		var tokens int64
+		for _, item := range input {
+			tokens += EstimateEmbeddingsTokens(openai.EmbeddingRequest{Input: item})
+		}
+
+		return tokens
+	case float64:
+		// A token ID decoded from JSON.
+		return 1
+	default:
+		return 0
+	}
+}
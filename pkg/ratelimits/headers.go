@@ -0,0 +1,54 @@
+package ratelimits
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+const (
+	HeaderLimitRequests     = "x-ratelimit-limit-requests"
+	HeaderRemainingRequests = "x-ratelimit-remaining-requests"
+	HeaderResetRequests     = "x-ratelimit-reset-requests"
+	HeaderLimitTokens       = "x-ratelimit-limit-tokens"
+	HeaderRemainingTokens   = "x-ratelimit-remaining-tokens"
+	HeaderResetTokens       = "x-ratelimit-reset-tokens"
+	HeaderRetryAfter        = "retry-after"
+)
+
+func init() {
+	headers.Register(
+		HeaderLimitRequests,
+		HeaderRemainingRequests,
+		HeaderResetRequests,
+		HeaderLimitTokens,
+		HeaderRemainingTokens,
+		HeaderResetTokens,
+		HeaderRetryAfter,
+	)
+}
+
+// Header returns the x-ratelimit-* headers of the decision, as OpenAI
+// reports them, and Retry-After, in seconds, when the request is rejected.
+func (d Decision) Header() http.Header {
+	header := make(http.Header)
+
+	if d.Requests != nil {
+		header.Set(HeaderLimitRequests, strconv.FormatInt(d.Requests.Limit, 10))
+		header.Set(HeaderRemainingRequests, strconv.FormatInt(d.Requests.Remaining, 10))
+		header.Set(HeaderResetRequests, d.Requests.Reset.Round(time.Millisecond).String())
+	}
+	if d.Tokens != nil {
+		header.Set(HeaderLimitTokens, strconv.FormatInt(d.Tokens.Limit, 10))
+		header.Set(HeaderRemainingTokens, strconv.FormatInt(d.Tokens.Remaining, 10))
+		header.Set(HeaderResetTokens, d.Tokens.Reset.Round(time.Millisecond).String())
+	}
+	if !d.Allowed {
+		header.Set(HeaderRetryAfter, strconv.FormatInt(max(int64(math.Ceil(d.RetryAfter.Seconds())), 1), 10))
+	}
+
+	return header
+}
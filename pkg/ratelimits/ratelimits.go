@@ -0,0 +1,330 @@
+// Package ratelimits limits the requests and the tokens per minute of
+// tenants, teams, groups and endpoints across the replicas of the gateway,
+// with the generic cell rate algorithm (GCRA) run atomically in Redis.
+package ratelimits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+// period is what the limits are declared per. It is also the burst the
+// limits tolerate, i.e. the whole limit may be consumed at once.
+const period = time.Minute
+
+var (
+	ErrExceeded = errors.New("rate limit exceeded")
+)
+
+// Rule is the limits shared by the requests of a tenant, team, group or
+// endpoint, limits of 0 are not enforced.
+type Rule struct {
+	// Scope identifies who shares the limits, e.g. tenant:acme or
+	// endpoint:chat.
+	Scope             string `json:"scope" yaml:"scope"`
+	RequestsPerMinute int64  `json:"requestsPerMinute,omitempty" yaml:"requestsPerMinute,omitempty"`
+	TokensPerMinute   int64  `json:"tokensPerMinute,omitempty" yaml:"tokensPerMinute,omitempty"`
+}
+
+// Quota is what is left of the most restrictive limit of the requests, or
+// of the tokens.
+type Quota struct {
+	Limit     int64
+	Remaining int64
+	// Reset is how long until the limit is fully replenished.
+	Reset time.Duration
+}
+
+// Decision is whether a request is allowed by the rules of its endpoint.
+type Decision struct {
+	Allowed bool
+	// RetryAfter is how long until the request would be allowed, when it is
+	// not.
+	RetryAfter time.Duration
+	// Requests and Tokens are nil when no rule limits them.
+	Requests *Quota
+	Tokens   *Quota
+}
+
+// ExceededError rejects the requests exceeding any of the rules of their
+// endpoint, it matches ErrExceeded.
+type ExceededError struct {
+	Decision Decision
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrExceeded, e.Decision.RetryAfter.Round(time.Second))
+}
+
+func (e *ExceededError) Unwrap() error {
+	return ErrExceeded
+}
+
+const (
+	dimensionRequests = "requests"
+	dimensionTokens   = "tokens"
+)
+
+// acquireScript consumes the cost of every limit of KEYS, or of none of
+// them when any is exceeded. Each key holds the theoretical arrival time
+// (TAT) of its limit, in milliseconds.
+//
+// ARGV[1] is now, ARGV[2] the period, both in milliseconds, followed by
+// the emission interval, in milliseconds, and the cost of each limit.
+//
+// It returns whether the request is allowed, how long to wait until it
+// would be otherwise, followed by the remaining quota of each limit and how
+// long until it is fully replenished.
+var acquireScript = rueidis.NewLuaScript(`
+local now = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local tats = {}
+local retry_after = 0
+
+for i, key in ipairs(KEYS) do
+  local interval = tonumber(ARGV[2 * i + 1])
+  local cost = tonumber(ARGV[2 * i + 2])
+  local tat = tonumber(redis.call('GET', key) or '0')
+  if tat < now then
+    tat = now
+  end
+
+  local new_tat = tat + cost * interval
+  local wait = new_tat - period - now
+  if wait > retry_after then
+    retry_after = wait
+  end
+
+  tats[i] = { tat, new_tat }
+end
+
+local allowed = retry_after <= 0
+local result = { allowed and 1 or 0, math.ceil(retry_after) }
+
+for i, key in ipairs(KEYS) do
+  local interval = tonumber(ARGV[2 * i + 1])
+  local tat = tats[i][1]
+  if allowed then
+    tat = tats[i][2]
+    redis.call('SET', key, string.format('%.3f', tat), 'PX', math.ceil(tat - now) + 1)
+  end
+
+  table.insert(result, math.floor((period - (tat - now)) / interval))
+  table.insert(result, math.ceil(tat - now))
+end
+
+return result
+`)
+
+// adjustScript moves the TAT of each limit of KEYS by the milliseconds of
+// ARGV[i + 1], which are negative to give back what was consumed. ARGV[1]
+// is now, in milliseconds.
+var adjustScript = rueidis.NewLuaScript(`
+local now = tonumber(ARGV[1])
+
+for i, key in ipairs(KEYS) do
+  local tat = tonumber(redis.call('GET', key) or '0')
+  if tat < now then
+    tat = now
+  end
+
+  tat = tat + tonumber(ARGV[i + 1])
+  if tat <= now then
+    redis.call('DEL', key)
+  else
+    redis.call('SET', key, string.format('%.3f', tat), 'PX', math.ceil(tat - now) + 1)
+  end
+end
+
+return 0
+`)
+
+// limit is a limit of a rule, along with what a request costs it.
+type limit struct {
+	key       string
+	dimension string
+	perMinute int64
+	cost      int64
+}
+
+// interval is the milliseconds each unit of the limit takes to replenish.
+func (l limit) interval() float64 {
+	return float64(period.Milliseconds()) / float64(l.perMinute)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Limiter enforces rules in Redis. The nil Limiter allows every request.
+type Limiter struct {
+	rueidis rueidis.Client
+	now     func() time.Time
+}
+
+func NewLimiter() func(client rueidis.Client) *Limiter {
+	return func(client rueidis.Client) *Limiter {
+		return &Limiter{
+			rueidis: client,
+			now:     time.Now,
+		}
+	}
+}
+
+func (l *Limiter) limitsOf(tenantID string, rules []Rule, tokens int64) []limit {
+	limits := make([]limit, 0, 2*len(rules)) //nolint:mnd
+
+	for _, rule := range rules {
+		if rule.RequestsPerMinute > 0 {
+			limits = append(limits, limit{
+				key:       rediskeys.RateLimitByScope3.Format(tenantID, rule.Scope, dimensionRequests),
+				dimension: dimensionRequests,
+				perMinute: rule.RequestsPerMinute,
+				cost:      1,
+			})
+		}
+		if rule.TokensPerMinute > 0 {
+			limits = append(limits, limit{
+				key:       rediskeys.RateLimitByScope3.Format(tenantID, rule.Scope, dimensionTokens),
+				dimension: dimensionTokens,
+				perMinute: rule.TokensPerMinute,
+				// Requests estimated to cost more than the whole limit
+				// would never be allowed otherwise, they are allowed once
+				// the limit is fully replenished, and the difference is
+				// charged when corrected.
+				cost: min(max(tokens, 0), rule.TokensPerMinute),
+			})
+		}
+	}
+
+	return limits
+}
+
+// Acquire consumes a request, and the tokens it is estimated to cost, from
+// each of rules, which all belong to the tenant of tenantID, so that they
+// are checked atomically in the same slot on clusters. Requests exceeding
+// any of the rules consume nothing, and are rejected with an
+// *ExceededError. The headers of the decision are reported to the reporter
+// of ctx, see headers.WithReporter.
+//
+// The tokens estimated are corrected with Reservation.Correct once the
+// actual usage is known. The reservation is nil when no rule limits the
+// request.
+func (l *Limiter) Acquire(ctx context.Context, tenantID string, rules []Rule, tokens int64) (*Reservation, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	limits := l.limitsOf(tenantID, rules, tokens)
+	if len(limits) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(limits))
+	args := []string{
+		strconv.FormatInt(l.now().UnixMilli(), 10),
+		strconv.FormatInt(period.Milliseconds(), 10),
+	}
+
+	for _, limit := range limits {
+		keys = append(keys, limit.key)
+		args = append(args, formatFloat(limit.interval()), strconv.FormatInt(limit.cost, 10))
+	}
+
+	values, err := acquireScript.Exec(ctx, l.rueidis, keys, args).AsIntSlice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 2+2*len(limits) {
+		return nil, fmt.Errorf("unexpected reply of %d values from the rate limit script", len(values))
+	}
+
+	decision := Decision{
+		Allowed:    values[0] == 1,
+		RetryAfter: time.Duration(values[1]) * time.Millisecond,
+	}
+
+	for i, limit := range limits {
+		quota := Quota{
+			Limit:     limit.perMinute,
+			Remaining: min(max(values[2+2*i], 0), limit.perMinute),
+			Reset:     time.Duration(max(values[3+2*i], 0)) * time.Millisecond,
+		}
+
+		switch limit.dimension {
+		case dimensionRequests:
+			decision.Requests = mostRestrictive(decision.Requests, quota)
+		case dimensionTokens:
+			decision.Tokens = mostRestrictive(decision.Tokens, quota)
+		}
+	}
+
+	headers.Report(ctx, decision.Header())
+
+	if !decision.Allowed {
+		return nil, &ExceededError{Decision: decision}
+	}
+
+	return &Reservation{
+		limiter:  l,
+		limits:   limits,
+		Decision: decision,
+	}, nil
+}
+
+func mostRestrictive(current *Quota, quota Quota) *Quota {
+	if current == nil || quota.Remaining < current.Remaining ||
+		(quota.Remaining == current.Remaining && quota.Reset > current.Reset) {
+		return &quota
+	}
+
+	return current
+}
+
+// Reservation is what a request allowed by Limiter.Acquire consumed.
+type Reservation struct {
+	limiter *Limiter
+	limits  []limit
+
+	Decision Decision
+}
+
+// Correct charges the difference between the tokens the request actually
+// used and the tokens it was estimated to cost, which are given back when
+// the request used fewer tokens. It does nothing on the nil Reservation.
+func (r *Reservation) Correct(ctx context.Context, tokens int64) error {
+	if r == nil {
+		return nil
+	}
+
+	var keys []string
+
+	args := []string{strconv.FormatInt(r.limiter.now().UnixMilli(), 10)}
+
+	for _, limit := range r.limits {
+		if limit.dimension != dimensionTokens {
+			continue
+		}
+
+		delta := max(tokens, 0) - limit.cost
+		if delta == 0 {
+			continue
+		}
+
+		keys = append(keys, limit.key)
+		args = append(args, formatFloat(float64(delta)*limit.interval()))
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return adjustScript.Exec(ctx, r.limiter.rueidis, keys, args).Error()
+}
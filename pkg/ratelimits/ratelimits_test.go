@@ -0,0 +1,195 @@
+package ratelimits
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+func newTestLimiter(t *testing.T) (*Limiter, *time.Time) {
+	t.Helper()
+
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(r.Close)
+
+	now := time.Now()
+
+	limiter := NewLimiter()(r)
+	limiter.now = func() time.Time { return now }
+
+	return limiter, &now
+}
+
+// newTenantID keeps the limits of the tests apart from the ones of former
+// runs.
+func newTenantID(t *testing.T) string {
+	t.Helper()
+
+	return t.Name() + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+func TestLimiter_Acquire(t *testing.T) {
+	t.Run("Requests", func(t *testing.T) {
+		limiter, now := newTestLimiter(t)
+		tenantID := newTenantID(t)
+		rules := []Rule{{Scope: "endpoint:chat", RequestsPerMinute: 2}}
+
+		reservation, err := limiter.Acquire(context.Background(), tenantID, rules, 0)
+		require.NoError(t, err)
+		require.NotNil(t, reservation)
+		assert.Equal(t, int64(2), reservation.Decision.Requests.Limit)
+		assert.Equal(t, int64(1), reservation.Decision.Requests.Remaining)
+		assert.Nil(t, reservation.Decision.Tokens)
+
+		_, err = limiter.Acquire(context.Background(), tenantID, rules, 0)
+		require.NoError(t, err)
+
+		_, err = limiter.Acquire(context.Background(), tenantID, rules, 0)
+		require.ErrorIs(t, err, ErrExceeded)
+
+		var exceededErr *ExceededError
+		require.ErrorAs(t, err, &exceededErr)
+		assert.False(t, exceededErr.Decision.Allowed)
+		assert.Equal(t, 30*time.Second, exceededErr.Decision.RetryAfter)
+		assert.Equal(t, int64(0), exceededErr.Decision.Requests.Remaining)
+
+		*now = now.Add(30 * time.Second)
+
+		_, err = limiter.Acquire(context.Background(), tenantID, rules, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("AllOrNothing", func(t *testing.T) {
+		limiter, _ := newTestLimiter(t)
+		tenantID := newTenantID(t)
+		tenant := Rule{Scope: "tenant:" + tenantID, RequestsPerMinute: 1}
+		endpoint := Rule{Scope: "endpoint:chat", RequestsPerMinute: 2}
+
+		reservation, err := limiter.Acquire(context.Background(), tenantID, []Rule{tenant, endpoint}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), reservation.Decision.Requests.Limit)
+		assert.Equal(t, int64(0), reservation.Decision.Requests.Remaining)
+
+		_, err = limiter.Acquire(context.Background(), tenantID, []Rule{tenant, endpoint}, 0)
+		require.ErrorIs(t, err, ErrExceeded)
+
+		// The rejected request consumed nothing from the limits of the
+		// endpoint.
+		reservation, err = limiter.Acquire(context.Background(), tenantID, []Rule{endpoint}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), reservation.Decision.Requests.Remaining)
+	})
+
+	t.Run("Tokens", func(t *testing.T) {
+		limiter, _ := newTestLimiter(t)
+		tenantID := newTenantID(t)
+		rules := []Rule{{Scope: "endpoint:chat", TokensPerMinute: 100}}
+
+		reservation, err := limiter.Acquire(context.Background(), tenantID, rules, 80)
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), reservation.Decision.Tokens.Remaining)
+
+		// The request actually used fewer tokens than estimated.
+		require.NoError(t, reservation.Correct(context.Background(), 30))
+
+		reservation, err = limiter.Acquire(context.Background(), tenantID, rules, 60)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), reservation.Decision.Tokens.Remaining)
+
+		_, err = limiter.Acquire(context.Background(), tenantID, rules, 20)
+		require.ErrorIs(t, err, ErrExceeded)
+
+		var exceededErr *ExceededError
+		require.ErrorAs(t, err, &exceededErr)
+		assert.Equal(t, 6*time.Second, exceededErr.Decision.RetryAfter)
+
+		// The request actually used more tokens than estimated, which is
+		// charged afterwards.
+		require.NoError(t, reservation.Correct(context.Background(), 80))
+
+		_, err = limiter.Acquire(context.Background(), tenantID, rules, 0)
+		require.ErrorIs(t, err, ErrExceeded)
+	})
+
+	t.Run("Reporter", func(t *testing.T) {
+		limiter, _ := newTestLimiter(t)
+		tenantID := newTenantID(t)
+		rules := []Rule{{Scope: "endpoint:chat", RequestsPerMinute: 1, TokensPerMinute: 100}}
+
+		var reported http.Header
+
+		ctx := headers.WithReporter(context.Background(), func(header http.Header) {
+			reported = header
+		})
+
+		_, err := limiter.Acquire(ctx, tenantID, rules, 10)
+		require.NoError(t, err)
+		assert.Equal(t, "1", reported.Get(HeaderLimitRequests))
+		assert.Equal(t, "0", reported.Get(HeaderRemainingRequests))
+		assert.Equal(t, "100", reported.Get(HeaderLimitTokens))
+		assert.Equal(t, "90", reported.Get(HeaderRemainingTokens))
+		assert.Empty(t, reported.Get(HeaderRetryAfter))
+
+		_, err = limiter.Acquire(ctx, tenantID, rules, 10)
+		require.ErrorIs(t, err, ErrExceeded)
+		assert.Equal(t, "60", reported.Get(HeaderRetryAfter))
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		var limiter *Limiter
+
+		reservation, err := limiter.Acquire(context.Background(), "tenant", []Rule{{Scope: "endpoint:chat", RequestsPerMinute: 1}}, 0)
+		require.NoError(t, err)
+		assert.Nil(t, reservation)
+		require.NoError(t, reservation.Correct(context.Background(), 10))
+	})
+}
+
+func TestDecision_Header(t *testing.T) {
+	header := Decision{
+		Allowed:    false,
+		RetryAfter: 1500 * time.Millisecond,
+		Requests:   &Quota{Limit: 60, Remaining: 0, Reset: 2 * time.Second},
+	}.Header()
+
+	assert.Equal(t, "60", header.Get("X-Ratelimit-Limit-Requests"))
+	assert.Equal(t, "0", header.Get("X-Ratelimit-Remaining-Requests"))
+	assert.Equal(t, "2s", header.Get("X-Ratelimit-Reset-Requests"))
+	assert.Empty(t, header.Get("X-Ratelimit-Limit-Tokens"))
+	assert.Equal(t, "2", header.Get("Retry-After"))
+	assert.True(t, headers.IsRegistered("X-Ratelimit-Limit-Requests"))
+	assert.False(t, headers.IsRegistered("X-Api-Key"))
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, int64(3+3+3+16), EstimateChatCompletionTokens(openai.ChatCompletionRequest{
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, Content: "Hello, world"},
+		},
+		MaxTokens: 8,
+		N:         2,
+	}))
+	assert.Equal(t, int64(3+3+2), EstimateChatCompletionPromptTokens(openai.ChatCompletionRequest{
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, Content: "Hello, w"},
+		},
+		MaxCompletionTokens: 8,
+	}))
+
+	assert.Equal(t, int64(2), EstimateEmbeddingsTokens(openai.EmbeddingRequest{Input: "Hello, w"}))
+	assert.Equal(t, int64(3), EstimateEmbeddingsTokens(openai.EmbeddingRequest{Input: []string{"Hello, w", "!"}}))
+	assert.Equal(t, int64(3), EstimateEmbeddingsTokens(openai.EmbeddingRequest{Input: []any{[]any{1.0, 2.0}, 3.0}}))
+}
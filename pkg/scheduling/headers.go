@@ -0,0 +1,33 @@
+package scheduling
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+const (
+	// HeaderPriority requests a class, and responds with the class the
+	// request was scheduled in.
+	HeaderPriority  = "x-llmg-priority"
+	HeaderQueueWait = "x-llmg-queue-wait-ms"
+)
+
+func init() {
+	headers.Register(HeaderPriority, HeaderQueueWait)
+}
+
+// Header returns the x-llmg-priority and x-llmg-queue-wait-ms headers of
+// the ticket.
+func (t *Ticket) Header() http.Header {
+	header := make(http.Header)
+	if t == nil {
+		return header
+	}
+
+	header.Set(HeaderPriority, string(t.Class))
+	header.Set(HeaderQueueWait, strconv.FormatInt(t.Wait.Milliseconds(), 10))
+
+	return header
+}
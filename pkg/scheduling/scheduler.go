@@ -0,0 +1,377 @@
+package scheduling
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// pruneThreshold is how many flows a queue remembers the finish tag of
+// before forgetting the ones which have no say anymore.
+const pruneThreshold = 1024
+
+// Flow is what the capacity of an upstream is shared fairly between, the
+// requests of a tenant in a class.
+type Flow struct {
+	Tenant string
+	Class  Class
+}
+
+type waiter struct {
+	flow Flow
+	// start is the virtual start tag of the request, requests are
+	// dispatched by increasing start tags, then in order of arrival.
+	start float64
+	seq   uint64
+
+	enqueuedAt time.Time
+	wait       time.Duration
+	ready      chan struct{}
+	// index is the index of the waiter in the heap, -1 once dispatched.
+	index int
+}
+
+type waiters []*waiter
+
+func (w waiters) Len() int {
+	return len(w)
+}
+
+func (w waiters) Less(i, j int) bool {
+	if w[i].start != w[j].start {
+		return w[i].start < w[j].start
+	}
+
+	return w[i].seq < w[j].seq
+}
+
+func (w waiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *waiters) Push(x any) {
+	item := x.(*waiter) //nolint:forcetypeassert
+	item.index = len(*w)
+	*w = append(*w, item)
+}
+
+func (w *waiters) Pop() any {
+	old := *w
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	item.index = -1
+	*w = old[:len(old)-1]
+
+	return item
+}
+
+type classStats struct {
+	waiting    int
+	dispatched int64
+	totalWait  time.Duration
+	maxWait    time.Duration
+}
+
+// queue is the queue of an upstream, which dispatches its requests by
+// start-time fair queuing: the virtual start tag of a request is the
+// maximum of the virtual time and of the finish tag of the previous request
+// of its flow, its finish tag its start tag plus the inverse of the weight
+// of its class, and the virtual time is the start tag of the last request
+// dispatched. Each flow then gets a share of the capacity proportional to
+// the weight of its class, however many requests it queues.
+type queue struct {
+	capacity int
+	inFlight int
+
+	virtual float64
+	finish  map[Flow]float64
+	seq     uint64
+	waiters waiters
+
+	stats map[Class]*classStats
+}
+
+func newQueue(capacity int) *queue {
+	return &queue{
+		capacity: capacity,
+		finish:   make(map[Flow]float64),
+		stats: lo.SliceToMap(Classes, func(item Class) (Class, *classStats) {
+			return item, &classStats{}
+		}),
+	}
+}
+
+func (q *queue) enqueue(flow Flow, weight int, now time.Time) *waiter {
+	start := max(q.virtual, q.finish[flow])
+	q.finish[flow] = start + 1/float64(weight)
+	q.seq++
+
+	w := &waiter{
+		flow:       flow,
+		start:      start,
+		seq:        q.seq,
+		enqueuedAt: now,
+		ready:      make(chan struct{}),
+	}
+
+	heap.Push(&q.waiters, w)
+	q.stats[flow.Class].waiting++
+
+	return w
+}
+
+// dispatch dispatches the waiters as long as the upstream has capacity.
+func (q *queue) dispatch(now time.Time) {
+	for q.inFlight < q.capacity && len(q.waiters) > 0 {
+		w := heap.Pop(&q.waiters).(*waiter) //nolint:forcetypeassert
+		w.wait = now.Sub(w.enqueuedAt)
+		q.inFlight++
+		q.virtual = w.start
+
+		stats := q.stats[w.flow.Class]
+		stats.waiting--
+		stats.dispatched++
+		stats.totalWait += w.wait
+		stats.maxWait = max(stats.maxWait, w.wait)
+
+		close(w.ready)
+	}
+
+	q.prune()
+}
+
+// prune forgets the finish tags behind the virtual time, which the start
+// tags of the next requests of their flows do not depend on anymore.
+func (q *queue) prune() {
+	if len(q.finish) < pruneThreshold {
+		return
+	}
+
+	for flow, finish := range q.finish {
+		if finish <= q.virtual {
+			delete(q.finish, flow)
+		}
+	}
+}
+
+// Options configure a Scheduler.
+type Options struct {
+	// Capacities are the maximum numbers of requests in flight of the
+	// upstreams, by base URL. The requests of the other upstreams are not
+	// scheduled.
+	Capacities map[string]int
+	// Weights are the weights of the classes, DefaultWeights when not set.
+	Weights map[Class]int
+	// MaxWait bounds how long requests wait for capacity before failing
+	// with ErrQueueTimeout, they wait as long as their context otherwise.
+	MaxWait time.Duration
+}
+
+// Scheduler queues the requests of the upstreams of constrained capacity,
+// and dispatches them fairly across the tenants, and weighted by their
+// class, as capacity frees up. A nil Scheduler dispatches every request
+// immediately. Capacities are enforced per replica of the gateway.
+type Scheduler struct {
+	options Options
+
+	mutex  sync.Mutex
+	queues map[string]*queue
+	now    func() time.Time
+}
+
+func NewScheduler() func(options Options) *Scheduler {
+	return func(options Options) *Scheduler {
+		return &Scheduler{
+			options: options,
+			queues:  make(map[string]*queue),
+			now:     time.Now,
+		}
+	}
+}
+
+func (s *Scheduler) weightOf(class Class) int {
+	weight, ok := s.options.Weights[class]
+	if !ok {
+		weight = DefaultWeights[class]
+	}
+
+	return max(weight, 1)
+}
+
+// Ticket is a request dispatched to an upstream, which holds a share of
+// the capacity of the upstream until released.
+type Ticket struct {
+	Class Class
+	// Wait is how long the request was queued.
+	Wait time.Duration
+
+	scheduler *Scheduler
+	queue     *queue
+	once      sync.Once
+}
+
+// Release gives back the capacity held by the ticket, at most once. Nil
+// tickets, of requests which were not scheduled, are ignored.
+func (t *Ticket) Release() {
+	if t == nil {
+		return
+	}
+
+	t.once.Do(func() {
+		t.scheduler.mutex.Lock()
+		defer t.scheduler.mutex.Unlock()
+
+		t.queue.inFlight--
+		t.queue.dispatch(t.scheduler.now())
+	})
+}
+
+// Acquire waits until the request of flow may be sent to upstream, and
+// returns the ticket to release once it is done. It returns a nil ticket
+// when the capacity of upstream is not constrained, the error of ctx when it
+// is done first, and ErrQueueTimeout when the request waited MaxWait.
+func (s *Scheduler) Acquire(ctx context.Context, upstream string, flow Flow) (*Ticket, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	capacity := s.options.Capacities[upstream]
+	if capacity <= 0 {
+		return nil, nil
+	}
+
+	s.mutex.Lock()
+
+	q, ok := s.queues[upstream]
+	if !ok {
+		q = newQueue(capacity)
+		s.queues[upstream] = q
+	}
+
+	w := q.enqueue(flow, s.weightOf(flow.Class), s.now())
+	q.dispatch(s.now())
+
+	s.mutex.Unlock()
+
+	ticket := &Ticket{Class: flow.Class, scheduler: s, queue: q}
+
+	var timeout <-chan time.Time
+
+	if s.options.MaxWait > 0 {
+		timer := time.NewTimer(s.options.MaxWait)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	var err error
+
+	select {
+	case <-w.ready:
+		ticket.Wait = w.wait
+		return ticket, nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout:
+		err = fmt.Errorf("%w: waited %s for %s", ErrQueueTimeout, s.options.MaxWait, upstream)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if w.index >= 0 {
+		heap.Remove(&q.waiters, w.index)
+		q.stats[flow.Class].waiting--
+
+		return nil, err
+	}
+
+	// The request was dispatched meanwhile, its capacity is given to the
+	// next one.
+	q.inFlight--
+	q.dispatch(s.now())
+
+	return nil, err
+}
+
+// ClassStats are the statistics of the requests of a class to an upstream,
+// since the gateway started.
+type ClassStats struct {
+	Class Class
+	// Waiting is how many requests are queued, i.e. the depth of the
+	// queue.
+	Waiting    int
+	Dispatched int64
+	TotalWait  time.Duration
+	MaxWait    time.Duration
+}
+
+// AverageWait is how long the requests dispatched waited on average.
+func (s ClassStats) AverageWait() time.Duration {
+	if s.Dispatched == 0 {
+		return 0
+	}
+
+	return s.TotalWait / time.Duration(s.Dispatched)
+}
+
+// QueueStats are the statistics of the queue of an upstream.
+type QueueStats struct {
+	Upstream string
+	Capacity int
+	InFlight int
+	Classes  []ClassStats
+}
+
+// Stats returns the statistics of the queues of the upstreams of
+// constrained capacity, sorted by upstream.
+func (s *Scheduler) Stats() []QueueStats {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := make([]QueueStats, 0, len(s.options.Capacities))
+
+	for upstream, capacity := range s.options.Capacities {
+		if capacity <= 0 {
+			continue
+		}
+
+		q, ok := s.queues[upstream]
+		if !ok {
+			q = newQueue(capacity)
+		}
+
+		stats = append(stats, QueueStats{
+			Upstream: upstream,
+			Capacity: q.capacity,
+			InFlight: q.inFlight,
+			Classes: lo.Map(Classes, func(item Class, _ int) ClassStats {
+				return ClassStats{
+					Class:      item,
+					Waiting:    q.stats[item].waiting,
+					Dispatched: q.stats[item].dispatched,
+					TotalWait:  q.stats[item].totalWait,
+					MaxWait:    q.stats[item].maxWait,
+				}
+			}),
+		})
+	}
+
+	slices.SortFunc(stats, func(a, b QueueStats) int {
+		return cmp.Compare(a.Upstream, b.Upstream)
+	})
+
+	return stats
+}
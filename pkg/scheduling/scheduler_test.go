@@ -0,0 +1,190 @@
+package scheduling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUpstream = "https://api.openai.com/v1"
+
+func TestPolicyResolve(t *testing.T) {
+	class, err := Policy{}.Resolve("")
+	require.NoError(t, err)
+	assert.Equal(t, ClassStandard, class)
+
+	class, err = Policy{Class: ClassInteractive}.Resolve("Batch")
+	require.NoError(t, err)
+	assert.Equal(t, ClassBatch, class)
+
+	_, err = Policy{}.Resolve("interactive")
+	require.ErrorIs(t, err, ErrClassNotPermitted)
+
+	class, err = Policy{Permitted: []Class{ClassInteractive}}.Resolve("interactive")
+	require.NoError(t, err)
+	assert.Equal(t, ClassInteractive, class)
+
+	_, err = Policy{}.Resolve("urgent")
+	require.ErrorIs(t, err, ErrUnknownClass)
+}
+
+// waitingOf returns how many requests of class are queued for testUpstream.
+func waitingOf(s *Scheduler, class Class) int {
+	for _, queue := range s.Stats() {
+		if queue.Upstream != testUpstream {
+			continue
+		}
+
+		for _, stats := range queue.Classes {
+			if stats.Class == class {
+				return stats.Waiting
+			}
+		}
+	}
+
+	return 0
+}
+
+// enqueue queues the request of flow, once the ones queued before, and
+// sends flow to dispatched once it is dispatched, releasing its ticket right
+// away.
+func enqueue(t *testing.T, s *Scheduler, flow Flow, dispatched chan<- Flow) {
+	t.Helper()
+
+	waiting := waitingOf(s, flow.Class)
+
+	go func() {
+		ticket, err := s.Acquire(context.Background(), testUpstream, flow)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		dispatched <- flow
+
+		ticket.Release()
+	}()
+
+	require.Eventually(t, func() bool {
+		return waitingOf(s, flow.Class) == waiting+1
+	}, time.Second, time.Millisecond)
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("Unconstrained", func(t *testing.T) {
+		s := NewScheduler()(Options{})
+
+		ticket, err := s.Acquire(context.Background(), testUpstream, Flow{Tenant: "a", Class: ClassStandard})
+		require.NoError(t, err)
+		assert.Nil(t, ticket)
+
+		ticket.Release()
+
+		var nilScheduler *Scheduler
+
+		ticket, err = nilScheduler.Acquire(context.Background(), testUpstream, Flow{Tenant: "a", Class: ClassStandard})
+		require.NoError(t, err)
+		assert.Nil(t, ticket)
+	})
+
+	t.Run("FairAcrossTenants", func(t *testing.T) {
+		s := NewScheduler()(Options{Capacities: map[string]int{testUpstream: 1}})
+
+		held, err := s.Acquire(context.Background(), testUpstream, Flow{Tenant: "a", Class: ClassBatch})
+		require.NoError(t, err)
+		require.NotNil(t, held)
+		assert.Equal(t, ClassBatch, held.Class)
+
+		dispatched := make(chan Flow, 8)
+
+		// Tenant a backfills batches, then tenant b sends interactive
+		// requests, which are dispatched first despite arriving last.
+		for range 4 {
+			enqueue(t, s, Flow{Tenant: "a", Class: ClassBatch}, dispatched)
+		}
+		for range 2 {
+			enqueue(t, s, Flow{Tenant: "b", Class: ClassInteractive}, dispatched)
+		}
+
+		held.Release()
+
+		var order []string
+		for range 6 {
+			flow := <-dispatched
+			order = append(order, flow.Tenant)
+		}
+
+		assert.Equal(t, []string{"b", "b", "a", "a", "a", "a"}, order)
+
+		stats := s.Stats()
+		require.Len(t, stats, 1)
+		assert.Equal(t, 1, stats[0].Capacity)
+		assert.Equal(t, ClassInteractive, stats[0].Classes[0].Class)
+		assert.Equal(t, int64(2), stats[0].Classes[0].Dispatched)
+		assert.Equal(t, int64(5), stats[0].Classes[2].Dispatched)
+		assert.Positive(t, stats[0].Classes[2].MaxWait)
+	})
+
+	t.Run("SameClass", func(t *testing.T) {
+		s := NewScheduler()(Options{Capacities: map[string]int{testUpstream: 1}})
+
+		held, err := s.Acquire(context.Background(), testUpstream, Flow{Tenant: "a", Class: ClassStandard})
+		require.NoError(t, err)
+
+		dispatched := make(chan Flow, 8)
+
+		for range 3 {
+			enqueue(t, s, Flow{Tenant: "a", Class: ClassStandard}, dispatched)
+		}
+		for range 2 {
+			enqueue(t, s, Flow{Tenant: "b", Class: ClassStandard}, dispatched)
+		}
+
+		held.Release()
+
+		var order []string
+		for range 5 {
+			flow := <-dispatched
+			order = append(order, flow.Tenant)
+		}
+
+		// The requests of b alternate with the ones of a, which already
+		// holds the upstream.
+		assert.Equal(t, []string{"b", "a", "b", "a", "a"}, order)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		s := NewScheduler()(Options{Capacities: map[string]int{testUpstream: 1}, MaxWait: 10 * time.Millisecond})
+
+		held, err := s.Acquire(context.Background(), testUpstream, Flow{Tenant: "a", Class: ClassStandard})
+		require.NoError(t, err)
+
+		_, err = s.Acquire(context.Background(), testUpstream, Flow{Tenant: "b", Class: ClassStandard})
+		require.ErrorIs(t, err, ErrQueueTimeout)
+		assert.Zero(t, waitingOf(s, ClassStandard))
+
+		held.Release()
+		held.Release()
+
+		ticket, err := s.Acquire(context.Background(), testUpstream, Flow{Tenant: "b", Class: ClassStandard})
+		require.NoError(t, err)
+		assert.Equal(t, "standard", ticket.Header().Get(HeaderPriority))
+		assert.Equal(t, "0", ticket.Header().Get(HeaderQueueWait))
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		s := NewScheduler()(Options{Capacities: map[string]int{testUpstream: 1}})
+
+		_, err := s.Acquire(context.Background(), testUpstream, Flow{Tenant: "a", Class: ClassStandard})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = s.Acquire(ctx, testUpstream, Flow{Tenant: "b", Class: ClassStandard})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, waitingOf(s, ClassStandard))
+	})
+}
@@ -0,0 +1,103 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownClass      = errors.New("unknown priority class")
+	ErrClassNotPermitted = errors.New("priority class not permitted")
+	ErrQueueTimeout      = errors.New("timed out waiting for upstream capacity")
+)
+
+// Class is the priority class of a request, which weighs how much of the
+// capacity of a constrained upstream its requests are shared.
+type Class string
+
+const (
+	ClassInteractive Class = "interactive"
+	ClassStandard    Class = "standard"
+	ClassBatch       Class = "batch"
+)
+
+// Classes are all the classes, from the highest priority to the lowest.
+var Classes = []Class{ClassInteractive, ClassStandard, ClassBatch}
+
+// DefaultWeights are the weights of the classes when not configured, the
+// requests of an interactive flow are dispatched 8 times as often as the
+// ones of a batch flow while both are queued.
+var DefaultWeights = map[Class]int{
+	ClassInteractive: 8,
+	ClassStandard:    4,
+	ClassBatch:       1,
+}
+
+// ParseClass parses s, in any case, into one of Classes, and returns
+// ErrUnknownClass otherwise.
+func ParseClass(s string) (Class, error) {
+	class := Class(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(Classes, class) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownClass, s)
+	}
+
+	return class, nil
+}
+
+// rank is the position of the class in Classes, lower ranks have higher
+// priorities.
+func (c Class) rank() int {
+	return slices.Index(Classes, c)
+}
+
+// Policy is the priority of the requests of an endpoint.
+type Policy struct {
+	// Class is the class of the requests not requesting one, ClassStandard
+	// when empty.
+	Class Class
+	// Permitted are the classes above Class the requests may request with
+	// the x-llmg-priority header, the classes not above Class may always be
+	// requested.
+	Permitted []Class
+}
+
+// Resolve returns the class of a request of the policy which requested
+// requested, either empty or the value of the x-llmg-priority header.
+func (p Policy) Resolve(requested string) (Class, error) {
+	class := p.Class
+	if class == "" {
+		class = ClassStandard
+	}
+	if requested == "" {
+		return class, nil
+	}
+
+	requestedClass, err := ParseClass(requested)
+	if err != nil {
+		return "", err
+	}
+	if requestedClass.rank() < class.rank() && !slices.Contains(p.Permitted, requestedClass) {
+		return "", fmt.Errorf("%w: %s is above %s", ErrClassNotPermitted, requestedClass, class)
+	}
+
+	return requestedClass, nil
+}
+
+type requestedClassContextKey struct{}
+
+// WithRequestedClass attaches the class requested by the request, e.g. read
+// from the x-llmg-priority header, to ctx, where the gateway resolves it
+// with the policy of the endpoint of the request.
+func WithRequestedClass(ctx context.Context, requested string) context.Context {
+	return context.WithValue(ctx, requestedClassContextKey{}, requested)
+}
+
+// RequestedClassFromContext returns the class attached by
+// WithRequestedClass, empty when none was requested.
+func RequestedClassFromContext(ctx context.Context) string {
+	requested, _ := ctx.Value(requestedClassContextKey{}).(string)
+	return requested
+}
@@ -0,0 +1,245 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MasterKeyEnvName is the environment variable holding the base64 encoded
+// 32 bytes AES-256 key the enc: secrets are encrypted with.
+const MasterKeyEnvName = "LLMG_SECRETS_MASTER_KEY"
+
+const (
+	envPrefix      = "env:"
+	filePrefix     = "file:"
+	encPrefix      = "enc:"
+	redactedPrefix = "redacted:"
+)
+
+var (
+	ErrUnresolvable = errors.New("secret cannot be resolved")
+	ErrNoMasterKey  = errors.New(MasterKeyEnvName + " is not set")
+)
+
+// IsReference reports whether value refers to a secret, i.e. env:NAME,
+// file:/path or enc:ciphertext, rather than being the secret itself.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, envPrefix) ||
+		strings.HasPrefix(value, filePrefix) ||
+		strings.HasPrefix(value, encPrefix)
+}
+
+// IsEncrypted reports whether value is an enc:ciphertext reference, which,
+// unlike the env: and file: ones, does not read the environment or the
+// files of the gateway when resolved.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encPrefix)
+}
+
+// IsRedacted reports whether value was returned by Redact for a plaintext
+// secret, and thus cannot be used as a secret.
+func IsRedacted(value string) bool {
+	return strings.HasPrefix(value, redactedPrefix)
+}
+
+// Redact returns the value to show in place of a secret, references are
+// shown as is, while plaintext secrets are replaced by redacted: followed
+// by their last 4 characters when they are long enough not to be guessed.
+func Redact(value string) string {
+	if value == "" || IsReference(value) || IsRedacted(value) {
+		return value
+	}
+	if len(value) < 16 {
+		return redactedPrefix
+	}
+
+	return redactedPrefix + value[len(value)-4:]
+}
+
+// MasterKeyFromEnv reads the master key from MasterKeyEnvName.
+func MasterKeyFromEnv() ([]byte, error) {
+	encoded := os.Getenv(MasterKeyEnvName)
+	if encoded == "" {
+		return nil, ErrNoMasterKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not base64 encoded: %w", MasterKeyEnvName, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%s must be 32 bytes long, got %d", MasterKeyEnvName, len(key))
+	}
+
+	return key, nil
+}
+
+func newGCM(masterKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts plaintext with masterKey into an enc: reference.
+func Encrypt(masterKey []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(masterKey)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	return encPrefix + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+// Decrypt decrypts an enc: reference encrypted with masterKey.
+func Decrypt(masterKey []byte, reference string) (string, error) {
+	if !strings.HasPrefix(reference, encPrefix) {
+		return "", fmt.Errorf("%w: not an %s reference", ErrUnresolvable, encPrefix)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(reference, encPrefix))
+	if err != nil {
+		return "", fmt.Errorf("%w: ciphertext is not base64 encoded", ErrUnresolvable)
+	}
+
+	gcm, err := newGCM(masterKey)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("%w: ciphertext is truncated", ErrUnresolvable)
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: ciphertext cannot be decrypted with the master key", ErrUnresolvable)
+	}
+
+	return string(plaintext), nil
+}
+
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	value   string
+}
+
+// Resolver resolves secrets at the time they are used, so that the
+// plaintext is never stored along with the configuration. The contents of
+// files are cached until the files change, so that secrets mounted as files
+// are rotated without restarting, and decrypted secrets are cached.
+type Resolver struct {
+	mutex     sync.Mutex
+	files     map[string]cachedFile
+	decrypted map[string]string
+}
+
+func NewResolver() func() *Resolver {
+	return func() *Resolver {
+		return &Resolver{
+			files:     make(map[string]cachedFile),
+			decrypted: make(map[string]string),
+		}
+	}
+}
+
+// Resolve returns the secret value refers to, or value itself when it is not
+// a reference, see IsReference. The errors returned wrap ErrUnresolvable and
+// never contain the secret.
+func (r *Resolver) Resolve(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, envPrefix):
+		return r.resolveEnv(strings.TrimPrefix(value, envPrefix))
+	case strings.HasPrefix(value, filePrefix):
+		return r.resolveFile(strings.TrimPrefix(value, filePrefix))
+	case strings.HasPrefix(value, encPrefix):
+		return r.resolveEncrypted(value)
+	case IsRedacted(value):
+		return "", fmt.Errorf("%w: the secret was redacted", ErrUnresolvable)
+	default:
+		return value, nil
+	}
+}
+
+func (r *Resolver) resolveEnv(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrUnresolvable, name)
+	}
+
+	return value, nil
+}
+
+func (r *Resolver) resolveFile(path string) (string, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnresolvable, err)
+	}
+
+	r.mutex.Lock()
+	cached, ok := r.files[path]
+	r.mutex.Unlock()
+
+	if ok && cached.modTime.Equal(stat.ModTime()) && cached.size == stat.Size() {
+		return cached.value, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnresolvable, err)
+	}
+
+	value := strings.TrimSpace(string(b))
+	if value == "" {
+		return "", fmt.Errorf("%w: file %s is empty", ErrUnresolvable, path)
+	}
+
+	r.mutex.Lock()
+	r.files[path] = cachedFile{modTime: stat.ModTime(), size: stat.Size(), value: value}
+	r.mutex.Unlock()
+
+	return value, nil
+}
+
+func (r *Resolver) resolveEncrypted(reference string) (string, error) {
+	r.mutex.Lock()
+	value, ok := r.decrypted[reference]
+	r.mutex.Unlock()
+
+	if ok {
+		return value, nil
+	}
+
+	masterKey, err := MasterKeyFromEnv()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnresolvable, err)
+	}
+
+	value, err = Decrypt(masterKey, reference)
+	if err != nil {
+		return "", err
+	}
+
+	r.mutex.Lock()
+	r.decrypted[reference] = value
+	r.mutex.Unlock()
+
+	return value, nil
+}
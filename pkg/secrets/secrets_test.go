@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMasterKey(t *testing.T) []byte {
+	t.Helper()
+
+	key := make([]byte, 32)
+
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Run("Plaintext", func(t *testing.T) {
+		value, err := NewResolver()().Resolve("sk-plaintext")
+		require.NoError(t, err)
+		assert.Equal(t, "sk-plaintext", value)
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		t.Setenv("LLMG_TEST_OPENAI_KEY", "sk-env")
+
+		value, err := NewResolver()().Resolve("env:LLMG_TEST_OPENAI_KEY")
+		require.NoError(t, err)
+		assert.Equal(t, "sk-env", value)
+
+		_, err = NewResolver()().Resolve("env:LLMG_TEST_UNSET_OPENAI_KEY")
+		require.ErrorIs(t, err, ErrUnresolvable)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openai")
+		require.NoError(t, os.WriteFile(path, []byte("sk-file\n"), 0o600))
+
+		resolver := NewResolver()()
+
+		value, err := resolver.Resolve("file:" + path)
+		require.NoError(t, err)
+		assert.Equal(t, "sk-file", value)
+
+		// Rotated secrets are read again once the file changed.
+		require.NoError(t, os.WriteFile(path, []byte("sk-rotated\n"), 0o600))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+		value, err = resolver.Resolve("file:" + path)
+		require.NoError(t, err)
+		assert.Equal(t, "sk-rotated", value)
+
+		_, err = resolver.Resolve("file:" + filepath.Join(t.TempDir(), "missing"))
+		require.ErrorIs(t, err, ErrUnresolvable)
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		masterKey := newMasterKey(t)
+		t.Setenv(MasterKeyEnvName, base64.StdEncoding.EncodeToString(masterKey))
+
+		reference, err := Encrypt(masterKey, "sk-encrypted")
+		require.NoError(t, err)
+		assert.True(t, IsReference(reference))
+		assert.True(t, IsEncrypted(reference))
+		assert.False(t, IsEncrypted("env:LLMG_TEST_SECRET"))
+		assert.NotContains(t, reference, "sk-encrypted")
+
+		value, err := NewResolver()().Resolve(reference)
+		require.NoError(t, err)
+		assert.Equal(t, "sk-encrypted", value)
+
+		t.Setenv(MasterKeyEnvName, base64.StdEncoding.EncodeToString(newMasterKey(t)))
+
+		_, err = NewResolver()().Resolve(reference)
+		require.ErrorIs(t, err, ErrUnresolvable)
+
+		t.Setenv(MasterKeyEnvName, "")
+
+		_, err = NewResolver()().Resolve(reference)
+		require.ErrorIs(t, err, ErrUnresolvable)
+		require.ErrorIs(t, err, ErrNoMasterKey)
+	})
+
+	t.Run("Redacted", func(t *testing.T) {
+		_, err := NewResolver()().Resolve(Redact("sk-0123456789abcdef"))
+		require.ErrorIs(t, err, ErrUnresolvable)
+	})
+}
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "", Redact(""))
+	assert.Equal(t, "env:OPENAI_KEY_PROD", Redact("env:OPENAI_KEY_PROD"))
+	assert.Equal(t, "file:/run/secrets/openai", Redact("file:/run/secrets/openai"))
+	assert.Equal(t, "redacted:", Redact("sk-short"))
+	assert.Equal(t, "redacted:cdef", Redact("sk-0123456789abcdef"))
+	assert.Equal(t, "redacted:cdef", Redact(Redact("sk-0123456789abcdef")))
+	assert.True(t, IsRedacted(Redact("sk-0123456789abcdef")))
+}
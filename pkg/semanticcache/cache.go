@@ -0,0 +1,128 @@
+package semanticcache
+
+import (
+	"context"
+	"time"
+)
+
+// Tags scope an object cached: the retrievals filtered by tags only
+// retrieve the objects cached with the same ones.
+type Tags struct {
+	Tenant   string `json:"tenant"`
+	Team     string `json:"team"`
+	Endpoint string `json:"endpoint"`
+	Model    string `json:"model"`
+	// SystemPrompt is a hash of the system prompt, see SystemPromptHash.
+	SystemPrompt string `json:"system_prompt"`
+}
+
+// TagFields are the names of the fields of the tags in the indexes.
+var TagFields = []string{"tenant", "team", "endpoint", "model", "system_prompt"}
+
+// Fields returns the tags by the names of their fields in the indexes, the
+// empty ones included.
+func (t Tags) Fields() map[string]string {
+	return map[string]string{
+		"tenant":        t.Tenant,
+		"team":          t.Team,
+		"endpoint":      t.Endpoint,
+		"model":         t.Model,
+		"system_prompt": t.SystemPrompt,
+	}
+}
+
+// Match reports whether the tags of an object cached match the filter t,
+// every tag being compared: the empty tags of t only match the objects cached
+// without them, they are not wildcards.
+func (t Tags) Match(tags Tags) bool {
+	return t == tags
+}
+
+// Cached is an object cached along with its vectors.
+type Cached[T any] struct {
+	Key    string    `json:"key" redis:",key"` // the redis:",key" is required to indicate which field is the ULID key
+	Ver    int64     `json:"ver" redis:",ver"` // the redis:",ver" is required to do optimistic locking to prevent lost update
+	Vec    []float64 `json:"vec"`
+	Tags   Tags      `json:"tags"`
+	Object T         `json:"object"`
+}
+
+// Retrieved is an object retrieved by the similarity of its vectors.
+type Retrieved[T any] struct {
+	Key string `json:"key"`
+	// Score is the cosine similarity of the vectors of the object to the
+	// ones retrieved by, 1 when they point the same way.
+	Score  float64 `json:"score"`
+	Object T       `json:"object"`
+}
+
+type CacheOptions struct {
+	Tags Tags
+}
+
+type CacheCallOption func(*CacheOptions)
+
+// WithTags tags the object cached.
+func WithTags(tags Tags) CacheCallOption {
+	return func(o *CacheOptions) {
+		o.Tags = tags
+	}
+}
+
+func ApplyCacheCallOptions(opts []CacheCallOption) *CacheOptions {
+	options := &CacheOptions{}
+	for _, o := range opts {
+		o(options)
+	}
+
+	return options
+}
+
+type RetrieveOptions struct {
+	// Filter is nil unless set, which retrieves the objects of any tags.
+	Filter *Tags
+	// MinSimilarity is -1 unless set, the lowest cosine similarity.
+	MinSimilarity float64
+}
+
+type RetrieveCallOption func(*RetrieveOptions)
+
+// WithFilter only retrieves the objects whose tags match filter, see
+// Tags.Match, before their nearest ones are searched for, rather than after.
+func WithFilter(filter Tags) RetrieveCallOption {
+	return func(o *RetrieveOptions) {
+		o.Filter = &filter
+	}
+}
+
+// WithMinSimilarity only retrieves the objects whose score is at least
+// similarity.
+func WithMinSimilarity(similarity float64) RetrieveCallOption {
+	return func(o *RetrieveOptions) {
+		o.MinSimilarity = similarity
+	}
+}
+
+func ApplyRetrieveCallOptions(opts []RetrieveCallOption) *RetrieveOptions {
+	options := &RetrieveOptions{MinSimilarity: -1}
+	for _, o := range opts {
+		o(options)
+	}
+
+	return options
+}
+
+// Cache caches objects by their vectors, and retrieves the ones nearest to
+// vectors, e.g. the embeddings of questions. It is implemented by the
+// RediSearch index of the rueidis package, and by the in-process HNSW index
+// of the hnsw package.
+type Cache[T any] interface {
+	// CacheVectors caches doc by vectors for ttl, forever when ttl is 0.
+	CacheVectors(ctx context.Context, doc *T, vectors []float64, ttl time.Duration, opts ...CacheCallOption) (*Cached[*T], error)
+	// RetrieveByVectors retrieves the first objects nearest to vectors, the
+	// nearest first. The objects expired are not retrieved.
+	RetrieveByVectors(ctx context.Context, vectors []float64, first int, opts ...RetrieveCallOption) ([]*Retrieved[*T], error)
+	// RetrieveFirstByVectors retrieves the object nearest to vectors, nil
+	// when none is cached.
+	RetrieveFirstByVectors(ctx context.Context, vectors []float64, opts ...RetrieveCallOption) (*Retrieved[*T], error)
+}
@@ -0,0 +1,42 @@
+package semanticcache
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/lingticio/llmg/pkg/util/headers"
+)
+
+const (
+	// HeaderCache is hit when the answer was served from the cache, and
+	// miss when it was not but is cached.
+	HeaderCache           = "x-llmg-cache"
+	HeaderCacheSimilarity = "x-llmg-cache-similarity"
+)
+
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+func init() {
+	headers.Register(HeaderCache, HeaderCacheSimilarity)
+}
+
+// HitHeader returns the headers of an answer served from the cache, whose
+// question was similarity similar to the one of the request.
+func HitHeader(similarity float64) http.Header {
+	header := make(http.Header)
+	header.Set(HeaderCache, CacheHit)
+	header.Set(HeaderCacheSimilarity, strconv.FormatFloat(similarity, 'f', 4, 64))
+
+	return header
+}
+
+// MissHeader returns the headers of an answer not served from the cache.
+func MissHeader() http.Header {
+	header := make(http.Header)
+	header.Set(HeaderCache, CacheMiss)
+
+	return header
+}
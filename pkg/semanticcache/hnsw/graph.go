@@ -0,0 +1,394 @@
+package hnsw
+
+import (
+	"container/heap"
+	"container/list"
+	"encoding/json"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/lingticio/llmg/pkg/semanticcache"
+)
+
+// nodeOverhead is roughly what a node weighs besides its vector, its
+// object, its key and its links.
+const nodeOverhead = 128
+
+type node struct {
+	id  uint64
+	key string
+	// vector is normalized, so that the cosine distance of two vectors is 1
+	// minus their dot product.
+	vector    []float64
+	object    json.RawMessage
+	tags      semanticcache.Tags
+	expiresAt time.Time
+	// neighbors are the IDs of the neighbors of the node, by layer, from
+	// the bottom one, which holds every node.
+	neighbors [][]uint64
+
+	bytes int64
+	order *list.Element
+}
+
+func (n *node) level() int {
+	return len(n.neighbors) - 1
+}
+
+func (n *node) expired(now time.Time) bool {
+	return !n.expiresAt.IsZero() && !now.Before(n.expiresAt)
+}
+
+func normalize(vector []float64) []float64 {
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+
+	normalized := make([]float64, len(vector))
+	if norm == 0 {
+		return normalized
+	}
+
+	norm = math.Sqrt(norm)
+	for i, v := range vector {
+		normalized[i] = v / norm
+	}
+
+	return normalized
+}
+
+// distance is the cosine distance of normalized vectors.
+func distance(a, b []float64) float64 {
+	var dot float64
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+
+	return 1 - dot
+}
+
+type candidate struct {
+	node     *node
+	distance float64
+}
+
+func compareCandidates(a, b candidate) int {
+	if a.distance != b.distance {
+		if a.distance < b.distance {
+			return -1
+		}
+
+		return 1
+	}
+	if a.node.id < b.node.id {
+		return -1
+	}
+	if a.node.id > b.node.id {
+		return 1
+	}
+
+	return 0
+}
+
+// candidateHeap is a min-heap of candidates by distance, or a max-heap when
+// farthest is set.
+type candidateHeap struct {
+	candidates []candidate
+	farthest   bool
+}
+
+func (h *candidateHeap) Len() int {
+	return len(h.candidates)
+}
+
+func (h *candidateHeap) Less(i, j int) bool {
+	if h.farthest {
+		return compareCandidates(h.candidates[i], h.candidates[j]) > 0
+	}
+
+	return compareCandidates(h.candidates[i], h.candidates[j]) < 0
+}
+
+func (h *candidateHeap) Swap(i, j int) {
+	h.candidates[i], h.candidates[j] = h.candidates[j], h.candidates[i]
+}
+
+func (h *candidateHeap) Push(x any) {
+	h.candidates = append(h.candidates, x.(candidate)) //nolint:forcetypeassert
+}
+
+func (h *candidateHeap) Pop() any {
+	last := h.candidates[len(h.candidates)-1]
+	h.candidates = h.candidates[:len(h.candidates)-1]
+
+	return last
+}
+
+func (h *candidateHeap) top() candidate {
+	return h.candidates[0]
+}
+
+// graph is a hierarchical navigable small world graph of the nodes, as
+// described by Malkov and Yashunin, whose nodes may be removed: the
+// neighbors of a node removed are linked to its other neighbors instead.
+// The links to the nodes removed from which the nodes removed were not
+// linked back are left dangling, and skipped.
+type graph struct {
+	options *hnswOptions
+
+	nodes map[uint64]*node
+	entry *node
+}
+
+// maxLinks is how many neighbors the nodes keep on layer.
+func (g *graph) maxLinks(layer int) int {
+	if layer == 0 {
+		return 2 * g.options.m
+	}
+
+	return g.options.m
+}
+
+// greedy walks layer from entry to the node nearest to vector.
+func (g *graph) greedy(vector []float64, entry candidate, layer int) candidate {
+	current := entry
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, id := range current.node.neighbors[layer] {
+			neighbor, ok := g.nodes[id]
+			if !ok {
+				continue
+			}
+
+			d := distance(vector, neighbor.vector)
+			if d < current.distance {
+				current = candidate{node: neighbor, distance: d}
+				changed = true
+			}
+		}
+	}
+
+	return current
+}
+
+// searchLayer returns the ef nodes of layer nearest to vector it finds from
+// entries, the nearest first. Only the nodes accept accepts are returned,
+// any when accept is nil, but the graph is walked through the other ones
+// still, so that the nodes accepted are found however few they are.
+func (g *graph) searchLayer(vector []float64, entries []candidate, ef int, layer int, accept func(*node) bool) []candidate {
+	visited := make(map[uint64]struct{}, ef*g.options.m)
+	candidates := &candidateHeap{}
+	results := &candidateHeap{farthest: true}
+
+	for _, entry := range entries {
+		visited[entry.node.id] = struct{}{}
+		heap.Push(candidates, entry)
+
+		if accept == nil || accept(entry.node) {
+			heap.Push(results, entry)
+		}
+		if results.Len() > ef {
+			heap.Pop(results)
+		}
+	}
+
+	for candidates.Len() > 0 {
+		nearest := heap.Pop(candidates).(candidate) //nolint:forcetypeassert
+		if results.Len() >= ef && nearest.distance > results.top().distance {
+			break
+		}
+
+		for _, id := range nearest.node.neighbors[layer] {
+			if _, ok := visited[id]; ok {
+				continue
+			}
+
+			visited[id] = struct{}{}
+
+			neighbor, ok := g.nodes[id]
+			if !ok {
+				continue
+			}
+
+			d := distance(vector, neighbor.vector)
+			if results.Len() < ef || d < results.top().distance {
+				heap.Push(candidates, candidate{node: neighbor, distance: d})
+
+				if accept == nil || accept(neighbor) {
+					heap.Push(results, candidate{node: neighbor, distance: d})
+				}
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(results.candidates, compareCandidates)
+
+	return results.candidates
+}
+
+// selectNeighbors selects up to m of the candidates sorted by distance with
+// the heuristic of the paper, which prefers the candidates nearer to the
+// node than to the neighbors already selected, so that the neighbors span
+// the directions around the node, then fills up with the nearest of the
+// others.
+func selectNeighbors(candidates []candidate, m int) []candidate {
+	if len(candidates) <= m {
+		return candidates
+	}
+
+	selected := make([]candidate, 0, m)
+	pruned := make([]candidate, 0, len(candidates))
+
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+
+		diverse := true
+
+		for _, s := range selected {
+			if distance(c.node.vector, s.node.vector) < c.distance {
+				diverse = false
+				break
+			}
+		}
+
+		if diverse {
+			selected = append(selected, c)
+		} else {
+			pruned = append(pruned, c)
+		}
+	}
+
+	for _, c := range pruned {
+		if len(selected) == m {
+			break
+		}
+
+		selected = append(selected, c)
+	}
+
+	return selected
+}
+
+func idsOf(candidates []candidate) []uint64 {
+	ids := make([]uint64, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.node.id
+	}
+
+	return ids
+}
+
+// link replaces the neighbors of n on layer with the best of ids.
+func (g *graph) link(n *node, layer int, ids []uint64) {
+	candidates := make([]candidate, 0, len(ids))
+
+	for _, id := range ids {
+		neighbor, ok := g.nodes[id]
+		if !ok || id == n.id {
+			continue
+		}
+
+		candidates = append(candidates, candidate{node: neighbor, distance: distance(n.vector, neighbor.vector)})
+	}
+
+	slices.SortFunc(candidates, compareCandidates)
+	candidates = slices.CompactFunc(candidates, func(a, b candidate) bool {
+		return a.node.id == b.node.id
+	})
+
+	n.neighbors[layer] = idsOf(selectNeighbors(candidates, g.maxLinks(layer)))
+}
+
+// insert inserts n, whose level is already drawn, into the graph.
+func (g *graph) insert(n *node) {
+	g.nodes[n.id] = n
+
+	if g.entry == nil {
+		g.entry = n
+		return
+	}
+
+	entry := candidate{node: g.entry, distance: distance(n.vector, g.entry.vector)}
+	for layer := g.entry.level(); layer > n.level(); layer-- {
+		entry = g.greedy(n.vector, entry, layer)
+	}
+
+	entries := []candidate{entry}
+
+	for layer := min(n.level(), g.entry.level()); layer >= 0; layer-- {
+		nearest := g.searchLayer(n.vector, entries, g.options.efConstruction, layer, nil)
+		neighbors := selectNeighbors(nearest, g.options.m)
+		n.neighbors[layer] = idsOf(neighbors)
+
+		for _, neighbor := range neighbors {
+			links := append(neighbor.node.neighbors[layer], n.id)
+			if len(links) > g.maxLinks(layer) {
+				g.link(neighbor.node, layer, links)
+			} else {
+				neighbor.node.neighbors[layer] = links
+			}
+		}
+
+		entries = nearest
+	}
+
+	if n.level() > g.entry.level() {
+		g.entry = n
+	}
+}
+
+// remove removes n from the graph, linking its neighbors to its other
+// neighbors instead.
+func (g *graph) remove(n *node) {
+	delete(g.nodes, n.id)
+
+	for layer, neighbors := range n.neighbors {
+		for _, id := range neighbors {
+			neighbor, ok := g.nodes[id]
+			if !ok || neighbor.level() < layer {
+				continue
+			}
+
+			links := slices.DeleteFunc(slices.Clone(neighbor.neighbors[layer]), func(item uint64) bool {
+				return item == n.id
+			})
+
+			g.link(neighbor, layer, append(links, neighbors...))
+		}
+	}
+
+	if g.entry != n {
+		return
+	}
+
+	g.entry = nil
+	for _, candidate := range g.nodes {
+		if g.entry == nil || candidate.level() > g.entry.level() {
+			g.entry = candidate
+		}
+	}
+}
+
+// search returns the k nodes accept accepts nearest to vector, the nearest
+// first, among ef candidates.
+func (g *graph) search(vector []float64, k int, ef int, accept func(*node) bool) []candidate {
+	if g.entry == nil {
+		return nil
+	}
+
+	entry := candidate{node: g.entry, distance: distance(vector, g.entry.vector)}
+	for layer := g.entry.level(); layer > 0; layer-- {
+		entry = g.greedy(vector, entry, layer)
+	}
+
+	return g.searchLayer(vector, []candidate{entry}, max(ef, k), 0, accept)
+}
@@ -0,0 +1,340 @@
+// Package hnsw caches objects by their vectors in process, in a
+// hierarchical navigable small world (HNSW) graph, for the deployments of a
+// single replica, and the tests, which cannot depend on Redis Stack.
+package hnsw
+
+import (
+	"container/heap"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/lingticio/llmg/pkg/semanticcache"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
+)
+
+const keyLength = 24
+
+const (
+	defaultM              = 16
+	defaultEfConstruction = 200
+	defaultEfSearch       = 64
+)
+
+var (
+	ErrEmptyVectors      = errors.New("vectors must not be empty")
+	ErrDimensionMismatch = errors.New("vectors of another dimension than the ones cached")
+)
+
+type hnswOptions struct {
+	m              int
+	efConstruction int
+	efSearch       int
+	maxBytes       int64
+}
+
+type HNSWCallOption func(*hnswOptions)
+
+// WithM sets how many neighbors the nodes are linked to on each layer, twice
+// as many on the bottom one, 16 by default.
+func WithM(m int) HNSWCallOption {
+	return func(o *hnswOptions) {
+		o.m = m
+	}
+}
+
+// WithEfConstruction sets how many candidates are considered as the
+// neighbors of the nodes inserted, 200 by default.
+func WithEfConstruction(efConstruction int) HNSWCallOption {
+	return func(o *hnswOptions) {
+		o.efConstruction = efConstruction
+	}
+}
+
+// WithEfSearch sets how many candidates are considered by the retrievals,
+// 64 by default, or how many objects they retrieve when more.
+func WithEfSearch(efSearch int) HNSWCallOption {
+	return func(o *hnswOptions) {
+		o.efSearch = efSearch
+	}
+}
+
+// WithMaxBytes bounds roughly how much memory the objects cached, their
+// vectors and their links take, the objects cached first are evicted
+// beyond it. The cache is not bounded by default.
+func WithMaxBytes(maxBytes int64) HNSWCallOption {
+	return func(o *hnswOptions) {
+		o.maxBytes = maxBytes
+	}
+}
+
+func applyHNSWCallOptions(defaultOpts *hnswOptions, opts []HNSWCallOption) *hnswOptions {
+	for _, o := range opts {
+		o(defaultOpts)
+	}
+
+	return defaultOpts
+}
+
+type expiry struct {
+	id        uint64
+	expiresAt time.Time
+}
+
+// expiryHeap is a min-heap of the expiries of the nodes.
+type expiryHeap []expiry
+
+func (h expiryHeap) Len() int {
+	return len(h)
+}
+
+func (h expiryHeap) Less(i, j int) bool {
+	return h[i].expiresAt.Before(h[j].expiresAt)
+}
+
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *expiryHeap) Push(x any) {
+	*h = append(*h, x.(expiry)) //nolint:forcetypeassert
+}
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return last
+}
+
+var _ semanticcache.Cache[any] = (*SemanticCacheHNSW[any])(nil)
+
+// SemanticCacheHNSW caches the objects in process, as JSON, and retrieves
+// them by the cosine similarity of their vectors, as SemanticCacheRueidisJSON
+// does. The objects expire lazily: the retrievals skip them, and they are
+// evicted as objects are cached, or by EvictExpired.
+type SemanticCacheHNSW[T any] struct {
+	options *hnswOptions
+
+	mutex     sync.RWMutex
+	graph     *graph
+	dimension int
+	nextID    uint64
+	bytes     int64
+	// order is the nodes in the order they were cached, the first ones
+	// are evicted beyond the maximum bytes.
+	order    *list.List
+	expiries expiryHeap
+	levels   *rand.Rand
+
+	now func() time.Time
+}
+
+func HNSW[T any](callOptions ...HNSWCallOption) *SemanticCacheHNSW[T] {
+	opts := applyHNSWCallOptions(&hnswOptions{
+		m:              defaultM,
+		efConstruction: defaultEfConstruction,
+		efSearch:       defaultEfSearch,
+	}, callOptions)
+
+	return &SemanticCacheHNSW[T]{
+		options: opts,
+		graph:   &graph{options: opts, nodes: make(map[uint64]*node)},
+		order:   list.New(),
+		levels:  rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())), //nolint:gosec
+		now:     time.Now,
+	}
+}
+
+// randomLevel draws the level of a node, the levels decay exponentially
+// as in the paper.
+func (c *SemanticCacheHNSW[T]) randomLevel() int {
+	return int(math.Floor(-math.Log(1-c.levels.Float64()) / math.Log(float64(c.options.m))))
+}
+
+func (c *SemanticCacheHNSW[T]) checkDimension(vectors []float64) error {
+	if len(vectors) == 0 {
+		return ErrEmptyVectors
+	}
+	if c.dimension != 0 && len(vectors) != c.dimension {
+		return fmt.Errorf("%w: %d instead of %d", ErrDimensionMismatch, len(vectors), c.dimension)
+	}
+
+	return nil
+}
+
+// Len returns how many objects are cached, the ones expired included until
+// they are evicted.
+func (c *SemanticCacheHNSW[T]) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return len(c.graph.nodes)
+}
+
+// Bytes returns roughly how much memory the objects cached take.
+func (c *SemanticCacheHNSW[T]) Bytes() int64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.bytes
+}
+
+func (c *SemanticCacheHNSW[T]) CacheVectors(ctx context.Context, doc *T, vectors []float64, ttl time.Duration, opts ...semanticcache.CacheCallOption) (*semanticcache.Cached[*T], error) {
+	options := semanticcache.ApplyCacheCallOptions(opts)
+
+	object, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = c.checkDimension(vectors)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.now()
+	c.evictExpired(now)
+
+	c.nextID++
+
+	n := &node{
+		id:        c.nextID,
+		key:       nanoid.NewWithLength(keyLength),
+		vector:    normalize(vectors),
+		object:    object,
+		tags:      options.Tags,
+		neighbors: make([][]uint64, c.randomLevel()+1),
+	}
+	if ttl > 0 {
+		n.expiresAt = now.Add(ttl)
+	}
+
+	c.add(n)
+	c.graph.insert(n)
+	c.evictBeyondMaxBytes()
+
+	return &semanticcache.Cached[*T]{
+		Key:    n.key,
+		Vec:    vectors,
+		Tags:   options.Tags,
+		Object: doc,
+	}, nil
+}
+
+// add accounts for n, which is about to be inserted into the graph.
+func (c *SemanticCacheHNSW[T]) add(n *node) {
+	c.dimension = len(n.vector)
+	n.bytes = int64(8*len(n.vector)+len(n.object)+len(n.key)+8*c.options.m*(len(n.neighbors)+1)) + nodeOverhead
+	n.order = c.order.PushBack(n)
+	c.bytes += n.bytes
+
+	if !n.expiresAt.IsZero() {
+		heap.Push(&c.expiries, expiry{id: n.id, expiresAt: n.expiresAt})
+	}
+}
+
+func (c *SemanticCacheHNSW[T]) remove(n *node) {
+	c.graph.remove(n)
+	c.order.Remove(n.order)
+	c.bytes -= n.bytes
+}
+
+func (c *SemanticCacheHNSW[T]) evictExpired(now time.Time) int {
+	evicted := 0
+
+	for len(c.expiries) > 0 && !now.Before(c.expiries[0].expiresAt) {
+		expired := heap.Pop(&c.expiries).(expiry) //nolint:forcetypeassert
+
+		n, ok := c.graph.nodes[expired.id]
+		if !ok {
+			continue
+		}
+
+		c.remove(n)
+		evicted++
+	}
+
+	return evicted
+}
+
+// evictBeyondMaxBytes evicts the objects cached first until the cache fits
+// in the maximum bytes, the last object cached is kept however.
+func (c *SemanticCacheHNSW[T]) evictBeyondMaxBytes() {
+	for c.options.maxBytes > 0 && c.bytes > c.options.maxBytes && c.order.Len() > 1 {
+		c.remove(c.order.Front().Value.(*node)) //nolint:forcetypeassert
+	}
+}
+
+// EvictExpired evicts the objects expired, and returns how many were.
+func (c *SemanticCacheHNSW[T]) EvictExpired() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.evictExpired(c.now())
+}
+
+func (c *SemanticCacheHNSW[T]) RetrieveFirstByVectors(ctx context.Context, vectors []float64, opts ...semanticcache.RetrieveCallOption) (*semanticcache.Retrieved[*T], error) {
+	retrieved, err := c.RetrieveByVectors(ctx, vectors, 1, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(retrieved) == 0 {
+		return nil, nil
+	}
+
+	return retrieved[0], nil
+}
+
+func (c *SemanticCacheHNSW[T]) RetrieveByVectors(ctx context.Context, vectors []float64, first int, opts ...semanticcache.RetrieveCallOption) ([]*semanticcache.Retrieved[*T], error) {
+	options := semanticcache.ApplyRetrieveCallOptions(opts)
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	err := c.checkDimension(vectors)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.now()
+	// The nodes expired, or tagged otherwise, are skipped while searching,
+	// rather than after, so that first nodes are found if any.
+	accept := func(n *node) bool {
+		return !n.expired(now) && (options.Filter == nil || options.Filter.Match(n.tags))
+	}
+
+	retrieved := make([]*semanticcache.Retrieved[*T], 0, first)
+
+	for _, candidate := range c.graph.search(normalize(vectors), first, c.options.efSearch, accept) {
+		similarity := 1 - candidate.distance
+		if len(retrieved) == first || similarity < options.MinSimilarity {
+			break
+		}
+
+		var object T
+
+		err := json.Unmarshal(candidate.node.object, &object)
+		if err != nil {
+			return nil, err
+		}
+
+		retrieved = append(retrieved, &semanticcache.Retrieved[*T]{
+			Key:    candidate.node.key,
+			Score:  similarity,
+			Object: &object,
+		})
+	}
+
+	return retrieved, nil
+}
@@ -0,0 +1,253 @@
+package hnsw
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"math/rand/v2"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/pkg/semanticcache"
+	"github.com/lingticio/llmg/pkg/semanticcache/semanticcachetest"
+)
+
+func TestSemanticCacheHNSW(t *testing.T) {
+	semanticcachetest.Run(t, func(t *testing.T) semanticcache.Cache[semanticcachetest.Doc] {
+		return HNSW[semanticcachetest.Doc]()
+	})
+}
+
+func randomVectors(r *rand.Rand, dimension int) []float64 {
+	vectors := make([]float64, dimension)
+	for i := range vectors {
+		vectors[i] = r.NormFloat64()
+	}
+
+	return vectors
+}
+
+func TestSemanticCacheHNSW_Recall(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2)) //nolint:gosec
+	c := HNSW[int](WithM(8), WithEfConstruction(64))
+
+	vectors := make([][]float64, 2000)
+	for i := range vectors {
+		vectors[i] = randomVectors(r, 16)
+
+		_, err := c.CacheVectors(context.Background(), &i, vectors[i], 0)
+		require.NoError(t, err)
+	}
+
+	found := 0
+
+	for range 50 {
+		query := randomVectors(r, 16)
+		normalized := normalize(query)
+
+		ids := make([]int, len(vectors))
+		for i := range ids {
+			ids[i] = i
+		}
+
+		sort.Slice(ids, func(i, j int) bool {
+			return distance(normalized, normalize(vectors[ids[i]])) < distance(normalized, normalize(vectors[ids[j]]))
+		})
+
+		retrieved, err := c.RetrieveByVectors(context.Background(), query, 10)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 10)
+
+		for _, want := range ids[:10] {
+			for _, got := range retrieved {
+				if *got.Object == want {
+					found++
+					break
+				}
+			}
+		}
+	}
+
+	assert.GreaterOrEqual(t, float64(found)/500, 0.9)
+}
+
+func TestSemanticCacheHNSW_SelectiveFilter(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10)) //nolint:gosec
+	c := HNSW[int]()
+
+	for i := range 2000 {
+		tags := semanticcache.Tags{Tenant: "common"}
+		if i%200 == 0 {
+			tags.Tenant = "rare"
+		}
+
+		_, err := c.CacheVectors(context.Background(), &i, randomVectors(r, 16), 0, semanticcache.WithTags(tags))
+		require.NoError(t, err)
+	}
+
+	retrieved, err := c.RetrieveByVectors(context.Background(), randomVectors(r, 16), 20, semanticcache.WithFilter(semanticcache.Tags{Tenant: "rare"}))
+	require.NoError(t, err)
+	require.Len(t, retrieved, 10)
+
+	for i, got := range retrieved {
+		assert.Zero(t, *got.Object%200)
+
+		if i > 0 {
+			assert.LessOrEqual(t, got.Score, retrieved[i-1].Score)
+		}
+	}
+}
+
+func TestSemanticCacheHNSW_EmptyTags(t *testing.T) {
+	c := HNSW[string]()
+
+	tagged := "tagged"
+	_, err := c.CacheVectors(context.Background(), &tagged, []float64{1, 0}, 0, semanticcache.WithTags(semanticcache.Tags{Tenant: "a", Team: "b"}))
+	require.NoError(t, err)
+
+	untagged := "untagged"
+	_, err = c.CacheVectors(context.Background(), &untagged, []float64{0, 1}, 0, semanticcache.WithTags(semanticcache.Tags{Tenant: "a"}))
+	require.NoError(t, err)
+
+	// The empty team of the filter only matches the objects without team.
+	retrieved, err := c.RetrieveByVectors(context.Background(), []float64{1, 0}, 2, semanticcache.WithFilter(semanticcache.Tags{Tenant: "a"}))
+	require.NoError(t, err)
+	require.Len(t, retrieved, 1)
+	assert.Equal(t, "untagged", *retrieved[0].Object)
+}
+
+func TestSemanticCacheHNSW_DimensionMismatch(t *testing.T) {
+	c := HNSW[string]()
+
+	_, err := c.CacheVectors(context.Background(), lo.ToPtr("a"), []float64{1, 0}, 0)
+	require.NoError(t, err)
+
+	_, err = c.CacheVectors(context.Background(), lo.ToPtr("b"), []float64{1, 0, 0}, 0)
+	require.ErrorIs(t, err, ErrDimensionMismatch)
+
+	_, err = c.RetrieveByVectors(context.Background(), nil, 1)
+	require.ErrorIs(t, err, ErrEmptyVectors)
+}
+
+func TestSemanticCacheHNSW_MaxBytes(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4)) //nolint:gosec
+	c := HNSW[int](WithMaxBytes(64 * 1024))
+
+	for i := range 1000 {
+		_, err := c.CacheVectors(context.Background(), &i, randomVectors(r, 32), 0)
+		require.NoError(t, err)
+		require.LessOrEqual(t, c.Bytes(), int64(64*1024))
+	}
+
+	assert.Less(t, c.Len(), 1000)
+	assert.Positive(t, c.Len())
+
+	retrieved, err := c.RetrieveByVectors(context.Background(), randomVectors(r, 32), c.Len())
+	require.NoError(t, err)
+	assert.Len(t, retrieved, c.Len())
+
+	for _, got := range retrieved {
+		// the objects cached first are the ones evicted
+		assert.GreaterOrEqual(t, *got.Object, 1000-c.Len())
+	}
+}
+
+func TestSemanticCacheHNSW_EvictExpired(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6)) //nolint:gosec
+	c := HNSW[int]()
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	for i := range 200 {
+		_, err := c.CacheVectors(context.Background(), &i, randomVectors(r, 8), time.Duration(1+i%2)*time.Minute)
+		require.NoError(t, err)
+	}
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 100, c.EvictExpired())
+	assert.Equal(t, 100, c.Len())
+
+	retrieved, err := c.RetrieveByVectors(context.Background(), randomVectors(r, 8), 100)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 100)
+
+	for _, got := range retrieved {
+		assert.Equal(t, 1, *got.Object%2)
+	}
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 100, c.EvictExpired())
+	assert.Zero(t, c.Len())
+	assert.Zero(t, c.Bytes())
+
+	retrieved, err = c.RetrieveByVectors(context.Background(), randomVectors(r, 8), 1)
+	require.NoError(t, err)
+	assert.Empty(t, retrieved)
+}
+
+func TestSemanticCacheHNSW_Snapshot(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8)) //nolint:gosec
+	c := HNSW[int]()
+
+	for i := range 300 {
+		_, err := c.CacheVectors(context.Background(), &i, randomVectors(r, 8), 0)
+		require.NoError(t, err)
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, c.Snapshot(&buffer))
+
+	restored := HNSW[int]()
+	require.NoError(t, restored.Restore(&buffer))
+	assert.Equal(t, c.Len(), restored.Len())
+	assert.Equal(t, c.Bytes(), restored.Bytes())
+
+	for range 20 {
+		query := randomVectors(r, 8)
+
+		want, err := c.RetrieveByVectors(context.Background(), query, 5)
+		require.NoError(t, err)
+
+		got, err := restored.RetrieveByVectors(context.Background(), query, 5)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	i := 300
+	_, err := restored.CacheVectors(context.Background(), &i, randomVectors(r, 8), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 301, restored.Len())
+}
+
+func TestSemanticCacheHNSW_SaveSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "semantic_cache.gob")
+
+	c := HNSW[string]()
+
+	err := c.LoadSnapshot(path)
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+
+	_, err = c.CacheVectors(context.Background(), lo.ToPtr("north"), []float64{1, 0}, 0)
+	require.NoError(t, err)
+	require.NoError(t, c.SaveSnapshot(path))
+
+	restored := HNSW[string]()
+	require.NoError(t, restored.LoadSnapshot(path))
+
+	retrieved, err := restored.RetrieveFirstByVectors(context.Background(), []float64{1, 0})
+	require.NoError(t, err)
+	require.NotNil(t, retrieved)
+	assert.Equal(t, "north", *retrieved.Object)
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
@@ -0,0 +1,166 @@
+package hnsw
+
+import (
+	"container/heap"
+	"container/list"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lingticio/llmg/pkg/semanticcache"
+)
+
+// snapshotVersion is bumped whenever the format of the snapshots changes, the
+// snapshots of another version are refused.
+const snapshotVersion = 1
+
+type snapshot struct {
+	Version   int
+	Dimension int
+	NextID    uint64
+	EntryID   uint64
+	// Nodes are in the order they were cached.
+	Nodes []snapshotNode
+}
+
+type snapshotNode struct {
+	ID        uint64
+	Key       string
+	Vector    []float64
+	Object    []byte
+	Tags      semanticcache.Tags
+	ExpiresAt time.Time
+	Neighbors [][]uint64
+}
+
+var ErrSnapshotVersion = errors.New("snapshot of an unsupported version")
+
+// Snapshot writes the objects cached, their vectors and the graph to w, so
+// that Restore rebuilds the cache without inserting them again.
+func (c *SemanticCacheHNSW[T]) Snapshot(w io.Writer) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	s := snapshot{
+		Version:   snapshotVersion,
+		Dimension: c.dimension,
+		NextID:    c.nextID,
+		Nodes:     make([]snapshotNode, 0, c.order.Len()),
+	}
+	if c.graph.entry != nil {
+		s.EntryID = c.graph.entry.id
+	}
+
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		n := e.Value.(*node) //nolint:forcetypeassert
+
+		s.Nodes = append(s.Nodes, snapshotNode{
+			ID:        n.id,
+			Key:       n.key,
+			Vector:    n.vector,
+			Object:    n.object,
+			Tags:      n.tags,
+			ExpiresAt: n.expiresAt,
+			Neighbors: n.neighbors,
+		})
+	}
+
+	return gob.NewEncoder(w).Encode(s)
+}
+
+// Restore replaces the objects cached by the ones of a snapshot written by
+// Snapshot, the ones expired since are evicted.
+func (c *SemanticCacheHNSW[T]) Restore(r io.Reader) error {
+	var s snapshot
+
+	err := gob.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return err
+	}
+	if s.Version != snapshotVersion {
+		return fmt.Errorf("%w: %d", ErrSnapshotVersion, s.Version)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.graph = &graph{options: c.options, nodes: make(map[uint64]*node, len(s.Nodes))}
+	c.dimension = s.Dimension
+	c.nextID = s.NextID
+	c.bytes = 0
+	c.order = list.New()
+	c.expiries = nil
+
+	for _, sn := range s.Nodes {
+		n := &node{
+			id:        sn.ID,
+			key:       sn.Key,
+			vector:    sn.Vector,
+			object:    sn.Object,
+			tags:      sn.Tags,
+			expiresAt: sn.ExpiresAt,
+			neighbors: sn.Neighbors,
+		}
+		// gob decodes the layers without links as nil, the levels are kept
+		// by the length of the neighbors still.
+		if len(n.neighbors) == 0 {
+			n.neighbors = make([][]uint64, 1)
+		}
+
+		c.add(n)
+		c.graph.nodes[n.id] = n
+	}
+
+	c.graph.entry = c.graph.nodes[s.EntryID]
+	heap.Init(&c.expiries)
+
+	c.evictExpired(c.now())
+	c.evictBeyondMaxBytes()
+
+	return nil
+}
+
+// SaveSnapshot writes a snapshot to path, replacing the previous one only
+// once it has been written entirely.
+func (c *SemanticCacheHNSW[T]) SaveSnapshot(path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	err = c.Snapshot(file)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// LoadSnapshot restores the snapshot at path, the error wraps
+// fs.ErrNotExist when there is none yet.
+func (c *SemanticCacheHNSW[T]) LoadSnapshot(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return c.Restore(file)
+}
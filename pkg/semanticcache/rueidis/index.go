@@ -0,0 +1,282 @@
+package rueidis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/redis/rueidis"
+)
+
+type Algorithm string
+
+const (
+	// AlgorithmFLAT compares the vectors queried with every vector indexed,
+	// exact, but slow for many vectors.
+	AlgorithmFLAT Algorithm = "FLAT"
+	// AlgorithmHNSW searches a hierarchical navigable small world graph of
+	// the vectors, approximate, but fast for many vectors.
+	AlgorithmHNSW Algorithm = "HNSW"
+)
+
+type VectorType string
+
+const (
+	VectorTypeFloat32 VectorType = "FLOAT32"
+	VectorTypeFloat64 VectorType = "FLOAT64"
+)
+
+type DistanceMetric string
+
+const (
+	DistanceMetricCosine DistanceMetric = "COSINE"
+	// DistanceMetricIP is the inner product, which ranks normalized vectors
+	// as the cosine does, without normalizing them.
+	DistanceMetricIP DistanceMetric = "IP"
+	// DistanceMetricL2 is the squared euclidean distance.
+	DistanceMetricL2 DistanceMetric = "L2"
+)
+
+// The defaults of RediSearch for the HNSW indexes.
+const (
+	defaultM              = 16
+	defaultEfConstruction = 200
+)
+
+// indexVersion is bumped whenever the fields of the index change, so that the
+// indexes of the previous fields are replaced.
+const indexVersion = 2
+
+// vectorArgs returns the attributes of the vector field of the index.
+func (o *rueidisJSONOptions) vectorArgs(dimension int) []string {
+	args := []string{
+		"TYPE", string(o.vectorType),
+		"DIM", strconv.FormatInt(int64(dimension), 10),
+		"DISTANCE_METRIC", string(o.distanceMetric),
+	}
+	if o.algorithm == AlgorithmHNSW {
+		args = append(args,
+			"M", strconv.FormatInt(int64(o.m), 10),
+			"EF_CONSTRUCTION", strconv.FormatInt(int64(o.efConstruction), 10),
+		)
+	}
+
+	return args
+}
+
+// fingerprint identifies the schema of the index, EF_RUNTIME aside as it is
+// passed by the queries.
+func (o *rueidisJSONOptions) fingerprint(dimension int) string {
+	hash := sha256.Sum256([]byte(strings.Join(append([]string{
+		strconv.Itoa(indexVersion),
+		string(o.algorithm),
+	}, o.vectorArgs(dimension)...), " ")))
+
+	return hex.EncodeToString(hash[:])[:12]
+}
+
+// similarity returns the cosine similarity of the vectors of a document to
+// the ones queried from the score of the index. The vectors are expected to
+// be normalized, as the embeddings of OpenAI are, for the IP and L2 metrics.
+func (o *rueidisJSONOptions) similarity(score float64) float64 {
+	switch o.distanceMetric {
+	case DistanceMetricL2:
+		// |a - b|² = 2 - 2 a·b for normalized vectors.
+		return 1 - score/2 //nolint:mnd
+	default:
+		// COSINE scores 1 - cos(a, b), and IP 1 - a·b.
+		return 1 - score
+	}
+}
+
+// legacyIndexName is the name of the index of the versions which did not
+// fingerprint it.
+func (c *SemanticCacheRueidisJSON[T]) legacyIndexName() string {
+	return "jsonidx:" + c.name
+}
+
+// indexName returns the name of the index of the options, which carries the
+// fingerprint of its schema, so that the indexes of other options are told
+// apart by their names.
+func (c *SemanticCacheRueidisJSON[T]) indexName(dimension int) string {
+	return c.legacyIndexName() + ":" + c.options.fingerprint(c.dimension(dimension))
+}
+
+// isStaleIndex reports whether index is an index of the documents of the
+// cache, of other options than the ones of current.
+func (c *SemanticCacheRueidisJSON[T]) isStaleIndex(index string, current string) bool {
+	if index == current {
+		return false
+	}
+	if index == c.legacyIndexName() {
+		return true
+	}
+
+	fingerprint, ok := strings.CutPrefix(index, c.legacyIndexName()+":")
+	if !ok || len(fingerprint) != 12 {
+		return false
+	}
+
+	_, err := hex.DecodeString(fingerprint)
+
+	return err == nil
+}
+
+func (c *SemanticCacheRueidisJSON[T]) dimension(dimension int) int {
+	if c.options.dimension > 0 {
+		return c.options.dimension
+	}
+
+	return dimension
+}
+
+func (c *SemanticCacheRueidisJSON[T]) createIndex(ctx context.Context, name string, dimension int) error {
+	vectorArgs := c.options.vectorArgs(c.dimension(dimension))
+
+	// The tags, of semanticcache.TagFields, pre-filter the KNN queries.
+	cmd := c.rueidis.B().
+		FtCreate().Index(name).OnJson().Prefix(1).Prefix(c.name+":").Schema().
+		FieldName("$.vec").As("vec").Vector(string(c.options.algorithm), int64(len(vectorArgs)), vectorArgs...).
+		FieldName("$.tags.tenant").As("tenant").Tag().Casesensitive().
+		FieldName("$.tags.team").As("team").Tag().Casesensitive().
+		FieldName("$.tags.endpoint").As("endpoint").Tag().Casesensitive().
+		FieldName("$.tags.model").As("model").Tag().Casesensitive().
+		FieldName("$.tags.system_prompt").As("system_prompt").Tag().Casesensitive().
+		Build()
+
+	err := c.rueidis.Do(ctx, cmd).Error()
+	// Another replica may have just created it.
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "already exists") {
+		return err
+	}
+
+	return nil
+}
+
+// indexed reports whether RediSearch has finished indexing the documents
+// existing when index was created.
+func (c *SemanticCacheRueidisJSON[T]) indexed(ctx context.Context, index string) (bool, error) {
+	info, err := c.rueidis.Do(ctx, c.rueidis.B().FtInfo().Index(index).Build()).AsMap()
+	if err != nil {
+		return false, err
+	}
+
+	percent, ok := info["percent_indexed"]
+	if !ok {
+		return true, nil
+	}
+
+	indexed, err := percent.AsFloat64()
+	if err != nil {
+		return false, err
+	}
+
+	return indexed >= 1, nil
+}
+
+// isUnknownIndex reports whether err is the error of RediSearch for an index
+// which does not exist.
+func isUnknownIndex(err error) bool {
+	message := strings.ToLower(err.Error())
+
+	return strings.Contains(message, "unknown index") || strings.Contains(message, "no such index")
+}
+
+// ensureIndex returns the name of the index to query, and the name of the
+// index of the options, which it creates when missing. The documents are
+// indexed again in the background by the index created, RediSearch allowing
+// several indexes of the same documents: the queries are answered from an
+// index of other options, of a previous configuration, meanwhile, which is
+// dropped once the index created has indexed every document, the documents
+// kept. The index of the options is then memoized, until forgotten by
+// forgetIndex.
+func (c *SemanticCacheRueidisJSON[T]) ensureIndex(ctx context.Context, dimension int) (string, string, error) {
+	name := c.indexName(dimension)
+
+	c.mutex.RLock()
+	resolved := c.resolved[name]
+	c.mutex.RUnlock()
+
+	if resolved {
+		return name, name, nil
+	}
+
+	indexes, err := c.rueidis.Do(ctx, c.rueidis.B().FtList().Build()).AsStrSlice()
+	if err != nil {
+		return "", "", err
+	}
+
+	exists := false
+	stale := make([]string, 0)
+
+	for _, index := range indexes {
+		if index == name {
+			exists = true
+		}
+		if c.isStaleIndex(index, name) {
+			stale = append(stale, index)
+		}
+	}
+
+	if !exists {
+		err = c.createIndex(ctx, name, dimension)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if len(stale) > 0 {
+		indexed, err := c.indexed(ctx, name)
+		if err != nil || !indexed {
+			return stale[len(stale)-1], name, nil //nolint:nilerr
+		}
+
+		for _, index := range stale {
+			err = c.rueidis.Do(ctx, c.rueidis.B().FtDropindex().Index(index).Build()).Error()
+			if err != nil && !isUnknownIndex(err) {
+				return "", "", err
+			}
+		}
+	}
+
+	c.mutex.Lock()
+	c.resolved[name] = true
+	c.mutex.Unlock()
+
+	return name, name, nil
+}
+
+// forgetIndex forgets that the index name was resolved, e.g. once dropped,
+// so that it is created again by the next call to ensureIndex.
+func (c *SemanticCacheRueidisJSON[T]) forgetIndex(name string) {
+	c.mutex.Lock()
+	delete(c.resolved, name)
+	c.mutex.Unlock()
+}
+
+// queryVectors returns the vectors queried as the parameter of the type of
+// the index.
+func (o *rueidisJSONOptions) queryVectors(vectors []float64) string {
+	if o.vectorType == VectorTypeFloat32 {
+		float32s := make([]float32, len(vectors))
+		for i, v := range vectors {
+			float32s[i] = float32(v)
+		}
+
+		return rueidis.VectorString32(float32s)
+	}
+
+	return rueidis.VectorString64(vectors)
+}
+
+// knnQuery returns the KNN query of the first documents pre-filtered by
+// filter.
+func (o *rueidisJSONOptions) knnQuery(filter string, first int) string {
+	query := "(" + filter + ")=>[KNN " + strconv.FormatInt(int64(first), 10) + " @vec $V"
+	if o.algorithm == AlgorithmHNSW && o.efRuntime > 0 {
+		query += " EF_RUNTIME " + strconv.FormatInt(int64(o.efRuntime), 10)
+	}
+
+	return query + " AS cache_score]"
+}
@@ -3,13 +3,22 @@ package rueidis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/redis/rueidis"
 	"github.com/redis/rueidis/om"
-	"github.com/samber/lo"
+
+	"github.com/lingticio/llmg/pkg/semanticcache"
+)
+
+var (
+	ErrEmptyTag = errors.New("the filters of empty tags are not supported")
 )
 
 const (
@@ -18,7 +27,13 @@ const (
 )
 
 type rueidisJSONOptions struct {
-	dimension int
+	dimension      int
+	algorithm      Algorithm
+	vectorType     VectorType
+	distanceMetric DistanceMetric
+	m              int
+	efConstruction int
+	efRuntime      int
 }
 
 func WithDimension(dimension int) RueidisJSONCallOption {
@@ -27,6 +42,42 @@ func WithDimension(dimension int) RueidisJSONCallOption {
 	}
 }
 
+// WithFLAT indexes the vectors with the FLAT algorithm, the default.
+func WithFLAT() RueidisJSONCallOption {
+	return func(o *rueidisJSONOptions) {
+		o.algorithm = AlgorithmFLAT
+	}
+}
+
+// WithHNSW indexes the vectors with the HNSW algorithm, linking the vectors
+// to m neighbors among efConstruction candidates, 16 and 200 when 0, and
+// searching efRuntime candidates, the default of the index when 0. Changing
+// efRuntime does not require indexing the vectors again.
+func WithHNSW(m int, efConstruction int, efRuntime int) RueidisJSONCallOption {
+	return func(o *rueidisJSONOptions) {
+		o.algorithm = AlgorithmHNSW
+		o.m = m
+		o.efConstruction = efConstruction
+		o.efRuntime = efRuntime
+	}
+}
+
+// WithVectorType sets the type of the elements of the vectors indexed,
+// FLOAT64 by default. FLOAT32 halves the memory of the index.
+func WithVectorType(vectorType VectorType) RueidisJSONCallOption {
+	return func(o *rueidisJSONOptions) {
+		o.vectorType = vectorType
+	}
+}
+
+// WithDistanceMetric sets the metric of the vectors indexed, COSINE by
+// default.
+func WithDistanceMetric(distanceMetric DistanceMetric) RueidisJSONCallOption {
+	return func(o *rueidisJSONOptions) {
+		o.distanceMetric = distanceMetric
+	}
+}
+
 type RueidisJSONCallOption func(*rueidisJSONOptions)
 
 func applyRueidisJSONCallOptions(defaultOpts *rueidisJSONOptions, opts []RueidisJSONCallOption) *rueidisJSONOptions {
@@ -34,55 +85,69 @@ func applyRueidisJSONCallOptions(defaultOpts *rueidisJSONOptions, opts []Rueidis
 		o(defaultOpts)
 	}
 
-	return defaultOpts
-}
+	if defaultOpts.m <= 0 {
+		defaultOpts.m = defaultM
+	}
+	if defaultOpts.efConstruction <= 0 {
+		defaultOpts.efConstruction = defaultEfConstruction
+	}
 
-type Cached[T any] struct {
-	Key    string    `json:"key" redis:",key"` // the redis:",key" is required to indicate which field is the ULID key
-	Ver    int64     `json:"ver" redis:",ver"` // the redis:",ver" is required to do optimistic locking to prevent lost update
-	Vec    []float64 `json:"vec"`
-	Object T         `json:"object"`
+	return defaultOpts
 }
 
-type Retrieved[T any] struct {
-	Key    string  `json:"key"`
-	Score  float64 `json:"score"`
-	Object T       `json:"object"`
-}
+var _ semanticcache.Cache[any] = (*SemanticCacheRueidisJSON[any])(nil)
 
+// SemanticCacheRueidisJSON caches the objects as JSON documents of Redis,
+// which must provide RediSearch and RedisJSON, and retrieves them with a KNN
+// query of their vectors, pre-filtered by their tags. The index is created
+// on the first retrieval, and created again, from the documents cached, when
+// its options change, the index of the previous options answering the
+// retrievals until the documents are indexed again.
 type SemanticCacheRueidisJSON[T any] struct {
 	name    string
 	rueidis rueidis.Client
-	repo    om.Repository[Cached[*T]]
+	repo    om.Repository[semanticcache.Cached[*T]]
 	options *rueidisJSONOptions
+
+	mutex sync.RWMutex
+	// resolved are the indexes which exist and replaced the indexes of
+	// previous options, by name, see ensureIndex.
+	resolved map[string]bool
 }
 
 func RueidisJSON[T any](name string, rueidis rueidis.Client, callOptions ...RueidisJSONCallOption) *SemanticCacheRueidisJSON[T] {
-	var t Cached[*T]
+	var t semanticcache.Cached[*T]
 
-	opts := applyRueidisJSONCallOptions(&rueidisJSONOptions{}, callOptions)
+	opts := applyRueidisJSONCallOptions(&rueidisJSONOptions{
+		algorithm:      AlgorithmFLAT,
+		vectorType:     VectorTypeFloat64,
+		distanceMetric: DistanceMetricCosine,
+	}, callOptions)
 
 	return &SemanticCacheRueidisJSON[T]{
-		name:    name,
-		rueidis: rueidis,
-		repo:    om.NewJSONRepository(name, t, rueidis),
-		options: opts,
+		name:     name,
+		rueidis:  rueidis,
+		repo:     om.NewJSONRepository(name, t, rueidis),
+		options:  opts,
+		resolved: make(map[string]bool),
 	}
 }
 
-func (c *SemanticCacheRueidisJSON[T]) newCached(doc *T, vectors []float64) *Cached[*T] {
+func (c *SemanticCacheRueidisJSON[T]) newCached(doc *T, vectors []float64, tags semanticcache.Tags) *semanticcache.Cached[*T] {
 	entity := c.repo.NewEntity()
 
-	return &Cached[*T]{
+	return &semanticcache.Cached[*T]{
 		Key:    entity.Key,
 		Ver:    entity.Ver,
 		Vec:    vectors,
+		Tags:   tags,
 		Object: doc,
 	}
 }
 
-func (c *SemanticCacheRueidisJSON[T]) CacheVectors(ctx context.Context, doc *T, vectors []float64, seconds time.Duration) (*Cached[*T], error) {
-	cached := c.newCached(doc, vectors)
+func (c *SemanticCacheRueidisJSON[T]) CacheVectors(ctx context.Context, doc *T, vectors []float64, seconds time.Duration, opts ...semanticcache.CacheCallOption) (*semanticcache.Cached[*T], error) {
+	options := semanticcache.ApplyCacheCallOptions(opts)
+	cached := c.newCached(doc, vectors, options.Tags)
 
 	err := c.repo.Save(ctx, cached)
 	if err != nil {
@@ -102,52 +167,16 @@ func (c *SemanticCacheRueidisJSON[T]) CacheVectors(ctx context.Context, doc *T,
 	return cached, nil
 }
 
-func (c *SemanticCacheRueidisJSON[T]) createIndex(ctx context.Context, dimension int) error {
-	dim := dimension
-	if c.options.dimension > 0 {
-		dim = c.options.dimension
-	}
-
-	err := c.repo.CreateIndex(ctx, func(schema om.FtCreateSchema) rueidis.Completed {
-		return schema.
-			FieldName("$.vec").As("vec").Vector("FLAT", 6, "TYPE", "FLOAT64", "DIM", strconv.FormatInt(int64(dim), 10), "DISTANCE_METRIC", "COSINE"). //nolint:mnd
-			Build()
-	})
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (c *SemanticCacheRueidisJSON[T]) ensureIndex(ctx context.Context, dimension int) error {
-	cmd := c.rueidis.B().FtList().Build()
-
-	indexes, err := c.rueidis.Do(ctx, cmd).AsStrSlice()
-	if err != nil {
-		return err
-	}
-
-	indexName := c.repo.IndexName()
-	for _, idx := range indexes {
-		if idx == indexName {
-			return nil
-		}
-	}
-
-	return c.createIndex(ctx, dimension)
-}
-
-func (c *SemanticCacheRueidisJSON[T]) RetrieveTop3ByVectors(ctx context.Context, vectors []float64) ([]*Retrieved[*T], error) {
+func (c *SemanticCacheRueidisJSON[T]) RetrieveTop3ByVectors(ctx context.Context, vectors []float64) ([]*semanticcache.Retrieved[*T], error) {
 	return c.RetrieveByVectors(ctx, vectors, retrieveTop3)
 }
 
-func (c *SemanticCacheRueidisJSON[T]) RetrieveTop10ByVectors(ctx context.Context, vectors []float64) ([]*Retrieved[*T], error) {
+func (c *SemanticCacheRueidisJSON[T]) RetrieveTop10ByVectors(ctx context.Context, vectors []float64) ([]*semanticcache.Retrieved[*T], error) {
 	return c.RetrieveByVectors(ctx, vectors, retrieveTop10)
 }
 
-func (c *SemanticCacheRueidisJSON[T]) RetrieveFirstByVectors(ctx context.Context, vectors []float64) (*Retrieved[*T], error) {
-	retrieved, err := c.RetrieveByVectors(ctx, vectors, 1)
+func (c *SemanticCacheRueidisJSON[T]) RetrieveFirstByVectors(ctx context.Context, vectors []float64, opts ...semanticcache.RetrieveCallOption) (*semanticcache.Retrieved[*T], error) {
+	retrieved, err := c.RetrieveByVectors(ctx, vectors, 1, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,36 +187,104 @@ func (c *SemanticCacheRueidisJSON[T]) RetrieveFirstByVectors(ctx context.Context
 	return retrieved[0], nil
 }
 
-func (c *SemanticCacheRueidisJSON[T]) RetrieveByVectors(ctx context.Context, vectors []float64, first int) ([]*Retrieved[*T], error) {
-	err := c.ensureIndex(ctx, len(vectors))
-	if err != nil {
-		return nil, err
+// escapeTag escapes the punctuation and the spaces of a tag, which would
+// otherwise be parsed by the query.
+func escapeTag(tag string) string {
+	var b strings.Builder
+
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
 	}
 
+	return b.String()
+}
+
+// filterQuery returns the query pre-filtering the KNN query by the tags of
+// filter, all the documents when it is nil. RediSearch does not index the
+// empty tags, which cannot be queried: the filters of empty tags are rejected
+// with ErrEmptyTag, rather than matching the documents of any.
+func filterQuery(filter *semanticcache.Tags) (string, error) {
+	if filter == nil {
+		return "*", nil
+	}
+
+	fields := filter.Fields()
+	clauses := make([]string, 0, len(fields))
+
+	for _, field := range semanticcache.TagFields {
+		if fields[field] == "" {
+			return "", fmt.Errorf("%w: %s", ErrEmptyTag, field)
+		}
+
+		clauses = append(clauses, "@"+field+":{"+escapeTag(fields[field])+"}")
+	}
+
+	return strings.Join(clauses, " "), nil
+}
+
+// search runs the KNN query of the first documents nearest to vectors,
+// pre-filtered by filter, on index.
+func (c *SemanticCacheRueidisJSON[T]) search(ctx context.Context, index string, filter string, vectors []float64, first int) ([]rueidis.FtSearchDoc, error) {
 	cmd := c.rueidis.B().
-		FtSearch().Index(c.repo.IndexName()).Query("(*)=>[KNN "+strconv.FormatInt(int64(first), 10)+" @vec $V AS cache_score]").
+		FtSearch().Index(index).Query(c.options.knnQuery(filter, first)).
 		Return("3").Identifier("$.object").Identifier("__vec_score").Identifier("cache_score").
 		Sortby("cache_score").
-		Params().Nargs(2).NameValue().NameValue("V", rueidis.VectorString64(vectors)). //nolint:mnd
+		Limit().OffsetNum(0, int64(first)).
+		Params().Nargs(2).NameValue().NameValue("V", c.options.queryVectors(vectors)). //nolint:mnd
 		Dialect(2).                                                                    //nolint:mnd
 		Build()
 
-	resp := c.rueidis.Do(ctx, cmd)
+	_, records, err := c.rueidis.Do(ctx, cmd).AsFtSearch()
 
-	_, records, err := resp.AsFtSearch()
+	return records, err
+}
+
+func (c *SemanticCacheRueidisJSON[T]) RetrieveByVectors(ctx context.Context, vectors []float64, first int, opts ...semanticcache.RetrieveCallOption) ([]*semanticcache.Retrieved[*T], error) {
+	options := semanticcache.ApplyRetrieveCallOptions(opts)
+
+	filter, err := filterQuery(options.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	index, current, err := c.ensureIndex(ctx, len(vectors))
 	if err != nil {
 		return nil, err
 	}
 
-	records = lo.Map(records, func(record rueidis.FtSearchDoc, _ int) rueidis.FtSearchDoc {
-		record.Score, _ = strconv.ParseFloat(record.Doc["cache_score"], 64)
-		return record
-	})
+	records, err := c.search(ctx, index, filter, vectors, first)
+	// The index of previous options may not answer the vectors of the
+	// current ones, e.g. of another dimension.
+	if err != nil && index != current {
+		records, err = c.search(ctx, current, filter, vectors, first)
+	}
+	if err != nil {
+		if isUnknownIndex(err) {
+			c.forgetIndex(current)
+		}
+
+		return nil, err
+	}
+
+	retrieved := make([]*semanticcache.Retrieved[*T], 0, len(records))
+
+	for _, record := range records {
+		score, _ := strconv.ParseFloat(record.Doc["cache_score"], 64)
+
+		similarity := c.options.similarity(score)
+		if similarity < options.MinSimilarity {
+			break
+		}
 
-	return lo.Map(records, func(record rueidis.FtSearchDoc, _ int) *Retrieved[*T] {
 		var object T
 		_ = json.Unmarshal([]byte(record.Doc["$.object"]), &object)
 
-		return &Retrieved[*T]{Key: record.Key, Score: record.Score, Object: &object}
-	}), nil
+		retrieved = append(retrieved, &semanticcache.Retrieved[*T]{Key: record.Key, Score: similarity, Object: &object})
+	}
+
+	return retrieved, nil
 }
@@ -6,7 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lingticio/llmg/internal/configs"
 	"github.com/lingticio/llmg/internal/datastore"
+	"github.com/lingticio/llmg/pkg/semanticcache"
+	"github.com/lingticio/llmg/pkg/semanticcache/semanticcachetest"
+	"github.com/lingticio/llmg/pkg/util/nanoid"
 	"github.com/nekomeowww/xo"
 	"github.com/samber/lo"
 	"github.com/sashabaranov/go-openai"
@@ -20,7 +24,7 @@ type Example struct {
 }
 
 func TestSemanticCache(t *testing.T) {
-	r, err := datastore.NewRueidis()()
+	r, err := datastore.NewRueidisClient(configs.Redis{Host: "localhost", Port: "6379"})
 	require.NoError(t, err)
 	require.NotNil(t, r)
 
@@ -83,3 +87,118 @@ func TestSemanticCache(t *testing.T) {
 		xo.PrintJSON(retrieved)
 	}
 }
+
+func TestSemanticCacheRueidisJSON(t *testing.T) {
+	r, err := datastore.NewRueidisClient(configs.Redis{Host: "localhost", Port: "6379"})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	semanticcachetest.Run(t, func(t *testing.T) semanticcache.Cache[semanticcachetest.Doc] {
+		c := RueidisJSON[semanticcachetest.Doc]("semantic_cache_test_"+nanoid.New(), r, WithDimension(4), WithHNSW(8, 64, 32), WithVectorType(VectorTypeFloat32))
+
+		t.Cleanup(func() {
+			_ = r.Do(context.Background(), r.B().FtDropindex().Index(c.indexName(4)).Dd().Build()).Error()
+		})
+
+		return c
+	})
+}
+
+func TestFilterQuery(t *testing.T) {
+	query, err := filterQuery(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "*", query)
+
+	query, err = filterQuery(&semanticcache.Tags{Tenant: "tenant-a", Team: "b", Endpoint: "c:d", Model: "gpt-4o.mini", SystemPrompt: "f"})
+	require.NoError(t, err)
+	assert.Equal(t, `@tenant:{tenant\-a} @team:{b} @endpoint:{c\:d} @model:{gpt\-4o\.mini} @system_prompt:{f}`, query)
+
+	// The empty tags cannot be queried, rather than matching any.
+	_, err = filterQuery(&semanticcache.Tags{Tenant: "tenant-a", Model: "gpt-4o.mini"})
+	require.ErrorIs(t, err, ErrEmptyTag)
+
+	_, err = filterQuery(&semanticcache.Tags{})
+	require.ErrorIs(t, err, ErrEmptyTag)
+}
+
+func TestRueidisJSONOptions(t *testing.T) {
+	flat := RueidisJSON[Example]("cache", nil)
+	assert.Equal(t, []string{"TYPE", "FLOAT64", "DIM", "4", "DISTANCE_METRIC", "COSINE"}, flat.options.vectorArgs(4))
+	assert.Equal(t, "(*)=>[KNN 3 @vec $V AS cache_score]", flat.options.knnQuery("*", 3))
+	assert.InDelta(t, 0.9, flat.options.similarity(0.1), 1e-9)
+
+	hnsw := RueidisJSON[Example]("cache", nil, WithHNSW(0, 0, 64), WithVectorType(VectorTypeFloat32), WithDistanceMetric(DistanceMetricL2))
+	assert.Equal(t, []string{"TYPE", "FLOAT32", "DIM", "4", "DISTANCE_METRIC", "L2", "M", "16", "EF_CONSTRUCTION", "200"}, hnsw.options.vectorArgs(4))
+	assert.Equal(t, "(*)=>[KNN 3 @vec $V EF_RUNTIME 64 AS cache_score]", hnsw.options.knnQuery("*", 3))
+	assert.InDelta(t, 0.9, hnsw.options.similarity(0.2), 1e-9)
+	assert.Len(t, hnsw.options.queryVectors([]float64{1, 0, 0, 0}), 16)
+	assert.Len(t, flat.options.queryVectors([]float64{1, 0, 0, 0}), 32)
+
+	ip := RueidisJSON[Example]("cache", nil, WithDistanceMetric(DistanceMetricIP))
+	assert.InDelta(t, 0.9, ip.options.similarity(0.1), 1e-9)
+}
+
+func TestSemanticCacheRueidisJSON_IndexName(t *testing.T) {
+	flat := RueidisJSON[Example]("cache", nil)
+	hnsw := RueidisJSON[Example]("cache", nil, WithHNSW(16, 200, 10))
+	tuned := RueidisJSON[Example]("cache", nil, WithHNSW(16, 200, 100))
+
+	assert.Equal(t, flat.indexName(4), flat.indexName(4))
+	assert.NotEqual(t, flat.indexName(4), flat.indexName(8))
+	assert.NotEqual(t, flat.indexName(4), hnsw.indexName(4))
+	// EF_RUNTIME is passed by the queries, rather than indexed.
+	assert.Equal(t, hnsw.indexName(4), tuned.indexName(4))
+
+	dimensioned := RueidisJSON[Example]("cache", nil, WithDimension(4))
+	assert.Equal(t, flat.indexName(4), dimensioned.indexName(1536))
+
+	current := hnsw.indexName(4)
+	assert.False(t, hnsw.isStaleIndex(current, current))
+	assert.True(t, hnsw.isStaleIndex("jsonidx:cache", current))
+	assert.True(t, hnsw.isStaleIndex(flat.indexName(4), current))
+	assert.False(t, hnsw.isStaleIndex("jsonidx:cache_v2", current))
+	assert.False(t, hnsw.isStaleIndex("jsonidx:cache:other", current))
+	assert.False(t, hnsw.isStaleIndex(RueidisJSON[Example]("other", nil).indexName(4), current))
+}
+
+func TestSemanticCacheRueidisJSON_ReplaceIndex(t *testing.T) {
+	r, err := datastore.NewRueidisClient(configs.Redis{Host: "localhost", Port: "6379"})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	name := "semantic_cache_test_" + nanoid.New()
+	flat := RueidisJSON[Example](name, r, WithDimension(4))
+	hnsw := RueidisJSON[Example](name, r, WithDimension(4), WithHNSW(8, 64, 32))
+
+	t.Cleanup(func() {
+		_ = r.Do(context.Background(), r.B().FtDropindex().Index(flat.indexName(4)).Dd().Build()).Error()
+		_ = r.Do(context.Background(), r.B().FtDropindex().Index(hnsw.indexName(4)).Dd().Build()).Error()
+	})
+
+	_, err = flat.CacheVectors(context.Background(), &Example{Query: "north"}, []float64{1, 0, 0, 0}, 0)
+	require.NoError(t, err)
+
+	index, current, err := flat.ensureIndex(context.Background(), 4)
+	require.NoError(t, err)
+	assert.Equal(t, flat.indexName(4), index)
+	assert.Equal(t, index, current)
+
+	// The documents are retrieved all along, from the index of the previous
+	// options until they are indexed again.
+	require.Eventually(t, func() bool {
+		retrieved, err := hnsw.RetrieveByVectors(context.Background(), []float64{1, 0, 0, 0}, 1)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 1)
+		assert.Equal(t, "north", retrieved[0].Object.Query)
+
+		index, current, err := hnsw.ensureIndex(context.Background(), 4)
+		require.NoError(t, err)
+
+		return index == current
+	}, 10*time.Second, 50*time.Millisecond)
+
+	indexes, err := r.Do(context.Background(), r.B().FtList().Build()).AsStrSlice()
+	require.NoError(t, err)
+	assert.Contains(t, indexes, hnsw.indexName(4))
+	assert.NotContains(t, indexes, flat.indexName(4))
+}
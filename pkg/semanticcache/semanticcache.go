@@ -0,0 +1,121 @@
+// Package semanticcache serves the chat completions of questions similar
+// enough to the ones already answered from the answers cached, by the
+// similarity of the embeddings of the questions and of their context.
+package semanticcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sashabaranov/go-openai"
+)
+
+// contextMessages bounds how many of the turns preceding the last user turn
+// are embedded along with it, besides the system messages.
+const contextMessages = 4
+
+// Policy is the semantic caching of the chat completions of an endpoint.
+type Policy struct {
+	// Threshold is the similarity, between 0 and 1, above which cached
+	// answers are served, the default of the cache when 0.
+	Threshold float64
+	// TTL is how long answers are cached, the default of the cache when 0.
+	TTL time.Duration
+}
+
+// Normalize lower-cases s and collapses its whitespaces, so that questions
+// differing only by them are embedded alike.
+func Normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func messageText(message openai.ChatCompletionMessage) string {
+	if len(message.MultiContent) == 0 {
+		return message.Content
+	}
+
+	return strings.Join(lo.FilterMap(message.MultiContent, func(item openai.ChatMessagePart, _ int) (string, bool) {
+		return item.Text, item.Type == openai.ChatMessagePartTypeText
+	}), " ")
+}
+
+// Cacheable reports whether the answer to request may be served from, and
+// stored into, the cache: the request must end with a user turn of text, ask
+// for a single choice, and not involve tools, whose calls depend on more
+// than the conversation.
+func Cacheable(request openai.ChatCompletionRequest) bool {
+	if len(request.Messages) == 0 || request.N > 1 || len(request.Tools) > 0 || len(request.Functions) > 0 {
+		return false
+	}
+
+	last := request.Messages[len(request.Messages)-1]
+	if last.Role != openai.ChatMessageRoleUser {
+		return false
+	}
+
+	return lo.EveryBy(last.MultiContent, func(item openai.ChatMessagePart) bool {
+		return item.Type == openai.ChatMessagePartTypeText
+	})
+}
+
+// Text returns the text embedded for request: its system messages, the last
+// turns preceding its last user turn, then the last user turn itself, each
+// normalized and prefixed by its role.
+func Text(request openai.ChatCompletionRequest) string {
+	if len(request.Messages) == 0 {
+		return ""
+	}
+
+	last := len(request.Messages) - 1
+
+	var lines []string
+
+	for i, message := range request.Messages {
+		if message.Role != openai.ChatMessageRoleSystem && i < last-contextMessages {
+			continue
+		}
+
+		text := Normalize(messageText(message))
+		if text == "" {
+			continue
+		}
+
+		lines = append(lines, message.Role+": "+text)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// AcceptsResponse reports whether response may be cached, i.e. every choice
+// of it finished on its own, rather than on the length limit or the content
+// filter.
+func AcceptsResponse(response openai.ChatCompletionResponse) bool {
+	if len(response.Choices) == 0 {
+		return false
+	}
+
+	return lo.EveryBy(response.Choices, func(item openai.ChatCompletionChoice) bool {
+		return item.FinishReason == openai.FinishReasonStop && len(item.Message.ToolCalls) == 0
+	})
+}
+
+// SystemPromptHash returns a hash of the system messages of request, so that
+// the answers to the same questions are only served to the requests of the
+// same instructions, the same for the requests of none.
+func SystemPromptHash(request openai.ChatCompletionRequest) string {
+	hash := sha256.New()
+
+	for _, message := range request.Messages {
+		if message.Role != openai.ChatMessageRoleSystem {
+			continue
+		}
+
+		hash.Write([]byte(messageText(message)))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
@@ -0,0 +1,95 @@
+package semanticcache
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "when was chatgpt released?", Normalize("  When was\n ChatGPT\treleased? "))
+}
+
+func TestCacheable(t *testing.T) {
+	question := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: "When was ChatGPT released?"}
+
+	assert.True(t, Cacheable(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{question}}))
+	assert.False(t, Cacheable(openai.ChatCompletionRequest{}))
+	assert.False(t, Cacheable(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{question}, N: 2}))
+	assert.False(t, Cacheable(openai.ChatCompletionRequest{
+		Messages: []openai.ChatCompletionMessage{question},
+		Tools:    []openai.Tool{{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{Name: "search"}}},
+	}))
+	assert.False(t, Cacheable(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{
+		question,
+		{Role: openai.ChatMessageRoleAssistant, Content: "November 30, 2022."},
+	}}))
+	assert.False(t, Cacheable(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleUser, MultiContent: []openai.ChatMessagePart{
+			{Type: openai.ChatMessagePartTypeText, Text: "What is this?"},
+			{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{URL: "https://example.com/image.png"}},
+		}},
+	}}))
+}
+
+func TestText(t *testing.T) {
+	messages := []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: "You are the  FAQ bot."},
+	}
+	for range 3 {
+		messages = append(messages,
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: "Hi"},
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "Hello!"},
+		)
+	}
+
+	messages = append(messages, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, MultiContent: []openai.ChatMessagePart{
+		{Type: openai.ChatMessagePartTypeText, Text: "How do I reset"},
+		{Type: openai.ChatMessagePartTypeText, Text: "my password?"},
+	}})
+
+	// The system message is kept, along with the last 4 turns preceding the
+	// last user turn.
+	assert.Equal(t, "system: you are the faq bot.\n"+
+		"user: hi\nassistant: hello!\nuser: hi\nassistant: hello!\n"+
+		"user: how do i reset my password?", Text(openai.ChatCompletionRequest{Messages: messages}))
+}
+
+func TestAcceptsResponse(t *testing.T) {
+	assert.True(t, AcceptsResponse(openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+		{FinishReason: openai.FinishReasonStop},
+	}}))
+	assert.False(t, AcceptsResponse(openai.ChatCompletionResponse{}))
+	assert.False(t, AcceptsResponse(openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+		{FinishReason: openai.FinishReasonLength},
+	}}))
+}
+
+func TestSystemPromptHash(t *testing.T) {
+	question := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: "When was ChatGPT released?"}
+	pirate := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleSystem, Content: "Answer as a pirate."}
+	poet := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleSystem, Content: "Answer as a poet."}
+
+	none := SystemPromptHash(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{question}})
+	assert.NotEmpty(t, none)
+	assert.Equal(t, none, SystemPromptHash(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Who founded OpenAI?"}}}))
+
+	asPirate := SystemPromptHash(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{pirate, question}})
+	assert.NotEqual(t, none, asPirate)
+	assert.NotEqual(t, asPirate, SystemPromptHash(openai.ChatCompletionRequest{Messages: []openai.ChatCompletionMessage{poet, question}}))
+}
+
+func TestTags_Match(t *testing.T) {
+	tags := Tags{Tenant: "a", Team: "b", Endpoint: "c", Model: "d", SystemPrompt: "e"}
+
+	assert.True(t, tags.Match(tags))
+	assert.True(t, Tags{}.Match(Tags{}))
+	assert.False(t, Tags{Tenant: "a", Model: "x"}.Match(tags))
+	assert.False(t, Tags{Endpoint: "c"}.Match(Tags{}))
+
+	// The empty tags are not wildcards.
+	assert.False(t, Tags{}.Match(tags))
+	assert.False(t, Tags{Tenant: "a", Model: "d"}.Match(tags))
+	assert.True(t, Tags{Tenant: "a", Model: "d"}.Match(Tags{Tenant: "a", Model: "d"}))
+}
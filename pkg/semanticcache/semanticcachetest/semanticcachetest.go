@@ -0,0 +1,165 @@
+// Package semanticcachetest tests the implementations of semanticcache.Cache
+// alike, so that they remain interchangeable.
+package semanticcachetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lingticio/llmg/pkg/semanticcache"
+)
+
+type Doc struct {
+	Query string `json:"query"`
+}
+
+var (
+	north     = []float64{1, 0, 0, 0}
+	northEast = []float64{1, 1, 0, 0}
+	east      = []float64{0, 1, 0, 0}
+	south     = []float64{-1, 0, 0, 0}
+)
+
+// Run runs the suite against the caches newCache creates, each one empty.
+func Run(t *testing.T, newCache func(t *testing.T) semanticcache.Cache[Doc]) {
+	t.Helper()
+
+	t.Run("RetrievesNearestFirst", func(t *testing.T) {
+		c := newCache(t)
+
+		for query, vectors := range map[string][]float64{"north": north, "east": east, "south": south} {
+			_, err := c.CacheVectors(context.Background(), &Doc{Query: query}, vectors, 0)
+			require.NoError(t, err)
+		}
+
+		retrieved, err := c.RetrieveByVectors(context.Background(), northEast, 3)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 3)
+
+		assert.ElementsMatch(t, []string{"north", "east"}, []string{retrieved[0].Object.Query, retrieved[1].Object.Query})
+		assert.Equal(t, "south", retrieved[2].Object.Query)
+		assert.InDelta(t, 0.7071, retrieved[0].Score, 1e-3)
+		assert.InDelta(t, 0.7071, retrieved[1].Score, 1e-3)
+		assert.InDelta(t, -0.7071, retrieved[2].Score, 1e-3)
+	})
+
+	t.Run("RetrievesFirst", func(t *testing.T) {
+		c := newCache(t)
+
+		for query, vectors := range map[string][]float64{"north": north, "east": east, "south": south} {
+			_, err := c.CacheVectors(context.Background(), &Doc{Query: query}, vectors, 0)
+			require.NoError(t, err)
+		}
+
+		retrieved, err := c.RetrieveByVectors(context.Background(), south, 2)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 2)
+		assert.Equal(t, "south", retrieved[0].Object.Query)
+		assert.Equal(t, "east", retrieved[1].Object.Query)
+	})
+
+	t.Run("RetrievesExactMatch", func(t *testing.T) {
+		c := newCache(t)
+
+		cached, err := c.CacheVectors(context.Background(), &Doc{Query: "north"}, north, 0)
+		require.NoError(t, err)
+		require.NotEmpty(t, cached.Key)
+
+		retrieved, err := c.RetrieveFirstByVectors(context.Background(), []float64{2, 0, 0, 0})
+		require.NoError(t, err)
+		require.NotNil(t, retrieved)
+		assert.Equal(t, "north", retrieved.Object.Query)
+		assert.InDelta(t, 1, retrieved.Score, 1e-6)
+	})
+
+	t.Run("RetrievesNothingWhenEmpty", func(t *testing.T) {
+		c := newCache(t)
+
+		retrieved, err := c.RetrieveFirstByVectors(context.Background(), north)
+		require.NoError(t, err)
+		assert.Nil(t, retrieved)
+
+		all, err := c.RetrieveByVectors(context.Background(), north, 3)
+		require.NoError(t, err)
+		assert.Empty(t, all)
+	})
+
+	t.Run("RetrievesAboveMinSimilarity", func(t *testing.T) {
+		c := newCache(t)
+
+		for query, vectors := range map[string][]float64{"north": north, "east": east, "south": south} {
+			_, err := c.CacheVectors(context.Background(), &Doc{Query: query}, vectors, 0)
+			require.NoError(t, err)
+		}
+
+		retrieved, err := c.RetrieveByVectors(context.Background(), northEast, 3, semanticcache.WithMinSimilarity(0.5))
+		require.NoError(t, err)
+		assert.Len(t, retrieved, 2)
+
+		retrieved, err = c.RetrieveByVectors(context.Background(), northEast, 3, semanticcache.WithMinSimilarity(0.9))
+		require.NoError(t, err)
+		assert.Empty(t, retrieved)
+
+		first, err := c.RetrieveFirstByVectors(context.Background(), south, semanticcache.WithMinSimilarity(0.99))
+		require.NoError(t, err)
+		require.NotNil(t, first)
+		assert.Equal(t, "south", first.Object.Query)
+	})
+
+	t.Run("RetrievesByTags", func(t *testing.T) {
+		c := newCache(t)
+
+		tenantA := semanticcache.Tags{Tenant: "tenant-a", Team: "team.a", Endpoint: "endpoint:a", Model: "gpt-4o", SystemPrompt: "0123"}
+		tenantB := semanticcache.Tags{Tenant: "tenant-b", Team: "team.b", Endpoint: "endpoint:b", Model: "gpt-4o", SystemPrompt: "0123"}
+
+		_, err := c.CacheVectors(context.Background(), &Doc{Query: "a"}, north, 0, semanticcache.WithTags(tenantA))
+		require.NoError(t, err)
+		_, err = c.CacheVectors(context.Background(), &Doc{Query: "b"}, northEast, 0, semanticcache.WithTags(tenantB))
+		require.NoError(t, err)
+
+		retrieved, err := c.RetrieveByVectors(context.Background(), northEast, 2, semanticcache.WithFilter(tenantA))
+		require.NoError(t, err)
+		require.Len(t, retrieved, 1)
+		assert.Equal(t, "a", retrieved[0].Object.Query)
+
+		retrieved, err = c.RetrieveByVectors(context.Background(), north, 2, semanticcache.WithFilter(tenantB))
+		require.NoError(t, err)
+		require.Len(t, retrieved, 1)
+		assert.Equal(t, "b", retrieved[0].Object.Query)
+
+		retrieved, err = c.RetrieveByVectors(context.Background(), north, 2)
+		require.NoError(t, err)
+		assert.Len(t, retrieved, 2)
+
+		otherSystemPrompt := tenantA
+		otherSystemPrompt.SystemPrompt = "4567"
+
+		first, err := c.RetrieveFirstByVectors(context.Background(), north, semanticcache.WithFilter(otherSystemPrompt))
+		require.NoError(t, err)
+		assert.Nil(t, first)
+	})
+
+	t.Run("ExpiresByTTL", func(t *testing.T) {
+		c := newCache(t)
+
+		_, err := c.CacheVectors(context.Background(), &Doc{Query: "north"}, north, time.Second)
+		require.NoError(t, err)
+		_, err = c.CacheVectors(context.Background(), &Doc{Query: "east"}, east, 0)
+		require.NoError(t, err)
+
+		retrieved, err := c.RetrieveByVectors(context.Background(), north, 2)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 2)
+
+		require.Eventually(t, func() bool {
+			retrieved, err := c.RetrieveByVectors(context.Background(), north, 2)
+			require.NoError(t, err)
+
+			return len(retrieved) == 1 && retrieved[0].Object.Query == "east"
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
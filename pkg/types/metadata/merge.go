@@ -0,0 +1,216 @@
+package metadata
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/samber/lo"
+)
+
+// UpstreamLayer is the upstream override configured at a level of the
+// hierarchy, e.g. a tenant.
+type UpstreamLayer struct {
+	// Source identifies the level, e.g. tenant:acme, in
+	// EffectiveUpstream.Sources.
+	Source   string
+	Upstream *UpstreamSingleOrMultiple
+}
+
+// EffectiveUpstream is the upstream resulting from merging the overrides of
+// the levels of the hierarchy, see MergeUpstreams.
+type EffectiveUpstream struct {
+	Upstream *UpstreamSingleOrMultiple
+	// Sources maps the path of each field set, e.g. openai.base_url,
+	// openai.extra_headers.X-Request-Source or group.1.openai.weight, to the
+	// source of the layer it comes from.
+	Sources map[string]string
+}
+
+// MergeUpstreams merges layers, ordered from the root of the hierarchy, the
+// tenant, to the endpoint, field by field, so that a level only has to set
+// the fields it overrides:
+//
+//   - weight, base_url and api_key are overridden when set. A base_url set
+//     without an api_key drops the api_key and the credential headers
+//     inherited from the levels above, e.g. Authorization, so that the
+//     credentials of a host are never sent to another one.
+//   - extra_headers are merged by name, the values of a header replace the
+//     inherited ones, and an empty list removes the header.
+//   - model_aliases are merged by alias, and an empty model removes the
+//     alias.
+//   - compatible is overridden as a whole when any of its flags is set,
+//     since flags set to false cannot be told apart from flags not set.
+//   - group replaces the group inherited, its upstreams inherit the fields
+//     of the single upstreams of the levels above, and of the level itself.
+//     The single upstream of a level below a group applies to each upstream
+//     of the group.
+//
+// The upstream is nil when none of the layers has one.
+func MergeUpstreams(layers ...UpstreamLayer) EffectiveUpstream {
+	var (
+		found  bool
+		shared = newMergedUpstream()
+		group  []*mergedUpstream
+	)
+
+	for _, layer := range layers {
+		if layer.Upstream == nil {
+			continue
+		}
+
+		found = true
+
+		if layer.Upstream.Upstream != nil {
+			shared.merge(layer.Upstream.Upstream, layer.Source)
+
+			for _, member := range group {
+				member.merge(layer.Upstream.Upstream, layer.Source)
+			}
+		}
+		if len(layer.Upstream.Group) > 0 {
+			group = lo.Map(layer.Upstream.Group, func(item *Upstream, _ int) *mergedUpstream {
+				member := shared.clone()
+				if item != nil {
+					member.merge(item, layer.Source)
+				}
+
+				return member
+			})
+		}
+	}
+
+	if !found {
+		return EffectiveUpstream{Sources: map[string]string{}}
+	}
+	if len(group) == 0 {
+		return EffectiveUpstream{
+			Upstream: &UpstreamSingleOrMultiple{Upstream: &shared.upstream},
+			Sources:  shared.sources,
+		}
+	}
+
+	effective := EffectiveUpstream{
+		Upstream: &UpstreamSingleOrMultiple{Group: make(Upstreams, 0, len(group))},
+		Sources:  make(map[string]string),
+	}
+
+	for i, member := range group {
+		effective.Upstream.Group = append(effective.Upstream.Group, &member.upstream)
+
+		for path, source := range member.sources {
+			effective.Sources["group."+strconv.Itoa(i)+"."+path] = source
+		}
+	}
+
+	return effective
+}
+
+// credentialHeaders are the extra_headers carrying the credentials of an
+// upstream, which are not inherited by a level changing the base_url
+// without setting an api_key.
+var credentialHeaders = []string{"Authorization", "Api-Key"}
+
+type mergedUpstream struct {
+	upstream Upstream
+	sources  map[string]string
+}
+
+func newMergedUpstream() *mergedUpstream {
+	return &mergedUpstream{sources: make(map[string]string)}
+}
+
+func (m *mergedUpstream) clone() *mergedUpstream {
+	cloned := &mergedUpstream{
+		upstream: m.upstream,
+		sources:  maps.Clone(m.sources),
+	}
+
+	if m.upstream.OpenAI.Weight != nil {
+		cloned.upstream.OpenAI.Weight = lo.ToPtr(*m.upstream.OpenAI.Weight)
+	}
+
+	cloned.upstream.OpenAI.ExtraHeaders = m.upstream.OpenAI.ExtraHeaders.Clone()
+	cloned.upstream.OpenAI.ModelAliases = maps.Clone(m.upstream.OpenAI.ModelAliases)
+
+	return cloned
+}
+
+func (m *mergedUpstream) merge(patch *Upstream, source string) {
+	openai := &m.upstream.OpenAI
+
+	if patch.OpenAI.Weight != nil {
+		openai.Weight = lo.ToPtr(*patch.OpenAI.Weight)
+		m.sources["openai.weight"] = source
+	}
+	if patch.OpenAI.BaseURL != "" {
+		if patch.OpenAI.BaseURL != openai.BaseURL && patch.OpenAI.APIKey == "" {
+			m.dropInheritedCredentials(source)
+		}
+
+		openai.BaseURL = patch.OpenAI.BaseURL
+		m.sources["openai.base_url"] = source
+	}
+	if patch.OpenAI.APIKey != "" {
+		openai.APIKey = patch.OpenAI.APIKey
+		m.sources["openai.api_key"] = source
+	}
+
+	for name, values := range patch.OpenAI.ExtraHeaders {
+		name = http.CanonicalHeaderKey(name)
+		path := "openai.extra_headers." + name
+
+		if len(values) == 0 {
+			delete(openai.ExtraHeaders, name)
+			delete(m.sources, path)
+
+			continue
+		}
+		if openai.ExtraHeaders == nil {
+			openai.ExtraHeaders = make(http.Header)
+		}
+
+		openai.ExtraHeaders[name] = slices.Clone(values)
+		m.sources[path] = source
+	}
+
+	if patch.OpenAI.Compatible != (UpstreamOpenAICompatible{}) {
+		openai.Compatible = patch.OpenAI.Compatible
+		m.sources["openai.compatible"] = source
+	}
+
+	for alias, model := range patch.OpenAI.ModelAliases {
+		path := "openai.model_aliases." + alias
+
+		if model == "" {
+			delete(openai.ModelAliases, alias)
+			delete(m.sources, path)
+
+			continue
+		}
+		if openai.ModelAliases == nil {
+			openai.ModelAliases = make(map[string]string)
+		}
+
+		openai.ModelAliases[alias] = model
+		m.sources[path] = source
+	}
+}
+
+// dropInheritedCredentials drops the api_key and the credential headers set
+// by the levels other than source, i.e. the ones above it.
+func (m *mergedUpstream) dropInheritedCredentials(source string) {
+	if m.sources["openai.api_key"] != source {
+		m.upstream.OpenAI.APIKey = ""
+		delete(m.sources, "openai.api_key")
+	}
+
+	for _, name := range credentialHeaders {
+		path := "openai.extra_headers." + name
+		if m.sources[path] != source {
+			delete(m.upstream.OpenAI.ExtraHeaders, name)
+			delete(m.sources, path)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package metadata
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeUpstreams(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		effective := MergeUpstreams(UpstreamLayer{Source: "tenant:a"}, UpstreamLayer{Source: "team:b"})
+		assert.Nil(t, effective.Upstream)
+		assert.Empty(t, effective.Sources)
+	})
+
+	t.Run("FieldByField", func(t *testing.T) {
+		tenant := &UpstreamSingleOrMultiple{
+			Upstream: &Upstream{
+				OpenAI: UpstreamOpenAI{
+					BaseURL: "https://tenant",
+					APIKey:  "tenantKey",
+					ExtraHeaders: http.Header{
+						"X-Tenant":   []string{"tenant"},
+						"x-removed":  []string{"tenant"},
+						"X-Replaced": []string{"tenant"},
+					},
+					Compatible: UpstreamOpenAICompatible{
+						Chat:   UpstreamOpenAICompatibleChat{Usage: true, Stream: true},
+						Models: true,
+					},
+					ModelAliases: map[string]string{"fast": "gpt-4o-mini", "smart": "gpt-4o"},
+				},
+			},
+		}
+		endpoint := &UpstreamSingleOrMultiple{
+			Upstream: &Upstream{
+				OpenAI: UpstreamOpenAI{
+					Weight: lo.ToPtr(uint(2)),
+					ExtraHeaders: http.Header{
+						"X-Removed":  []string{},
+						"X-Replaced": []string{"endpoint"},
+					},
+					Compatible: UpstreamOpenAICompatible{
+						Chat: UpstreamOpenAICompatibleChat{Stream: true},
+					},
+					ModelAliases: map[string]string{"fast": "", "smart": "o1"},
+				},
+			},
+		}
+
+		effective := MergeUpstreams(
+			UpstreamLayer{Source: "tenant:a", Upstream: tenant},
+			UpstreamLayer{Source: "team:b"},
+			UpstreamLayer{Source: "endpoint:c", Upstream: endpoint},
+		)
+		require.NotNil(t, effective.Upstream)
+		require.True(t, effective.Upstream.IsSingleUpstream())
+
+		openai := effective.Upstream.GetUpstream().OpenAI
+		assert.Equal(t, lo.ToPtr(uint(2)), openai.Weight)
+		assert.Equal(t, "https://tenant", openai.BaseURL)
+		assert.Equal(t, "tenantKey", openai.APIKey)
+		assert.Equal(t, http.Header{"X-Tenant": []string{"tenant"}, "X-Replaced": []string{"endpoint"}}, openai.ExtraHeaders)
+		assert.Equal(t, UpstreamOpenAICompatible{Chat: UpstreamOpenAICompatibleChat{Stream: true}}, openai.Compatible)
+		assert.Equal(t, map[string]string{"smart": "o1"}, openai.ModelAliases)
+
+		assert.Equal(t, map[string]string{
+			"openai.weight":                   "endpoint:c",
+			"openai.base_url":                 "tenant:a",
+			"openai.api_key":                  "tenant:a",
+			"openai.extra_headers.X-Tenant":   "tenant:a",
+			"openai.extra_headers.X-Replaced": "endpoint:c",
+			"openai.compatible":               "endpoint:c",
+			"openai.model_aliases.smart":      "endpoint:c",
+		}, effective.Sources)
+
+		// The layers are left untouched.
+		assert.Equal(t, []string{"tenant"}, tenant.Upstream.OpenAI.ExtraHeaders["X-Replaced"])
+		assert.Equal(t, "gpt-4o-mini", tenant.Upstream.OpenAI.ModelAliases["fast"])
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		effective := MergeUpstreams(
+			UpstreamLayer{Source: "tenant:a", Upstream: &UpstreamSingleOrMultiple{
+				Upstream: &Upstream{OpenAI: UpstreamOpenAI{APIKey: "tenantKey"}},
+			}},
+			UpstreamLayer{Source: "team:b", Upstream: &UpstreamSingleOrMultiple{
+				Group: Upstreams{
+					{OpenAI: UpstreamOpenAI{BaseURL: "https://first", Weight: lo.ToPtr(uint(1))}},
+					{OpenAI: UpstreamOpenAI{BaseURL: "https://second", APIKey: "secondKey"}},
+				},
+			}},
+			UpstreamLayer{Source: "endpoint:c", Upstream: &UpstreamSingleOrMultiple{
+				Upstream: &Upstream{OpenAI: UpstreamOpenAI{ExtraHeaders: http.Header{"X-Endpoint": []string{"c"}}}},
+			}},
+		)
+		require.NotNil(t, effective.Upstream)
+		require.False(t, effective.Upstream.IsSingleUpstream())
+		require.Len(t, effective.Upstream.GetUpstreams(), 2)
+
+		first := effective.Upstream.GetUpstreams()[0].OpenAI
+		assert.Equal(t, "https://first", first.BaseURL)
+		assert.Empty(t, first.APIKey)
+		assert.Equal(t, "c", first.ExtraHeaders.Get("X-Endpoint"))
+
+		second := effective.Upstream.GetUpstreams()[1].OpenAI
+		assert.Equal(t, "https://second", second.BaseURL)
+		assert.Equal(t, "secondKey", second.APIKey)
+		assert.Equal(t, "c", second.ExtraHeaders.Get("X-Endpoint"))
+
+		assert.Equal(t, map[string]string{
+			"group.0.openai.base_url":                 "team:b",
+			"group.0.openai.weight":                   "team:b",
+			"group.0.openai.extra_headers.X-Endpoint": "endpoint:c",
+			"group.1.openai.api_key":                  "team:b",
+			"group.1.openai.base_url":                 "team:b",
+			"group.1.openai.extra_headers.X-Endpoint": "endpoint:c",
+		}, effective.Sources)
+	})
+
+	t.Run("BaseURLDropsCredentials", func(t *testing.T) {
+		tenant := &UpstreamSingleOrMultiple{
+			Upstream: &Upstream{
+				OpenAI: UpstreamOpenAI{
+					BaseURL: "https://tenant",
+					APIKey:  "tenantKey",
+					ExtraHeaders: http.Header{
+						"Authorization": []string{"Bearer tenantKey"},
+						"api-key":       []string{"tenantKey"},
+						"X-Tenant":      []string{"tenant"},
+					},
+				},
+			},
+		}
+
+		// A level changing the base_url without an api_key inherits neither
+		// the api_key, nor the credential headers of the host above.
+		effective := MergeUpstreams(
+			UpstreamLayer{Source: "tenant:a", Upstream: tenant},
+			UpstreamLayer{Source: "team:b", Upstream: &UpstreamSingleOrMultiple{
+				Upstream: &Upstream{OpenAI: UpstreamOpenAI{BaseURL: "https://team"}},
+			}},
+		)
+		require.NotNil(t, effective.Upstream)
+
+		openai := effective.Upstream.GetUpstream().OpenAI
+		assert.Equal(t, "https://team", openai.BaseURL)
+		assert.Empty(t, openai.APIKey)
+		assert.Equal(t, http.Header{"X-Tenant": []string{"tenant"}}, openai.ExtraHeaders)
+		assert.Equal(t, map[string]string{
+			"openai.base_url":               "team:b",
+			"openai.extra_headers.X-Tenant": "tenant:a",
+		}, effective.Sources)
+
+		// Nor do the upstreams of a group changing it.
+		effective = MergeUpstreams(
+			UpstreamLayer{Source: "tenant:a", Upstream: tenant},
+			UpstreamLayer{Source: "team:b", Upstream: &UpstreamSingleOrMultiple{
+				Group: Upstreams{
+					{OpenAI: UpstreamOpenAI{BaseURL: "https://tenant"}},
+					{OpenAI: UpstreamOpenAI{BaseURL: "https://second"}},
+				},
+			}},
+		)
+		require.Len(t, effective.Upstream.GetUpstreams(), 2)
+		assert.Equal(t, "tenantKey", effective.Upstream.GetUpstreams()[0].OpenAI.APIKey)
+		assert.Equal(t, "Bearer tenantKey", effective.Upstream.GetUpstreams()[0].OpenAI.ExtraHeaders.Get("Authorization"))
+		assert.Empty(t, effective.Upstream.GetUpstreams()[1].OpenAI.APIKey)
+		assert.Empty(t, effective.Upstream.GetUpstreams()[1].OpenAI.ExtraHeaders.Get("Authorization"))
+
+		// The credentials set along with the base_url are kept.
+		effective = MergeUpstreams(
+			UpstreamLayer{Source: "tenant:a", Upstream: tenant},
+			UpstreamLayer{Source: "team:b", Upstream: &UpstreamSingleOrMultiple{
+				Upstream: &Upstream{OpenAI: UpstreamOpenAI{
+					BaseURL:      "https://team",
+					ExtraHeaders: http.Header{"Api-Key": []string{"teamKey"}},
+				}},
+			}},
+		)
+
+		openai = effective.Upstream.GetUpstream().OpenAI
+		assert.Empty(t, openai.APIKey)
+		assert.Equal(t, "teamKey", openai.ExtraHeaders.Get("Api-Key"))
+		assert.Empty(t, openai.ExtraHeaders.Get("Authorization"))
+
+		// The layers are left untouched.
+		assert.Equal(t, "tenantKey", tenant.Upstream.OpenAI.APIKey)
+		assert.Equal(t, []string{"Bearer tenantKey"}, tenant.Upstream.OpenAI.ExtraHeaders["Authorization"])
+	})
+
+	t.Run("GroupReplacesGroup", func(t *testing.T) {
+		effective := MergeUpstreams(
+			UpstreamLayer{Source: "team:b", Upstream: &UpstreamSingleOrMultiple{
+				Group: Upstreams{{OpenAI: UpstreamOpenAI{BaseURL: "https://first"}}, {OpenAI: UpstreamOpenAI{BaseURL: "https://second"}}},
+			}},
+			UpstreamLayer{Source: "group:d", Upstream: &UpstreamSingleOrMultiple{
+				Upstream: &Upstream{OpenAI: UpstreamOpenAI{APIKey: "groupKey"}},
+				Group:    Upstreams{{OpenAI: UpstreamOpenAI{BaseURL: "https://third"}}},
+			}},
+		)
+		require.NotNil(t, effective.Upstream)
+		require.Len(t, effective.Upstream.GetUpstreams(), 1)
+		assert.Equal(t, "https://third", effective.Upstream.GetUpstream().OpenAI.BaseURL)
+		assert.Equal(t, "groupKey", effective.Upstream.GetUpstream().OpenAI.APIKey)
+	})
+}
@@ -77,10 +77,45 @@ type UpstreamOpenAICompatible struct {
 type UpstreamOpenAI struct {
 	Weight *uint `json:"weight" yaml:"weight"`
 
-	BaseURL      string                   `json:"base_url" yaml:"base_url"`
+	BaseURL string `json:"base_url" yaml:"base_url"`
+	// APIKey is either the API key itself, or a reference to the API key
+	// resolved when the upstream is called, i.e. env:NAME, file:/path or
+	// enc:ciphertext, see secrets.Resolver.
 	APIKey       string                   `json:"api_key" yaml:"api_key"`
 	ExtraHeaders http.Header              `json:"extra_headers" yaml:"extra_headers"`
 	Compatible   UpstreamOpenAICompatible `json:"compatible" yaml:"compatible"`
+
+	// ModelAliases maps the model names exposed by the gateway to the model
+	// names known by the upstream.
+	ModelAliases map[string]string `json:"model_aliases,omitempty" yaml:"model_aliases,omitempty"`
+}
+
+// ModelOfAlias returns the upstream model name of the given alias, or the
+// alias itself when it is not mapped.
+func (u UpstreamOpenAI) ModelOfAlias(alias string) string {
+	model, ok := u.ModelAliases[alias]
+	if !ok {
+		return alias
+	}
+
+	return model
+}
+
+// AliasOfModel returns the alias exposed by the gateway for the given
+// upstream model name, or the model name itself when it is not aliased.
+func (u UpstreamOpenAI) AliasOfModel(model string) string {
+	aliases := make([]string, 0)
+
+	for alias, upstreamModel := range u.ModelAliases {
+		if upstreamModel == model {
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) == 0 {
+		return model
+	}
+
+	return lo.Min(aliases)
 }
 
 var _ Upstreamable = (*Upstream)(nil)
@@ -13,10 +13,15 @@ func (k Key) Format(params ...interface{}) string {
 // Endpoint Provider
 
 const (
-	// EndpointMetadataByAPIKey1.
+	// EndpointMetadataByAPIKey1, where API keys used to be stored in
+	// plaintext, kept for migrating them to EndpointMetadataByAPIKeyDigest1.
 	// Params: API Key.
 	EndpointMetadataByAPIKey1 Key = "config:providers:auth:metadata:api_key:%s"
 
+	// EndpointMetadataByAPIKeyDigest1.
+	// Params: API Key digest.
+	EndpointMetadataByAPIKeyDigest1 Key = "config:providers:auth:metadata:api_key_digest:%s"
+
 	// EndpointUpstreamByTenantID1.
 	// Params: Tenant ID.
 	EndpointUpstreamByTenantID1 Key = "config:providers:auth:metadata:upstream:tenant:%s"
@@ -33,7 +38,109 @@ const (
 	// Params: Endpoint ID.
 	EndpointUpstreamByEndpointID1 Key = "config:providers:auth:metadata:upstream:endpoint:%s"
 
+	// EndpointPolicyByTenantID1.
+	// Params: Tenant ID.
+	EndpointPolicyByTenantID1 Key = "config:providers:auth:metadata:policy:tenant:%s"
+
+	// EndpointPolicyByTeamID1.
+	// Params: Team ID.
+	EndpointPolicyByTeamID1 Key = "config:providers:auth:metadata:policy:team:%s"
+
+	// EndpointPolicyByGroupID1.
+	// Params: Group ID.
+	EndpointPolicyByGroupID1 Key = "config:providers:auth:metadata:policy:group:%s"
+
+	// EndpointPolicyByEndpointID1.
+	// Params: Endpoint ID.
+	EndpointPolicyByEndpointID1 Key = "config:providers:auth:metadata:policy:endpoint:%s"
+
+	// EndpointMetadataByEndpointID1.
+	// Params: Endpoint ID.
+	EndpointMetadataByEndpointID1 Key = "config:providers:auth:metadata:endpoint:%s"
+
 	// EndpointMetadataByAlias1.
 	// Params: Alias.
 	EndpointMetadataByAlias1 Key = "config:providers:auth:metadata:alias:%s"
+
+	// EndpointTenants0, hash of the tenants by their ID.
+	EndpointTenants0 Key = "config:providers:auth:metadata:tenants"
+
+	// EndpointTeams0, hash of the teams by their ID.
+	EndpointTeams0 Key = "config:providers:auth:metadata:teams"
+
+	// EndpointGroups0, hash of the groups by their ID.
+	EndpointGroups0 Key = "config:providers:auth:metadata:groups"
+
+	// EndpointInvalidations0, channel the replicas are notified on when
+	// endpoints change, to flush their caches.
+	EndpointInvalidations0 Key = "config:providers:auth:invalidations"
+)
+
+// Rate Limits
+
+const (
+	// RateLimitByScope3, the theoretical arrival time of the GCRA limit of
+	// a tenant, team, group or endpoint. The tenant is the hash tag, so
+	// that the limits of an endpoint are kept in the same slot.
+	// Params: Tenant ID, Scope, Dimension.
+	RateLimitByScope3 Key = "ratelimits:{%s}:%s:%s"
+)
+
+// Budgets
+
+const (
+	// BudgetSpentByScope4, what a tenant, team, group or endpoint spent in
+	// a period of a window, in millionths of USD. The tenant is the hash
+	// tag, as for RateLimitByScope3.
+	// Params: Tenant ID, Scope, Window, Period.
+	BudgetSpentByScope4 Key = "budgets:{%s}:%s:%s:%s"
+)
+
+// Usage
+
+const (
+	// UsageEventsByTenant1, stream of the usage events of a tenant, trimmed
+	// to the retention of the Redis usage sink.
+	// Params: Tenant ID.
+	UsageEventsByTenant1 Key = "usage:{%s}:events"
+)
+
+// Exact Cache
+
+const (
+	// ExactCacheResponseByKey2, the answer of a deterministic chat
+	// completion of an endpoint.
+	// Params: Endpoint ID, Key.
+	ExactCacheResponseByKey2 Key = "exactcache:%s:%s"
+)
+
+// Batches
+
+const (
+	// BatchesFileByID1.
+	// Params: File ID.
+	BatchesFileByID1 Key = "batches:files:%s"
+
+	// BatchesFileContentByID1.
+	// Params: File ID.
+	BatchesFileContentByID1 Key = "batches:files:%s:content"
+
+	// BatchesJobByID1.
+	// Params: Batch ID.
+	BatchesJobByID1 Key = "batches:jobs:%s"
+
+	// BatchesJobIDs0.
+	BatchesJobIDs0 Key = "batches:jobs"
+
+	// BatchesJobLeaseByID1, the replica processing the job.
+	// Params: Batch ID.
+	BatchesJobLeaseByID1 Key = "batches:leases:%s"
+)
+
+// Responses
+
+const (
+	// ResponseByID1, a response stored along with its conversation.
+	// Params: Response ID.
+	ResponseByID1 Key = "responses:%s"
 )
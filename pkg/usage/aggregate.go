@@ -0,0 +1,284 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidQuery = errors.New("invalid usage query")
+)
+
+// MaxScanRange bounds the range of the queries of the sinks not implementing
+// Aggregator, e.g. the JSONL files and the Redis streams, which have every
+// event of the range scanned on each query.
+const MaxScanRange = 31 * 24 * time.Hour
+
+// Dimension is what events may be grouped by, besides time.
+type Dimension string
+
+const (
+	DimensionTeam      Dimension = "team"
+	DimensionGroup     Dimension = "group"
+	DimensionEndpoint  Dimension = "endpoint"
+	DimensionModel     Dimension = "model"
+	DimensionUpstream  Dimension = "upstream"
+	DimensionUser      Dimension = "user"
+	DimensionOperation Dimension = "operation"
+	DimensionStatus    Dimension = "status"
+	DimensionCache     Dimension = "cache"
+)
+
+// Dimensions are all the dimensions events may be grouped by.
+var Dimensions = []Dimension{
+	DimensionTeam,
+	DimensionGroup,
+	DimensionEndpoint,
+	DimensionModel,
+	DimensionUpstream,
+	DimensionUser,
+	DimensionOperation,
+	DimensionStatus,
+	DimensionCache,
+}
+
+func (d Dimension) valueOf(event Event) string {
+	switch d {
+	case DimensionTeam:
+		return event.TeamID
+	case DimensionGroup:
+		return event.GroupID
+	case DimensionEndpoint:
+		return event.EndpointID
+	case DimensionModel:
+		return event.Model
+	case DimensionUpstream:
+		return event.Upstream
+	case DimensionUser:
+		return event.User
+	case DimensionOperation:
+		return event.Operation
+	case DimensionStatus:
+		return strconv.Itoa(event.Status)
+	case DimensionCache:
+		return event.Cache
+	default:
+		return ""
+	}
+}
+
+// Query aggregates the events selected by its filter into buckets.
+type Query struct {
+	Filter
+
+	// Width is the width of the time buckets, e.g. an hour or a day, which
+	// are aligned on UTC. The events are aggregated over the whole range of
+	// the filter when it is zero.
+	Width time.Duration
+	// GroupBy splits the buckets by the values of the dimensions.
+	GroupBy []Dimension
+}
+
+// Validate rejects the queries without tenant, of unknown dimensions, or of
+// invalid ranges, with ErrInvalidQuery.
+func (q Query) Validate() error {
+	if q.TenantID == "" {
+		return fmt.Errorf("%w: tenant is required", ErrInvalidQuery)
+	}
+	if q.Width < 0 {
+		return fmt.Errorf("%w: width must not be negative", ErrInvalidQuery)
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		return fmt.Errorf("%w: since must be before until", ErrInvalidQuery)
+	}
+
+	for _, dimension := range q.GroupBy {
+		if !slices.Contains(Dimensions, dimension) {
+			return fmt.Errorf("%w: unknown dimension %q", ErrInvalidQuery, dimension)
+		}
+	}
+
+	return nil
+}
+
+// Bucket is the usage of the events of a time bucket, and of a group when
+// grouped by dimensions.
+type Bucket struct {
+	// Start and End bound the bucket, they are zero when the range of the
+	// query is unbounded.
+	Start time.Time
+	End   time.Time
+	// Group holds the value of each dimension the query groups by.
+	Group map[Dimension]string
+
+	Requests       int64
+	FailedRequests int64
+	// CacheHits are the requests answered from the caches of the gateway.
+	CacheHits        int64
+	PromptTokens     int64
+	CompletionTokens int64
+	CachedTokens     int64
+	Cost             float64
+	// Latency is the total latency of the requests.
+	Latency time.Duration
+}
+
+// AverageLatency is the average latency of the requests.
+func (b Bucket) AverageLatency() time.Duration {
+	if b.Requests == 0 {
+		return 0
+	}
+
+	return b.Latency / time.Duration(b.Requests)
+}
+
+// ID identifies the bucket among the buckets of its query, e.g. to paginate
+// them.
+func (b Bucket) ID() string {
+	parts := []string{strconv.FormatInt(b.Start.UnixMilli(), 10)}
+
+	for _, dimension := range Dimensions {
+		value, ok := b.Group[dimension]
+		if ok {
+			parts = append(parts, string(dimension)+"="+value)
+		}
+	}
+
+	return strings.Join(parts, "\x1f")
+}
+
+func (b *Bucket) add(event Event) {
+	b.Requests++
+	if event.Failed() {
+		b.FailedRequests++
+	}
+	if event.Cache != "" {
+		b.CacheHits++
+	}
+
+	b.PromptTokens += event.PromptTokens
+	b.CompletionTokens += event.CompletionTokens
+	b.CachedTokens += event.CachedTokens
+	b.Cost += event.Cost
+	b.Latency += event.Latency
+}
+
+// boundsOf returns the bounds of the bucket of the query event belongs to.
+func (q Query) boundsOf(event Event) (time.Time, time.Time) {
+	if q.Width == 0 {
+		return q.Since, q.Until
+	}
+
+	start := event.Time.UTC().Truncate(q.Width)
+
+	return start, start.Add(q.Width)
+}
+
+// scanned bounds the range of the query to MaxScanRange before it is
+// scanned. Until defaults to now, and Since to MaxScanRange before Until,
+// wider ranges are rejected with ErrInvalidQuery.
+func (q Query) scanned(now time.Time) (Query, error) {
+	if q.Until.IsZero() {
+		q.Until = now
+	}
+	if q.Since.IsZero() {
+		q.Since = q.Until.Add(-MaxScanRange)
+	}
+	if !q.Since.Before(q.Until) {
+		return q, fmt.Errorf("%w: since must be before until, which defaults to now", ErrInvalidQuery)
+	}
+	if q.Until.Sub(q.Since) > MaxScanRange {
+		return q, fmt.Errorf("%w: the range must not exceed %d days", ErrInvalidQuery, MaxScanRange/(24*time.Hour))
+	}
+
+	return q, nil
+}
+
+// Aggregate scans the events selected by the query from sink into buckets,
+// sorted by time, then by group. The sinks implementing Aggregator aggregate
+// the events themselves, the range of the queries of the other sinks is
+// bounded to MaxScanRange, see Query.scanned.
+func Aggregate(ctx context.Context, sink Sink, query Query) ([]Bucket, error) {
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregator, ok := sink.(Aggregator)
+	if ok {
+		result, err := aggregator.Aggregate(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		sortBuckets(result)
+
+		return result, nil
+	}
+
+	query, err = query.scanned(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return scan(ctx, sink, query)
+}
+
+// scan aggregates the events selected by the query, over its whole range,
+// from sink into buckets, sorted by time, then by group.
+func scan(ctx context.Context, sink Sink, query Query) ([]Bucket, error) {
+	buckets := make(map[string]*Bucket)
+
+	err := sink.Scan(ctx, query.Filter, func(event Event) error {
+		start, end := query.boundsOf(event)
+		bucket := &Bucket{
+			Start: start,
+			End:   end,
+			Group: make(map[Dimension]string, len(query.GroupBy)),
+		}
+
+		for _, dimension := range query.GroupBy {
+			bucket.Group[dimension] = dimension.valueOf(event)
+		}
+
+		existing, ok := buckets[bucket.ID()]
+		if ok {
+			bucket = existing
+		} else {
+			buckets[bucket.ID()] = bucket
+		}
+
+		bucket.add(event)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Bucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, *bucket)
+	}
+
+	sortBuckets(result)
+
+	return result, nil
+}
+
+// sortBuckets sorts buckets by time, then by group.
+func sortBuckets(buckets []Bucket) {
+	slices.SortFunc(buckets, func(a, b Bucket) int {
+		c := a.Start.Compare(b.Start)
+		if c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID(), b.ID())
+	})
+}
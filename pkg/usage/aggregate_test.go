@@ -0,0 +1,181 @@
+package usage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sliceSink keeps the events in memory.
+type sliceSink []Event
+
+func (s *sliceSink) Write(_ context.Context, events []Event) error {
+	*s = append(*s, events...)
+	return nil
+}
+
+func (s *sliceSink) Scan(_ context.Context, filter Filter, fn func(event Event) error) error {
+	for _, event := range *s {
+		if !filter.Matches(event) {
+			continue
+		}
+
+		err := fn(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// aggregatorSink records the query it aggregates.
+type aggregatorSink struct {
+	sliceSink
+
+	query Query
+}
+
+func (s *aggregatorSink) Aggregate(_ context.Context, query Query) ([]Bucket, error) {
+	s.query = query
+	return nil, nil
+}
+
+var testTime = time.Date(2024, time.December, 31, 10, 0, 0, 0, time.UTC)
+
+// newTestEvents returns events of two tenants, the first one at base.
+func newTestEvents(base time.Time) []Event {
+	return []Event{
+		{
+			ID: "1", Time: base, TenantID: "tenant", TeamID: "team1", EndpointID: "endpoint1", Model: "gpt-4o",
+			PromptTokens: 10, CompletionTokens: 20, CachedTokens: 5, Cost: 0.5, Latency: time.Second, Status: http.StatusOK, User: "alice",
+		},
+		{
+			ID: "2", Time: base.Add(30 * time.Minute), TenantID: "tenant", TeamID: "team1", EndpointID: "endpoint1", Model: "gpt-4o",
+			PromptTokens: 30, CompletionTokens: 0, Cost: 0.25, Latency: 3 * time.Second, Status: http.StatusBadGateway, User: "bob",
+		},
+		{
+			ID: "3", Time: base.Add(90 * time.Minute), TenantID: "tenant", TeamID: "team2", EndpointID: "endpoint2", Model: "gpt-4o-mini",
+			Latency: time.Second, Status: http.StatusOK, Cache: CacheExact,
+		},
+		{
+			ID: "4", Time: base, TenantID: "other", TeamID: "team1", Model: "gpt-4o",
+			PromptTokens: 1000, Status: http.StatusOK,
+		},
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	sink := sliceSink(newTestEvents(testTime))
+
+	t.Run("Buckets", func(t *testing.T) {
+		buckets, err := Aggregate(context.Background(), &sink, Query{
+			Filter: Filter{
+				TenantID: "tenant",
+				Since:    testTime.Add(-time.Hour),
+				Until:    testTime.Add(24 * time.Hour),
+			},
+			Width: time.Hour,
+		})
+		require.NoError(t, err)
+		require.Len(t, buckets, 2)
+
+		assert.Equal(t, testTime, buckets[0].Start)
+		assert.Equal(t, testTime.Add(time.Hour), buckets[0].End)
+		assert.Equal(t, int64(2), buckets[0].Requests)
+		assert.Equal(t, int64(1), buckets[0].FailedRequests)
+		assert.Equal(t, int64(40), buckets[0].PromptTokens)
+		assert.Equal(t, int64(20), buckets[0].CompletionTokens)
+		assert.Equal(t, int64(5), buckets[0].CachedTokens)
+		assert.InDelta(t, 0.75, buckets[0].Cost, 1e-9)
+		assert.Equal(t, 2*time.Second, buckets[0].AverageLatency())
+
+		assert.Equal(t, testTime.Add(time.Hour), buckets[1].Start)
+		assert.Equal(t, int64(1), buckets[1].Requests)
+		assert.Equal(t, int64(1), buckets[1].CacheHits)
+		assert.Zero(t, buckets[1].Cost)
+	})
+
+	t.Run("GroupBy", func(t *testing.T) {
+		buckets, err := Aggregate(context.Background(), &sink, Query{
+			Filter: Filter{
+				TenantID: "tenant",
+				Since:    testTime,
+				Until:    testTime.Add(24 * time.Hour),
+			},
+			GroupBy: []Dimension{DimensionTeam, DimensionModel},
+		})
+		require.NoError(t, err)
+		require.Len(t, buckets, 2)
+
+		assert.Equal(t, map[Dimension]string{DimensionTeam: "team1", DimensionModel: "gpt-4o"}, buckets[0].Group)
+		assert.Equal(t, testTime, buckets[0].Start)
+		assert.Equal(t, testTime.Add(24*time.Hour), buckets[0].End)
+		assert.Equal(t, int64(2), buckets[0].Requests)
+		assert.Equal(t, map[Dimension]string{DimensionTeam: "team2", DimensionModel: "gpt-4o-mini"}, buckets[1].Group)
+		assert.NotEqual(t, buckets[0].ID(), buckets[1].ID())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		buckets, err := Aggregate(context.Background(), &sink, Query{
+			Filter: Filter{TenantID: "tenant", User: "alice", Since: testTime, Until: testTime.Add(time.Hour)},
+		})
+		require.NoError(t, err)
+		require.Len(t, buckets, 1)
+		assert.Equal(t, int64(1), buckets[0].Requests)
+		assert.InDelta(t, 0.5, buckets[0].Cost, 1e-9)
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		now := time.Now()
+		recent := sliceSink(append(newTestEvents(now.Add(-2*time.Hour)), newTestEvents(now.Add(-MaxScanRange-2*time.Hour))...))
+
+		// The range of the unbounded queries defaults to the last
+		// MaxScanRange.
+		buckets, err := Aggregate(context.Background(), &recent, Query{Filter: Filter{TenantID: "tenant"}})
+		require.NoError(t, err)
+		require.Len(t, buckets, 1)
+		assert.Equal(t, int64(3), buckets[0].Requests)
+		assert.Equal(t, MaxScanRange, buckets[0].End.Sub(buckets[0].Start))
+		assert.False(t, buckets[0].End.Before(now))
+
+		buckets, err = Aggregate(context.Background(), &recent, Query{Filter: Filter{TenantID: "tenant", Since: now.Add(-MaxScanRange - 3*time.Hour), Until: now.Add(-MaxScanRange)}})
+		require.NoError(t, err)
+		require.Len(t, buckets, 1)
+		assert.Equal(t, int64(3), buckets[0].Requests)
+
+		for _, filter := range []Filter{
+			{TenantID: "tenant", Since: now.Add(-MaxScanRange - time.Hour)},
+			{TenantID: "tenant", Since: testTime},
+			{TenantID: "tenant", Since: testTime, Until: testTime.Add(MaxScanRange + time.Second)},
+			{TenantID: "tenant", Since: now.Add(time.Hour)},
+		} {
+			_, err = Aggregate(context.Background(), &recent, Query{Filter: filter})
+			require.ErrorIs(t, err, ErrInvalidQuery)
+		}
+
+		// The range of the sinks aggregating the events themselves is not
+		// bounded.
+		aggregator := &aggregatorSink{sliceSink: recent}
+
+		_, err = Aggregate(context.Background(), aggregator, Query{Filter: Filter{TenantID: "tenant", Until: testTime}})
+		require.NoError(t, err)
+		assert.True(t, aggregator.query.Since.IsZero())
+		assert.Equal(t, testTime, aggregator.query.Until)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Aggregate(context.Background(), &sink, Query{Filter: Filter{TenantID: "tenant"}, GroupBy: []Dimension{"color"}})
+		require.ErrorIs(t, err, ErrInvalidQuery)
+
+		_, err = Aggregate(context.Background(), &sink, Query{})
+		require.ErrorIs(t, err, ErrInvalidQuery)
+
+		_, err = Aggregate(context.Background(), &sink, Query{Filter: Filter{TenantID: "tenant", Since: testTime, Until: testTime}})
+		require.ErrorIs(t, err, ErrInvalidQuery)
+	})
+}
@@ -0,0 +1,171 @@
+package usage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Sink = (*JSONLSink)(nil)
+
+const jsonlFilePrefix = "usage-"
+
+// JSONLSink appends the events to JSON Lines files under a directory, one
+// file per day, in UTC, e.g. usage-2024-12-31.jsonl, which are easily
+// shipped to data warehouses.
+type JSONLSink struct {
+	directory string
+	mutex     sync.Mutex
+}
+
+func NewJSONLSink(directory string) (*JSONLSink, error) {
+	err := os.MkdirAll(directory, 0o750)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONLSink{directory: directory}, nil
+}
+
+func (s *JSONLSink) pathOf(day time.Time) string {
+	return filepath.Join(s.directory, jsonlFilePrefix+day.UTC().Format(time.DateOnly)+".jsonl")
+}
+
+func (s *JSONLSink) Write(ctx context.Context, events []Event) error {
+	byPath := make(map[string][]Event)
+	for _, event := range events {
+		path := s.pathOf(event.Time)
+		byPath[path] = append(byPath[path], event)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for path, events := range byPath {
+		err := appendJSONL(path, events)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func appendJSONL(path string, events []Event) error {
+	var b []byte
+
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		b = append(b, line...)
+		b = append(b, '\n')
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(b)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// pathsOf returns the files of the days of the filter, in order.
+func (s *JSONLSink) pathsOf(filter Filter) ([]string, error) {
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, jsonlFilePrefix) || !strings.HasSuffix(name, ".jsonl") {
+			continue
+		}
+
+		day, err := time.Parse(time.DateOnly, strings.TrimSuffix(strings.TrimPrefix(name, jsonlFilePrefix), ".jsonl"))
+		if err != nil {
+			continue
+		}
+		if !filter.Since.IsZero() && !day.AddDate(0, 0, 1).After(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && !day.Before(filter.Until) {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(s.directory, name))
+	}
+
+	slices.Sort(paths)
+
+	return paths, nil
+}
+
+func (s *JSONLSink) Scan(ctx context.Context, filter Filter, fn func(event Event) error) error {
+	paths, err := s.pathsOf(filter)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		err := scanJSONL(ctx, path, filter, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanJSONL(ctx context.Context, path string, filter Filter, fn func(event Event) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var event Event
+
+		// A line partially written when the gateway stopped is skipped.
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil || !filter.Matches(event) {
+			continue
+		}
+
+		err = fn(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
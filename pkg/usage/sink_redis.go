@@ -0,0 +1,128 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/lingticio/llmg/pkg/types/redis/rediskeys"
+)
+
+var _ Sink = (*RedisSink)(nil)
+
+const (
+	redisEventField = "event"
+	// redisScanCount is how many entries are read from the streams at once.
+	redisScanCount = 1000
+	// redisWriteDelay bounds how long after their time the events are
+	// written, the entries of the streams are identified by the time they
+	// are written at rather than by the time of their events.
+	redisWriteDelay = time.Minute
+)
+
+// RedisSink appends the events to a Redis stream per tenant, trimmed to the
+// events of the last retention, which keeps the recent, hot, events at hand
+// for every replica of the gateway.
+type RedisSink struct {
+	rueidis   rueidis.Client
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewRedisSink() func(client rueidis.Client, retention time.Duration) *RedisSink {
+	return func(client rueidis.Client, retention time.Duration) *RedisSink {
+		return &RedisSink{
+			rueidis:   client,
+			retention: retention,
+			now:       time.Now,
+		}
+	}
+}
+
+// Retention is how long the events are kept.
+func (s *RedisSink) Retention() time.Duration {
+	return s.retention
+}
+
+func (s *RedisSink) Write(ctx context.Context, events []Event) error {
+	minID := strconv.FormatInt(s.now().Add(-s.retention).UnixMilli(), 10)
+	cmds := make(rueidis.Commands, 0, len(events))
+
+	for _, event := range events {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		cmds = append(cmds, s.rueidis.B().
+			Xadd().
+			Key(rediskeys.UsageEventsByTenant1.Format(event.TenantID)).
+			Minid().
+			Almost().
+			Threshold(minID).
+			Id("*").
+			FieldValue().
+			FieldValue(redisEventField, string(b)).
+			Build())
+	}
+
+	for _, result := range s.rueidis.DoMulti(ctx, cmds...) {
+		err := result.Error()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *RedisSink) Scan(ctx context.Context, filter Filter, fn func(event Event) error) error {
+	start := "-"
+	if !filter.Since.IsZero() {
+		start = strconv.FormatInt(filter.Since.UnixMilli(), 10)
+	}
+
+	end := "+"
+	if !filter.Until.IsZero() {
+		end = strconv.FormatInt(filter.Until.Add(redisWriteDelay).UnixMilli(), 10)
+	}
+
+	key := rediskeys.UsageEventsByTenant1.Format(filter.TenantID)
+
+	for {
+		cmd := s.rueidis.B().
+			Xrange().
+			Key(key).
+			Start(start).
+			End(end).
+			Count(redisScanCount).
+			Build()
+
+		entries, err := s.rueidis.Do(ctx, cmd).AsXRange()
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			var event Event
+
+			err := json.Unmarshal([]byte(entry.FieldValues[redisEventField]), &event)
+			if err != nil || !filter.Matches(event) {
+				continue
+			}
+
+			err = fn(event)
+			if err != nil {
+				return err
+			}
+		}
+		if len(entries) < redisScanCount {
+			return nil
+		}
+
+		start = "(" + entries[len(entries)-1].ID
+	}
+}
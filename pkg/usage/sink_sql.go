@@ -0,0 +1,316 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Sink = (*SQLSink)(nil)
+
+// sqlMigrations are applied in order, each of them exactly once. The
+// statements are written in the subset of SQL shared by Postgres and SQLite,
+// times are stored in milliseconds since epoch.
+var sqlMigrations = [][]string{
+	{
+		`CREATE TABLE IF NOT EXISTS llmg_usage_events (
+		id TEXT PRIMARY KEY,
+		created_at BIGINT NOT NULL,
+		tenant_id TEXT NOT NULL,
+		team_id TEXT NOT NULL,
+		group_id TEXT NOT NULL,
+		endpoint_id TEXT NOT NULL,
+		operation TEXT NOT NULL,
+		model TEXT NOT NULL,
+		upstream TEXT NOT NULL,
+		prompt_tokens BIGINT NOT NULL,
+		completion_tokens BIGINT NOT NULL,
+		cached_tokens BIGINT NOT NULL,
+		cost DOUBLE PRECISION NOT NULL,
+		latency_ms BIGINT NOT NULL,
+		status INTEGER NOT NULL,
+		user_id TEXT NOT NULL
+	)`,
+		`CREATE INDEX IF NOT EXISTS llmg_usage_events_tenant_id_created_at_idx ON llmg_usage_events (tenant_id, created_at)`,
+	},
+	// Requests answered from the caches of the gateway are recorded as well.
+	{
+		`ALTER TABLE llmg_usage_events ADD COLUMN cache TEXT NOT NULL DEFAULT ''`,
+	},
+}
+
+// SQLSink keeps the events in the llmg_usage_events table of a relational
+// database, either Postgres or SQLite.
+type SQLSink struct {
+	db *sql.DB
+}
+
+func NewSQLSink() func(*sql.DB) *SQLSink {
+	return func(db *sql.DB) *SQLSink {
+		return &SQLSink{
+			db: db,
+		}
+	}
+}
+
+// Migrate brings the table of the events up to date, the migrations applied
+// are tracked in llmg_usage_schema_migrations, so that the database may be
+// shared with the rds endpoints provider.
+func (s *SQLSink) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS llmg_usage_schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create usage schema migrations table: %w", err)
+	}
+
+	var current int
+
+	err = s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM llmg_usage_schema_migrations`).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("failed to read usage schema version: %w", err)
+	}
+
+	for i := current; i < len(sqlMigrations); i++ {
+		err = s.migrate(ctx, i+1, sqlMigrations[i])
+		if err != nil {
+			return fmt.Errorf("failed to apply usage schema migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLSink) migrate(ctx context.Context, version int, statements []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		_, err := tx.ExecContext(ctx, statement)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO llmg_usage_schema_migrations (version, applied_at) VALUES ($1, $2)`, version, time.Now().Unix())
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLSink) Write(ctx context.Context, events []Event) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		// Events written again, e.g. retried after a timeout, are only kept
+		// once.
+		_, err := tx.ExecContext(ctx, `INSERT INTO llmg_usage_events (
+				id, created_at, tenant_id, team_id, group_id, endpoint_id, operation, model, upstream,
+				prompt_tokens, completion_tokens, cached_tokens, cost, latency_ms, status, user_id, cache
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+			ON CONFLICT (id) DO NOTHING`,
+			event.ID, event.Time.UnixMilli(), event.TenantID, event.TeamID, event.GroupID, event.EndpointID,
+			event.Operation, event.Model, event.Upstream,
+			event.PromptTokens, event.CompletionTokens, event.CachedTokens, event.Cost, event.Latency.Milliseconds(),
+			event.Status, event.User, event.Cache,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// sqlWhere selects the events of a filter, given the arguments returned by
+// sqlWhereArgs as $1 to $8.
+const sqlWhere = `WHERE tenant_id = $1 AND created_at >= $2 AND created_at < $3
+			AND ($4 = '' OR team_id = $4)
+			AND ($5 = '' OR group_id = $5)
+			AND ($6 = '' OR endpoint_id = $6)
+			AND ($7 = '' OR model = $7)
+			AND ($8 = '' OR user_id = $8)`
+
+func sqlWhereArgs(filter Filter) []any {
+	since := int64(math.MinInt64)
+	if !filter.Since.IsZero() {
+		since = filter.Since.UnixMilli()
+	}
+
+	until := int64(math.MaxInt64)
+	if !filter.Until.IsZero() {
+		until = filter.Until.UnixMilli()
+	}
+
+	return []any{filter.TenantID, since, until, filter.TeamID, filter.GroupID, filter.EndpointID, filter.Model, filter.User}
+}
+
+// sqlColumns are the columns of the dimensions.
+var sqlColumns = map[Dimension]string{
+	DimensionTeam:      "team_id",
+	DimensionGroup:     "group_id",
+	DimensionEndpoint:  "endpoint_id",
+	DimensionModel:     "model",
+	DimensionUpstream:  "upstream",
+	DimensionUser:      "user_id",
+	DimensionOperation: "operation",
+	DimensionStatus:    "status",
+	DimensionCache:     "cache",
+}
+
+// zeroTimeOffset is the time in milliseconds from the zero time to the Unix
+// epoch, the time buckets are aligned on the former, as time.Truncate does.
+var zeroTimeOffset = -time.Time{}.UnixMilli()
+
+func (s *SQLSink) Scan(ctx context.Context, filter Filter, fn func(event Event) error) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT
+			id, created_at, tenant_id, team_id, group_id, endpoint_id, operation, model, upstream,
+			prompt_tokens, completion_tokens, cached_tokens, cost, latency_ms, status, user_id, cache
+		FROM llmg_usage_events
+		`+sqlWhere+`
+		ORDER BY created_at, id`,
+		sqlWhereArgs(filter)...,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event     Event
+			createdAt int64
+			latency   int64
+		)
+
+		err := rows.Scan(
+			&event.ID, &createdAt, &event.TenantID, &event.TeamID, &event.GroupID, &event.EndpointID,
+			&event.Operation, &event.Model, &event.Upstream,
+			&event.PromptTokens, &event.CompletionTokens, &event.CachedTokens, &event.Cost, &latency,
+			&event.Status, &event.User, &event.Cache,
+		)
+		if err != nil {
+			return err
+		}
+
+		event.Time = time.UnixMilli(createdAt).UTC()
+		event.Latency = time.Duration(latency) * time.Millisecond
+
+		err = fn(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Aggregate groups the events by time bucket and by the dimensions of query
+// in the database, which then only returns the buckets.
+func (s *SQLSink) Aggregate(ctx context.Context, query Query) ([]Bucket, error) {
+	args := sqlWhereArgs(query.Filter)
+
+	groupBy := make([]string, 0, len(query.GroupBy)+1)
+	if query.Width > 0 {
+		args = append(args, zeroTimeOffset, query.Width.Milliseconds())
+		groupBy = append(groupBy, "created_at - ((created_at + $9) % $10)")
+	}
+	for _, dimension := range query.GroupBy {
+		groupBy = append(groupBy, sqlColumns[dimension])
+	}
+
+	columns := strings.Join(append(slices.Clone(groupBy), ""), ", ")
+	statement := `SELECT ` + columns + `COUNT(*),
+			COALESCE(SUM(CASE WHEN status >= 400 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN cache <> '' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(cached_tokens), 0),
+			COALESCE(SUM(cost), 0), COALESCE(SUM(latency_ms), 0)
+		FROM llmg_usage_events
+		` + sqlWhere
+	if len(groupBy) > 0 {
+		// The columns are grouped by position, the time bucket being an
+		// expression.
+		positions := make([]string, len(groupBy))
+		for i := range positions {
+			positions[i] = strconv.Itoa(i + 1)
+		}
+
+		statement += `
+		GROUP BY ` + strings.Join(positions, ", ")
+	}
+
+	rows, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var buckets []Bucket
+
+	for rows.Next() {
+		var (
+			start   int64
+			latency int64
+			bucket  Bucket
+		)
+
+		values := make([]string, len(query.GroupBy))
+		dest := make([]any, 0, len(groupBy)+8)
+
+		if query.Width > 0 {
+			dest = append(dest, &start)
+		}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+
+		dest = append(dest,
+			&bucket.Requests, &bucket.FailedRequests, &bucket.CacheHits,
+			&bucket.PromptTokens, &bucket.CompletionTokens, &bucket.CachedTokens,
+			&bucket.Cost, &latency,
+		)
+
+		err := rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+		// The range is aggregated as a single row, even without events.
+		if bucket.Requests == 0 {
+			continue
+		}
+
+		bucket.Start, bucket.End = query.Since, query.Until
+		if query.Width > 0 {
+			bucket.Start = time.UnixMilli(start).UTC()
+			bucket.End = bucket.Start.Add(query.Width)
+		}
+
+		bucket.Group = make(map[Dimension]string, len(query.GroupBy))
+		for i, dimension := range query.GroupBy {
+			bucket.Group[dimension] = values[i]
+		}
+
+		bucket.Latency = time.Duration(latency) * time.Millisecond
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
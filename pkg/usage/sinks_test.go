@@ -0,0 +1,149 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+// testSink writes the test events of tenantID at base into sink, and scans
+// them back.
+func testSink(t *testing.T, sink Sink, tenantID string, base time.Time) {
+	t.Helper()
+
+	events := lo.Map(newTestEvents(base), func(item Event, _ int) Event {
+		if item.TenantID == "tenant" {
+			item.TenantID = tenantID
+		}
+
+		return item
+	})
+
+	err := sink.Write(context.Background(), events[:2])
+	require.NoError(t, err)
+	err = sink.Write(context.Background(), events[2:])
+	require.NoError(t, err)
+
+	var scanned []Event
+
+	err = sink.Scan(context.Background(), Filter{TenantID: tenantID}, func(event Event) error {
+		scanned = append(scanned, event)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, scanned, 3)
+
+	for i, event := range scanned {
+		assert.Equal(t, events[i].ID, event.ID)
+		assert.True(t, events[i].Time.Equal(event.Time))
+		assert.Equal(t, events[i].PromptTokens, event.PromptTokens)
+		assert.Equal(t, events[i].CachedTokens, event.CachedTokens)
+		assert.InDelta(t, events[i].Cost, event.Cost, 1e-9)
+		assert.Equal(t, events[i].Latency, event.Latency)
+		assert.Equal(t, events[i].Status, event.Status)
+		assert.Equal(t, events[i].User, event.User)
+		assert.Equal(t, events[i].Cache, event.Cache)
+	}
+
+	scanned = nil
+
+	err = sink.Scan(context.Background(), Filter{
+		TenantID: tenantID,
+		TeamID:   "team1",
+		Since:    base.Add(time.Minute),
+		Until:    base.Add(time.Hour),
+	}, func(event Event) error {
+		scanned = append(scanned, event)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, scanned, 1)
+	assert.Equal(t, "2", scanned[0].ID)
+}
+
+func TestJSONLSink(t *testing.T) {
+	sink, err := NewJSONLSink(t.TempDir())
+	require.NoError(t, err)
+
+	testSink(t, sink, "tenant", testTime)
+}
+
+func TestSQLSink(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sink := NewSQLSink()(db)
+
+	err = sink.Migrate(context.Background())
+	require.NoError(t, err)
+
+	// Migrating again is a no-op.
+	err = sink.Migrate(context.Background())
+	require.NoError(t, err)
+
+	testSink(t, sink, "tenant", testTime)
+
+	t.Run("Aggregate", func(t *testing.T) {
+		// The database aggregates the events as they are aggregated in
+		// memory, over any range.
+		scanned := sliceSink(newTestEvents(testTime))
+
+		for _, query := range []Query{
+			{Filter: Filter{TenantID: "tenant"}},
+			{Filter: Filter{TenantID: "tenant"}, Width: time.Hour},
+			{Filter: Filter{TenantID: "tenant"}, Width: 7 * 24 * time.Hour, GroupBy: []Dimension{DimensionStatus, DimensionCache}},
+			{Filter: Filter{TenantID: "tenant", Since: testTime, Until: testTime.Add(24 * time.Hour)}, GroupBy: []Dimension{DimensionTeam, DimensionModel}},
+			{Filter: Filter{TenantID: "tenant", User: "alice"}, Width: time.Minute, GroupBy: Dimensions},
+			{Filter: Filter{TenantID: "nobody"}},
+		} {
+			expected, err := scan(context.Background(), &scanned, query)
+			require.NoError(t, err)
+
+			buckets, err := Aggregate(context.Background(), sink, query)
+			require.NoError(t, err)
+			require.Len(t, buckets, len(expected))
+
+			for i, bucket := range buckets {
+				assert.Equal(t, expected[i].ID(), bucket.ID())
+				assert.True(t, expected[i].End.Equal(bucket.End))
+				assert.Equal(t, expected[i].Requests, bucket.Requests)
+				assert.Equal(t, expected[i].FailedRequests, bucket.FailedRequests)
+				assert.Equal(t, expected[i].CacheHits, bucket.CacheHits)
+				assert.Equal(t, expected[i].PromptTokens, bucket.PromptTokens)
+				assert.Equal(t, expected[i].CachedTokens, bucket.CachedTokens)
+				assert.InDelta(t, expected[i].Cost, bucket.Cost, 1e-9)
+				assert.Equal(t, expected[i].Latency, bucket.Latency)
+			}
+		}
+	})
+}
+
+func TestRedisSink(t *testing.T) {
+	r, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress: []string{"localhost:6379"},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(r.Close)
+
+	sink := NewRedisSink()(r, 2*time.Hour)
+
+	// The entries of the streams are identified by the time they are
+	// written at, i.e. now, the events are then of the last hour.
+	testSink(t, sink, "tenant-"+strconv.FormatInt(time.Now().UnixNano(), 10), time.Now().Add(-time.Hour).Round(time.Millisecond))
+}
@@ -0,0 +1,111 @@
+// Package usage meters the requests served by the gateway. Each completed
+// request is recorded as an Event in one or several sinks, which the events
+// are later scanned from to aggregate them, e.g. into chargeback reports.
+package usage
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	OperationChatCompletions = "chat.completions"
+	OperationEmbeddings      = "embeddings"
+)
+
+const (
+	CacheExact    = "exact"
+	CacheSemantic = "semantic"
+)
+
+// Event is a request served by an upstream, successfully or not, or answered
+// from a cache of the gateway.
+type Event struct {
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+
+	TenantID   string `json:"tenant_id"`
+	TeamID     string `json:"team_id"`
+	GroupID    string `json:"group_id"`
+	EndpointID string `json:"endpoint_id"`
+
+	// Operation is what was requested, e.g. chat.completions.
+	Operation string `json:"operation"`
+	// Model is the model requested by the caller, which may be an alias of
+	// the model sent to the upstream.
+	Model string `json:"model"`
+	// Upstream is the base URL of the upstream the request was sent to,
+	// empty when answered from a cache.
+	Upstream string `json:"upstream"`
+	// Cache is the cache the request was answered from, either exact or
+	// semantic, in which case it cost nothing. Empty when the request was
+	// sent to the upstream.
+	Cache string `json:"cache,omitempty"`
+
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	// CachedTokens are the tokens of the prompt the upstream served from
+	// its cache, they are included in PromptTokens.
+	CachedTokens int64 `json:"cached_tokens"`
+	// Cost is in USD, as priced by the budgets.
+	Cost float64 `json:"cost"`
+
+	Latency time.Duration `json:"latency"`
+	// Status is the HTTP status the request was responded with.
+	Status int `json:"status"`
+	// User is the user field of the request, as sent by the caller.
+	User string `json:"user,omitempty"`
+}
+
+// Failed reports whether the request failed.
+func (e Event) Failed() bool {
+	return e.Status >= http.StatusBadRequest
+}
+
+// Filter selects the events of a tenant. Empty fields match every event.
+type Filter struct {
+	TenantID   string
+	TeamID     string
+	GroupID    string
+	EndpointID string
+	Model      string
+	User       string
+
+	// Since and Until bound the time of the events, Since included and
+	// Until excluded.
+	Since time.Time
+	Until time.Time
+}
+
+func matches(value string, filter string) bool {
+	return filter == "" || value == filter
+}
+
+// Matches reports whether the filter selects event.
+func (f Filter) Matches(event Event) bool {
+	return event.TenantID == f.TenantID &&
+		matches(event.TeamID, f.TeamID) &&
+		matches(event.GroupID, f.GroupID) &&
+		matches(event.EndpointID, f.EndpointID) &&
+		matches(event.Model, f.Model) &&
+		matches(event.User, f.User) &&
+		(f.Since.IsZero() || !event.Time.Before(f.Since)) &&
+		(f.Until.IsZero() || event.Time.Before(f.Until))
+}
+
+// Sink keeps the events.
+type Sink interface {
+	// Write records events, which are usually written in batches.
+	Write(ctx context.Context, events []Event) error
+	// Scan calls fn with each event selected by filter, stopping at the
+	// first error fn returns.
+	Scan(ctx context.Context, filter Filter, fn func(event Event) error) error
+}
+
+// Aggregator is implemented by the sinks aggregating the events themselves,
+// e.g. in their database, instead of having every event selected scanned.
+type Aggregator interface {
+	// Aggregate returns the buckets of query, which is valid, in any order.
+	Aggregate(ctx context.Context, query Query) ([]Bucket, error)
+}
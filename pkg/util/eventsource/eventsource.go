@@ -217,3 +217,27 @@ func (e *EventSource[D]) SendWithID(id string, message D) error {
 
 	return nil
 }
+
+func (e *EventSource[D]) SendEvent(name string, message D) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	event := Event{
+		Event: []byte(name),
+		Data:  jsonData,
+	}
+
+	switch e.options.responseType {
+	case ResponseAdapterTypeEcho:
+		err = event.MarshalTo(e.options.echoResponse.Writer)
+		if err != nil {
+			return err
+		}
+
+		e.options.echoResponse.Flush()
+	}
+
+	return nil
+}
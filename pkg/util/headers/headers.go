@@ -0,0 +1,67 @@
+// Package headers reports headers from deep in the handling of a request,
+// e.g. from the gateway, to the response, whether the request is served
+// over HTTP, gRPC or GraphQL.
+package headers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+var (
+	registeredMutex sync.RWMutex
+	registered      []string
+)
+
+// Register registers names as headers reported, lower-cased as in gRPC
+// metadata, so that the surfaces forward them as they are, and expose them
+// to browsers. The packages reporting headers register them on init.
+func Register(names ...string) {
+	registeredMutex.Lock()
+	defer registeredMutex.Unlock()
+
+	for _, name := range names {
+		if !slices.Contains(registered, name) {
+			registered = append(registered, name)
+		}
+	}
+}
+
+// Registered returns the headers registered, see Register.
+func Registered() []string {
+	registeredMutex.RLock()
+	defer registeredMutex.RUnlock()
+
+	return slices.Clone(registered)
+}
+
+// IsRegistered reports whether key, in any case, is a header registered.
+func IsRegistered(key string) bool {
+	registeredMutex.RLock()
+	defer registeredMutex.RUnlock()
+
+	return slices.ContainsFunc(registered, func(name string) bool {
+		return http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name)
+	})
+}
+
+type reporterContextKey struct{}
+
+// WithReporter attaches report to ctx, which Report calls with the headers
+// reported while the request is served, so that the surfaces serving the
+// requests can respond with them, e.g. as HTTP headers or gRPC metadata.
+func WithReporter(ctx context.Context, report func(header http.Header)) context.Context {
+	return context.WithValue(ctx, reporterContextKey{}, report)
+}
+
+// Report calls the reporter attached to ctx, if any, with header.
+func Report(ctx context.Context, header http.Header) {
+	report, _ := ctx.Value(reporterContextKey{}).(func(header http.Header))
+	if report == nil || len(header) == 0 {
+		return
+	}
+
+	report(header)
+}
@@ -0,0 +1,43 @@
+package headers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	Register("x-test-header", "x-test-other-header")
+	Register("x-test-header")
+
+	registered := Registered()
+	assert.Subset(t, registered, []string{"x-test-header", "x-test-other-header"})
+	assert.Len(t, registered, len(Registered()))
+	assert.True(t, IsRegistered("X-Test-Header"))
+	assert.False(t, IsRegistered("x-api-key"))
+
+	count := 0
+	for _, name := range registered {
+		if name == "x-test-header" {
+			count++
+		}
+	}
+
+	assert.Equal(t, 1, count)
+}
+
+func TestReport(t *testing.T) {
+	var reported http.Header
+
+	ctx := WithReporter(context.Background(), func(header http.Header) {
+		reported = header
+	})
+
+	Report(ctx, http.Header{"X-Test-Header": []string{"1"}})
+	assert.Equal(t, "1", reported.Get("x-test-header"))
+
+	Report(context.Background(), http.Header{"X-Test-Header": []string{"2"}})
+	assert.Equal(t, "1", reported.Get("x-test-header"))
+}
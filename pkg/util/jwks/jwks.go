@@ -0,0 +1,241 @@
+// Package jwks loads JSON Web Key Sets (RFC 7517) verifying JWTs.
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeyNotFound = errors.New("key not found in the key set")
+)
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// PublicKey returns the *rsa.PublicKey or *ecdsa.PublicKey of the key.
+func (k JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %q: %w", k.Kid, err)
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %q: %w", k.Kid, err)
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q of key %q", k.Crv, k.Kid)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x of key %q: %w", k.Kid, err)
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y of key %q: %w", k.Kid, err)
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q of key %q", k.Kty, k.Kid)
+	}
+}
+
+// Parse parses a key set, the public keys are indexed by their kid. Keys not
+// meant for signatures, and keys of unsupported types, are skipped.
+func Parse(data []byte) (map[string]crypto.PublicKey, error) {
+	var set JSONWebKeySet
+
+	err := json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := key.PublicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[key.Kid] = publicKey
+	}
+
+	return keys, nil
+}
+
+// KeySet is a key set loaded from a file or an http(s) URL, reloaded once it
+// is older than the refresh interval, or when a key is not found in it.
+type KeySet struct {
+	source          string
+	refreshInterval time.Duration
+	httpClient      *http.Client
+
+	mutex     sync.Mutex
+	keys      map[string]crypto.PublicKey
+	loadedAt  time.Time
+	lastError error
+}
+
+// minReloadInterval bounds how often unknown key IDs trigger a reload.
+const minReloadInterval = time.Minute
+
+func NewKeySet(source string, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		source:          source,
+		refreshInterval: refreshInterval,
+		httpClient:      &http.Client{Timeout: 10 * time.Second}, //nolint:mnd
+	}
+}
+
+func (s *KeySet) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.source, "http://") && !strings.HasPrefix(s.source, "https://") {
+		return os.ReadFile(s.source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching key set", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20)) //nolint:mnd
+}
+
+// Load (re)loads the key set, the keys loaded previously are kept when
+// loading fails.
+func (s *KeySet) Load(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.load(ctx)
+}
+
+func (s *KeySet) load(ctx context.Context) error {
+	s.loadedAt = time.Now()
+
+	data, err := s.read(ctx)
+	if err == nil {
+		var keys map[string]crypto.PublicKey
+
+		keys, err = Parse(data)
+		if err == nil {
+			s.keys = keys
+		}
+	}
+
+	s.lastError = err
+
+	return err
+}
+
+// Key returns the public key of kid. When kid is empty, the key set must hold
+// a single key.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stale := s.keys == nil || (s.refreshInterval > 0 && time.Since(s.loadedAt) > s.refreshInterval)
+	if stale && time.Since(s.loadedAt) > minReloadInterval {
+		_ = s.load(ctx)
+	}
+
+	key, ok := s.lookup(kid)
+	if !ok && time.Since(s.loadedAt) > minReloadInterval {
+		_ = s.load(ctx)
+		key, ok = s.lookup(kid)
+	}
+	if !ok {
+		if s.keys == nil && s.lastError != nil {
+			return nil, fmt.Errorf("failed to load key set: %w", s.lastError)
+		}
+
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (s *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+
+	return key, ok
+}
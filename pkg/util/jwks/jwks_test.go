@@ -0,0 +1,110 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func newTestKeySet(t *testing.T) ([]byte, *rsa.PrivateKey, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(JSONWebKeySet{
+		Keys: []JSONWebKey{
+			{Kty: "RSA", Kid: "rsa", Use: "sig", N: encodeBigInt(rsaKey.N), E: encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+			{Kty: "EC", Kid: "ec", Crv: "P-256", X: encodeBigInt(ecKey.X), Y: encodeBigInt(ecKey.Y)},
+			{Kty: "RSA", Kid: "enc", Use: "enc", N: encodeBigInt(rsaKey.N), E: encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+			{Kty: "oct", Kid: "oct"},
+		},
+	})
+	require.NoError(t, err)
+
+	return data, rsaKey, ecKey
+}
+
+func TestParse(t *testing.T) {
+	data, rsaKey, ecKey := newTestKeySet(t)
+
+	keys, err := Parse(data)
+	require.NoError(t, err)
+
+	require.Len(t, keys, 2)
+	assert.True(t, rsaKey.PublicKey.Equal(keys["rsa"]))
+	assert.True(t, ecKey.PublicKey.Equal(keys["ec"]))
+}
+
+func TestKeySet(t *testing.T) {
+	data, rsaKey, _ := newTestKeySet(t)
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		keySet := NewKeySet(path, 0)
+
+		key, err := keySet.Key(context.Background(), "rsa")
+		require.NoError(t, err)
+		assert.True(t, rsaKey.PublicKey.Equal(key))
+
+		_, err = keySet.Key(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		// Without kid, the key set must hold a single key.
+		_, err = keySet.Key(context.Background(), "")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("URL", func(t *testing.T) {
+		var requests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			_, _ = w.Write(data)
+		}))
+		defer server.Close()
+
+		keySet := NewKeySet(server.URL, 0)
+		require.NoError(t, keySet.Load(context.Background()))
+
+		key, err := keySet.Key(context.Background(), "rsa")
+		require.NoError(t, err)
+		assert.True(t, rsaKey.PublicKey.Equal(key))
+
+		// Unknown key IDs do not reload the key set more than once a minute.
+		_, err = keySet.Key(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		keySet := NewKeySet(filepath.Join(t.TempDir(), "missing.json"), 0)
+
+		_, err := keySet.Key(context.Background(), "rsa")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrKeyNotFound)
+	})
+}
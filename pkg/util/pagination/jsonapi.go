@@ -0,0 +1,38 @@
+package pagination
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/lingticio/llmg/apis/jsonapi"
+)
+
+// ParamsFromJSONAPI returns the pagination arguments of gRPC requests, where
+// zero first and last mean unset.
+func ParamsFromJSONAPI(req *jsonapi.PaginationRequest) Params {
+	if req == nil {
+		return Params{}
+	}
+
+	params := Params{
+		After:  req.After,
+		Before: req.Before,
+	}
+	if req.GetFirst() > 0 {
+		params.First = lo.ToPtr(int(req.GetFirst()))
+	}
+	if req.GetLast() > 0 {
+		params.Last = lo.ToPtr(int(req.GetLast()))
+	}
+
+	return params
+}
+
+// JSONAPI returns the page info of gRPC responses.
+func (p PageInfo) JSONAPI() *jsonapi.PageInfo {
+	return &jsonapi.PageInfo{
+		HasPreviousPage: p.HasPreviousPage,
+		HasNextPage:     p.HasNextPage,
+		StartCursor:     p.StartCursor,
+		EndCursor:       p.EndCursor,
+	}
+}
@@ -0,0 +1,126 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+var (
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrNegativeFirst  = errors.New("first must be a non-negative integer")
+	ErrNegativeLast   = errors.New("last must be a non-negative integer")
+	ErrCursorNotFound = errors.New("cursor not found")
+)
+
+// Edge is an element of a page together with its opaque cursor.
+type Edge[T any] struct {
+	Node   T
+	Cursor string
+}
+
+// PageInfo follows the PageInfo definition of the GraphQL Cursor Connections
+// Specification, https://relay.dev/graphql/connections.htm#sec-undefined.PageInfo
+type PageInfo struct {
+	StartCursor     *string
+	EndCursor       *string
+	HasNextPage     bool
+	HasPreviousPage bool
+}
+
+// Params holds the pagination arguments of a connection, nil means unset.
+type Params struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// EncodeCursor encodes the stable identifier of a node into an opaque cursor.
+func EncodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// DecodeCursor decodes an opaque cursor back to the identifier of a node.
+func DecodeCursor(cursor string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+
+	return string(decoded), nil
+}
+
+func indexOf[T any](items []T, idOf func(T) string, cursor string) (int, error) {
+	id, err := DecodeCursor(cursor)
+	if err != nil {
+		return -1, err
+	}
+
+	for i, item := range items {
+		if idOf(item) == id {
+			return i, nil
+		}
+	}
+
+	return -1, ErrCursorNotFound
+}
+
+// Paginate slices items according to the algorithm described in
+// https://relay.dev/graphql/connections.htm#sec-Pagination-algorithm, items
+// must already be sorted and idOf must return a unique, stable identifier.
+func Paginate[T any](items []T, idOf func(T) string, params Params) ([]Edge[T], PageInfo, error) {
+	if params.First != nil && *params.First < 0 {
+		return nil, PageInfo{}, ErrNegativeFirst
+	}
+	if params.Last != nil && *params.Last < 0 {
+		return nil, PageInfo{}, ErrNegativeLast
+	}
+
+	start, end := 0, len(items)
+
+	if params.After != nil {
+		index, err := indexOf(items, idOf, *params.After)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+
+		start = index + 1
+	}
+	if params.Before != nil {
+		index, err := indexOf(items, idOf, *params.Before)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+
+		end = index
+	}
+	if end < start {
+		end = start
+	}
+
+	var pageInfo PageInfo
+
+	if params.First != nil && end-start > *params.First {
+		end = start + *params.First
+		pageInfo.HasNextPage = true
+	}
+	if params.Last != nil && end-start > *params.Last {
+		start = end - *params.Last
+		pageInfo.HasPreviousPage = true
+	}
+
+	edges := make([]Edge[T], 0, end-start)
+	for _, item := range items[start:end] {
+		edges = append(edges, Edge[T]{
+			Node:   item,
+			Cursor: EncodeCursor(idOf(item)),
+		})
+	}
+
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return edges, pageInfo, nil
+}
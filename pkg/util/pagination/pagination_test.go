@@ -0,0 +1,80 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nodesOf(edges []Edge[string]) []string {
+	return lo.Map(edges, func(item Edge[string], _ int) string {
+		return item.Node
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	idOf := func(item string) string { return item }
+
+	t.Run("All", func(t *testing.T) {
+		edges, pageInfo, err := Paginate(items, idOf, Params{})
+		require.NoError(t, err)
+
+		assert.Equal(t, items, nodesOf(edges))
+		assert.False(t, pageInfo.HasNextPage)
+		assert.False(t, pageInfo.HasPreviousPage)
+		assert.Equal(t, EncodeCursor("a"), lo.FromPtr(pageInfo.StartCursor))
+		assert.Equal(t, EncodeCursor("e"), lo.FromPtr(pageInfo.EndCursor))
+	})
+
+	t.Run("FirstAfter", func(t *testing.T) {
+		edges, pageInfo, err := Paginate(items, idOf, Params{First: lo.ToPtr(2)})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a", "b"}, nodesOf(edges))
+		assert.True(t, pageInfo.HasNextPage)
+
+		edges, pageInfo, err = Paginate(items, idOf, Params{First: lo.ToPtr(2), After: pageInfo.EndCursor})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"c", "d"}, nodesOf(edges))
+		assert.True(t, pageInfo.HasNextPage)
+
+		edges, pageInfo, err = Paginate(items, idOf, Params{First: lo.ToPtr(2), After: pageInfo.EndCursor})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"e"}, nodesOf(edges))
+		assert.False(t, pageInfo.HasNextPage)
+	})
+
+	t.Run("LastBefore", func(t *testing.T) {
+		edges, pageInfo, err := Paginate(items, idOf, Params{Last: lo.ToPtr(2), Before: lo.ToPtr(EncodeCursor("e"))})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"c", "d"}, nodesOf(edges))
+		assert.True(t, pageInfo.HasPreviousPage)
+		assert.False(t, pageInfo.HasNextPage)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		edges, pageInfo, err := Paginate(items, idOf, Params{After: lo.ToPtr(EncodeCursor("e"))})
+		require.NoError(t, err)
+
+		assert.Empty(t, edges)
+		assert.Nil(t, pageInfo.StartCursor)
+		assert.Nil(t, pageInfo.EndCursor)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		_, _, err := Paginate(items, idOf, Params{First: lo.ToPtr(-1)})
+		require.ErrorIs(t, err, ErrNegativeFirst)
+
+		_, _, err = Paginate(items, idOf, Params{After: lo.ToPtr("!!!")})
+		require.ErrorIs(t, err, ErrInvalidCursor)
+
+		_, _, err = Paginate(items, idOf, Params{After: lo.ToPtr(EncodeCursor("z"))})
+		require.ErrorIs(t, err, ErrCursorNotFound)
+	})
+}